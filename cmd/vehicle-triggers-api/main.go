@@ -23,6 +23,11 @@ import (
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/migrations"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/kafka"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/absence"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/cachesync"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/subscriptionsync"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/vehiclestate"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookretry"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
 )
@@ -92,8 +97,18 @@ func main() {
 	runFiberWithLogging(runnerCtx, runnerGroup, &logger, servers.Application, net.JoinHostPort("0.0.0.0", strconv.Itoa(settings.Port)))
 	RunConsumer(runnerCtx, runnerGroup, &logger, servers.SignalConsumer)
 	RunConsumer(runnerCtx, runnerGroup, &logger, servers.EventConsumer)
+	RunRetryWorker(runnerCtx, runnerGroup, &logger, servers.RetryWorker)
+	RunAbsenceScheduler(runnerCtx, runnerGroup, &logger, servers.AbsenceScheduler)
+	RunVehicleState(runnerCtx, runnerGroup, &logger, servers.VehicleState)
+	RunSubscriptionReconciler(runnerCtx, runnerGroup, &logger, servers.SubscriptionReconciler)
+	RunCacheListener(runnerCtx, runnerGroup, &logger, servers.CacheListener)
 
-	if err := runnerGroup.Wait(); err != nil {
+	err = runnerGroup.Wait()
+	// Store the state recorded by messages that were still in flight when the cache stopped.
+	if flushErr := servers.VehicleState.Flush(context.Background()); flushErr != nil {
+		logger.Error().Err(flushErr).Msg("Failed to store vehicle state.")
+	}
+	if err != nil {
 		logger.Fatal().Err(err).Msg("Server failed.")
 	}
 	logger.Info().Msg("Server stopped.")
@@ -124,6 +139,78 @@ func RunConsumer(ctx context.Context, group *errgroup.Group, logger *zerolog.Log
 	})
 }
 
+// RunRetryWorker starts the webhook retry worker in a single goroutine.
+// Entry/exit is logged like the consumers so we can see which subsystem
+// returned first.
+func RunRetryWorker(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, worker *webhookretry.Worker) {
+	const name = "webhook-retry"
+	group.Go(func() error {
+		logger.Info().Str("worker", name).Msg("worker goroutine: run enter")
+		err := worker.Run(ctx)
+		logger.Info().Str("worker", name).Err(err).Msg("worker goroutine: run exit")
+		if err != nil {
+			return fmt.Errorf("worker %q run: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// RunAbsenceScheduler starts the absence scheduler in a single goroutine.
+func RunAbsenceScheduler(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, scheduler *absence.Scheduler) {
+	const name = "absence-scheduler"
+	group.Go(func() error {
+		logger.Info().Str("worker", name).Msg("worker goroutine: run enter")
+		err := scheduler.Run(ctx)
+		logger.Info().Str("worker", name).Err(err).Msg("worker goroutine: run exit")
+		if err != nil {
+			return fmt.Errorf("worker %q run: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// RunVehicleState starts writing the cached vehicle state to the database in a single goroutine.
+func RunVehicleState(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, cache *vehiclestate.Cache) {
+	const name = "vehicle-state"
+	group.Go(func() error {
+		logger.Info().Str("worker", name).Msg("worker goroutine: run enter")
+		err := cache.Run(ctx)
+		logger.Info().Str("worker", name).Err(err).Msg("worker goroutine: run exit")
+		if err != nil {
+			return fmt.Errorf("worker %q run: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// RunSubscriptionReconciler starts syncing the subscriptions of allShared webhooks in a single goroutine.
+func RunSubscriptionReconciler(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, reconciler *subscriptionsync.Reconciler) {
+	const name = "subscription-reconciler"
+	group.Go(func() error {
+		logger.Info().Str("worker", name).Msg("worker goroutine: run enter")
+		err := reconciler.Run(ctx)
+		logger.Info().Str("worker", name).Err(err).Msg("worker goroutine: run exit")
+		if err != nil {
+			return fmt.Errorf("worker %q run: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// RunCacheListener starts applying the changes of other replicas to the webhook cache in a single goroutine.
+func RunCacheListener(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, listener *cachesync.Listener) {
+	const name = "cache-listener"
+	group.Go(func() error {
+		logger.Info().Str("worker", name).Msg("worker goroutine: run enter")
+		err := listener.Run(ctx)
+		logger.Info().Str("worker", name).Err(err).Msg("worker goroutine: run exit")
+		if err != nil {
+			return fmt.Errorf("worker %q run: %w", name, err)
+		}
+		return nil
+	})
+}
+
 // runFiberWithLogging mirrors runner.RunFiber but logs goroutine
 // enter/exit so we can see which subsystem returned first.
 func runFiberWithLogging(ctx context.Context, group *errgroup.Group, logger *zerolog.Logger, fiberApp runner.FiberApp, addr string) {
@@ -15,6 +15,271 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v1/conditions/evaluate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Evaluates a CEL condition against the given samples without saving anything, using the same variables a webhook would. Each result reports whether the webhook would have fired for the sample, the variables the condition was evaluated with and any evaluation error. Cooldowns are not applied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conditions"
+                ],
+                "summary": "Dry-run a condition",
+                "parameters": [
+                    {
+                        "description": "Condition and samples",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.EvaluateConditionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result per sample",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.EvaluateConditionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, service or condition"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/geofences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the geofences of the developer license ordered by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "List geofences",
+                "responses": {
+                    "200": {
+                        "description": "Geofences",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers_webhook.GeofenceView"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a named GeoJSON polygon that signal conditions on vss.currentLocationCoordinates can refer to with inGeofence, enteredGeofence and exitedGeofence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Create a geofence",
+                "parameters": [
+                    {
+                        "description": "Geofence",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.CreateGeofenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Geofence created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GeofenceView"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid name or geometry"
+                    },
+                    "409": {
+                        "description": "A geofence with the name already exists"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/geofences/{geofenceId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a geofence by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Get a geofence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Geofence ID",
+                        "name": "geofenceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Geofence",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GeofenceView"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid geofence id"
+                    },
+                    "404": {
+                        "description": "Geofence not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the name or geometry of a geofence. Webhooks using the geofence are evaluated against the new geometry. A geofence used by a webhook condition can not be renamed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Update a geofence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Geofence ID",
+                        "name": "geofenceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Geofence fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.UpdateGeofenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Geofence updated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GeofenceView"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, name or geometry"
+                    },
+                    "404": {
+                        "description": "Geofence not found"
+                    },
+                    "409": {
+                        "description": "The name is taken or the geofence is used by a webhook"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a geofence by its ID. A geofence used by a webhook condition can not be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Delete a geofence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Geofence ID",
+                        "name": "geofenceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Geofence deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid geofence id"
+                    },
+                    "404": {
+                        "description": "Geofence not found"
+                    },
+                    "409": {
+                        "description": "The geofence is used by a webhook"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
         "/v1/webhooks": {
             "get": {
                 "security": [
@@ -324,21 +589,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/webhooks/{webhookId}/subscribe/all": {
-            "post": {
+        "/v1/webhooks/{webhookId}/dead-letters": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Subscribes every vehicle shared with this developer to the webhook.",
+                "description": "Lists the deliveries of a webhook that were given up on, newest first. A delivery is given up on when its retries are exhausted, the endpoint rejects it, or the webhook has failed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Subscribe all shared vehicles",
+                "summary": "List dead letters of a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -349,50 +614,35 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Count of subscribed vehicles",
+                    "200": {
+                        "description": "List of dead letters",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers_webhook.DeadLetterView"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Invalid webhook id"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "404": {
+                        "description": "Webhook not found"
                     },
                     "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/v1/webhooks/{webhookId}/subscribe/list": {
+        "/v1/webhooks/{webhookId}/dead-letters/replay": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Subscribes each assetDID to the webhook.",
+                "description": "Queues the selected dead letters, or all of them when no ids are given, for delivery again with a fresh set of retries. The webhook must be enabled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -402,7 +652,7 @@ const docTemplate = `{
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Assign multiple vehicles to a webhook from a list",
+                "summary": "Replay dead letters of a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -410,53 +660,116 @@ const docTemplate = `{
                         "name": "webhookId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Dead letters to replay",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.ReplayDeadLettersRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Count of subscribed vehicles",
+                    "200": {
+                        "description": "Dead letters queued for delivery",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                            "$ref": "#/definitions/internal_controllers_webhook.ReplayDeadLettersResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Invalid request payload or webhook not enabled"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "404": {
+                        "description": "Webhook not found"
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every attempt to deliver the webhook, including retries, newest first. Attempts are kept for the configured retention. Pass the returned nextCursor as cursor to fetch the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List delivery attempts of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return attempts for this asset DID",
+                        "name": "assetDid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return attempts made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return attempts made before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of attempts to return (default 100, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of delivery attempts",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_controllers_webhook.ListDeliveriesResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid webhook id or query parameters"
+                    },
+                    "404": {
+                        "description": "Webhook not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/v1/webhooks/{webhookId}/subscribe/{assetDID}": {
+        "/v1/webhooks/{webhookId}/mute/{assetDID}": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Associates a vehicle with a specific event webhook.",
+                "description": "Stops a webhook from firing for a single subscribed vehicle until the given time, without unsubscribing it. The mute expires on its own; the webhook fires for the vehicle again afterwards without another call.",
                 "consumes": [
                     "application/json"
                 ],
@@ -466,7 +779,7 @@ const docTemplate = `{
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Assign a vehicle to a webhook",
+                "summary": "Mute a vehicle of a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -481,11 +794,20 @@ const docTemplate = `{
                         "name": "assetDID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Mute end",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.PauseRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Vehicle assigned",
+                    "200": {
+                        "description": "Vehicle muted",
                         "schema": {
                             "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
                         }
@@ -508,6 +830,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Vehicle not subscribed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -518,23 +849,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/webhooks/{webhookId}/unsubscribe/all": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Removes every shared vehicle subscription for this webhook.",
+                "description": "Ends the mute of a subscribed vehicle so that the webhook fires for it again right away.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Unsubscribe all shared vehicles",
+                "summary": "Unmute a vehicle of a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -542,11 +871,18 @@ const docTemplate = `{
                         "name": "webhookId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset DID",
+                        "name": "assetDID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Count of unsubscribed vehicles",
+                        "description": "Vehicle unmuted",
                         "schema": {
                             "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
                         }
@@ -569,6 +905,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Vehicle not subscribed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -581,14 +926,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/webhooks/{webhookId}/unsubscribe/list": {
-            "delete": {
+        "/v1/webhooks/{webhookId}/secret": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unsubscribes each assetDID from the webhook.",
+                "description": "Issues a new signing secret for a webhook. The previous secret keeps signing deliveries alongside the new one for the requested overlap (24 hours by default) so receivers can switch without dropping requests. The new secret is only returned once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -598,7 +943,7 @@ const docTemplate = `{
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Unsubscribe multiple vehicles from a webhook using a list",
+                "summary": "Rotate a webhook signing secret",
                 "parameters": [
                     {
                         "type": "string",
@@ -606,60 +951,103 @@ const docTemplate = `{
                         "name": "webhookId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rotation options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.RotateWebhookSecretRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Count of unsubscribed vehicles",
+                        "description": "Signing secret rotated successfully",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                            "$ref": "#/definitions/internal_controllers_webhook.RotateWebhookSecretResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request payload"
+                    },
+                    "404": {
+                        "description": "Webhook not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/snooze": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops a webhook from firing for any vehicle until the given time. The snooze expires on its own; the webhook fires again afterwards without another call. Snoozing a snoozed webhook replaces the time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Snooze a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Snooze end",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_controllers_webhook.PauseRequest"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook snoozed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request payload"
+                    },
+                    "404": {
+                        "description": "Webhook not found"
+                    },
                     "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/v1/webhooks/{webhookId}/unsubscribe/{assetDID}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Removes a vehicle's subscription.",
+                "description": "Ends the snooze of a webhook so that it fires again right away.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Webhooks"
                 ],
-                "summary": "Unsubscribe a vehicle from a webhook",
+                "summary": "Unsnooze a webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -667,18 +1055,51 @@ const docTemplate = `{
                         "name": "webhookId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook unsnoozed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found"
                     },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/subscribe/all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes every vehicle shared with this developer to the webhook. Vehicles shared later are not subscribed, use the allShared subscription mode to keep them in sync.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Subscribe all shared vehicles",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Asset DID",
-                        "name": "assetDID",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Vehicle removed",
+                    "201": {
+                        "description": "Count of subscribed vehicles",
                         "schema": {
                             "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
                         }
@@ -712,63 +1133,735 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "big.Int": {
-            "type": "object"
         },
-        "cloudevent.ERC721DID": {
-            "type": "object",
-            "properties": {
-                "chainId": {
-                    "type": "integer"
-                },
-                "contract": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+        "/v1/webhooks/{webhookId}/subscribe/list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes each assetDID to the webhook. params optionally overrides the parameter defaults of the webhook per vehicle, keyed by asset DID; overrides must name parameters of the webhook with values of the same type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Assign multiple vehicles to a webhook from a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vehicles and their parameter overrides",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.VehicleListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Count of subscribed vehicles",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/subscribe/{assetDID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Associates a vehicle with a specific event webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Assign a vehicle to a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset DID",
+                        "name": "assetDID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Vehicle assigned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a synthetic payload for the webhook's service and metric to its target URI, signed like a real delivery and marked with the X-DIMO-Webhook-Test header, and reports how the endpoint answered. Test deliveries are sent regardless of the webhook's status, are not retried and do not count toward its failure threshold.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Send a test delivery to a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Outcome of the test delivery",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.TestWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook id"
+                    },
+                    "404": {
+                        "description": "Webhook not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/unsubscribe/all": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes every shared vehicle subscription for this webhook.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Unsubscribe all shared vehicles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count of unsubscribed vehicles",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/unsubscribe/list": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unsubscribes each assetDID from the webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Unsubscribe multiple vehicles from a webhook using a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count of unsubscribed vehicles",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookId}/unsubscribe/{assetDID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a vehicle's subscription.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Unsubscribe a vehicle from a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset DID",
+                        "name": "assetDID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle removed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_webhook.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "big.Int": {
+            "type": "object"
+        },
+        "cloudevent.ERC721DID": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "type": "integer"
+                },
+                "contract": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tokenId": {
+                    "$ref": "#/definitions/big.Int"
+                }
+            }
+        },
+        "github_com_DIMO-Network_vehicle-triggers-api_internal_signals.SignalDefinition": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description briefly explains what the signal represents.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the JSON-safe name of the signal.",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "Permissions is the permission required to access the signal.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "description": "Unit is the unit of measurement for the signal value (if any).",
+                    "type": "string"
+                },
+                "valueType": {
+                    "description": "ValueType is the data type for the value field e.g. \"float64\" or \"string\"",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_webhook.ConditionSample": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Current is the signal or event the condition is evaluated for.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.ConditionSampleValue"
+                        }
+                    ]
+                },
+                "previous": {
+                    "description": "Previous is the signal or event received before Current. The previous variables are zero when omitted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.ConditionSampleValue"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_controllers_webhook.ConditionSampleResult": {
+            "type": "object",
+            "properties": {
+                "bindings": {
+                    "description": "Bindings are the variables the condition was evaluated with.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "description": "Error is the error raised while evaluating the condition, if any.",
+                    "type": "string"
+                },
+                "fired": {
+                    "description": "Fired is true when the condition evaluated to true, i.e. the webhook would have fired.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controllers_webhook.ConditionSampleValue": {
+            "type": "object",
+            "properties": {
+                "durationNs": {
+                    "description": "DurationNs is the duration of the event in nanoseconds.",
+                    "type": "integer"
+                },
+                "metadata": {
+                    "description": "Metadata is the metadata of the event.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the name of the event.",
+                    "type": "string"
+                },
+                "offline": {
+                    "description": "Offline is true when the vehicle went silent and false when it came back online.",
+                    "type": "boolean"
+                },
+                "signals": {
+                    "description": "Signals maps signal names to their latest value: a number, a string or a location object.",
+                    "type": "object"
+                },
+                "silentSeconds": {
+                    "description": "SilentSeconds is how long the vehicle was silent.",
+                    "type": "number"
+                },
+                "source": {
+                    "description": "Source is the oracle the signal or event came from.",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp is when the signal or event was observed, read by hourOfDay and dayOfWeek.",
+                    "type": "string"
+                },
+                "valueLocation": {
+                    "description": "ValueLocation is the value of a location signal.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vss.Location"
+                        }
+                    ]
+                },
+                "valueNumber": {
+                    "description": "ValueNumber is the value of a numeric signal.",
+                    "type": "number"
+                },
+                "valueString": {
+                    "description": "ValueString is the value of a string signal.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_webhook.CreateGeofenceRequest": {
+            "type": "object",
+            "required": [
+                "geometry",
+                "name"
+            ],
+            "properties": {
+                "geometry": {
+                    "description": "Geometry is a GeoJSON Polygon, or a Feature with a Polygon geometry. Positions are [longitude, latitude].",
+                    "type": "object"
+                },
+                "name": {
+                    "description": "Name identifies the geofence in conditions, e.g. inGeofence(\"depot\"). It must be unique per developer license.",
+                    "type": "string",
+                    "example": "depot"
+                }
+            }
+        },
+        "internal_controllers_webhook.DeadLetterView": {
+            "type": "object",
+            "properties": {
+                "assetDid": {
+                    "description": "AssetDid is the DID of the asset the delivery is about.",
+                    "type": "string"
+                },
+                "attempts": {
+                    "description": "Attempts is the number of delivery attempts made before giving up.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the delivery was given up on.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the CloudEvent id of the delivery; a replay sends the same id.",
+                    "type": "string"
+                },
+                "lastError": {
+                    "description": "LastError is the error of the last attempt.",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is the CloudEvent that was sent to the webhook endpoint.",
+                    "type": "object"
+                }
+            }
+        },
+        "internal_controllers_webhook.DeliveryView": {
+            "type": "object",
+            "properties": {
+                "assetDid": {
+                    "description": "AssetDid is the DID of the asset the delivery is about.",
+                    "type": "string"
+                },
+                "attempt": {
+                    "description": "Attempt is the number of the attempt, starting at 1.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the attempt was made.",
+                    "type": "string"
+                },
+                "deliveryId": {
+                    "description": "DeliveryID is the CloudEvent id of the delivery; all attempts of a delivery share it.",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error describes why the attempt failed, including the start of the response body.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID identifies the attempt.",
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "description": "LatencyMs is how long the endpoint took to respond, in milliseconds.",
+                    "type": "integer"
+                },
+                "statusCode": {
+                    "description": "StatusCode is the HTTP status returned by the endpoint. It is omitted when no response was received.",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_webhook.EvaluateConditionRequest": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "description": "Condition is the CEL expression to evaluate.",
+                    "type": "string"
+                },
+                "metricName": {
+                    "description": "MetricName is the signal or event name; for signals it selects the value type of the value variables.",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Params are the parameter values the condition is evaluated with, e.g. params.limit.",
+                    "type": "object"
+                },
+                "samples": {
+                    "description": "Samples are evaluated one by one, in order.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_webhook.ConditionSample"
                     }
                 },
-                "tokenId": {
-                    "$ref": "#/definitions/big.Int"
+                "service": {
+                    "description": "Service is the service the condition is written for, \"signals\", \"events\", \"absence\" or \"composite\".",
+                    "type": "string"
                 }
             }
         },
-        "github_com_DIMO-Network_vehicle-triggers-api_internal_signals.SignalDefinition": {
+        "internal_controllers_webhook.EvaluateConditionResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "Description briefly explains what the signal represents.",
+                "results": {
+                    "description": "Results holds one result per sample, in the order of the samples.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_webhook.ConditionSampleResult"
+                    }
+                }
+            }
+        },
+        "internal_controllers_webhook.GenericResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Message provides a brief status message for the operation.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_webhook.GeofenceView": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is when the geofence was created.",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Name is the JSON-safe name of the signal.",
+                "geometry": {
+                    "description": "Geometry is the GeoJSON polygon of the geofence.",
+                    "type": "object"
+                },
+                "id": {
+                    "description": "ID is the unique identifier of the geofence.",
                     "type": "string"
                 },
-                "permissions": {
-                    "description": "Permissions is the permission required to access the signal.",
+                "name": {
+                    "description": "Name identifies the geofence in conditions.",
+                    "type": "string",
+                    "example": "depot"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the geofence was last changed.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_webhook.ListDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "description": "Deliveries are the attempts on this page, newest first.",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/internal_controllers_webhook.DeliveryView"
                     }
                 },
-                "unit": {
-                    "description": "Unit is the unit of measurement for the signal value (if any).",
-                    "type": "string"
-                },
-                "valueType": {
-                    "description": "ValueType is the data type for the value field e.g. \"float64\" or \"string\"",
+                "nextCursor": {
+                    "description": "NextCursor fetches the next page. It is omitted on the last page.",
                     "type": "string"
                 }
             }
         },
-        "internal_controllers_webhook.GenericResponse": {
+        "internal_controllers_webhook.PauseRequest": {
             "type": "object",
+            "required": [
+                "until"
+            ],
             "properties": {
-                "message": {
-                    "description": "Message provides a brief status message for the operation.",
+                "until": {
+                    "description": "Until is when the webhook fires again. It must be in the future and at most 90 days ahead.",
+                    "type": "string",
+                    "example": "2025-07-01T08:00:00Z"
+                }
+            }
+        },
+        "internal_controllers_webhook.ReconciliationView": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Added is the number of vehicles that were subscribed by the sync.",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error describes what could not be synced, e.g. vehicles whose permissions could not be checked. The sync\nis retried at the next interval.",
+                    "type": "string"
+                },
+                "reconciledAt": {
+                    "description": "ReconciledAt is when the subscriptions were last synced.",
                     "type": "string"
+                },
+                "removed": {
+                    "description": "Removed is the number of vehicles that were unsubscribed by the sync.",
+                    "type": "integer"
                 }
             }
         },
@@ -783,6 +1876,16 @@ const docTemplate = `{
                 "verificationToken"
             ],
             "properties": {
+                "absentFor": {
+                    "description": "AbsentFor is the number of seconds without a signal after which an absence webhook fires for a vehicle.\nRequired for absence webhooks, which fire again with offline false once the vehicle sends a signal.",
+                    "type": "integer",
+                    "example": 3600
+                },
+                "clearCondition": {
+                    "description": "ClearCondition is an optional CEL expression for edge webhooks. Once fired, the webhook fires again only after\na signal matches the clear condition and a later one matches the condition. Defaults to the negated condition.",
+                    "type": "string",
+                    "example": "valueNumber \u003c 50"
+                },
                 "condition": {
                     "description": "Condition is a CEL expression evaluated against the metric to decide when to fire.",
                     "type": "string",
@@ -803,13 +1906,50 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Speed Alert"
                 },
+                "fireMode": {
+                    "description": "FireMode is \"level\" (default) to fire on every matching signal, subject to the cool down period, or \"edge\"\nto fire only when the condition turns from false to true for a vehicle. Edge is only supported for signals.",
+                    "type": "string",
+                    "example": "edge"
+                },
+                "firingWindow": {
+                    "description": "FiringWindow is the number of seconds MaxFirings and GlobalMaxFirings apply to, counted back from each firing.\nRequired with either limit.",
+                    "type": "integer",
+                    "example": 3600
+                },
+                "globalMaxFirings": {
+                    "description": "GlobalMaxFirings is the maximum number of times the webhook fires across all vehicles within FiringWindow.\n0 disables the limit.",
+                    "type": "integer",
+                    "example": 1000
+                },
+                "maxFirings": {
+                    "description": "MaxFirings is the maximum number of times the webhook fires for a vehicle within FiringWindow. 0 disables the limit.",
+                    "type": "integer",
+                    "example": 5
+                },
                 "metricName": {
-                    "description": "MetricName is the fully qualified event/signal to monitor (e.g. \"vss.speed\" for signals, \"behavior.harshBraking\" for events).\nThis field can not be updated after the webhook is created.",
+                    "description": "MetricName is the fully qualified event/signal to monitor (e.g. \"vss.speed\" for signals, \"behavior.harshBraking\" for events).\nAbsence webhooks watch a signal (e.g. \"vss.speed\") or \"*\" for all signals of the vehicle. Composite webhooks use \"*\".\nThis field can not be updated after the webhook is created.",
                     "type": "string",
                     "example": "vss.speed"
                 },
+                "params": {
+                    "description": "Params are the default values of the parameters the conditions read, e.g. params.limit. Values are numbers,\nstrings or booleans. Vehicles can override them when they are subscribed from a list.",
+                    "type": "object"
+                },
+                "previousScope": {
+                    "description": "PreviousScope selects the value conditions read as previous for signal and event webhooks: \"lastObserved\"\n(default) for the last value the vehicle sent, \"lastFiredByThisTrigger\" for the value this webhook last fired\nfor, or \"lastFiredForMetric\" for the value any webhook of the same metric last fired for.",
+                    "type": "string",
+                    "example": "lastObserved"
+                },
+                "schedule": {
+                    "description": "Schedule restricts the times the webhook fires at. The webhook fires at any time when omitted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.Schedule"
+                        }
+                    ]
+                },
                 "service": {
-                    "description": "Service is the subsystem producing the metric: \"signals\" or \"events\".\nThis field can not be updated after the webhook is created.",
+                    "description": "Service is the subsystem producing the metric: \"signals\", \"events\", \"absence\" or \"composite\".\nThis field can not be updated after the webhook is created.",
                     "type": "string",
                     "example": "signals"
                 },
@@ -818,6 +1958,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "enabled"
                 },
+                "subscriptionMode": {
+                    "description": "SubscriptionMode is \"explicit\" (default) to subscribe vehicles through the subscribe endpoints, or \"allShared\"\nto keep the webhook subscribed to every vehicle shared with the developer license that has the permissions\nit needs. Vehicles shared later are subscribed, and vehicles whose permissions are revoked are unsubscribed.",
+                    "type": "string",
+                    "example": "allShared"
+                },
+                "sustainFor": {
+                    "description": "SustainFor is the number of seconds a signal condition must hold continuously before the webhook fires. 0 fires immediately.",
+                    "type": "integer",
+                    "example": 60
+                },
                 "targetURL": {
                     "description": "TargetURL is the HTTPS endpoint that will receive webhook callbacks.",
                     "type": "string",
@@ -840,6 +1990,120 @@ const docTemplate = `{
                 "message": {
                     "description": "Message provides a brief status message for the operation.",
                     "type": "string"
+                },
+                "signingSecret": {
+                    "description": "SigningSecret is the secret used to sign deliveries of this webhook.\nIt is only returned once; store it securely or rotate it to obtain a new one.",
+                    "type": "string",
+                    "example": "whsec_4f1c..."
+                }
+            }
+        },
+        "internal_controllers_webhook.ReplayDeadLettersRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "description": "IDs are the dead letters to replay. All dead letters of the webhook are replayed when omitted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_controllers_webhook.ReplayDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Message provides a brief status message for the operation.",
+                    "type": "string"
+                },
+                "replayed": {
+                    "description": "Replayed is the number of dead letters queued for delivery.",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_webhook.RotateWebhookSecretRequest": {
+            "type": "object",
+            "properties": {
+                "overlapSeconds": {
+                    "description": "OverlapSeconds is how long the previous secret keeps signing deliveries alongside the new one.\nDefaults to 24 hours when omitted; 0 revokes the previous secret immediately.",
+                    "type": "integer",
+                    "example": 86400
+                }
+            }
+        },
+        "internal_controllers_webhook.RotateWebhookSecretResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is the unique identifier of the webhook.",
+                    "type": "string"
+                },
+                "previousSecretExpiresAt": {
+                    "description": "PreviousSecretExpiresAt is when the previous secret stops signing deliveries, if it is still active.",
+                    "type": "string"
+                },
+                "signingSecret": {
+                    "description": "SigningSecret is the new secret used to sign deliveries of this webhook.\nIt is only returned once; store it securely.",
+                    "type": "string",
+                    "example": "whsec_4f1c..."
+                }
+            }
+        },
+        "internal_controllers_webhook.Schedule": {
+            "type": "object",
+            "properties": {
+                "blackoutDates": {
+                    "description": "BlackoutDates are local dates, YYYY-MM-DD, the webhook does not fire on.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25"
+                    ]
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone of the windows and blackout dates.",
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "windows": {
+                    "description": "Windows are the weekly windows the webhook fires in. The webhook fires all week when omitted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_webhook.ScheduleWindow"
+                    }
+                }
+            }
+        },
+        "internal_controllers_webhook.ScheduleWindow": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Days are the days the window starts on, \"sun\" to \"sat\". The window starts every day when omitted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "end": {
+                    "description": "End is the local time the window ends at, HH:MM or 24:00. A window that ends before it starts ends on the\nnext day.",
+                    "type": "string",
+                    "example": "06:00"
+                },
+                "start": {
+                    "description": "Start is the local time the window starts at, HH:MM.",
+                    "type": "string",
+                    "example": "20:00"
                 }
             }
         },
@@ -862,15 +2126,74 @@ const docTemplate = `{
                     "description": "Description is the optional description from the webhook trigger.",
                     "type": "string"
                 },
+                "mutedUntil": {
+                    "description": "MutedUntil is when the webhook fires for the vehicle again, if the subscription is muted.",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Params are the parameter values the vehicle overrides the defaults of the webhook with.",
+                    "type": "object"
+                },
+                "snoozedUntil": {
+                    "description": "SnoozedUntil is when the webhook fires again, if it is snoozed.",
+                    "type": "string"
+                },
                 "webhookId": {
                     "description": "webhookID is the identifier of the webhook trigger.",
                     "type": "string"
                 }
             }
         },
+        "internal_controllers_webhook.TestWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error describes why the delivery failed, including the start of the response body.",
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "description": "LatencyMs is how long the endpoint took to respond, in milliseconds.",
+                    "type": "integer"
+                },
+                "payload": {
+                    "description": "Payload is the CloudEvent that was sent to the webhook endpoint.",
+                    "type": "object"
+                },
+                "statusCode": {
+                    "description": "StatusCode is the HTTP status returned by the endpoint. It is omitted when no response was received.",
+                    "type": "integer"
+                },
+                "success": {
+                    "description": "Success is true when the endpoint answered with a 2xx or 3xx status.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controllers_webhook.UpdateGeofenceRequest": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "description": "Geometry updates the GeoJSON polygon of the geofence.",
+                    "type": "object"
+                },
+                "name": {
+                    "description": "Name updates the name of the geofence. A geofence used by a webhook condition can not be renamed.",
+                    "type": "string",
+                    "example": "depot"
+                }
+            }
+        },
         "internal_controllers_webhook.UpdateWebhookRequest": {
             "type": "object",
             "properties": {
+                "absentFor": {
+                    "description": "AbsentFor updates the number of seconds without a signal after which an absence webhook fires.",
+                    "type": "integer"
+                },
+                "clearCondition": {
+                    "description": "ClearCondition updates the CEL expression that re-arms an edge webhook. An empty string removes it.",
+                    "type": "string"
+                },
                 "condition": {
                     "description": "Condition updates the CEL expression used to decide when to fire.",
                     "type": "string"
@@ -887,10 +2210,50 @@ const docTemplate = `{
                     "description": "DisplayName updates the user-friendly unique name per developer license.",
                     "type": "string"
                 },
+                "fireMode": {
+                    "description": "FireMode updates whether the webhook fires on every matching signal (\"level\") or on transitions (\"edge\").\nSwitching to \"level\" removes the clear condition.",
+                    "type": "string"
+                },
+                "firingWindow": {
+                    "description": "FiringWindow updates the number of seconds the firing limits apply to.",
+                    "type": "integer"
+                },
+                "globalMaxFirings": {
+                    "description": "GlobalMaxFirings updates the maximum number of firings across all vehicles within the firing window. 0 removes the limit.",
+                    "type": "integer"
+                },
+                "maxFirings": {
+                    "description": "MaxFirings updates the maximum number of firings per vehicle within the firing window. 0 removes the limit.",
+                    "type": "integer"
+                },
+                "params": {
+                    "description": "Params replaces the parameter defaults of the webhook. An empty object, {}, removes them. Overrides of\nvehicles for parameters that are removed or change type are ignored.",
+                    "type": "object"
+                },
+                "previousScope": {
+                    "description": "PreviousScope updates which value conditions read as previous. An empty string resets it to \"lastObserved\".",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule replaces the schedule of the webhook. An empty schedule, {}, removes it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.Schedule"
+                        }
+                    ]
+                },
                 "status": {
                     "description": "Status updates the current state of the webhook (e.g. \"enabled\" or \"Disabled\").",
                     "type": "string"
                 },
+                "subscriptionMode": {
+                    "description": "SubscriptionMode updates how vehicles are subscribed. Switching to \"explicit\" keeps the vehicles that are\nsubscribed at the time.",
+                    "type": "string"
+                },
+                "sustainFor": {
+                    "description": "SustainFor updates the number of seconds a signal condition must hold continuously before firing.",
+                    "type": "integer"
+                },
                 "targetURL": {
                     "description": "TargetURL updates the HTTPS endpoint that will receive callbacks.",
                     "type": "string"
@@ -910,9 +2273,33 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers_webhook.VehicleListRequest": {
+            "type": "object",
+            "properties": {
+                "assetDIDs": {
+                    "description": "AssetDIDs is the list of asset DIDs to subscribe to the webhook.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cloudevent.ERC721DID"
+                    }
+                },
+                "params": {
+                    "description": "Params overrides the parameter defaults of the webhook per vehicle, keyed by asset DID. Vehicles without\noverrides use the defaults. Only used when subscribing.",
+                    "type": "object"
+                }
+            }
+        },
         "internal_controllers_webhook.WebhookView": {
             "type": "object",
             "properties": {
+                "absentFor": {
+                    "description": "AbsentFor is the number of seconds without a signal after which an absence webhook fires.",
+                    "type": "integer"
+                },
+                "clearCondition": {
+                    "description": "ClearCondition is the CEL expression that re-arms an edge webhook, empty if it has none.",
+                    "type": "string"
+                },
                 "condition": {
                     "description": "Condition is the CEL expression evaluated to decide when to fire.",
                     "type": "string"
@@ -937,22 +2324,74 @@ const docTemplate = `{
                     "description": "FailureCount counts consecutive delivery failures for observability.",
                     "type": "integer"
                 },
+                "fireMode": {
+                    "description": "FireMode is \"level\" to fire on every matching signal or \"edge\" to fire only on transitions.",
+                    "type": "string"
+                },
+                "firingWindow": {
+                    "description": "FiringWindow is the number of seconds the firing limits apply to.",
+                    "type": "integer"
+                },
+                "globalMaxFirings": {
+                    "description": "GlobalMaxFirings is the maximum number of firings across all vehicles within FiringWindow, 0 if unlimited.",
+                    "type": "integer"
+                },
                 "id": {
                     "description": "ID is the unique identifier of the webhook.",
                     "type": "string"
                 },
+                "maxFirings": {
+                    "description": "MaxFirings is the maximum number of firings per vehicle within FiringWindow, 0 if unlimited.",
+                    "type": "integer"
+                },
                 "metricName": {
                     "description": "MetricName is the fully qualified signal/metric monitored by the webhook.",
                     "type": "string"
                 },
+                "params": {
+                    "description": "Params are the default values of the parameters the conditions read.",
+                    "type": "object"
+                },
+                "previousScope": {
+                    "description": "PreviousScope is the value conditions read as previous: \"lastObserved\", \"lastFiredByThisTrigger\" or \"lastFiredForMetric\".",
+                    "type": "string"
+                },
+                "reconciliation": {
+                    "description": "Reconciliation is the outcome of the last sync of an allShared webhook with the shared vehicles, omitted\nuntil the webhook is synced.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.ReconciliationView"
+                        }
+                    ]
+                },
+                "schedule": {
+                    "description": "Schedule restricts the times the webhook fires at, nil if it fires at any time.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers_webhook.Schedule"
+                        }
+                    ]
+                },
                 "service": {
-                    "description": "Service is the subsystem producing the metric: \"signals\" or \"events\".",
+                    "description": "Service is the subsystem producing the metric: \"signals\", \"events\", \"absence\" or \"composite\".",
+                    "type": "string"
+                },
+                "snoozedUntil": {
+                    "description": "SnoozedUntil is when the webhook fires again, if it is snoozed.",
                     "type": "string"
                 },
                 "status": {
                     "description": "Status is the current state of the webhook (e.g. \"enabled\" or \"Disabled\").",
                     "type": "string"
                 },
+                "subscriptionMode": {
+                    "description": "SubscriptionMode is \"explicit\" when vehicles are subscribed through the subscribe endpoints, or \"allShared\"\nwhen the webhook is kept subscribed to the vehicles shared with the developer license.",
+                    "type": "string"
+                },
+                "sustainFor": {
+                    "description": "SustainFor is the number of seconds a signal condition must hold continuously before the webhook fires.",
+                    "type": "integer"
+                },
                 "targetURL": {
                     "description": "TargetURL is the HTTPS endpoint that receives webhook callbacks.",
                     "type": "string"
@@ -962,6 +2401,23 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "vss.Location": {
+            "type": "object",
+            "properties": {
+                "hdop": {
+                    "description": "HDOP is the Horizontal Dilution of Precision.",
+                    "type": "number"
+                },
+                "latitude": {
+                    "description": "Latitude is the latitude in degrees.",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Longitude is the longitude in degrees.",
+                    "type": "number"
+                }
+            }
         }
     },
     "securityDefinitions": {
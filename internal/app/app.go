@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,12 +14,19 @@ import (
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/clients/identity"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/clients/tokenexchange"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/health"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/metriclistener"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/kafka"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/absence"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/cachesync"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/signalstate"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/subscriptionsync"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/vehiclestate"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookretry"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
 	"github.com/IBM/sarama"
 	"github.com/gofiber/fiber/v2"
@@ -31,6 +39,15 @@ type Servers struct {
 	Application    *fiber.App
 	SignalConsumer *kafka.Consumer
 	EventConsumer  *kafka.Consumer
+	RetryWorker    *webhookretry.Worker
+	// AbsenceScheduler fires absence webhooks for vehicles that stopped sending signals.
+	AbsenceScheduler *absence.Scheduler
+	// VehicleState writes the evaluation state and last observed values of vehicles to the database.
+	VehicleState *vehiclestate.Cache
+	// SubscriptionReconciler keeps allShared webhooks subscribed to the vehicles shared with their developer license.
+	SubscriptionReconciler *subscriptionsync.Reconciler
+	// CacheListener applies the changes written by other replicas to the webhook cache.
+	CacheListener *cachesync.Listener
 }
 
 func CreateServers(ctx context.Context, settings *config.Settings, logger zerolog.Logger) (*Servers, error) {
@@ -45,42 +62,66 @@ func CreateServers(ctx context.Context, settings *config.Settings, logger zerolo
 
 	repo := triggersrepo.NewRepository(store.DBS().Writer.DB)
 
-	webhookCache, err := startWebhookCache(ctx, settings, tokenExchangeCache, repo)
+	webhookCache, cacheListener, err := startWebhookCache(ctx, settings, tokenExchangeCache, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start webhook cache: %w", err)
 	}
 
-	signalConsumer, err := createSignalConsumer(ctx, settings, tokenExchangeCache, repo, webhookCache)
+	// One sender, and with it one HTTP connection pool, is shared by the consumers and the retry worker.
+	webhookSender := webhooksender.NewWebhookSender(nil)
+
+	// The signal listener collects when absence webhooks last saw a vehicle, and fires them for the scheduler.
+	absenceTracker := absence.NewTracker()
+	// The signal listener records the latest signals that composite and event conditions read.
+	latestSignals := signalstate.NewStore()
+	// Evaluations of both consumers read and write the state of vehicles through one write-behind cache.
+	vehicleState := vehiclestate.NewCache(repo, settings)
+	signalProcessor := metriclistener.NewMetricsListener(webhookCache, repo, webhookSender, triggerevaluator.NewTriggerEvaluator(vehicleState, tokenExchangeCache), absenceTracker, latestSignals, vehicleState, settings)
+	signalConsumer, err := createSignalConsumer(settings, signalProcessor)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signal consumer: %w", err)
 	}
+	absenceScheduler := absence.NewScheduler(repo, absenceTracker, signalProcessor, settings)
 
-	eventConsumer, err := createEventConsumer(ctx, settings, tokenExchangeCache, repo, webhookCache)
+	eventConsumer, err := createEventConsumer(ctx, settings, tokenExchangeCache, repo, webhookCache, webhookSender, latestSignals, vehicleState)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create event consumer: %w", err)
 	}
 
+	retryWorker := webhookretry.NewWorker(repo, webhookSender, settings)
+
 	identityClient, err := identity.New(settings, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create identity client: %w", err)
 	}
+	subscriptionReconciler := subscriptionsync.NewReconciler(repo, identityClient, tokenExchangeCache, webhookCache, settings)
+
+	// The pod is ready once the webhook cache is built, the database responds and both consumers joined their group.
+	healthController := health.NewController(webhookCache, store.DBS().Writer.DB, []health.Consumer{signalConsumer, eventConsumer}, settings)
 
-	app, err := CreateFiberApp(logger, repo, webhookCache, tokenExchangeAPI, identityClient, settings)
+	app, err := CreateFiberApp(logger, repo, webhookCache, webhookSender, tokenExchangeAPI, identityClient, healthController, settings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create fiber app: %w", err)
 	}
 	return &Servers{
-		Application:    app,
-		SignalConsumer: signalConsumer,
-		EventConsumer:  eventConsumer,
+		Application:            app,
+		SignalConsumer:         signalConsumer,
+		EventConsumer:          eventConsumer,
+		RetryWorker:            retryWorker,
+		AbsenceScheduler:       absenceScheduler,
+		VehicleState:           vehicleState,
+		SubscriptionReconciler: subscriptionReconciler,
+		CacheListener:          cacheListener,
 	}, nil
 }
 
 // Run sets up the API routes and starts the HTTP server.
 func CreateFiberApp(logger zerolog.Logger, repo *triggersrepo.Repository,
 	webhookCache *webhookcache.WebhookCache,
+	webhookSender *webhooksender.WebhookSender,
 	tokenExchangeClient *tokenexchange.Client,
 	identityClient *identity.Client,
+	healthController *health.Controller,
 	settings *config.Settings) (*fiber.App, error) {
 
 	app := fiber.New(fiber.Config{
@@ -108,17 +149,18 @@ func CreateFiberApp(logger zerolog.Logger, repo *triggersrepo.Repository,
 	// settings.IdentityAPIURL is loaded from your settings.yaml.
 
 	// Register Webhook routes.
-	webhookController, err := webhook.NewWebhookController(repo, webhookCache)
+	webhookController, err := webhook.NewWebhookController(repo, webhookCache, webhookSender)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook controller: %w", err)
 	}
 	vehicleSubscriptionController := webhook.NewVehicleSubscriptionController(repo, identityClient, tokenExchangeClient, webhookCache)
+	conditionController := webhook.NewConditionController(repo)
+	geofenceController := webhook.NewGeofenceController(repo, webhookCache)
 
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"data": "Server is up and running",
-		})
-	})
+	// /health is kept as an alias of the liveness probe for existing monitors.
+	app.Get("/health", healthController.Live)
+	app.Get("/health/live", healthController.Live)
+	app.Get("/health/ready", healthController.Ready)
 
 	jwtMiddleware := auth.Middleware(settings)
 	devLicenseMiddleware := auth.NewDevLicenseValidator(identityClient)
@@ -130,6 +172,23 @@ func CreateFiberApp(logger zerolog.Logger, repo *triggersrepo.Repository,
 	devJWTAuth.Get("/v1/webhooks/:webhookId", vehicleSubscriptionController.ListVehiclesForWebhook)
 	devJWTAuth.Put("/v1/webhooks/:webhookId", webhookController.UpdateWebhook)
 	devJWTAuth.Delete("/v1/webhooks/:webhookId", webhookController.DeleteWebhook)
+	devJWTAuth.Post("/v1/webhooks/:webhookId/secret", webhookController.RotateWebhookSecret)
+	devJWTAuth.Post("/v1/webhooks/:webhookId/snooze", webhookController.SnoozeWebhook)
+	devJWTAuth.Delete("/v1/webhooks/:webhookId/snooze", webhookController.UnsnoozeWebhook)
+	devJWTAuth.Get("/v1/webhooks/:webhookId/dead-letters", webhookController.ListDeadLetters)
+	devJWTAuth.Post("/v1/webhooks/:webhookId/dead-letters/replay", webhookController.ReplayDeadLetters)
+	devJWTAuth.Get("/v1/webhooks/:webhookId/deliveries", webhookController.ListDeliveries)
+	devJWTAuth.Post("/v1/webhooks/:webhookId/test", webhookController.TestWebhook)
+
+	// Conditions
+	devJWTAuth.Post("/v1/conditions/evaluate", conditionController.EvaluateCondition)
+
+	// Geofences
+	devJWTAuth.Get("/v1/geofences", geofenceController.ListGeofences)
+	devJWTAuth.Post("/v1/geofences", geofenceController.CreateGeofence)
+	devJWTAuth.Get("/v1/geofences/:geofenceId", geofenceController.GetGeofence)
+	devJWTAuth.Put("/v1/geofences/:geofenceId", geofenceController.UpdateGeofence)
+	devJWTAuth.Delete("/v1/geofences/:geofenceId", geofenceController.DeleteGeofence)
 
 	// Vehicle subscriptions
 	devJWTAuth.Post("/v1/webhooks/:webhookId/subscribe/list", vehicleSubscriptionController.SubscribeVehiclesFromList)
@@ -138,27 +197,51 @@ func CreateFiberApp(logger zerolog.Logger, repo *triggersrepo.Repository,
 	devJWTAuth.Delete("/v1/webhooks/:webhookId/unsubscribe/list", vehicleSubscriptionController.UnsubscribeVehiclesFromList)
 	devJWTAuth.Delete("/v1/webhooks/:webhookId/unsubscribe/all", vehicleSubscriptionController.UnsubscribeAllVehiclesFromWebhook)
 	devJWTAuth.Delete("/v1/webhooks/:webhookId/unsubscribe/:assetDID", vehicleSubscriptionController.RemoveVehicleFromWebhook)
+	devJWTAuth.Post("/v1/webhooks/:webhookId/mute/:assetDID", vehicleSubscriptionController.MuteVehicle)
+	devJWTAuth.Delete("/v1/webhooks/:webhookId/mute/:assetDID", vehicleSubscriptionController.UnmuteVehicle)
 	devJWTAuth.Get("/v1/webhooks/vehicles/:assetDID", vehicleSubscriptionController.ListSubscriptions)
 
 	return app, nil
 }
 
 // startWebhookCache sets up and starts the Kafka consumer for signals and events.
-func startWebhookCache(ctx context.Context, settings *config.Settings, tokenExchangeAPI *tokenexchange.Cache, repo *triggersrepo.Repository) (*webhookcache.WebhookCache, error) {
+func startWebhookCache(ctx context.Context, settings *config.Settings, tokenExchangeAPI *tokenexchange.Cache, repo *triggersrepo.Repository) (*webhookcache.WebhookCache, *cachesync.Listener, error) {
 	// Initialize the in-memory webhook cache.
 	webhookCache := webhookcache.NewWebhookCache(repo, settings)
 
-	// load all existing webhooks into memory so GetWebhooks() won't be empty
-	if err := webhookCache.PopulateCache(ctx); err != nil {
-		return nil, fmt.Errorf("failed to populate webhook cache at startup: %w", err)
+	// Listen for the changes of other replicas before populating, so the ones
+	// written meanwhile are applied once the listener runs.
+	cacheListener, err := cachesync.NewListener(ctx, repo, webhookCache, settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cache listener: %w", err)
 	}
 
 	logger := zerolog.Ctx(ctx)
-	// Periodically refresh the cache so new/updated webhooks show up without
-	// a restart. Each refresh re-scans the full subscriptions table and
-	// recompiles every trigger's CEL program, so the interval is set
-	// conservatively; subscription writes go through the API which already
-	// nudges the cache via ScheduleRefresh.
+	// Start from the snapshot of the last build if there is one, so the
+	// consumers can begin right away, and reconcile it with the database in
+	// the background. Otherwise load all existing webhooks into memory so
+	// GetWebhooks() won't be empty.
+	if err := webhookCache.LoadSnapshot(ctx); err == nil {
+		go func() {
+			if err := webhookCache.PopulateCache(ctx); err != nil {
+				logger.Error().Err(err).Msg("failed to reconcile webhook cache snapshot, scheduling a rebuild")
+				webhookCache.ScheduleRefresh(ctx)
+			}
+		}()
+	} else {
+		if !errors.Is(err, webhookcache.ErrNoSnapshot) {
+			logger.Warn().Err(err).Msg("failed to load webhook cache snapshot, populating from database")
+		}
+		if err := webhookCache.PopulateCache(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to populate webhook cache at startup: %w", err)
+		}
+	}
+
+	// Periodically rebuild the cache as a safety net for changes that were
+	// not applied to it, e.g. when a change notification failed to send. Each
+	// rebuild re-scans the full subscriptions table and recompiles every
+	// trigger's CEL program, so the interval is set conservatively; writes of
+	// any instance update the cache right away through the cache listener.
 	go func() {
 		ticker := time.NewTicker(5 * time.Minute)
 		defer ticker.Stop()
@@ -169,16 +252,13 @@ func startWebhookCache(ctx context.Context, settings *config.Settings, tokenExch
 
 	logger.Info().Msgf("Device signals consumer started on topic: %s", settings.DeviceSignalsTopic)
 
-	return webhookCache, nil
+	return webhookCache, cacheListener, nil
 }
 
-func createSignalConsumer(ctx context.Context, settings *config.Settings, tokenExchangeCache *tokenexchange.Cache, repo *triggersrepo.Repository, webhookCache *webhookcache.WebhookCache) (*kafka.Consumer, error) {
+func createSignalConsumer(settings *config.Settings, vehicleProcessor *metriclistener.MetricListener) (*kafka.Consumer, error) {
 	clusterConfig := sarama.NewConfig()
 	clusterConfig.Version = sarama.V2_8_1_0
 	clusterConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
-	webhookSender := webhooksender.NewWebhookSender(nil)
-	triggerEvaluator := triggerevaluator.NewTriggerEvaluator(repo, tokenExchangeCache)
-	vehicleProcessor := metriclistener.NewMetricsListener(webhookCache, repo, webhookSender, triggerEvaluator, settings)
 	consumerConfig := &kafka.Config{
 		ClusterConfig:   clusterConfig,
 		BrokerAddresses: strings.Split(settings.KafkaBrokers, ","),
@@ -197,13 +277,12 @@ func createSignalConsumer(ctx context.Context, settings *config.Settings, tokenE
 	return consumer, nil
 }
 
-func createEventConsumer(ctx context.Context, settings *config.Settings, tokenExchangeCache *tokenexchange.Cache, repo *triggersrepo.Repository, webhookCache *webhookcache.WebhookCache) (*kafka.Consumer, error) {
+func createEventConsumer(ctx context.Context, settings *config.Settings, tokenExchangeCache *tokenexchange.Cache, repo *triggersrepo.Repository, webhookCache *webhookcache.WebhookCache, webhookSender *webhooksender.WebhookSender, latestSignals *signalstate.Store, vehicleState *vehiclestate.Cache) (*kafka.Consumer, error) {
 	clusterConfig := sarama.NewConfig()
 	clusterConfig.Version = sarama.V2_8_1_0
 	clusterConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
-	webhookSender := webhooksender.NewWebhookSender(nil)
-	triggerEvaluator := triggerevaluator.NewTriggerEvaluator(repo, tokenExchangeCache)
-	vehicleProcessor := metriclistener.NewMetricsListener(webhookCache, repo, webhookSender, triggerEvaluator, settings)
+	triggerEvaluator := triggerevaluator.NewTriggerEvaluator(vehicleState, tokenExchangeCache)
+	vehicleProcessor := metriclistener.NewMetricsListener(webhookCache, repo, webhookSender, triggerEvaluator, nil, latestSignals, vehicleState, settings)
 	consumerConfig := &kafka.Config{
 		ClusterConfig:   clusterConfig,
 		BrokerAddresses: strings.Split(settings.KafkaBrokers, ","),
@@ -0,0 +1,77 @@
+package celcondition
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+)
+
+var absenceEnvOnce = sync.OnceValues(buildAbsenceEnv)
+
+// buildAbsenceEnv builds the env of absence conditions. offline is true when the vehicle went silent and false
+// when it came back online; silentSeconds is how long the vehicle was silent.
+func buildAbsenceEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("offline", cel.BoolType),
+		cel.Variable("silentSeconds", cel.DoubleType),
+		paramsOpt(),
+		cel.CrossTypeNumericComparisons(true),
+	)
+}
+
+// PrepareAbsenceCondition compiles an absence condition. params are the default values of the parameters it may
+// read.
+func PrepareAbsenceCondition(celCondition string, params Params) (cel.Program, error) {
+	env, err := absenceEnvOnce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build absence CEL env: %w", err)
+	}
+	ast, issues := env.Compile(celCondition)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if err := checkParams(ast.NativeRep().Expr(), params); err != nil {
+		return nil, err
+	}
+	prg, err := env.Program(ast,
+		cel.CostLimit(CostLimit),
+		cel.InterruptCheckFrequency(InterruptCheckFrequency),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to program CEL expression: %w", err)
+	}
+
+	vars := AbsenceVariables(true, 0)
+	vars[paramsVariable] = paramsBinding(params)
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	if out.Type() != celtypes.BoolType {
+		return nil, fmt.Errorf("output type is not bool: %s", out.Type())
+	}
+	return prg, nil
+}
+
+// EvaluateAbsenceCondition evaluates the condition for a vehicle that went silent or came back online
+// after being silent for silentFor. params are the parameter values of the vehicle.
+func EvaluateAbsenceCondition(prg cel.Program, offline bool, silentFor time.Duration, params Params) (bool, error) {
+	vars := AbsenceVariables(offline, silentFor)
+	vars[paramsVariable] = paramsBinding(params)
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+}
+
+// AbsenceVariables returns the CEL variables an absence condition is evaluated with.
+func AbsenceVariables(offline bool, silentFor time.Duration) map[string]any {
+	return map[string]any{
+		"offline":       offline,
+		"silentSeconds": silentFor.Seconds(),
+	}
+}
@@ -0,0 +1,43 @@
+package celcondition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsenceCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		condition   string
+		offline     bool
+		silentFor   time.Duration
+		expected    bool
+		expectError bool
+	}{
+		{name: "offline", condition: "offline", offline: true, silentFor: time.Hour, expected: true},
+		{name: "back online", condition: "!offline", offline: false, silentFor: time.Hour, expected: true},
+		{name: "both transitions", condition: "true", offline: false, silentFor: time.Minute, expected: true},
+		{name: "silent for long enough", condition: "offline && silentSeconds >= 3600", offline: true, silentFor: 2 * time.Hour, expected: true},
+		{name: "silent for too short", condition: "!offline && silentSeconds > 86400", offline: false, silentFor: time.Hour, expected: false},
+		{name: "signal variables", condition: "valueNumber > 10", expectError: true},
+		{name: "not a bool", condition: "silentSeconds", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := PrepareCondition(triggersrepo.ServiceAbsence, tt.condition, "", nil, nil)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			result, err := EvaluateAbsenceCondition(prg, tt.offline, tt.silentFor, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
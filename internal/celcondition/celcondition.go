@@ -3,6 +3,7 @@ package celcondition
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/DIMO-Network/model-garage/pkg/vss"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
@@ -45,6 +46,10 @@ func buildSignalEnv() (*cel.Env, error) {
 		cel.Variable("previousValue.longitude", cel.DynType),
 		cel.Variable("previousValue.hdop", cel.DynType),
 		cel.Variable("previousSource", cel.StringType),
+		windowOpt("valueNumber", "value"),
+		geofenceOpt(),
+		localTimeOpt(),
+		paramsOpt(),
 		cel.CrossTypeNumericComparisons(true),
 	)
 }
@@ -59,22 +64,34 @@ func buildEventEnv() (*cel.Env, error) {
 		cel.Variable("previousName", cel.StringType),
 		cel.Variable("previousDurationNs", cel.DynType),
 		cel.Variable("previousMetadata", cel.StringType),
+		cel.Variable(signalsVariable, cel.MapType(cel.StringType, cel.DynType)),
+		windowOpt("durationNs"),
+		geoDistanceOpt(),
+		localTimeOpt(),
+		paramsOpt(),
 		cel.CrossTypeNumericComparisons(true),
 	)
 }
 
-func PrepareCondition(serviceName, celCondition string, valueType string) (cel.Program, error) {
+// PrepareCondition compiles a condition of the given service. geofences are the geofences a signal condition
+// may refer to; they are ignored for other services. params are the default values of the parameters the
+// condition may read.
+func PrepareCondition(serviceName, celCondition string, valueType string, geofences Geofences, params Params) (cel.Program, error) {
 	switch {
 	case triggersrepo.IsSignalService(serviceName):
-		return PrepareSignalCondition(celCondition, valueType)
+		return PrepareSignalCondition(celCondition, valueType, geofences, params)
 	case triggersrepo.IsEventService(serviceName):
-		return PrepareEventCondition(celCondition)
+		return PrepareEventCondition(celCondition, params)
+	case triggersrepo.IsAbsenceService(serviceName):
+		return PrepareAbsenceCondition(celCondition, params)
+	case triggersrepo.IsCompositeService(serviceName):
+		return PrepareCompositeCondition(celCondition, params)
 	default:
 		return nil, fmt.Errorf("unknown service name: %s", serviceName)
 	}
 }
 
-func PrepareEventCondition(celCondition string) (cel.Program, error) {
+func PrepareEventCondition(celCondition string, params Params) (cel.Program, error) {
 	env, err := eventEnvOnce()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build event CEL env: %w", err)
@@ -83,6 +100,13 @@ func PrepareEventCondition(celCondition string) (cel.Program, error) {
 	if issues != nil && issues.Err() != nil {
 		return nil, issues.Err()
 	}
+	latest, err := zeroSignalValues(ast)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkParams(ast.NativeRep().Expr(), params); err != nil {
+		return nil, err
+	}
 	prg, err := env.Program(ast)
 	if err != nil {
 		return nil, fmt.Errorf("failed to program CEL expression: %w", err)
@@ -96,6 +120,10 @@ func PrepareEventCondition(celCondition string) (cel.Program, error) {
 		"previousName":       "",
 		"previousDurationNs": 0,
 		"previousMetadata":   "",
+		windowVariable:       windowValue{},
+		signalsVariable:      signalsBinding(latest),
+		timestampVariable:    time.Time{},
+		paramsVariable:       paramsBinding(params),
 	}
 
 	out, _, err := prg.Eval(vars)
@@ -108,14 +136,37 @@ func PrepareEventCondition(celCondition string) (cel.Program, error) {
 	return prg, nil
 }
 
-func EvaluateEventCondition(prg cel.Program, event *vss.Event, previousEvent *vss.Event) (bool, error) {
+// EvaluateEventCondition evaluates the condition for event. window holds the recent events of the same name,
+// including event, that aggregate functions are computed over; a nil window only holds event. latest holds the
+// latest values of the signals the condition reads, keyed by signal name. params are the parameter values of the
+// vehicle.
+func EvaluateEventCondition(prg cel.Program, event *vss.Event, previousEvent *vss.Event, window []WindowSample, latest map[string]vss.Signal, params Params) (bool, error) {
+	vars, err := EventVariables(event, previousEvent)
+	if err != nil {
+		return false, err
+	}
+	vars[windowVariable] = windowBinding(WindowSample{Timestamp: event.Data.Timestamp, Value: float64(event.Data.DurationNs)}, window)
+	vars[signalsVariable] = signalsBinding(latest)
+	vars[timestampVariable] = event.Data.Timestamp
+	vars[paramsVariable] = paramsBinding(params)
+
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+}
+
+// EventVariables returns the CEL variables an event condition is evaluated with.
+// A nil previousEvent binds the previous* variables to zero values.
+func EventVariables(event *vss.Event, previousEvent *vss.Event) (map[string]any, error) {
 	if event == nil {
-		return false, fmt.Errorf("event is nil")
+		return nil, fmt.Errorf("event is nil")
 	}
 	if previousEvent == nil {
 		previousEvent = &vss.Event{}
 	}
-	vars := map[string]any{
+	return map[string]any{
 		"source":             event.Source,
 		"name":               event.Data.Name,
 		"durationNs":         event.Data.DurationNs,
@@ -124,13 +175,7 @@ func EvaluateEventCondition(prg cel.Program, event *vss.Event, previousEvent *vs
 		"previousName":       previousEvent.Data.Name,
 		"previousDurationNs": previousEvent.Data.DurationNs,
 		"previousMetadata":   previousEvent.Data.Metadata,
-	}
-
-	out, _, err := prg.Eval(vars)
-	if err != nil {
-		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
-	}
-	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+	}, nil
 }
 
 func toFloat64(value any) float64 {
@@ -183,7 +228,22 @@ func geoDistanceOpt() cel.EnvOption {
 	)
 }
 
-func PrepareSignalCondition(celCondition string, valueType string) (cel.Program, error) {
+// combineOpts applies opts in order as a single option.
+func combineOpts(opts []cel.EnvOption) cel.EnvOption {
+	return func(env *cel.Env) (*cel.Env, error) {
+		var err error
+		for _, opt := range opts {
+			if env, err = opt(env); err != nil {
+				return nil, err
+			}
+		}
+		return env, nil
+	}
+}
+
+// PrepareSignalCondition compiles a signal condition. geofences are the geofences the condition may refer to and
+// params the default values of the parameters it may read.
+func PrepareSignalCondition(celCondition string, valueType string, geofences Geofences, params Params) (cel.Program, error) {
 	env, err := signalEnv()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build signal CEL env: %w", err)
@@ -192,9 +252,19 @@ func PrepareSignalCondition(celCondition string, valueType string) (cel.Program,
 	if issues != nil && issues.Err() != nil {
 		return nil, issues.Err()
 	}
+	if names := geofenceNames(ast.NativeRep().Expr()); len(names) > 0 && valueType != signals.LocationType {
+		return nil, fmt.Errorf("geofence functions require a %s signal", signals.LocationType)
+	}
+	if err := checkGeofences(ast.NativeRep().Expr(), geofences); err != nil {
+		return nil, err
+	}
+	if err := checkParams(ast.NativeRep().Expr(), params); err != nil {
+		return nil, err
+	}
 	prg, err := env.Program(ast,
 		cel.CostLimit(CostLimit),
 		cel.InterruptCheckFrequency(InterruptCheckFrequency),
+		cel.Globals(map[string]any{geofencesVariable: geofencesValue(geofences)}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to program CEL expression: %w", err)
@@ -206,6 +276,9 @@ func PrepareSignalCondition(celCondition string, valueType string) (cel.Program,
 		"previousValueNumber": 0,
 		"previousValueString": "",
 		"previousSource":      "",
+		windowVariable:        windowValue{},
+		timestampVariable:     time.Time{},
+		paramsVariable:        paramsBinding(params),
 	}
 
 	switch valueType {
@@ -236,9 +309,30 @@ func PrepareSignalCondition(celCondition string, valueType string) (cel.Program,
 	return prg, nil
 }
 
-func EvaluateSignalCondition(prg cel.Program, signal, previousSignal *vss.Signal, valueType string) (bool, error) {
+// EvaluateSignalCondition evaluates the condition for signal. window holds the recent samples of the signal,
+// including signal, that aggregate functions are computed over; a nil window only holds signal. params are the
+// parameter values of the vehicle.
+func EvaluateSignalCondition(prg cel.Program, signal, previousSignal *vss.Signal, valueType string, window []WindowSample, params Params) (bool, error) {
+	vars, err := SignalVariables(signal, previousSignal, valueType)
+	if err != nil {
+		return false, err
+	}
+	vars[windowVariable] = windowBinding(WindowSample{Timestamp: signal.Data.Timestamp, Value: signal.Data.ValueNumber}, window)
+	vars[timestampVariable] = signal.Data.Timestamp
+	vars[paramsVariable] = paramsBinding(params)
+
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+}
+
+// SignalVariables returns the CEL variables a signal condition is evaluated with.
+// A nil previousSignal binds the previous* variables to zero values.
+func SignalVariables(signal, previousSignal *vss.Signal, valueType string) (map[string]any, error) {
 	if signal == nil {
-		return false, fmt.Errorf("signal is nil")
+		return nil, fmt.Errorf("signal is nil")
 	}
 	if previousSignal == nil {
 		previousSignal = &vss.Signal{}
@@ -266,12 +360,7 @@ func EvaluateSignalCondition(prg cel.Program, signal, previousSignal *vss.Signal
 		vars["previousValue.longitude"] = previousSignal.Data.ValueLocation.Longitude
 		vars["previousValue.hdop"] = previousSignal.Data.ValueLocation.HDOP
 	default:
-		return false, fmt.Errorf("unknown value type: %s", valueType)
-	}
-
-	out, _, err := prg.Eval(vars)
-	if err != nil {
-		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+		return nil, fmt.Errorf("unknown value type: %s", valueType)
 	}
-	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+	return vars, nil
 }
@@ -47,12 +47,12 @@ func TestPrepareCondition_ConcurrentCompile_NoRace(t *testing.T) {
 				switch (gid + i) % 3 {
 				case 0, 1:
 					tc := signalConditions[(gid+i)%len(signalConditions)]
-					if _, err := PrepareSignalCondition(tc.expr, tc.valueType); err != nil {
+					if _, err := PrepareSignalCondition(tc.expr, tc.valueType, nil, nil); err != nil {
 						errCh <- err
 					}
 				case 2:
 					expr := eventConditions[(gid+i)%len(eventConditions)]
-					if _, err := PrepareEventCondition(expr); err != nil {
+					if _, err := PrepareEventCondition(expr, nil); err != nil {
 						errCh <- err
 					}
 				}
@@ -72,9 +72,9 @@ func TestPrepareCondition_ConcurrentEval_NoRace(t *testing.T) {
 	// concurrent Eval calls across goroutines. Programs are read-only at
 	// eval time and must be safe for concurrent use because the Kafka
 	// consumers fan out message processing.
-	signalPrg, err := PrepareSignalCondition(`valueNumber > 10`, signals.NumberType)
+	signalPrg, err := PrepareSignalCondition(`valueNumber > 10`, signals.NumberType, nil, nil)
 	require.NoError(t, err)
-	eventPrg, err := PrepareEventCondition(`name == "ignition.on"`)
+	eventPrg, err := PrepareEventCondition(`name == "ignition.on"`, nil)
 	require.NoError(t, err)
 
 	signalSamples := []*vss.Signal{
@@ -103,13 +103,13 @@ func TestPrepareCondition_ConcurrentEval_NoRace(t *testing.T) {
 			for i := 0; i < iterations; i++ {
 				if (gid+i)%2 == 0 {
 					sig := signalSamples[(gid+i)%len(signalSamples)]
-					if _, err := EvaluateSignalCondition(signalPrg, sig, prev, signals.NumberType); err != nil {
+					if _, err := EvaluateSignalCondition(signalPrg, sig, prev, signals.NumberType, nil, nil); err != nil {
 						errCh <- err
 					}
 					prev = sig
 				} else {
 					ev := eventSamples[(gid+i)%len(eventSamples)]
-					if _, err := EvaluateEventCondition(eventPrg, ev, prevEv); err != nil {
+					if _, err := EvaluateEventCondition(eventPrg, ev, prevEv, nil, nil, nil); err != nil {
 						errCh <- err
 					}
 					prevEv = ev
@@ -129,7 +129,7 @@ func TestPrepareCondition_ConcurrentMixed_NoRace(t *testing.T) {
 	// Worst case: half the goroutines compile fresh programs while the
 	// other half evaluate pre-compiled programs. This exercises Compile +
 	// Check + Program + Eval against the shared env simultaneously.
-	pre, err := PrepareSignalCondition(`valueNumber > 10`, signals.NumberType)
+	pre, err := PrepareSignalCondition(`valueNumber > 10`, signals.NumberType, nil, nil)
 	require.NoError(t, err)
 
 	const goroutines = 64
@@ -157,13 +157,13 @@ func TestPrepareCondition_ConcurrentMixed_NoRace(t *testing.T) {
 			for i := 0; i < iterations; i++ {
 				if gid%2 == 0 {
 					idx := (gid + i) % len(exprs)
-					if _, err := PrepareSignalCondition(exprs[idx], valueTypes[idx]); err != nil {
+					if _, err := PrepareSignalCondition(exprs[idx], valueTypes[idx], nil, nil); err != nil {
 						errCh <- err
 					}
 					continue
 				}
 				sig := &vss.Signal{Data: vss.SignalData{ValueNumber: float64(i)}}
-				if _, err := EvaluateSignalCondition(pre, sig, &vss.Signal{}, signals.NumberType); err != nil {
+				if _, err := EvaluateSignalCondition(pre, sig, &vss.Signal{}, signals.NumberType, nil, nil); err != nil {
 					errCh <- err
 				}
 			}
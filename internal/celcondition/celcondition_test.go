@@ -20,7 +20,7 @@ func TestPrepareConditionLocation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := PrepareSignalCondition(tt.condition, tt.valueType)
+			_, err := PrepareSignalCondition(tt.condition, tt.valueType, nil, nil)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -180,7 +180,7 @@ func TestPrepareCondition(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := PrepareSignalCondition(tt.condition, tt.valueType)
+			_, err := PrepareSignalCondition(tt.condition, tt.valueType, nil, nil)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -493,12 +493,12 @@ func TestEvaluateCondition(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// First prepare the condition
-			prg, err := PrepareSignalCondition(tt.condition, tt.valueType)
+			prg, err := PrepareSignalCondition(tt.condition, tt.valueType, nil, nil)
 			require.NoError(t, err)
 			require.NotNil(t, prg)
 
 			// Then evaluate it
-			result, err := EvaluateSignalCondition(prg, tt.signal, tt.previousSignal, tt.valueType)
+			result, err := EvaluateSignalCondition(prg, tt.signal, tt.previousSignal, tt.valueType, nil, nil)
 			if tt.expectError {
 				require.Error(t, err)
 				return
@@ -510,7 +510,7 @@ func TestEvaluateCondition(t *testing.T) {
 }
 
 func TestEvaluateCondition_WithNilSignal(t *testing.T) {
-	prg, err := PrepareSignalCondition("valueNumber > 10.0", signals.NumberType)
+	prg, err := PrepareSignalCondition("valueNumber > 10.0", signals.NumberType, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to prepare condition: %v", err)
 	}
@@ -519,7 +519,7 @@ func TestEvaluateCondition_WithNilSignal(t *testing.T) {
 	}
 
 	// This should handle nil signal gracefully
-	_, err = EvaluateSignalCondition(prg, nil, nil, signals.NumberType)
+	_, err = EvaluateSignalCondition(prg, nil, nil, signals.NumberType, nil, nil)
 	if err == nil {
 		t.Error("expected error when evaluating with nil signal, got nil")
 	}
@@ -567,7 +567,7 @@ func TestPrepareCondition_WithDecimalNumbers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test the full flow
-			prg, err := PrepareSignalCondition(tt.condition, signals.NumberType)
+			prg, err := PrepareSignalCondition(tt.condition, signals.NumberType, nil, nil)
 			if err != nil {
 				t.Fatalf("failed to prepare condition %q: %v", tt.condition, err)
 			}
@@ -575,7 +575,7 @@ func TestPrepareCondition_WithDecimalNumbers(t *testing.T) {
 				t.Fatalf("expected non-nil program for condition %q", tt.condition)
 			}
 
-			result, err := EvaluateSignalCondition(prg, tt.signal, tt.signal, signals.NumberType)
+			result, err := EvaluateSignalCondition(prg, tt.signal, tt.signal, signals.NumberType, nil, nil)
 			if err != nil {
 				t.Errorf("unexpected error evaluating condition %q: %v", tt.condition, err)
 			}
@@ -621,7 +621,7 @@ func TestPrepareCondition_ServiceName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := PrepareCondition(tt.serviceName, tt.condition, signals.NumberType)
+			_, err := PrepareCondition(tt.serviceName, tt.condition, signals.NumberType, nil, nil)
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
@@ -716,7 +716,7 @@ func TestPrepareEventCondition(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := PrepareEventCondition(tt.condition)
+			_, err := PrepareEventCondition(tt.condition, nil)
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
@@ -1130,12 +1130,12 @@ func TestEvaluateEventCondition(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// First prepare the condition
-			prg, err := PrepareEventCondition(tt.condition)
+			prg, err := PrepareEventCondition(tt.condition, nil)
 			require.NoError(t, err)
 			require.NotNil(t, prg)
 
 			// Then evaluate it
-			result, err := EvaluateEventCondition(prg, tt.event, tt.previousEvent)
+			result, err := EvaluateEventCondition(prg, tt.event, tt.previousEvent, nil, nil, nil)
 			if tt.expectError {
 				require.Error(t, err)
 				return
@@ -1147,7 +1147,7 @@ func TestEvaluateEventCondition(t *testing.T) {
 }
 
 func TestEvaluateEventCondition_WithNilEvent(t *testing.T) {
-	prg, err := PrepareEventCondition("name == 'DoorOpened'")
+	prg, err := PrepareEventCondition("name == 'DoorOpened'", nil)
 	require.NoError(t, err)
 	require.NotNil(t, prg)
 
@@ -1164,7 +1164,7 @@ func TestEvaluateEventCondition_WithNilEvent(t *testing.T) {
 	}
 
 	// This should not panic but may fail depending on implementation
-	_, err = EvaluateEventCondition(prg, nil, previousEvent)
+	_, err = EvaluateEventCondition(prg, nil, previousEvent, nil, nil, nil)
 	// We expect this to either work with empty/zero values or return an error
 	// The function should handle nil gracefully
 	require.Error(t, err)
@@ -1181,9 +1181,9 @@ func TestEvaluateEventCondition_WithNilEvent(t *testing.T) {
 		},
 	}
 
-	_, err = EvaluateEventCondition(prg, currentEvent, nil)
+	_, err = EvaluateEventCondition(prg, currentEvent, nil, nil, nil, nil)
 	require.NoError(t, err)
 	// Test with both nil
-	_, err = EvaluateEventCondition(prg, nil, nil)
+	_, err = EvaluateEventCondition(prg, nil, nil, nil, nil, nil)
 	require.Error(t, err)
 }
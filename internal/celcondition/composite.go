@@ -0,0 +1,215 @@
+package celcondition
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	celtypes "github.com/google/cel-go/common/types"
+)
+
+// signalsVariable is the map of the latest signal values a composite or event condition reads, e.g. signals.speed.
+const signalsVariable = "signals"
+
+var compositeEnvOnce = sync.OnceValues(buildCompositeEnv)
+
+// buildCompositeEnv builds the env of composite conditions. signals maps signal names to their latest value:
+// a number, a string, or a map with latitude, longitude and hdop for locations.
+func buildCompositeEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(signalsVariable, cel.MapType(cel.StringType, cel.DynType)),
+		geoDistanceOpt(),
+		paramsOpt(),
+		cel.CrossTypeNumericComparisons(true),
+	)
+}
+
+// PrepareCompositeCondition compiles a composite condition. The condition must read at least one signal, and
+// only signals of the schema. params are the default values of the parameters it may read.
+func PrepareCompositeCondition(celCondition string, params Params) (cel.Program, error) {
+	env, err := compositeEnvOnce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build composite CEL env: %w", err)
+	}
+	ast, issues := env.Compile(celCondition)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	values, err := zeroSignalValues(ast)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("composite conditions must read at least one signal, e.g. %s.speed", signalsVariable)
+	}
+	if err := checkParams(ast.NativeRep().Expr(), params); err != nil {
+		return nil, err
+	}
+	prg, err := env.Program(ast,
+		cel.CostLimit(CostLimit),
+		cel.InterruptCheckFrequency(InterruptCheckFrequency),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to program CEL expression: %w", err)
+	}
+
+	vars := CompositeVariables(values)
+	vars[paramsVariable] = paramsBinding(params)
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	if out.Type() != celtypes.BoolType {
+		return nil, fmt.Errorf("output type is not bool: %s", out.Type())
+	}
+	return prg, nil
+}
+
+// EvaluateCompositeCondition evaluates the condition against the latest values of the signals it reads, keyed by
+// signal name. Evaluation fails if a signal read by the condition is missing. params are the parameter values of
+// the vehicle.
+func EvaluateCompositeCondition(prg cel.Program, values map[string]vss.Signal, params Params) (bool, error) {
+	vars := CompositeVariables(values)
+	vars[paramsVariable] = paramsBinding(params)
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL condition: %w", err)
+	}
+	return out.Type() == celtypes.BoolType && out.Value() == true, nil
+}
+
+// CompositeVariables returns the CEL variables a composite condition is evaluated with.
+func CompositeVariables(values map[string]vss.Signal) map[string]any {
+	return map[string]any{signalsVariable: signalsBinding(values)}
+}
+
+// signalsBinding returns the value of the signals variable. Each value is bound according to the value type of
+// its signal definition.
+func signalsBinding(values map[string]vss.Signal) map[string]any {
+	bound := make(map[string]any, len(values))
+	for name, signal := range values {
+		switch signals.GetSignalDefinitionOrDefault(name, signals.NumberType).ValueType {
+		case signals.StringType:
+			bound[name] = signal.Data.ValueString
+		case signals.LocationType:
+			bound[name] = map[string]any{
+				"latitude":  signal.Data.ValueLocation.Latitude,
+				"longitude": signal.Data.ValueLocation.Longitude,
+				"hdop":      signal.Data.ValueLocation.HDOP,
+			}
+		default:
+			bound[name] = signal.Data.ValueNumber
+		}
+	}
+	return bound
+}
+
+// zeroSignalValues returns a zero signal for each signal read by the checked condition, keyed by signal name, to
+// test-evaluate the condition with. Only signals of the schema can be read.
+func zeroSignalValues(checked *cel.Ast) (map[string]vss.Signal, error) {
+	names, err := signalReferences(checked.NativeRep().Expr())
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]vss.Signal, len(names))
+	for _, name := range names {
+		if _, err := signals.GetSignalDefinition(name); err != nil {
+			return nil, fmt.Errorf("unknown signal %q", name)
+		}
+		values[name] = vss.Signal{}
+	}
+	return values, nil
+}
+
+// ConditionSignals returns the names of the signals read by a composite or event condition, sorted and without
+// duplicates, e.g. "speed" for signals.speed. It returns nil for conditions of other services.
+func ConditionSignals(serviceName, celCondition string) ([]string, error) {
+	var env *cel.Env
+	var err error
+	switch {
+	case triggersrepo.IsCompositeService(serviceName):
+		env, err = compositeEnvOnce()
+	case triggersrepo.IsEventService(serviceName):
+		env, err = eventEnvOnce()
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL env: %w", err)
+	}
+	parsed, issues := env.Parse(celCondition)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return signalReferences(parsed.NativeRep().Expr())
+}
+
+// TriggerPermissions returns the vehicle permissions a developer license needs for a trigger to watch a vehicle.
+// Signal and absence triggers of a single signal need the permissions of that signal, and composite triggers the
+// permissions of all the signals their condition reads.
+func TriggerPermissions(trigger *models.Trigger) []string {
+	if triggersrepo.IsCompositeService(trigger.Service) {
+		if names, err := ConditionSignals(trigger.Service, trigger.Condition); err == nil && len(names) > 0 {
+			return signals.GetPermissions(names)
+		}
+		return signals.DefaultPermissions
+	}
+	watchesSignal := triggersrepo.IsSignalService(trigger.Service) ||
+		(triggersrepo.IsAbsenceService(trigger.Service) && trigger.MetricName != triggersrepo.AnySignal)
+	if !watchesSignal {
+		return signals.DefaultPermissions
+	}
+	return signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).Permissions
+}
+
+// signalReferences returns the names of the signals read by the expanded expression. Signals must be read by
+// name, as signals.speed or signals["speed"], so that the signals a condition depends on are known up front.
+func signalReferences(expr ast.Expr) ([]string, error) {
+	names, ok := fieldReferences(expr, signalsVariable)
+	if !ok {
+		return nil, fmt.Errorf("%s must be read by signal name, e.g. %s.speed", signalsVariable, signalsVariable)
+	}
+	return names, nil
+}
+
+// fieldReferences returns the fields of the map variable read by the expanded expression, sorted and without
+// duplicates. ok is false if the variable is used other than by reading a field by name, as variable.field or
+// variable["field"].
+func fieldReferences(expr ast.Expr, variable string) (names []string, ok bool) {
+	isVariable := func(e ast.Expr) bool {
+		return e.Kind() == ast.IdentKind && e.AsIdent() == variable
+	}
+	uses, named := 0, 0
+	ast.PostOrderVisit(expr, ast.NewExprVisitor(func(e ast.Expr) {
+		switch e.Kind() {
+		case ast.IdentKind:
+			if e.AsIdent() == variable {
+				uses++
+			}
+		case ast.SelectKind:
+			if isVariable(e.AsSelect().Operand()) {
+				names = append(names, e.AsSelect().FieldName())
+				named++
+			}
+		case ast.CallKind:
+			call := e.AsCall()
+			if call.FunctionName() != "_[_]" || len(call.Args()) != 2 || !isVariable(call.Args()[0]) {
+				return
+			}
+			if key := call.Args()[1]; key.Kind() == ast.LiteralKind {
+				if name, ok := key.AsLiteral().(celtypes.String); ok {
+					names = append(names, string(name))
+					named++
+				}
+			}
+		}
+	}))
+	slices.Sort(names)
+	return slices.Compact(names), uses == named
+}
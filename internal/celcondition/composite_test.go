@@ -0,0 +1,127 @@
+package celcondition
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionSignals(t *testing.T) {
+	tests := []struct {
+		name        string
+		service     string
+		condition   string
+		expected    []string
+		expectError bool
+	}{
+		{name: "select", service: triggersrepo.ServiceComposite, condition: "signals.speed > 0 && signals.isIgnitionOn == 0", expected: []string{"isIgnitionOn", "speed"}},
+		{name: "index", service: triggersrepo.ServiceComposite, condition: `signals["speed"] > 0`, expected: []string{"speed"}},
+		{name: "duplicates", service: triggersrepo.ServiceComposite, condition: "signals.speed > 10 && signals.speed < 20", expected: []string{"speed"}},
+		{name: "has", service: triggersrepo.ServiceComposite, condition: "has(signals.speed) && signals.speed > 0", expected: []string{"speed"}},
+		{name: "dynamic key", service: triggersrepo.ServiceComposite, condition: `signals["sp" + "eed"] > 0`, expectError: true},
+		{name: "whole map", service: triggersrepo.ServiceComposite, condition: "size(signals) > 0", expectError: true},
+		{name: "event", service: triggersrepo.ServiceEvent, condition: "durationNs > 0 && signals.speed > 80", expected: []string{"speed"}},
+		{name: "event without signals", service: triggersrepo.ServiceEvent, condition: "durationNs > 0"},
+		{name: "other service", service: triggersrepo.ServiceSignal, condition: "valueNumber > 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ConditionSignals(tt.service, tt.condition)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestCompositeCondition(t *testing.T) {
+	number := func(v float64) vss.Signal { return vss.Signal{Data: vss.SignalData{ValueNumber: v}} }
+	location := func(lat, lon float64) vss.Signal {
+		return vss.Signal{Data: vss.SignalData{ValueLocation: vss.Location{Latitude: lat, Longitude: lon}}}
+	}
+
+	tests := []struct {
+		name        string
+		condition   string
+		values      map[string]vss.Signal
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:      "moving with ignition off",
+			condition: "signals.speed > 0 && signals.isIgnitionOn == 0",
+			values:    map[string]vss.Signal{"speed": number(12), "isIgnitionOn": number(0)},
+			expected:  true,
+		},
+		{
+			name:      "ignition on",
+			condition: "signals.speed > 0 && signals.isIgnitionOn == 0",
+			values:    map[string]vss.Signal{"speed": number(12), "isIgnitionOn": number(1)},
+			expected:  false,
+		},
+		{
+			name:      "low fuel far from home",
+			condition: "signals.powertrainFuelSystemRelativeLevel < 10 && geoDistance(signals.currentLocationCoordinates.latitude, signals.currentLocationCoordinates.longitude, 40.72, -74.00) > 50.0",
+			values:    map[string]vss.Signal{"powertrainFuelSystemRelativeLevel": number(5), "currentLocationCoordinates": location(41.72, -74.00)},
+			expected:  true,
+		},
+		{name: "no signals", condition: "true", expectError: true},
+		{name: "unknown signal", condition: "signals.warpSpeed > 0", expectError: true},
+		{name: "signal variables", condition: "valueNumber > 0", expectError: true},
+		{name: "not a bool", condition: "signals.speed", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := PrepareCondition(triggersrepo.ServiceComposite, tt.condition, "", nil, nil)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			result, err := EvaluateCompositeCondition(prg, tt.values, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("missing signal", func(t *testing.T) {
+		prg, err := PrepareCompositeCondition("signals.speed > 0 && signals.isIgnitionOn == 0", nil)
+		require.NoError(t, err)
+		_, err = EvaluateCompositeCondition(prg, map[string]vss.Signal{"speed": number(12)}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestEventConditionSignals(t *testing.T) {
+	event := &vss.Event{Data: vss.EventData{Name: "behavior.harshBraking", DurationNs: 1000}}
+	speed := func(v float64) map[string]vss.Signal {
+		return map[string]vss.Signal{"speed": {Data: vss.SignalData{ValueNumber: v}}}
+	}
+
+	prg, err := PrepareEventCondition("name == 'behavior.harshBraking' && signals.speed > 80", nil)
+	require.NoError(t, err)
+
+	result, err := EvaluateEventCondition(prg, event, nil, nil, speed(95), nil)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateEventCondition(prg, event, nil, nil, speed(40), nil)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	_, err = EvaluateEventCondition(prg, event, nil, nil, nil, nil)
+	require.Error(t, err)
+
+	_, err = PrepareEventCondition("signals.warpSpeed > 80", nil)
+	require.Error(t, err)
+	_, err = PrepareEventCondition(`signals["sp" + "eed"] > 80`, nil)
+	require.Error(t, err)
+}
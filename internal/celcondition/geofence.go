@@ -0,0 +1,202 @@
+package celcondition
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/geofence"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// geofencesVariable is bound to the geofences a condition can refer to.
+const geofencesVariable = "_geofences"
+
+// Geofence functions are macros so that the geofence name is a literal that can be checked when the condition
+// is compiled. inGeofence("depot") expands to _inGeofence(_geofences, "depot", value.latitude, value.longitude).
+var geofenceFunctions = map[string]string{
+	"inGeofence":      "_inGeofence",
+	"enteredGeofence": "_enteredGeofence",
+	"exitedGeofence":  "_exitedGeofence",
+}
+
+// Geofences are the geofences of a developer license by name.
+type Geofences map[string]*geofence.Polygon
+
+// ParseGeofences parses the geometries of the stored geofences.
+func ParseGeofences(geofences models.GeofenceSlice) (Geofences, error) {
+	parsed := make(Geofences, len(geofences))
+	for _, g := range geofences {
+		poly, err := geofence.ParsePolygon(g.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse geofence %q: %w", g.Name, err)
+		}
+		parsed[g.Name] = poly
+	}
+	return parsed, nil
+}
+
+// geofenceOpt declares the geofence functions of the signal environment.
+func geofenceOpt() cel.EnvOption {
+	location := func(eh cel.MacroExprFactory, prefix string) []ast.Expr {
+		return []ast.Expr{
+			eh.NewSelect(eh.NewIdent(prefix), "latitude"),
+			eh.NewSelect(eh.NewIdent(prefix), "longitude"),
+		}
+	}
+	macro := func(name string, previous bool) cel.Macro {
+		return cel.GlobalMacro(name, 1, func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *common.Error) {
+			if args[0].Kind() != ast.LiteralKind || args[0].AsLiteral().Type() != celtypes.StringType {
+				return nil, eh.NewError(args[0].ID(), fmt.Sprintf("%s requires a geofence name literal", name))
+			}
+			callArgs := []ast.Expr{eh.NewIdent(geofencesVariable), args[0]}
+			if previous {
+				callArgs = append(callArgs, location(eh, "previousValue")...)
+			}
+			callArgs = append(callArgs, location(eh, "value")...)
+			return eh.NewCall(geofenceFunctions[name], callArgs...), nil
+		})
+	}
+	lookup := func(set, name ref.Val) (*geofence.Polygon, ref.Val) {
+		geofences, ok := set.(geofencesValue)
+		if !ok {
+			return nil, celtypes.NewErr("no geofences bound")
+		}
+		poly, ok := geofences[string(name.(celtypes.String))]
+		if !ok {
+			return nil, celtypes.NewErr("unknown geofence %q", name)
+		}
+		return poly, nil
+	}
+	crossing := func(entered bool) func(args ...ref.Val) ref.Val {
+		return func(args ...ref.Val) ref.Val {
+			poly, errVal := lookup(args[0], args[1])
+			if errVal != nil {
+				return errVal
+			}
+			prevLat, prevLon := toFloat64(args[2].Value()), toFloat64(args[3].Value())
+			// Without a previous location there is no boundary to cross.
+			if prevLat == 0 && prevLon == 0 {
+				return celtypes.False
+			}
+			wasInside := poly.Contains(prevLat, prevLon)
+			isInside := poly.Contains(toFloat64(args[4].Value()), toFloat64(args[5].Value()))
+			if entered {
+				return celtypes.Bool(!wasInside && isInside)
+			}
+			return celtypes.Bool(wasInside && !isInside)
+		}
+	}
+	crossingArgs := []*cel.Type{cel.DynType, cel.StringType, cel.DynType, cel.DynType, cel.DynType, cel.DynType}
+
+	opts := []cel.EnvOption{
+		cel.Variable(geofencesVariable, cel.DynType),
+		cel.Macros(macro("inGeofence", false), macro("enteredGeofence", true), macro("exitedGeofence", true)),
+		cel.Function("_inGeofence",
+			cel.Overload("_inGeofence_dyn_string_dyn_dyn", []*cel.Type{cel.DynType, cel.StringType, cel.DynType, cel.DynType}, cel.BoolType,
+				cel.FunctionBinding(func(args ...ref.Val) ref.Val {
+					poly, errVal := lookup(args[0], args[1])
+					if errVal != nil {
+						return errVal
+					}
+					return celtypes.Bool(poly.Contains(toFloat64(args[2].Value()), toFloat64(args[3].Value())))
+				}),
+			),
+		),
+		cel.Function("_enteredGeofence",
+			cel.Overload("_enteredGeofence_dyn_string_dyn_dyn_dyn_dyn", crossingArgs, cel.BoolType, cel.FunctionBinding(crossing(true))),
+		),
+		cel.Function("_exitedGeofence",
+			cel.Overload("_exitedGeofence_dyn_string_dyn_dyn_dyn_dyn", crossingArgs, cel.BoolType, cel.FunctionBinding(crossing(false))),
+		),
+	}
+	return combineOpts(opts)
+}
+
+// ConditionGeofences returns the names of the geofences the condition of the given service refers to.
+func ConditionGeofences(serviceName, celCondition string) ([]string, error) {
+	if !triggersrepo.IsSignalService(serviceName) {
+		return nil, nil
+	}
+	env, err := signalEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL env: %w", err)
+	}
+	parsed, issues := env.Parse(celCondition)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return geofenceNames(parsed.NativeRep().Expr()), nil
+}
+
+// geofenceNames returns the names of the geofences referred to by the expanded expression.
+func geofenceNames(expr ast.Expr) []string {
+	var names []string
+	ast.PostOrderVisit(expr, ast.NewExprVisitor(func(e ast.Expr) {
+		if e.Kind() != ast.CallKind || !isGeofenceFunction(e.AsCall().FunctionName()) {
+			return
+		}
+		args := e.AsCall().Args()
+		if len(args) < 2 || args[1].Kind() != ast.LiteralKind {
+			return
+		}
+		if name, ok := args[1].AsLiteral().(celtypes.String); ok && !slices.Contains(names, string(name)) {
+			names = append(names, string(name))
+		}
+	}))
+	return names
+}
+
+func isGeofenceFunction(name string) bool {
+	for _, fn := range geofenceFunctions {
+		if fn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGeofences returns an error if the expression refers to a geofence that is not in geofences.
+func checkGeofences(expr ast.Expr, geofences Geofences) error {
+	for _, name := range geofenceNames(expr) {
+		if _, ok := geofences[name]; !ok {
+			return fmt.Errorf("unknown geofence %q", name)
+		}
+	}
+	return nil
+}
+
+var geofencesType = celtypes.NewOpaqueType("celcondition.geofences")
+
+// geofencesValue is the CEL value of the geofences variable. It only exists to be passed to the geofence functions.
+type geofencesValue Geofences
+
+func (g geofencesValue) ConvertToNative(reflect.Type) (any, error) {
+	return nil, errors.New("geofences cannot be converted to a native value")
+}
+
+func (g geofencesValue) ConvertToType(t ref.Type) ref.Val {
+	if t.TypeName() == geofencesType.TypeName() {
+		return g
+	}
+	return celtypes.NewErr("type conversion error from geofences to %s", t.TypeName())
+}
+
+func (g geofencesValue) Equal(ref.Val) ref.Val {
+	return celtypes.False
+}
+
+func (g geofencesValue) Type() ref.Type {
+	return geofencesType
+}
+
+func (g geofencesValue) Value() any {
+	return Geofences(g)
+}
@@ -0,0 +1,92 @@
+package celcondition
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/stretchr/testify/require"
+)
+
+const depotGeometry = `{"type":"Polygon","coordinates":[[[-74.02,40.70],[-73.98,40.70],[-73.98,40.74],[-74.02,40.74],[-74.02,40.70]]]}`
+
+func testGeofences(t *testing.T) Geofences {
+	t.Helper()
+	geofences, err := ParseGeofences(models.GeofenceSlice{{Name: "depot", Geometry: []byte(depotGeometry)}})
+	require.NoError(t, err)
+	return geofences
+}
+
+func TestPrepareConditionGeofence(t *testing.T) {
+	geofences := testGeofences(t)
+
+	tests := []struct {
+		name        string
+		service     string
+		condition   string
+		valueType   string
+		geofences   []string
+		expectError bool
+	}{
+		{name: "inGeofence", service: triggersrepo.ServiceSignal, condition: `inGeofence("depot")`, valueType: signals.LocationType, geofences: []string{"depot"}},
+		{name: "crossings", service: triggersrepo.ServiceSignal, condition: `enteredGeofence("depot") || exitedGeofence("depot")`, valueType: signals.LocationType, geofences: []string{"depot"}},
+		{name: "no geofences", service: triggersrepo.ServiceSignal, condition: `value.latitude > 10.0`, valueType: signals.LocationType},
+		{name: "unknown geofence", service: triggersrepo.ServiceSignal, condition: `inGeofence("yard")`, valueType: signals.LocationType, geofences: []string{"yard"}, expectError: true},
+		{name: "name not a literal", service: triggersrepo.ServiceSignal, condition: `inGeofence(source)`, valueType: signals.LocationType, expectError: true},
+		{name: "not a location signal", service: triggersrepo.ServiceSignal, condition: `inGeofence("depot")`, valueType: signals.NumberType, geofences: []string{"depot"}, expectError: true},
+		{name: "events", service: triggersrepo.ServiceEvent, condition: `inGeofence("depot")`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PrepareCondition(tt.service, tt.condition, tt.valueType, geofences, nil)
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.geofences != nil || !tt.expectError {
+				names, err := ConditionGeofences(tt.service, tt.condition)
+				require.NoError(t, err)
+				require.Equal(t, tt.geofences, names)
+			}
+		})
+	}
+}
+
+func TestEvaluateConditionGeofence(t *testing.T) {
+	geofences := testGeofences(t)
+	location := func(lat, lon float64) *vss.Signal {
+		return &vss.Signal{Data: vss.SignalData{ValueLocation: vss.Location{Latitude: lat, Longitude: lon}}}
+	}
+	inside, outside := location(40.72, -74.00), location(40.80, -74.00)
+
+	tests := []struct {
+		name      string
+		condition string
+		signal    *vss.Signal
+		previous  *vss.Signal
+		expected  bool
+	}{
+		{name: "inside", condition: `inGeofence("depot")`, signal: inside, expected: true},
+		{name: "outside", condition: `inGeofence("depot")`, signal: outside, expected: false},
+		{name: "entered", condition: `enteredGeofence("depot")`, signal: inside, previous: outside, expected: true},
+		{name: "stayed inside", condition: `enteredGeofence("depot")`, signal: inside, previous: inside, expected: false},
+		{name: "entered without a previous location", condition: `enteredGeofence("depot")`, signal: inside, expected: false},
+		{name: "exited", condition: `exitedGeofence("depot")`, signal: outside, previous: inside, expected: true},
+		{name: "stayed outside", condition: `exitedGeofence("depot")`, signal: outside, previous: outside, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := PrepareSignalCondition(tt.condition, signals.LocationType, geofences, nil)
+			require.NoError(t, err)
+
+			result, err := EvaluateSignalCondition(prg, tt.signal, tt.previous, signals.LocationType, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
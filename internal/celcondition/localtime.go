@@ -0,0 +1,80 @@
+package celcondition
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// timestampVariable is bound to the timestamp of the signal or event a condition is evaluated for.
+const timestampVariable = "_timestamp"
+
+// Local time functions are macros so that the time zone is a literal that can be checked when the condition is
+// compiled. hourOfDay("Europe/Berlin") expands to _hourOfDay(_timestamp, "Europe/Berlin").
+var localTimeFunctions = map[string]string{
+	"hourOfDay": "_hourOfDay",
+	"dayOfWeek": "_dayOfWeek",
+}
+
+// locations caches the time zones loaded by the local time functions by name.
+var locations sync.Map
+
+// loadLocation returns the time zone of the given IANA name.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// localTimeOpt declares the local time functions of an environment: hourOfDay(tz) returns the hour, 0 to 23, and
+// dayOfWeek(tz) the day of the week, 0 for Sunday to 6 for Saturday, of the timestamp in the time zone tz.
+func localTimeOpt() cel.EnvOption {
+	macro := func(name string) cel.Macro {
+		return cel.GlobalMacro(name, 1, func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *common.Error) {
+			if args[0].Kind() != ast.LiteralKind || args[0].AsLiteral().Type() != celtypes.StringType {
+				return nil, eh.NewError(args[0].ID(), fmt.Sprintf("%s requires a time zone literal such as \"Europe/Berlin\"", name))
+			}
+			if _, err := loadLocation(string(args[0].AsLiteral().(celtypes.String))); err != nil {
+				return nil, eh.NewError(args[0].ID(), fmt.Sprintf("unknown time zone %q", args[0].AsLiteral()))
+			}
+			return eh.NewCall(localTimeFunctions[name], eh.NewIdent(timestampVariable), args[0]), nil
+		})
+	}
+	function := func(name string, fn func(t time.Time) int) cel.EnvOption {
+		return cel.Function(name,
+			cel.Overload(name+"_timestamp_string", []*cel.Type{cel.TimestampType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(func(ts, tz ref.Val) ref.Val {
+					t, ok := ts.Value().(time.Time)
+					if !ok {
+						return celtypes.NewErr("no timestamp bound for %s", name)
+					}
+					loc, err := loadLocation(string(tz.(celtypes.String)))
+					if err != nil {
+						return celtypes.NewErr("unknown time zone %q", tz)
+					}
+					return celtypes.Int(fn(t.In(loc)))
+				}),
+			),
+		)
+	}
+
+	opts := []cel.EnvOption{
+		cel.Variable(timestampVariable, cel.TimestampType),
+		cel.Macros(macro("hourOfDay"), macro("dayOfWeek")),
+		function("_hourOfDay", func(t time.Time) int { return t.Hour() }),
+		function("_dayOfWeek", func(t time.Time) int { return int(t.Weekday()) }),
+	}
+	return combineOpts(opts)
+}
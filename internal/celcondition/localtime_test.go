@@ -0,0 +1,56 @@
+package celcondition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareConditionLocalTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		service     string
+		condition   string
+		valueType   string
+		expectError bool
+	}{
+		{name: "hour of a signal", service: triggersrepo.ServiceSignal, condition: `hourOfDay("America/New_York") >= 20 && valueNumber > 0`, valueType: signals.NumberType},
+		{name: "day of an event", service: triggersrepo.ServiceEvent, condition: `dayOfWeek("Europe/Berlin") in [0, 6]`},
+		{name: "unknown time zone", service: triggersrepo.ServiceSignal, condition: `hourOfDay("Mars/Olympus") > 1`, valueType: signals.NumberType, expectError: true},
+		{name: "time zone not a literal", service: triggersrepo.ServiceSignal, condition: `hourOfDay(valueString) > 1`, valueType: signals.StringType, expectError: true},
+		{name: "not available in composites", service: triggersrepo.ServiceComposite, condition: `hourOfDay("UTC") > 1 && signals.speed > 0`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PrepareCondition(tt.service, tt.condition, tt.valueType, nil, nil)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEvaluateConditionLocalTime(t *testing.T) {
+	// A Saturday, 22:30 in New York.
+	timestamp := time.Date(2025, 12, 7, 3, 30, 0, 0, time.UTC)
+
+	prg, err := PrepareSignalCondition(`hourOfDay("America/New_York") == 22 && dayOfWeek("America/New_York") == 6`, signals.NumberType, nil, nil)
+	require.NoError(t, err)
+	met, err := EvaluateSignalCondition(prg, &vss.Signal{Data: vss.SignalData{Timestamp: timestamp}}, nil, signals.NumberType, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, met)
+
+	prg, err = PrepareEventCondition(`hourOfDay("UTC") == 3 && dayOfWeek("UTC") == 0`, nil)
+	require.NoError(t, err)
+	met, err = EvaluateEventCondition(prg, &vss.Event{Data: vss.EventData{Timestamp: timestamp}}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, met)
+}
@@ -0,0 +1,147 @@
+package celcondition
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+)
+
+// paramsVariable is the map of the parameters a condition reads, e.g. params.limit.
+const paramsVariable = "params"
+
+const (
+	// MaxParams bounds the parameters of a trigger.
+	MaxParams = 20
+	// maxParamStringLength bounds the length of string parameter values.
+	maxParamStringLength = 256
+)
+
+var paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// Params are the values of the parameters of a condition by name. Values are numbers (float64), strings or bools.
+type Params map[string]any
+
+// ParseParams parses a JSON object of parameter values such as {"limit": 120, "zone": "north"}. Empty or null
+// data returns nil.
+func ParseParams(data []byte) (Params, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var params Params
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("params must be an object of numbers, strings and booleans: %w", err)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Validate checks that the parameter names are identifiers and that the values are numbers, strings or bools.
+// Integer values are converted to float64 so that params parsed from JSON and params built in Go compare alike.
+func (p Params) Validate() error {
+	if len(p) > MaxParams {
+		return fmt.Errorf("at most %d params are allowed", MaxParams)
+	}
+	for name, value := range p {
+		if !paramNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid param name %q, names must be identifiers of at most 64 characters", name)
+		}
+		switch v := value.(type) {
+		case float64, bool:
+		case int:
+			p[name] = float64(v)
+		case string:
+			if len(v) > maxParamStringLength {
+				return fmt.Errorf("param %q must be at most %d characters", name, maxParamStringLength)
+			}
+		default:
+			return fmt.Errorf("param %q must be a number, string or boolean", name)
+		}
+	}
+	return nil
+}
+
+// Override returns a copy of the defaults p with the values of overrides. Overrides must name parameters of p and
+// have the same type. Invalid overrides are left out of the result and reported in the error, so that callers
+// may either reject them or fall back to the defaults.
+func (p Params) Override(overrides Params) (Params, error) {
+	if len(overrides) == 0 {
+		return p, nil
+	}
+	merged := maps.Clone(p)
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		value := overrides[name]
+		def, ok := p[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("unknown param %q", name))
+		case paramType(value) != paramType(def):
+			errs = append(errs, fmt.Errorf("param %q must be a %s", name, paramType(def)))
+		default:
+			merged[name] = value
+		}
+	}
+	return merged, errors.Join(errs...)
+}
+
+// MarshalJSON returns the params as a JSON object, {} for nil params.
+func (p Params) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]any(p))
+}
+
+func paramType(value any) string {
+	switch value.(type) {
+	case float64, int:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// paramsOpt declares the params variable of an environment.
+func paramsOpt() cel.EnvOption {
+	return cel.Variable(paramsVariable, cel.MapType(cel.StringType, cel.DynType))
+}
+
+// checkParams checks that the parameters read by the expanded expression have a value in params. Parameters
+// must be read by name, as params.limit or params["limit"].
+func checkParams(expr ast.Expr, params Params) error {
+	names, ok := fieldReferences(expr, paramsVariable)
+	if !ok {
+		return fmt.Errorf("%s must be read by name, e.g. %s.limit", paramsVariable, paramsVariable)
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := params[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("condition reads params without a default value: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// paramsBinding returns the value of the params variable.
+func paramsBinding(params Params) map[string]any {
+	if params == nil {
+		return map[string]any{}
+	}
+	return params
+}
@@ -0,0 +1,128 @@
+package celcondition
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParams(t *testing.T) {
+	tooMany := make([]string, 0, MaxParams+1)
+	for i := range MaxParams + 1 {
+		tooMany = append(tooMany, fmt.Sprintf(`"p%d": 1`, i))
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		expected    Params
+		expectError bool
+	}{
+		{name: "empty", data: ``},
+		{name: "null", data: `null`},
+		{name: "values", data: `{"limit": 120, "zone": "north", "strict": true}`, expected: Params{"limit": 120.0, "zone": "north", "strict": true}},
+		{name: "not an object", data: `[1, 2]`, expectError: true},
+		{name: "nested value", data: `{"limits": {"max": 1}}`, expectError: true},
+		{name: "null value", data: `{"limit": null}`, expectError: true},
+		{name: "name not an identifier", data: `{"speed-limit": 1}`, expectError: true},
+		{name: "too many", data: "{" + strings.Join(tooMany, ",") + "}", expectError: true},
+		{name: "string too long", data: `{"zone": "` + strings.Repeat("a", maxParamStringLength+1) + `"}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseParams([]byte(tt.data))
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
+
+func TestParamsOverride(t *testing.T) {
+	defaults := Params{"limit": 55.0, "zone": "north"}
+
+	merged, err := defaults.Override(Params{"limit": 70.0})
+	require.NoError(t, err)
+	assert.Equal(t, Params{"limit": 70.0, "zone": "north"}, merged)
+	assert.Equal(t, 55.0, defaults["limit"], "defaults must not be modified")
+
+	merged, err = defaults.Override(Params{"limit": "fast", "unknown": 1.0, "zone": "south"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `param "limit" must be a number`)
+	assert.Contains(t, err.Error(), `unknown param "unknown"`)
+	assert.Equal(t, Params{"limit": 55.0, "zone": "south"}, merged, "invalid overrides fall back to the defaults")
+}
+
+func TestPrepareConditionParams(t *testing.T) {
+	defaults := Params{"limit": 55.0}
+	tests := []struct {
+		name        string
+		service     string
+		condition   string
+		valueType   string
+		params      Params
+		expectError bool
+	}{
+		{name: "signal", service: triggersrepo.ServiceSignal, condition: `valueNumber > params.limit`, valueType: signals.NumberType, params: defaults},
+		{name: "index by literal", service: triggersrepo.ServiceSignal, condition: `valueNumber > params["limit"]`, valueType: signals.NumberType, params: defaults},
+		{name: "event", service: triggersrepo.ServiceEvent, condition: `durationNs > params.limit`, params: defaults},
+		{name: "absence", service: triggersrepo.ServiceAbsence, condition: `silentSeconds > params.limit`, params: defaults},
+		{name: "composite", service: triggersrepo.ServiceComposite, condition: `signals.speed > params.limit`, params: defaults},
+		{name: "no default", service: triggersrepo.ServiceSignal, condition: `valueNumber > params.max`, valueType: signals.NumberType, params: defaults, expectError: true},
+		{name: "no params", service: triggersrepo.ServiceSignal, condition: `valueNumber > params.limit`, valueType: signals.NumberType, expectError: true},
+		{name: "dynamic name", service: triggersrepo.ServiceSignal, condition: `valueNumber > params[valueString]`, valueType: signals.NumberType, params: defaults, expectError: true},
+		{name: "whole map", service: triggersrepo.ServiceSignal, condition: `size(params) > 0`, valueType: signals.NumberType, params: defaults, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PrepareCondition(tt.service, tt.condition, tt.valueType, nil, tt.params)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEvaluateConditionParams(t *testing.T) {
+	defaults := Params{"limit": 55.0}
+	overridden, err := defaults.Override(Params{"limit": 70.0})
+	require.NoError(t, err)
+
+	signalPrg, err := PrepareSignalCondition(`valueNumber > params.limit`, signals.NumberType, nil, defaults)
+	require.NoError(t, err)
+	signal := &vss.Signal{Data: vss.SignalData{ValueNumber: 60}}
+	met, err := EvaluateSignalCondition(signalPrg, signal, nil, signals.NumberType, nil, defaults)
+	require.NoError(t, err)
+	assert.True(t, met)
+	met, err = EvaluateSignalCondition(signalPrg, signal, nil, signals.NumberType, nil, overridden)
+	require.NoError(t, err)
+	assert.False(t, met)
+	_, err = EvaluateSignalCondition(signalPrg, signal, nil, signals.NumberType, nil, nil)
+	require.Error(t, err, "a condition reading a param fails without a value")
+
+	prg, err := PrepareCompositeCondition(`signals.speed > params.limit`, defaults)
+	require.NoError(t, err)
+	met, err = EvaluateCompositeCondition(prg, map[string]vss.Signal{"speed": *signal}, overridden)
+	require.NoError(t, err)
+	assert.False(t, met)
+
+	prg, err = PrepareAbsenceCondition(`silentSeconds > params.limit`, defaults)
+	require.NoError(t, err)
+	met, err = EvaluateAbsenceCondition(prg, true, time.Minute, defaults)
+	require.NoError(t, err)
+	assert.True(t, met)
+}
@@ -0,0 +1,229 @@
+package celcondition
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+const (
+	// MaxWindow bounds the time window of an aggregate function.
+	MaxWindow = 24 * time.Hour
+
+	// windowVariable is bound to the samples the aggregate functions of a condition are computed over.
+	windowVariable = "_window"
+)
+
+// Aggregate functions are macros so that their window is a literal that can be validated when the condition is
+// compiled. avg(valueNumber, "5m") expands to _windowAvg(_window, 300000000000).
+var windowFunctions = map[string]string{
+	"avg":   "_windowAvg",
+	"min":   "_windowMin",
+	"max":   "_windowMax",
+	"count": "_windowCount",
+}
+
+// WindowSample is a single observation of a metric kept for windowed aggregates.
+type WindowSample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// windowOpt declares the aggregate functions of an environment. valueIdents are the variables that may be
+// aggregated with avg, min and max.
+func windowOpt(valueIdents ...string) cel.EnvOption {
+	valueMacro := func(name string) cel.Macro {
+		return cel.GlobalMacro(name, 2, func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *common.Error) {
+			if args[0].Kind() != ast.IdentKind || !slices.Contains(valueIdents, args[0].AsIdent()) {
+				return nil, eh.NewError(args[0].ID(), fmt.Sprintf("%s can only aggregate %v", name, valueIdents))
+			}
+			window, err := windowLiteral(eh, args[1])
+			if err != nil {
+				return nil, err
+			}
+			return eh.NewCall(windowFunctions[name], eh.NewIdent(windowVariable), window), nil
+		})
+	}
+	countMacro := cel.GlobalMacro("count", 1, func(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *common.Error) {
+		window, err := windowLiteral(eh, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return eh.NewCall(windowFunctions["count"], eh.NewIdent(windowVariable), window), nil
+	})
+
+	aggregate := func(name string, resultType *cel.Type, fn func(samples []WindowSample) ref.Val) cel.EnvOption {
+		return cel.Function(name,
+			cel.Overload(name+"_dyn_int", []*cel.Type{cel.DynType, cel.IntType}, resultType,
+				cel.BinaryBinding(func(w, d ref.Val) ref.Val {
+					window, ok := w.(windowValue)
+					if !ok {
+						return celtypes.NewErr("no window bound for %s", name)
+					}
+					return fn(window.within(time.Duration(d.(celtypes.Int))))
+				}),
+			),
+		)
+	}
+
+	opts := []cel.EnvOption{
+		cel.Variable(windowVariable, cel.DynType),
+		cel.Macros(valueMacro("avg"), valueMacro("min"), valueMacro("max"), countMacro),
+		aggregate("_windowAvg", cel.DoubleType, func(samples []WindowSample) ref.Val {
+			if len(samples) == 0 {
+				return celtypes.Double(0)
+			}
+			var sum float64
+			for _, s := range samples {
+				sum += s.Value
+			}
+			return celtypes.Double(sum / float64(len(samples)))
+		}),
+		aggregate("_windowMin", cel.DoubleType, func(samples []WindowSample) ref.Val {
+			if len(samples) == 0 {
+				return celtypes.Double(0)
+			}
+			out := samples[0].Value
+			for _, s := range samples[1:] {
+				out = min(out, s.Value)
+			}
+			return celtypes.Double(out)
+		}),
+		aggregate("_windowMax", cel.DoubleType, func(samples []WindowSample) ref.Val {
+			if len(samples) == 0 {
+				return celtypes.Double(0)
+			}
+			out := samples[0].Value
+			for _, s := range samples[1:] {
+				out = max(out, s.Value)
+			}
+			return celtypes.Double(out)
+		}),
+		aggregate("_windowCount", cel.IntType, func(samples []WindowSample) ref.Val {
+			return celtypes.Int(len(samples))
+		}),
+	}
+	return combineOpts(opts)
+}
+
+// windowLiteral validates that expr is a duration string within the window limits and returns it as an
+// int literal of nanoseconds.
+func windowLiteral(eh cel.MacroExprFactory, expr ast.Expr) (ast.Expr, *common.Error) {
+	if expr.Kind() != ast.LiteralKind || expr.AsLiteral().Type() != celtypes.StringType {
+		return nil, eh.NewError(expr.ID(), `window must be a duration string such as "5m"`)
+	}
+	window, err := time.ParseDuration(string(expr.AsLiteral().(celtypes.String)))
+	if err != nil {
+		return nil, eh.NewError(expr.ID(), fmt.Sprintf("invalid window: %v", err))
+	}
+	if window <= 0 || window > MaxWindow {
+		return nil, eh.NewError(expr.ID(), fmt.Sprintf("window must be greater than 0 and at most %s", MaxWindow))
+	}
+	return eh.NewLiteral(celtypes.Int(window)), nil
+}
+
+// ConditionWindow returns the longest window aggregated over by the condition of the given service,
+// or 0 if the condition does not use aggregate functions.
+func ConditionWindow(serviceName, celCondition string) (time.Duration, error) {
+	if triggersrepo.IsAbsenceService(serviceName) || triggersrepo.IsCompositeService(serviceName) {
+		return 0, nil
+	}
+	var env *cel.Env
+	var err error
+	if triggersrepo.IsEventService(serviceName) {
+		env, err = eventEnvOnce()
+	} else {
+		env, err = signalEnv()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to build CEL env: %w", err)
+	}
+	parsed, issues := env.Parse(celCondition)
+	if issues != nil && issues.Err() != nil {
+		return 0, issues.Err()
+	}
+
+	var window time.Duration
+	ast.PostOrderVisit(parsed.NativeRep().Expr(), ast.NewExprVisitor(func(e ast.Expr) {
+		if e.Kind() != ast.CallKind || !isWindowFunction(e.AsCall().FunctionName()) {
+			return
+		}
+		args := e.AsCall().Args()
+		if len(args) == 2 && args[1].Kind() == ast.LiteralKind {
+			if d, ok := args[1].AsLiteral().(celtypes.Int); ok {
+				window = max(window, time.Duration(d))
+			}
+		}
+	}))
+	return window, nil
+}
+
+func isWindowFunction(name string) bool {
+	for _, fn := range windowFunctions {
+		if fn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// windowBinding returns the value bound to the window variable. samples are the recent samples of the metric,
+// including current. Without samples, aggregates are computed over current only.
+func windowBinding(current WindowSample, samples []WindowSample) windowValue {
+	if samples == nil {
+		samples = []WindowSample{current}
+	}
+	return windowValue{at: current.Timestamp, samples: samples}
+}
+
+var windowType = celtypes.NewOpaqueType("celcondition.window")
+
+// windowValue is the CEL value of the window variable. It only exists to be passed to the aggregate functions.
+type windowValue struct {
+	at      time.Time
+	samples []WindowSample
+}
+
+// within returns the samples taken during the window that ends at the current sample.
+func (w windowValue) within(window time.Duration) []WindowSample {
+	from := w.at.Add(-window)
+	out := make([]WindowSample, 0, len(w.samples))
+	for _, s := range w.samples {
+		if s.Timestamp.After(from) && !s.Timestamp.After(w.at) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (w windowValue) ConvertToNative(reflect.Type) (any, error) {
+	return nil, errors.New("window cannot be converted to a native value")
+}
+
+func (w windowValue) ConvertToType(t ref.Type) ref.Val {
+	if t.TypeName() == windowType.TypeName() {
+		return w
+	}
+	return celtypes.NewErr("type conversion error from window to %s", t.TypeName())
+}
+
+func (w windowValue) Equal(ref.Val) ref.Val {
+	return celtypes.False
+}
+
+func (w windowValue) Type() ref.Type {
+	return windowType
+}
+
+func (w windowValue) Value() any {
+	return w.samples
+}
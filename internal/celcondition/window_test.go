@@ -0,0 +1,108 @@
+package celcondition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareConditionWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		service     string
+		condition   string
+		valueType   string
+		window      time.Duration
+		expectError bool
+	}{
+		{name: "avg of valueNumber", service: triggersrepo.ServiceSignal, condition: `avg(valueNumber, "5m") > 90`, valueType: signals.NumberType, window: 5 * time.Minute},
+		{name: "min and max", service: triggersrepo.ServiceSignal, condition: `max(value, "1h") - min(value, "10m") > 20`, valueType: signals.NumberType, window: time.Hour},
+		{name: "count of signals", service: triggersrepo.ServiceSignal, condition: `count("30s") >= 3`, valueType: signals.NumberType, window: 30 * time.Second},
+		{name: "count of events", service: triggersrepo.ServiceEvent, condition: `name == 'HarshBraking' && count("1h") > 10`, window: time.Hour},
+		{name: "avg of durationNs", service: triggersrepo.ServiceEvent, condition: `avg(durationNs, "24h") > 1000`, window: 24 * time.Hour},
+		{name: "no aggregates", service: triggersrepo.ServiceSignal, condition: `valueNumber > 90`, valueType: signals.NumberType},
+		{name: "window too long", service: triggersrepo.ServiceSignal, condition: `avg(valueNumber, "25h") > 90`, valueType: signals.NumberType, expectError: true},
+		{name: "negative window", service: triggersrepo.ServiceEvent, condition: `count("-1m") > 1`, expectError: true},
+		{name: "invalid window", service: triggersrepo.ServiceSignal, condition: `count("soon") > 1`, valueType: signals.NumberType, expectError: true},
+		{name: "window not a literal", service: triggersrepo.ServiceSignal, condition: `count(valueString) > 1`, valueType: signals.StringType, expectError: true},
+		{name: "aggregate of an expression", service: triggersrepo.ServiceSignal, condition: `avg(valueNumber * 2, "5m") > 1`, valueType: signals.NumberType, expectError: true},
+		{name: "aggregate of a signal variable in events", service: triggersrepo.ServiceEvent, condition: `avg(valueNumber, "5m") > 1`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PrepareCondition(tt.service, tt.condition, tt.valueType, nil, nil)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			window, err := ConditionWindow(tt.service, tt.condition)
+			require.NoError(t, err)
+			require.Equal(t, tt.window, window)
+		})
+	}
+}
+
+func TestEvaluateSignalConditionWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	window := []WindowSample{
+		{Timestamp: now.Add(-10 * time.Minute), Value: 50},
+		{Timestamp: now.Add(-4 * time.Minute), Value: 80},
+		{Timestamp: now.Add(-2 * time.Minute), Value: 95},
+		{Timestamp: now, Value: 110},
+	}
+	signal := &vss.Signal{Data: vss.SignalData{Timestamp: now, ValueNumber: 110}}
+
+	tests := []struct {
+		name      string
+		condition string
+		window    []WindowSample
+		expected  bool
+	}{
+		{name: "avg over the window", condition: `avg(valueNumber, "5m") > 90 && avg(valueNumber, "5m") < 96`, window: window, expected: true},
+		{name: "samples outside the window are ignored", condition: `avg(valueNumber, "15m") > 90`, window: window, expected: false},
+		{name: "min", condition: `min(value, "5m") == 80.0`, window: window, expected: true},
+		{name: "max", condition: `max(value, "1h") == 110.0`, window: window, expected: true},
+		{name: "count", condition: `count("3m") == 2`, window: window, expected: true},
+		{name: "nil window holds the current signal", condition: `count("1h") == 1 && avg(valueNumber, "1h") == 110.0`, expected: true},
+		{name: "combined with the current value", condition: `valueNumber > avg(valueNumber, "15m")`, window: window, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := PrepareSignalCondition(tt.condition, signals.NumberType, nil, nil)
+			require.NoError(t, err)
+			result, err := EvaluateSignalCondition(prg, signal, nil, signals.NumberType, tt.window, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEvaluateEventConditionWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := &vss.Event{Data: vss.EventData{Name: "HarshBraking", Timestamp: now, DurationNs: 300}}
+	window := []WindowSample{
+		{Timestamp: now.Add(-2 * time.Hour), Value: 100},
+		{Timestamp: now.Add(-30 * time.Minute), Value: 100},
+		{Timestamp: now.Add(-10 * time.Minute), Value: 200},
+		{Timestamp: now, Value: 300},
+	}
+
+	prg, err := PrepareEventCondition(`name == 'HarshBraking' && count("1h") > 2 && avg(durationNs, "1h") == 200.0`, nil)
+	require.NoError(t, err)
+
+	result, err := EvaluateEventCondition(prg, event, nil, window, nil, nil)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateEventCondition(prg, event, nil, window[2:], nil, nil)
+	require.NoError(t, err)
+	require.False(t, result)
+}
@@ -74,54 +74,68 @@ func (c *Client) IsDevLicense(ctx context.Context, clientID common.Address) (boo
 	return true, nil
 }
 
+// GetSharedVehicles returns all vehicles shared with the developer license, following the pages of the
+// Identity API.
 func (c *Client) GetSharedVehicles(ctx context.Context, devLicense []byte) ([]cloudevent.ERC721DID, error) {
 	ethAddress := common.BytesToAddress(devLicense).Hex()
 	query := `
-	query($clientId: Address){
-		vehicles(first: 100, filterBy: { privileged: $clientId }) {
+	query($clientId: Address, $after: String){
+		vehicles(first: 100, after: $after, filterBy: { privileged: $clientId }) {
 			nodes {
 				tokenDID
 			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
 		}
 	}`
 
-	bodyBytes, err := c.SendRequest(ctx, query, map[string]any{
-		"clientId": ethAddress,
-	})
-	if err != nil {
-		return nil, richerrors.Error{
-			Code:        http.StatusInternalServerError,
-			ExternalMsg: "Failed to get shared vehicles",
-			Err:         err,
-		}
-	}
-	var result IdentityResponse[SharedVehiclesResponse]
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, richerrors.Error{
-			Code:        http.StatusInternalServerError,
-			ExternalMsg: "Failed to get shared vehicles",
-			Err:         fmt.Errorf("failed to unmarshal GraphQL response: %w", err),
+	var assetDIDs []cloudevent.ERC721DID
+	var after *string
+	for {
+		bodyBytes, err := c.SendRequest(ctx, query, map[string]any{
+			"clientId": ethAddress,
+			"after":    after,
+		})
+		if err != nil {
+			return nil, richerrors.Error{
+				Code:        http.StatusInternalServerError,
+				ExternalMsg: "Failed to get shared vehicles",
+				Err:         err,
+			}
 		}
-	}
-	if len(result.Errors) > 0 {
-		return nil, richerrors.Error{
-			Code:        http.StatusInternalServerError,
-			ExternalMsg: "Failed to get shared vehicles",
-			Err:         errors.New("GraphQL errors occurred"),
+		var result IdentityResponse[SharedVehiclesResponse]
+		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+			return nil, richerrors.Error{
+				Code:        http.StatusInternalServerError,
+				ExternalMsg: "Failed to get shared vehicles",
+				Err:         fmt.Errorf("failed to unmarshal GraphQL response: %w", err),
+			}
 		}
-	}
-	assetDIDs := make([]cloudevent.ERC721DID, len(result.Data.Vehicles.Nodes))
-	for i, node := range result.Data.Vehicles.Nodes {
-		if node.TokenDID.ContractAddress != c.vehicleContractAddress || node.TokenDID.ChainID != c.chainID {
+		if len(result.Errors) > 0 {
 			return nil, richerrors.Error{
 				Code:        http.StatusInternalServerError,
 				ExternalMsg: "Failed to get shared vehicles",
-				Err:         errors.New("vehicle contract address or chain ID mismatch"),
+				Err:         errors.New("GraphQL errors occurred"),
 			}
 		}
-		assetDIDs[i] = node.TokenDID
+		for _, node := range result.Data.Vehicles.Nodes {
+			if node.TokenDID.ContractAddress != c.vehicleContractAddress || node.TokenDID.ChainID != c.chainID {
+				return nil, richerrors.Error{
+					Code:        http.StatusInternalServerError,
+					ExternalMsg: "Failed to get shared vehicles",
+					Err:         errors.New("vehicle contract address or chain ID mismatch"),
+				}
+			}
+			assetDIDs = append(assetDIDs, node.TokenDID)
+		}
+		pageInfo := result.Data.Vehicles.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			return assetDIDs, nil
+		}
+		after = &pageInfo.EndCursor
 	}
-	return assetDIDs, nil
 }
 
 func (c *Client) SendRequest(ctx context.Context, query string, variables map[string]any) ([]byte, error) {
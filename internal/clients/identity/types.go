@@ -22,7 +22,14 @@ type SharedVehiclesResponse struct {
 }
 
 type VehicleResponse struct {
-	Nodes []DIDNode `json:"nodes"`
+	Nodes    []DIDNode `json:"nodes"`
+	PageInfo PageInfo  `json:"pageInfo"`
+}
+
+// PageInfo tells whether a connection of the Identity API has more pages.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
 }
 type DIDNode struct {
 	TokenDID cloudevent.ERC721DID `json:"tokenDID"`
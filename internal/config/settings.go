@@ -29,12 +29,50 @@ type Settings struct {
 	MaxInFlight int `env:"MAX_IN_FLIGHT" envDefault:"50"`
 	// CacheDebounceTime wait time betweeen to successive cache refreshes
 	CacheDebounceTime time.Duration `env:"CACHE_DEBOUNCE_TIME"`
-	// CacheBuildWorkers caps the parallelism of the per-trigger fetch+CEL
-	// compile loop in webhookcache.PopulateCache. Each worker takes one DB
-	// roundtrip plus one CEL compile at a time, so it doubles as a DB
-	// connection-pool guard. Defaults to 2 because the prod pod is pinned
-	// to ~1 CPU; raise it on multi-core nodes.
+	// CacheBuildWorkers caps the parallelism of the batched trigger fetch+CEL
+	// compile loop in webhookcache.PopulateCache. Each worker loads one batch
+	// of triggers at a time and compiles its distinct conditions, so it
+	// doubles as a DB connection-pool guard. Defaults to 2 because the prod
+	// pod is pinned to ~1 CPU; raise it on multi-core nodes.
 	CacheBuildWorkers int `env:"CACHE_BUILD_WORKERS" envDefault:"2"`
+	// CacheSnapshotPath is the file the webhook cache writes a snapshot of its
+	// subscriptions and triggers to after every build from the database, and
+	// starts from on the next start while it reconciles with the database in
+	// the background. The snapshot holds the signing secrets of the triggers,
+	// so it belongs on a volume local to the pod. Empty disables snapshots.
+	CacheSnapshotPath string `env:"CACHE_SNAPSHOT_PATH"`
+	// ReadinessMaxCacheAge is how long ago the webhook cache may have last
+	// been refreshed from the database for the pod to stay ready.
+	ReadinessMaxCacheAge time.Duration `env:"READINESS_MAX_CACHE_AGE" envDefault:"15m"`
+	// WebhookMaxAttempts is the number of delivery attempts, including the first one,
+	// before a failed delivery counts toward MaxWebhookFailureCount.
+	WebhookMaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"6"`
+	// WebhookRetryBaseDelay is the delay before the first retry; it doubles on every further attempt.
+	WebhookRetryBaseDelay time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"30s"`
+	// WebhookRetryMaxDelay caps the delay between two attempts, including delays requested via Retry-After.
+	WebhookRetryMaxDelay time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" envDefault:"1h"`
+	// WebhookRetryPollInterval is how often the retry worker looks for deliveries that are due.
+	WebhookRetryPollInterval time.Duration `env:"WEBHOOK_RETRY_POLL_INTERVAL" envDefault:"5s"`
+	// WebhookMaxPendingRetries caps the deliveries waiting for a retry per trigger. Failures beyond
+	// the cap are counted toward MaxWebhookFailureCount right away.
+	WebhookMaxPendingRetries int `env:"WEBHOOK_MAX_PENDING_RETRIES" envDefault:"100"`
+	// WebhookMaxDeadLetters caps the dead letters kept per trigger; deliveries given up on beyond the cap are dropped.
+	WebhookMaxDeadLetters int `env:"WEBHOOK_MAX_DEAD_LETTERS" envDefault:"1000"`
+	// WebhookDeliveryRetention is how long the log of delivery attempts is kept.
+	WebhookDeliveryRetention time.Duration `env:"WEBHOOK_DELIVERY_RETENTION" envDefault:"168h"`
+	// WindowMaxSamples caps the samples kept per vehicle and metric for windowed aggregates in conditions.
+	WindowMaxSamples int `env:"WINDOW_MAX_SAMPLES" envDefault:"1000"`
+	// AbsenceCheckInterval is how often last seen timestamps are stored and silent vehicles are looked for.
+	// It bounds how late an absence webhook fires after the vehicle reached its absentFor.
+	AbsenceCheckInterval time.Duration `env:"ABSENCE_CHECK_INTERVAL" envDefault:"30s"`
+	// VehicleStateFlushInterval is how often the evaluation state and last observed values cached in memory are
+	// written to the database. Up to this much state is lost if the process is killed.
+	VehicleStateFlushInterval time.Duration `env:"VEHICLE_STATE_FLUSH_INTERVAL" envDefault:"5s"`
+	// VehicleStateIdleTimeout is how long the state of a vehicle stays cached after it was last used.
+	VehicleStateIdleTimeout time.Duration `env:"VEHICLE_STATE_IDLE_TIMEOUT" envDefault:"10m"`
+	// SubscriptionReconcileInterval is how often the subscriptions of allShared webhooks are synced with the
+	// vehicles shared with their developer license. It bounds how late a newly shared vehicle is subscribed.
+	SubscriptionReconcileInterval time.Duration `env:"SUBSCRIPTION_RECONCILE_INTERVAL" envDefault:"15m"`
 
 	DB db.Settings `envPrefix:"DB_"`
 }
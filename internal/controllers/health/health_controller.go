@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/kafka"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultMaxCacheAge allows two failed rebuilds of the webhook cache, which is rebuilt every 5 minutes.
+	defaultMaxCacheAge = 15 * time.Minute
+	// dbPingTimeout bounds the database check, so a hanging connection fails the probe instead of timing it out.
+	dbPingTimeout = 2 * time.Second
+)
+
+type WebhookCache interface {
+	LastRefresh() time.Time
+}
+
+type DB interface {
+	PingContext(ctx context.Context) error
+}
+
+type Consumer interface {
+	Name() string
+	Status() kafka.ConsumerStatus
+}
+
+// Controller serves the liveness and readiness probes.
+type Controller struct {
+	cache       WebhookCache
+	db          DB
+	consumers   []Consumer
+	maxCacheAge time.Duration
+}
+
+// NewController creates a new Controller. The pod is ready once the webhook cache is built, the database
+// responds and all consumers joined their group.
+func NewController(cache WebhookCache, db DB, consumers []Consumer, settings *config.Settings) *Controller {
+	maxCacheAge := settings.ReadinessMaxCacheAge
+	if maxCacheAge <= 0 {
+		maxCacheAge = defaultMaxCacheAge
+	}
+	return &Controller{
+		cache:       cache,
+		db:          db,
+		consumers:   consumers,
+		maxCacheAge: maxCacheAge,
+	}
+}
+
+// Check is the result of a readiness check.
+type Check struct {
+	Ready bool `json:"ready"`
+	// Error explains why the check is not ready.
+	Error string `json:"error,omitempty"`
+}
+
+// CacheCheck is the readiness of the webhook cache.
+type CacheCheck struct {
+	Check
+	// LastRefresh is when the data of the cache was loaded from the database, empty if it was not built yet.
+	LastRefresh *time.Time `json:"lastRefresh,omitempty"`
+}
+
+// ConsumerCheck is the readiness of a Kafka consumer.
+type ConsumerCheck struct {
+	Check
+	Status kafka.ConsumerStatus `json:"status"`
+}
+
+// ReadinessResponse is the body of the readiness probe.
+type ReadinessResponse struct {
+	Ready     bool                     `json:"ready"`
+	Cache     CacheCheck               `json:"cache"`
+	Database  Check                    `json:"database"`
+	Consumers map[string]ConsumerCheck `json:"consumers"`
+}
+
+// Live reports that the server is up. It checks no dependencies, so the pod is only restarted when it stops
+// responding, not while it waits for them.
+func (hc *Controller) Live(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"data": "Server is up and running",
+	})
+}
+
+// Ready reports whether the pod can serve traffic, with 503 Service Unavailable and the failed checks if not.
+func (hc *Controller) Ready(c *fiber.Ctx) error {
+	resp := ReadinessResponse{
+		Cache:     hc.checkCache(time.Now()),
+		Database:  hc.checkDB(c.UserContext()),
+		Consumers: make(map[string]ConsumerCheck, len(hc.consumers)),
+	}
+	resp.Ready = resp.Cache.Ready && resp.Database.Ready
+	for _, consumer := range hc.consumers {
+		check := checkConsumer(consumer.Status())
+		resp.Consumers[consumer.Name()] = check
+		resp.Ready = resp.Ready && check.Ready
+	}
+
+	status := fiber.StatusOK
+	if !resp.Ready {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(resp)
+}
+
+// checkCache checks that the webhook cache was built from data loaded from the database within maxCacheAge.
+func (hc *Controller) checkCache(now time.Time) CacheCheck {
+	lastRefresh := hc.cache.LastRefresh()
+	if lastRefresh.IsZero() {
+		return CacheCheck{Check: Check{Error: "webhook cache is not built yet"}}
+	}
+	check := CacheCheck{Check: Check{Ready: true}, LastRefresh: &lastRefresh}
+	if age := now.Sub(lastRefresh); age > hc.maxCacheAge {
+		check.Check = Check{Error: fmt.Sprintf("webhook cache was last refreshed %s ago, more than %s", age.Round(time.Second), hc.maxCacheAge)}
+	}
+	return check
+}
+
+func (hc *Controller) checkDB(ctx context.Context) Check {
+	ctx, cancel := context.WithTimeout(ctx, dbPingTimeout)
+	defer cancel()
+	if err := hc.db.PingContext(ctx); err != nil {
+		return Check{Error: fmt.Sprintf("database is unreachable: %v", err)}
+	}
+	return Check{Ready: true}
+}
+
+func checkConsumer(status kafka.ConsumerStatus) ConsumerCheck {
+	check := ConsumerCheck{Check: Check{Ready: status.Ready()}, Status: status}
+	switch {
+	case !status.Subscribed:
+		check.Error = "consumer is not subscribed"
+	case !status.Joined:
+		check.Error = "consumer has not joined its group"
+	}
+	return check
+}
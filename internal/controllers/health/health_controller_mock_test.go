@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health_controller.go -destination=health_controller_mock_test.go -package=health
+//
+
+// Package health is a generated GoMock package.
+package health
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	kafka "github.com/DIMO-Network/vehicle-triggers-api/internal/kafka"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookCache is a mock of WebhookCache interface.
+type MockWebhookCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookCacheMockRecorder is the mock recorder for MockWebhookCache.
+type MockWebhookCacheMockRecorder struct {
+	mock *MockWebhookCache
+}
+
+// NewMockWebhookCache creates a new mock instance.
+func NewMockWebhookCache(ctrl *gomock.Controller) *MockWebhookCache {
+	mock := &MockWebhookCache{ctrl: ctrl}
+	mock.recorder = &MockWebhookCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookCache) EXPECT() *MockWebhookCacheMockRecorder {
+	return m.recorder
+}
+
+// LastRefresh mocks base method.
+func (m *MockWebhookCache) LastRefresh() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastRefresh")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastRefresh indicates an expected call of LastRefresh.
+func (mr *MockWebhookCacheMockRecorder) LastRefresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastRefresh", reflect.TypeOf((*MockWebhookCache)(nil).LastRefresh))
+}
+
+// MockDB is a mock of DB interface.
+type MockDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBMockRecorder
+	isgomock struct{}
+}
+
+// MockDBMockRecorder is the mock recorder for MockDB.
+type MockDBMockRecorder struct {
+	mock *MockDB
+}
+
+// NewMockDB creates a new mock instance.
+func NewMockDB(ctrl *gomock.Controller) *MockDB {
+	mock := &MockDB{ctrl: ctrl}
+	mock.recorder = &MockDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDB) EXPECT() *MockDBMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockDB) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockDBMockRecorder) PingContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockDB)(nil).PingContext), ctx)
+}
+
+// MockConsumer is a mock of Consumer interface.
+type MockConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockConsumerMockRecorder is the mock recorder for MockConsumer.
+type MockConsumerMockRecorder struct {
+	mock *MockConsumer
+}
+
+// NewMockConsumer creates a new mock instance.
+func NewMockConsumer(ctrl *gomock.Controller) *MockConsumer {
+	mock := &MockConsumer{ctrl: ctrl}
+	mock.recorder = &MockConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsumer) EXPECT() *MockConsumerMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockConsumer) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockConsumerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockConsumer)(nil).Name))
+}
+
+// Status mocks base method.
+func (m *MockConsumer) Status() kafka.ConsumerStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(kafka.ConsumerStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockConsumerMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockConsumer)(nil).Status))
+}
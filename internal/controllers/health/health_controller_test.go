@@ -0,0 +1,126 @@
+//go:generate go tool mockgen -source=health_controller.go -destination=health_controller_mock_test.go -package=health
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/kafka"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestController_Ready(t *testing.T) {
+	t.Parallel()
+
+	joined := kafka.ConsumerStatus{Subscribed: true, Joined: true, Partitions: 2}
+	tests := []struct {
+		name          string
+		lastRefresh   time.Time
+		pingErr       error
+		status        kafka.ConsumerStatus
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "ready",
+			lastRefresh:  time.Now().Add(-time.Minute),
+			status:       joined,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "ready without assigned partitions",
+			lastRefresh:  time.Now().Add(-time.Minute),
+			status:       kafka.ConsumerStatus{Subscribed: true, Joined: true},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "cache not built yet",
+			status:        joined,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: "webhook cache is not built yet",
+		},
+		{
+			name:          "cache refreshed too long ago",
+			lastRefresh:   time.Now().Add(-time.Hour),
+			status:        joined,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: "webhook cache was last refreshed 1h0m0s ago, more than 15m0s",
+		},
+		{
+			name:          "database unreachable",
+			lastRefresh:   time.Now().Add(-time.Minute),
+			pingErr:       errors.New("connection refused"),
+			status:        joined,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: "database is unreachable: connection refused",
+		},
+		{
+			name:          "consumer not subscribed",
+			lastRefresh:   time.Now().Add(-time.Minute),
+			status:        kafka.ConsumerStatus{},
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: "consumer is not subscribed",
+		},
+		{
+			name:          "consumer not joined",
+			lastRefresh:   time.Now().Add(-time.Minute),
+			status:        kafka.ConsumerStatus{Subscribed: true},
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: "consumer has not joined its group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			cache := NewMockWebhookCache(ctrl)
+			db := NewMockDB(ctrl)
+			consumer := NewMockConsumer(ctrl)
+			cache.EXPECT().LastRefresh().Return(tt.lastRefresh)
+			db.EXPECT().PingContext(gomock.Any()).Return(tt.pingErr)
+			consumer.EXPECT().Name().Return("signals")
+			consumer.EXPECT().Status().Return(tt.status)
+
+			controller := NewController(cache, db, []Consumer{consumer}, &config.Settings{})
+			app := fiber.New()
+			app.Get("/health/ready", controller.Ready)
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			var body ReadinessResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expectedCode == http.StatusOK, body.Ready)
+			assert.Equal(t, tt.status, body.Consumers["signals"].Status)
+			errs := []string{body.Cache.Error, body.Database.Error, body.Consumers["signals"].Error}
+			if tt.expectedError == "" {
+				assert.Equal(t, []string{"", "", ""}, errs)
+			} else {
+				assert.Contains(t, errs, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestController_Live(t *testing.T) {
+	t.Parallel()
+
+	// Liveness checks no dependencies.
+	controller := NewController(nil, nil, nil, &config.Settings{})
+	app := fiber.New()
+	app.Get("/health/live", controller.Live)
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,85 @@
+package metriclistener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/absence"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+)
+
+// recordSeen tells the absence tracker that the vehicle sent the metric, for every absence webhook watching it.
+// Snoozed and muted webhooks keep tracking the vehicle, so they do not fire for signals missed while paused.
+func (m *MetricListener) recordSeen(vehicleDID cloudevent.ERC721DID, metricName string) {
+	if m.absences == nil {
+		return
+	}
+	webhooks := m.webhookCache.GetWebhooksIncludingPaused(vehicleDID.String(), triggersrepo.ServiceAbsence, metricName)
+	if len(webhooks) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	for _, wh := range webhooks {
+		m.absences.Seen(wh.Trigger.ID, vehicleDID.String(), now)
+	}
+}
+
+// HandleAbsence fires the absence webhook of a vehicle that went silent or came back online.
+// Transitions of webhooks that are no longer in the cache, e.g. because the vehicle was unsubscribed, are dropped.
+func (m *MetricListener) HandleAbsence(ctx context.Context, transition absence.Transition) error {
+	vehicleDID, err := cloudevent.DecodeERC721DID(transition.AssetDID)
+	if err != nil {
+		return fmt.Errorf("failed to decode asset DID: %w", err)
+	}
+	var wh *webhookcache.Webhook
+	for _, candidate := range m.webhookCache.GetWebhooks(transition.AssetDID, triggersrepo.ServiceAbsence, transition.MetricName) {
+		if candidate.Trigger.ID == transition.TriggerID {
+			wh = candidate
+			break
+		}
+	}
+	if wh == nil {
+		return nil
+	}
+
+	absenceData := &triggerevaluator.AbsenceEvaluationData{
+		VehicleDID: vehicleDID,
+		Offline:    transition.Offline,
+		LastSeenAt: transition.LastSeenAt,
+		SilentFor:  transition.SilentFor(),
+		Schedule:   wh.Schedule,
+		Params:     wh.Params,
+	}
+	result, err := m.triggerEvaluator.EvaluateAbsenceTrigger(ctx, wh.Trigger, wh.Program, absenceData)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate absence trigger: %w", err)
+	}
+	if !result.ShouldFire {
+		// Handle permission denied - unsubscribe from the trigger
+		if result.PermissionDenied {
+			if _, err := m.repo.DeleteVehicleSubscription(ctx, wh.Trigger.ID, vehicleDID); err != nil {
+				return fmt.Errorf("failed to delete vehicle subscription: %w", err)
+			}
+			m.webhookCache.RemoveSubscription(wh.Trigger.ID, vehicleDID.String())
+		}
+		return nil
+	}
+
+	payload := m.createWebhookPayload(wh.Trigger, vehicleDID)
+	payload.Data.Absence = &webhook.AbsenceData{
+		Offline:       transition.Offline,
+		LastSeenAt:    transition.LastSeenAt,
+		SilentSeconds: transition.SilentFor().Seconds(),
+	}
+	snapshot, err := json.Marshal(payload.Data.Absence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal absence data: %w", err)
+	}
+	return m.handleTriggeredWebhook(ctx, wh.Trigger, snapshot, payload)
+}
@@ -0,0 +1,114 @@
+package metriclistener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+// processCompositeSignals records the signals of a message that composite and event webhooks of the vehicle read,
+// and evaluates each composite webhook once against the latest values of all the signals its condition reads.
+// Event webhooks are evaluated when their event arrives. Signals older than the recorded value do not trigger an
+// evaluation.
+func (m *MetricListener) processCompositeSignals(ctx context.Context, vehicleDID cloudevent.ERC721DID, sigs []vss.Signal) error {
+	var webhooks []*webhookcache.Webhook
+	updated := make(map[string]bool)
+	for _, sig := range sigs {
+		candidates := m.webhookCache.GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, signals.VSSPrefix+sig.Data.Name)
+		if len(candidates) == 0 || !m.latestSignals.Update(vehicleDID.String(), sig) {
+			continue
+		}
+		for _, wh := range candidates {
+			if triggersrepo.IsCompositeService(wh.Trigger.Service) && !updated[wh.Trigger.ID] {
+				updated[wh.Trigger.ID] = true
+				webhooks = append(webhooks, wh)
+			}
+		}
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(100)
+	for _, wh := range webhooks {
+		group.Go(func() error {
+			if err := m.processCompositeWebhook(groupCtx, wh, vehicleDID); err != nil {
+				zerolog.Ctx(groupCtx).Error().Str("trigger_id", wh.Trigger.ID).Err(err).Msg("failed to process webhook")
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// processCompositeWebhook evaluates a composite webhook for the vehicle. It is not evaluated until every signal
+// its condition reads has been received.
+func (m *MetricListener) processCompositeWebhook(ctx context.Context, wh *webhookcache.Webhook, vehicleDID cloudevent.ERC721DID) error {
+	values, ok := m.latestSignals.Latest(vehicleDID.String(), wh.Signals)
+	if !ok {
+		return nil
+	}
+	rawData, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signals: %w", err)
+	}
+	compositeEval := &triggerevaluator.CompositeEvaluationData{
+		VehicleDID: vehicleDID,
+		Signals:    values,
+		RawData:    rawData,
+		Schedule:   wh.Schedule,
+		Params:     wh.Params,
+	}
+
+	result, err := m.triggerEvaluator.EvaluateCompositeTrigger(ctx, wh.Trigger, wh.Program, compositeEval)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate composite trigger: %w", err)
+	}
+	if !result.ShouldFire {
+		// Handle permission denied - unsubscribe from the trigger
+		if result.PermissionDenied {
+			_, err := m.repo.DeleteVehicleSubscription(ctx, wh.Trigger.ID, vehicleDID)
+			if err != nil {
+				return fmt.Errorf("failed to delete vehicle subscription: %w", err)
+			}
+			m.webhookCache.RemoveSubscription(wh.Trigger.ID, vehicleDID.String())
+		}
+		return nil
+	}
+
+	payload := m.createWebhookPayload(wh.Trigger, vehicleDID)
+	payload.Data.Signals, err = newSignalsData(wh.Signals, values)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook payload: %w", err)
+	}
+
+	return m.handleTriggeredWebhook(ctx, wh.Trigger, rawData, payload)
+}
+
+// newSignalsData returns the payload data of the named signals in order, nil if there are none.
+func newSignalsData(names []string, values map[string]vss.Signal) ([]*webhook.SignalData, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	out := make([]*webhook.SignalData, 0, len(names))
+	for _, name := range names {
+		sig := values[name]
+		signalData, err := newSignalData(sig, signals.GetSignalDefinitionOrDefault(name, inferSignalValueType(sig)))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, signalData)
+	}
+	return out, nil
+}
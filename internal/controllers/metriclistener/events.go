@@ -5,15 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
 )
@@ -56,6 +59,11 @@ func (m *MetricListener) processSingleEvent(ctx context.Context, event vss.Event
 	if len(webhooks) == 0 {
 		return nil
 	}
+	if err := m.observe(ctx, eventEval.VehicleDID, event.Data.Name, rawPayload, event.Data.Timestamp, &eventEval.Previous, &eventEval.PreviousFired); err != nil {
+		return err
+	}
+	sample := celcondition.WindowSample{Timestamp: event.Data.Timestamp, Value: float64(event.Data.DurationNs)}
+	eventEval.Window = m.recordWindowSample(eventEval.VehicleDID.String(), event.Data.Name, sample, webhooks)
 
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(100)
@@ -71,6 +79,23 @@ func (m *MetricListener) processSingleEvent(ctx context.Context, event vss.Event
 }
 
 func (m *MetricListener) processEventWebhook(ctx context.Context, wh *webhookcache.Webhook, eventEval *triggerevaluator.EventEvaluationData) error {
+	if len(wh.Signals) != 0 {
+		// The condition reads the latest signals of the vehicle; it is not evaluated until all have been received.
+		values, ok := m.latestSignals.Latest(eventEval.VehicleDID.String(), wh.Signals)
+		if !ok {
+			return nil
+		}
+		withSignals := *eventEval
+		withSignals.Signals = values
+		eventEval = &withSignals
+	}
+	if wh.Schedule != nil || wh.Params != nil {
+		forWebhook := *eventEval
+		forWebhook.Schedule = wh.Schedule
+		forWebhook.Params = wh.Params
+		eventEval = &forWebhook
+	}
+
 	// Evaluate the trigger using the new service
 	result, err := m.triggerEvaluator.EvaluateEventTrigger(ctx, wh.Trigger, wh.Program, eventEval)
 	if err != nil {
@@ -84,12 +109,17 @@ func (m *MetricListener) processEventWebhook(ctx context.Context, wh *webhookcac
 			if err != nil {
 				return fmt.Errorf("failed to delete vehicle subscription: %w", err)
 			}
-			m.webhookCache.ScheduleRefresh(ctx)
+			m.webhookCache.RemoveSubscription(wh.Trigger.ID, eventEval.VehicleDID.String())
 		}
 		return nil
 	}
+	m.vehicleState.SetVehicleMetricFired(eventEval.VehicleDID, wh.Trigger.MetricName, types.JSON(eventEval.RawData), time.Now())
 
 	payload := m.createEventPayload(wh.Trigger, eventEval)
+	payload.Data.Signals, err = newSignalsData(wh.Signals, eventEval.Signals)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook payload: %w", err)
+	}
 	return m.handleTriggeredWebhook(ctx, wh.Trigger, eventEval.RawData, payload)
 
 }
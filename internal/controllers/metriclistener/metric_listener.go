@@ -3,19 +3,25 @@ package metriclistener
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/metricwindow"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/signalstate"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookretry"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/google/cel-go/cel"
 	"github.com/google/uuid"
@@ -23,15 +29,21 @@ import (
 	"golang.org/x/sync/semaphore"
 )
 
+// errWebhookFailing is recorded on firings that were not delivered because the webhook had failed.
+var errWebhookFailing = errors.New("webhook disabled due to excessive failures")
+
 type TriggerRepo interface {
 	CreateTriggerLog(ctx context.Context, triggerLog *models.TriggerLog) error
 	DeleteVehicleSubscription(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (int64, error)
 	ResetTriggerFailureCount(ctx context.Context, trigger *models.Trigger) error
 	IncrementTriggerFailureCount(ctx context.Context, trigger *models.Trigger, failureReason error, maxFailureCount int) error
+	EnqueueWebhookRetry(ctx context.Context, entry *models.WebhookOutbox, maxPending int) (bool, error)
+	CreateDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter, maxPerTrigger int) (bool, error)
+	CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
 }
 
 type WebhookSender interface {
-	SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) error
+	SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error)
 }
 
 type WebhookFailureManager interface {
@@ -41,13 +53,28 @@ type WebhookFailureManager interface {
 }
 
 type TriggerEvaluator interface {
-	EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, signal *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error)
+	EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program, clearProgram cel.Program, signal *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error)
 	EvaluateEventTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, ev *triggerevaluator.EventEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error)
+	EvaluateAbsenceTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, absence *triggerevaluator.AbsenceEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error)
+	EvaluateCompositeTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, composite *triggerevaluator.CompositeEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error)
 }
 
 type WebhookCache interface {
 	GetWebhooks(vehicleDID string, service string, metricName string) []*webhookcache.Webhook
-	ScheduleRefresh(ctx context.Context)
+	GetWebhooksIncludingPaused(vehicleDID string, service string, metricName string) []*webhookcache.Webhook
+	RemoveSubscription(triggerID, assetDID string)
+}
+
+// VehicleState holds the last observed value of each metric of a vehicle, and the value a trigger last fired for.
+type VehicleState interface {
+	GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error)
+	SetVehicleMetricState(state *models.VehicleMetricState)
+	SetVehicleMetricFired(assetDid cloudevent.ERC721DID, metricName string, data types.JSON, firedAt time.Time)
+}
+
+// AbsenceTracker collects when absence triggers last saw a signal of a vehicle.
+type AbsenceTracker interface {
+	Seen(triggerID, assetDID string, at time.Time)
 }
 
 type MetricListener struct {
@@ -56,25 +83,44 @@ type MetricListener struct {
 	webhookSender    WebhookSender
 	triggerEvaluator TriggerEvaluator
 	maxFailureCount  int
+	retryPolicy      webhookretry.Policy
+	windows          *metricwindow.Buffer
+	latestSignals    *signalstate.Store
+	vehicleState     VehicleState
+	absences         AbsenceTracker
 }
 
-// NewMetricsListener creates a new MetrticListener.
+// NewMetricsListener creates a new MetrticListener. absences is nil for listeners that do not consume signals.
+// latestSignals holds the latest signals read by composite and event conditions and is shared by the signal and
+// event listeners; a nil store creates one for the listener. vehicleState provides the previous values that
+// conditions compare against.
 func NewMetricsListener(wc WebhookCache,
 	repo TriggerRepo,
 	webhookSender WebhookSender,
 	triggerEvaluator TriggerEvaluator,
+	absences AbsenceTracker,
+	latestSignals *signalstate.Store,
+	vehicleState VehicleState,
 	settings *config.Settings,
 ) *MetricListener {
 	failureCount := int(settings.MaxWebhookFailureCount)
 	if failureCount < 1 {
 		failureCount = 1
 	}
+	if latestSignals == nil {
+		latestSignals = signalstate.NewStore()
+	}
 	return &MetricListener{
 		webhookCache:     wc,
 		repo:             repo,
 		webhookSender:    webhookSender,
 		triggerEvaluator: triggerEvaluator,
 		maxFailureCount:  failureCount,
+		retryPolicy:      webhookretry.NewPolicy(settings),
+		windows:          metricwindow.NewBuffer(settings.WindowMaxSamples),
+		latestSignals:    latestSignals,
+		vehicleState:     vehicleState,
+		absences:         absences,
 	}
 }
 
@@ -86,6 +132,49 @@ func (m *MetricListener) ProcessEventMessages(ctx context.Context, messages <-ch
 	return processMessage(ctx, messages, m.processEventMessage, maxInFlight)
 }
 
+// recordWindowSample buffers the sample for the aggregate functions of the webhooks and returns the samples
+// they are computed over. Nothing is buffered, and nil returned, if no webhook aggregates over the metric.
+func (m *MetricListener) recordWindowSample(vehicleDID, metric string, sample celcondition.WindowSample, webhooks []*webhookcache.Webhook) []celcondition.WindowSample {
+	var window time.Duration
+	for _, wh := range webhooks {
+		window = max(window, wh.Window)
+	}
+	if window == 0 {
+		return nil
+	}
+	return m.windows.Add(vehicleDID, metric, sample, window)
+}
+
+// observe unmarshals the last observed value of the metric of the vehicle into previous, and the value a trigger
+// last fired for into previousFired, each left as is if there is none. It then records rawData observed at
+// observedAt as the new last value.
+func (m *MetricListener) observe(ctx context.Context, vehicleDID cloudevent.ERC721DID, metricName string, rawData json.RawMessage, observedAt time.Time, previous, previousFired any) error {
+	state, err := m.vehicleState.GetVehicleMetricState(ctx, vehicleDID, metricName)
+	if err != nil {
+		return fmt.Errorf("failed to get last value of %s: %w", metricName, err)
+	}
+	if state != nil {
+		if err := json.Unmarshal(state.SnapshotData, previous); err != nil {
+			return fmt.Errorf("failed to unmarshal last value of %s: %w", metricName, err)
+		}
+		if state.FiredSnapshotData.Valid {
+			if err := json.Unmarshal(state.FiredSnapshotData.JSON, previousFired); err != nil {
+				return fmt.Errorf("failed to unmarshal last fired value of %s: %w", metricName, err)
+			}
+		}
+	}
+	if observedAt.IsZero() {
+		observedAt = time.Now()
+	}
+	m.vehicleState.SetVehicleMetricState(&models.VehicleMetricState{
+		AssetDid:     vehicleDID.String(),
+		MetricName:   metricName,
+		SnapshotData: types.JSON(rawData),
+		ObservedAt:   observedAt,
+	})
+	return nil
+}
+
 func processMessage(ctx context.Context, messages <-chan *message.Message, processor func(msg *message.Message) error, maxInFlight int) error {
 	logger := zerolog.Ctx(ctx)
 	sem := semaphore.NewWeighted(int64(maxInFlight))
@@ -135,14 +224,30 @@ func processMessage(ctx context.Context, messages <-chan *message.Message, proce
 func (m *MetricListener) handleTriggeredWebhook(ctx context.Context, trigger *models.Trigger, metricData json.RawMessage, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) error {
 	// Check if we should attempt the webhook (circuit breaker logic)
 	if !m.ShouldAttemptWebhook(trigger) {
+		// Keep firings of a failing webhook so they can be replayed once the endpoint is healthy again.
+		if trigger.Status == triggersrepo.StatusFailed || trigger.FailureCount >= m.maxFailureCount {
+			m.deadLetter(ctx, payload, metricData, 0, errWebhookFailing)
+		}
 		return nil
 	}
 
 	// Send the webhook
-	err := m.webhookSender.SendWebhook(ctx, trigger, payload)
+	resp, err := m.webhookSender.SendWebhook(ctx, trigger, payload)
+	m.logDeliveryAttempt(ctx, payload, resp, err)
 	if err != nil {
 		// Check if it's a webhook-specific failure
 		if richError, ok := richerrors.AsRichError(err); ok && richError.Code == webhooksender.WebhookFailureCode {
+			// Transient failures go to the outbox and only count toward the failure threshold once the retries are exhausted.
+			if m.retryPolicy.ShouldRetry(1, err) {
+				scheduled, qErr := m.scheduleRetry(ctx, trigger, payload, metricData, err)
+				if qErr != nil {
+					zerolog.Ctx(ctx).Error().Err(qErr).Str("triggerId", trigger.ID).Msg("failed to schedule webhook retry")
+				}
+				if scheduled {
+					return fmt.Errorf("webhook delivery failed, retry scheduled: %w", err)
+				}
+			}
+			m.deadLetter(ctx, payload, metricData, 1, err)
 			if failErr := m.repo.IncrementTriggerFailureCount(ctx, trigger, err, m.maxFailureCount); failErr != nil {
 				zerolog.Ctx(ctx).Error().Err(failErr).Str("triggerId", trigger.ID).Msg("failed to handle webhook failure")
 			}
@@ -167,6 +272,55 @@ func (m *MetricListener) handleTriggeredWebhook(ctx context.Context, trigger *mo
 	return nil
 }
 
+// scheduleRetry puts a failed delivery in the outbox for the retry worker.
+// It returns false if the trigger already has too many deliveries waiting.
+func (m *MetricListener) scheduleRetry(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload], metricData json.RawMessage, deliveryErr error) (bool, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	entry := &models.WebhookOutbox{
+		ID:            payload.ID,
+		TriggerID:     trigger.ID,
+		AssetDid:      payload.Data.AssetDID.String(),
+		Payload:       types.JSON(body),
+		SnapshotData:  types.JSON(metricData),
+		Attempts:      1,
+		NextAttemptAt: time.Now().UTC().Add(m.retryPolicy.NextDelay(1, deliveryErr)),
+		LastError:     null.StringFrom(deliveryErr.Error()),
+	}
+	return m.repo.EnqueueWebhookRetry(ctx, entry, m.retryPolicy.MaxPending)
+}
+
+// deadLetter stores a delivery that was given up on. Errors are only logged since the firing itself was handled.
+func (m *MetricListener) deadLetter(ctx context.Context, payload *cloudevent.CloudEvent[webhook.WebhookPayload], metricData json.RawMessage, attempts int, reason error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", payload.Data.WebhookId).Msg("failed to marshal dead letter payload")
+		return
+	}
+	deadLetter := &models.WebhookDeadLetter{
+		ID:           payload.ID,
+		TriggerID:    payload.Data.WebhookId,
+		AssetDid:     payload.Data.AssetDID.String(),
+		Payload:      types.JSON(body),
+		SnapshotData: types.JSON(metricData),
+		Attempts:     attempts,
+		LastError:    null.StringFrom(reason.Error()),
+	}
+	if _, err := m.repo.CreateDeadLetter(ctx, deadLetter, m.retryPolicy.MaxDeadLetters); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", payload.Data.WebhookId).Msg("failed to store dead letter")
+	}
+}
+
+// logDeliveryAttempt records the first delivery attempt of a firing. Errors are only logged so that a
+// failing delivery log does not affect deliveries.
+func (m *MetricListener) logDeliveryAttempt(ctx context.Context, payload *cloudevent.CloudEvent[webhook.WebhookPayload], resp webhook.DeliveryResult, sendErr error) {
+	if err := m.repo.CreateWebhookDelivery(ctx, webhooksender.NewDeliveryLog(payload, 1, resp, sendErr)); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", payload.Data.WebhookId).Msg("failed to log webhook delivery")
+	}
+}
+
 func (m *MetricListener) logWebhookTrigger(ctx context.Context, payload *cloudevent.CloudEvent[webhook.WebhookPayload], metricData json.RawMessage) error {
 	now := time.Now().UTC()
 	eventLog := &models.TriggerLog{
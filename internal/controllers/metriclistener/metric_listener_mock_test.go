@@ -12,12 +12,14 @@ package metriclistener
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	cloudevent "github.com/DIMO-Network/cloudevent"
 	webhook "github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	triggerevaluator "github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
 	webhookcache "github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+	types "github.com/aarondl/sqlboiler/v4/types"
 	cel "github.com/google/cel-go/cel"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -46,6 +48,21 @@ func (m *MockTriggerRepo) EXPECT() *MockTriggerRepoMockRecorder {
 	return m.recorder
 }
 
+// CreateDeadLetter mocks base method.
+func (m *MockTriggerRepo) CreateDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter, maxPerTrigger int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", ctx, deadLetter, maxPerTrigger)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockTriggerRepoMockRecorder) CreateDeadLetter(ctx, deadLetter, maxPerTrigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockTriggerRepo)(nil).CreateDeadLetter), ctx, deadLetter, maxPerTrigger)
+}
+
 // CreateTriggerLog mocks base method.
 func (m *MockTriggerRepo) CreateTriggerLog(ctx context.Context, triggerLog *models.TriggerLog) error {
 	m.ctrl.T.Helper()
@@ -60,6 +77,20 @@ func (mr *MockTriggerRepoMockRecorder) CreateTriggerLog(ctx, triggerLog any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTriggerLog", reflect.TypeOf((*MockTriggerRepo)(nil).CreateTriggerLog), ctx, triggerLog)
 }
 
+// CreateWebhookDelivery mocks base method.
+func (m *MockTriggerRepo) CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockTriggerRepoMockRecorder) CreateWebhookDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockTriggerRepo)(nil).CreateWebhookDelivery), ctx, delivery)
+}
+
 // DeleteVehicleSubscription mocks base method.
 func (m *MockTriggerRepo) DeleteVehicleSubscription(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -75,6 +106,21 @@ func (mr *MockTriggerRepoMockRecorder) DeleteVehicleSubscription(ctx, triggerID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVehicleSubscription", reflect.TypeOf((*MockTriggerRepo)(nil).DeleteVehicleSubscription), ctx, triggerID, assetDid)
 }
 
+// EnqueueWebhookRetry mocks base method.
+func (m *MockTriggerRepo) EnqueueWebhookRetry(ctx context.Context, entry *models.WebhookOutbox, maxPending int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookRetry", ctx, entry, maxPending)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookRetry indicates an expected call of EnqueueWebhookRetry.
+func (mr *MockTriggerRepoMockRecorder) EnqueueWebhookRetry(ctx, entry, maxPending any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookRetry", reflect.TypeOf((*MockTriggerRepo)(nil).EnqueueWebhookRetry), ctx, entry, maxPending)
+}
+
 // IncrementTriggerFailureCount mocks base method.
 func (m *MockTriggerRepo) IncrementTriggerFailureCount(ctx context.Context, trigger *models.Trigger, failureReason error, maxFailureCount int) error {
 	m.ctrl.T.Helper()
@@ -128,11 +174,12 @@ func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
 }
 
 // SendWebhook mocks base method.
-func (m *MockWebhookSender) SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) error {
+func (m *MockWebhookSender) SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendWebhook", ctx, trigger, payload)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(webhook.DeliveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendWebhook indicates an expected call of SendWebhook.
@@ -231,6 +278,36 @@ func (m *MockTriggerEvaluator) EXPECT() *MockTriggerEvaluatorMockRecorder {
 	return m.recorder
 }
 
+// EvaluateAbsenceTrigger mocks base method.
+func (m *MockTriggerEvaluator) EvaluateAbsenceTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, absence *triggerevaluator.AbsenceEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateAbsenceTrigger", ctx, trigger, program, absence)
+	ret0, _ := ret[0].(*triggerevaluator.TriggerEvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateAbsenceTrigger indicates an expected call of EvaluateAbsenceTrigger.
+func (mr *MockTriggerEvaluatorMockRecorder) EvaluateAbsenceTrigger(ctx, trigger, program, absence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateAbsenceTrigger", reflect.TypeOf((*MockTriggerEvaluator)(nil).EvaluateAbsenceTrigger), ctx, trigger, program, absence)
+}
+
+// EvaluateCompositeTrigger mocks base method.
+func (m *MockTriggerEvaluator) EvaluateCompositeTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, composite *triggerevaluator.CompositeEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateCompositeTrigger", ctx, trigger, program, composite)
+	ret0, _ := ret[0].(*triggerevaluator.TriggerEvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateCompositeTrigger indicates an expected call of EvaluateCompositeTrigger.
+func (mr *MockTriggerEvaluatorMockRecorder) EvaluateCompositeTrigger(ctx, trigger, program, composite any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateCompositeTrigger", reflect.TypeOf((*MockTriggerEvaluator)(nil).EvaluateCompositeTrigger), ctx, trigger, program, composite)
+}
+
 // EvaluateEventTrigger mocks base method.
 func (m *MockTriggerEvaluator) EvaluateEventTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, ev *triggerevaluator.EventEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
 	m.ctrl.T.Helper()
@@ -247,18 +324,18 @@ func (mr *MockTriggerEvaluatorMockRecorder) EvaluateEventTrigger(ctx, trigger, p
 }
 
 // EvaluateSignalTrigger mocks base method.
-func (m *MockTriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, signal *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+func (m *MockTriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program, clearProgram cel.Program, signal *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EvaluateSignalTrigger", ctx, trigger, program, signal)
+	ret := m.ctrl.Call(m, "EvaluateSignalTrigger", ctx, trigger, program, clearProgram, signal)
 	ret0, _ := ret[0].(*triggerevaluator.TriggerEvaluationResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EvaluateSignalTrigger indicates an expected call of EvaluateSignalTrigger.
-func (mr *MockTriggerEvaluatorMockRecorder) EvaluateSignalTrigger(ctx, trigger, program, signal any) *gomock.Call {
+func (mr *MockTriggerEvaluatorMockRecorder) EvaluateSignalTrigger(ctx, trigger, program, clearProgram, signal any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateSignalTrigger", reflect.TypeOf((*MockTriggerEvaluator)(nil).EvaluateSignalTrigger), ctx, trigger, program, signal)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateSignalTrigger", reflect.TypeOf((*MockTriggerEvaluator)(nil).EvaluateSignalTrigger), ctx, trigger, program, clearProgram, signal)
 }
 
 // MockWebhookCache is a mock of WebhookCache interface.
@@ -299,14 +376,127 @@ func (mr *MockWebhookCacheMockRecorder) GetWebhooks(vehicleDID, service, metricN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookCache)(nil).GetWebhooks), vehicleDID, service, metricName)
 }
 
-// ScheduleRefresh mocks base method.
-func (m *MockWebhookCache) ScheduleRefresh(ctx context.Context) {
+// GetWebhooksIncludingPaused mocks base method.
+func (m *MockWebhookCache) GetWebhooksIncludingPaused(vehicleDID, service, metricName string) []*webhookcache.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksIncludingPaused", vehicleDID, service, metricName)
+	ret0, _ := ret[0].([]*webhookcache.Webhook)
+	return ret0
+}
+
+// GetWebhooksIncludingPaused indicates an expected call of GetWebhooksIncludingPaused.
+func (mr *MockWebhookCacheMockRecorder) GetWebhooksIncludingPaused(vehicleDID, service, metricName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksIncludingPaused", reflect.TypeOf((*MockWebhookCache)(nil).GetWebhooksIncludingPaused), vehicleDID, service, metricName)
+}
+
+// RemoveSubscription mocks base method.
+func (m *MockWebhookCache) RemoveSubscription(triggerID, assetDID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveSubscription", triggerID, assetDID)
+}
+
+// RemoveSubscription indicates an expected call of RemoveSubscription.
+func (mr *MockWebhookCacheMockRecorder) RemoveSubscription(triggerID, assetDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockWebhookCache)(nil).RemoveSubscription), triggerID, assetDID)
+}
+
+// MockVehicleState is a mock of VehicleState interface.
+type MockVehicleState struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleStateMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleStateMockRecorder is the mock recorder for MockVehicleState.
+type MockVehicleStateMockRecorder struct {
+	mock *MockVehicleState
+}
+
+// NewMockVehicleState creates a new mock instance.
+func NewMockVehicleState(ctrl *gomock.Controller) *MockVehicleState {
+	mock := &MockVehicleState{ctrl: ctrl}
+	mock.recorder = &MockVehicleStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleState) EXPECT() *MockVehicleStateMockRecorder {
+	return m.recorder
+}
+
+// GetVehicleMetricState mocks base method.
+func (m *MockVehicleState) GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleMetricState", ctx, assetDid, metricName)
+	ret0, _ := ret[0].(*models.VehicleMetricState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleMetricState indicates an expected call of GetVehicleMetricState.
+func (mr *MockVehicleStateMockRecorder) GetVehicleMetricState(ctx, assetDid, metricName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleMetricState", reflect.TypeOf((*MockVehicleState)(nil).GetVehicleMetricState), ctx, assetDid, metricName)
+}
+
+// SetVehicleMetricFired mocks base method.
+func (m *MockVehicleState) SetVehicleMetricFired(assetDid cloudevent.ERC721DID, metricName string, data types.JSON, firedAt time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVehicleMetricFired", assetDid, metricName, data, firedAt)
+}
+
+// SetVehicleMetricFired indicates an expected call of SetVehicleMetricFired.
+func (mr *MockVehicleStateMockRecorder) SetVehicleMetricFired(assetDid, metricName, data, firedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVehicleMetricFired", reflect.TypeOf((*MockVehicleState)(nil).SetVehicleMetricFired), assetDid, metricName, data, firedAt)
+}
+
+// SetVehicleMetricState mocks base method.
+func (m *MockVehicleState) SetVehicleMetricState(state *models.VehicleMetricState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVehicleMetricState", state)
+}
+
+// SetVehicleMetricState indicates an expected call of SetVehicleMetricState.
+func (mr *MockVehicleStateMockRecorder) SetVehicleMetricState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVehicleMetricState", reflect.TypeOf((*MockVehicleState)(nil).SetVehicleMetricState), state)
+}
+
+// MockAbsenceTracker is a mock of AbsenceTracker interface.
+type MockAbsenceTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbsenceTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockAbsenceTrackerMockRecorder is the mock recorder for MockAbsenceTracker.
+type MockAbsenceTrackerMockRecorder struct {
+	mock *MockAbsenceTracker
+}
+
+// NewMockAbsenceTracker creates a new mock instance.
+func NewMockAbsenceTracker(ctrl *gomock.Controller) *MockAbsenceTracker {
+	mock := &MockAbsenceTracker{ctrl: ctrl}
+	mock.recorder = &MockAbsenceTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbsenceTracker) EXPECT() *MockAbsenceTrackerMockRecorder {
+	return m.recorder
+}
+
+// Seen mocks base method.
+func (m *MockAbsenceTracker) Seen(triggerID, assetDID string, at time.Time) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "ScheduleRefresh", ctx)
+	m.ctrl.Call(m, "Seen", triggerID, assetDID, at)
 }
 
-// ScheduleRefresh indicates an expected call of ScheduleRefresh.
-func (mr *MockWebhookCacheMockRecorder) ScheduleRefresh(ctx any) *gomock.Call {
+// Seen indicates an expected call of Seen.
+func (mr *MockAbsenceTrackerMockRecorder) Seen(triggerID, assetDID, at any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRefresh", reflect.TypeOf((*MockWebhookCache)(nil).ScheduleRefresh), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seen", reflect.TypeOf((*MockAbsenceTracker)(nil).Seen), triggerID, assetDID, at)
 }
@@ -6,19 +6,29 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/absence"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/signalstate"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/cel-go/cel"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,7 +48,7 @@ func TestNewMetricsListener(t *testing.T) {
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 		settings := createTestSettings()
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, settings)
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), settings)
 
 		require.NotNil(t, listener)
 		assert.Equal(t, int(settings.MaxWebhookFailureCount), listener.maxFailureCount)
@@ -118,7 +128,7 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx := context.Background()
 
 		vehicleDID := cloudevent.ERC721DID{
@@ -151,6 +161,10 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 			GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").
 			Return([]*webhookcache.Webhook{}).
 			Times(1)
+		mockCache.EXPECT().
+			GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").
+			Return(nil).
+			Times(1)
 
 		messages := make(chan *message.Message, 1)
 		msg := message.NewMessage(uuid.New().String(), signalJSON)
@@ -170,7 +184,7 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx := context.Background()
 
 		vehicleDID := cloudevent.ERC721DID{
@@ -217,9 +231,13 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 			GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").
 			Return([]*webhookcache.Webhook{mockWebhook}).
 			Times(1)
+		mockCache.EXPECT().
+			GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").
+			Return(nil).
+			Times(1)
 
 		mockTriggerEvaluator.EXPECT().
-			EvaluateSignalTrigger(gomock.Any(), mockTrigger, gomock.Any(), gomock.Any()).
+			EvaluateSignalTrigger(gomock.Any(), mockTrigger, gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(&triggerevaluator.TriggerEvaluationResult{
 				ShouldFire:       true,
 				PermissionDenied: false,
@@ -227,6 +245,11 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 			Times(1)
 		mockWebhookSender.EXPECT().
 			SendWebhook(gomock.Any(), mockTrigger, gomock.Any()).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		mockRepo.EXPECT().
+			CreateWebhookDelivery(gomock.Any(), gomock.Any()).
 			Return(nil).
 			Times(1)
 
@@ -249,6 +272,66 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("buffers samples for windowed conditions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := NewMockWebhookCache(ctrl)
+		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+
+		listener := NewMetricsListener(mockCache, NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
+		ctx := context.Background()
+
+		vehicleDID := cloudevent.ERC721DID{
+			ChainID:         137,
+			ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+			TokenID:         big.NewInt(12345),
+		}
+		mockTrigger := &models.Trigger{
+			ID:         "test-trigger-id",
+			Status:     triggersrepo.StatusEnabled,
+			Service:    triggersrepo.ServiceSignal,
+			MetricName: "vss.speed",
+			Condition:  `avg(valueNumber, "5m") > 20`,
+		}
+
+		mockCache.EXPECT().
+			GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").
+			Return([]*webhookcache.Webhook{{Trigger: mockTrigger, Window: 5 * time.Minute}}).
+			Times(3)
+
+		var windows [][]celcondition.WindowSample
+		mockTriggerEvaluator.EXPECT().
+			EvaluateSignalTrigger(gomock.Any(), mockTrigger, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, _, _ cel.Program, signal *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+				windows = append(windows, signal.Window)
+				return &triggerevaluator.TriggerEvaluationResult{ConditionNotMet: true}, nil
+			}).
+			Times(3)
+
+		now := time.Now().UTC()
+		for i, offset := range []time.Duration{-10 * time.Minute, -2 * time.Minute, 0} {
+			sig := vss.Signal{
+				CloudEventHeader: cloudevent.CloudEventHeader{Subject: vehicleDID.String()},
+				Data: vss.SignalData{
+					Timestamp:   now.Add(offset),
+					Name:        "speed",
+					ValueNumber: float64(i),
+				},
+			}
+			require.NoError(t, listener.processSingleSignal(ctx, sig, vehicleDID, nil))
+		}
+
+		require.Len(t, windows, 3)
+		assert.Equal(t, []celcondition.WindowSample{{Timestamp: now.Add(-10 * time.Minute), Value: 0}}, windows[0])
+		// the first sample is older than the window
+		assert.Equal(t, []celcondition.WindowSample{{Timestamp: now.Add(-2 * time.Minute), Value: 1}}, windows[1])
+		assert.Equal(t, []celcondition.WindowSample{
+			{Timestamp: now.Add(-2 * time.Minute), Value: 1},
+			{Timestamp: now, Value: 2},
+		}, windows[2])
+	})
+
 	t.Run("stops processing when context is cancelled", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -258,7 +341,7 @@ func TestMetricListener_ProcessSignalMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
@@ -306,7 +389,7 @@ func TestMetricListener_ProcessEventMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx := context.Background()
 
 		vehicleDID := cloudevent.ERC721DID{
@@ -355,7 +438,7 @@ func TestMetricListener_ProcessEventMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx := context.Background()
 
 		vehicleDID := cloudevent.ERC721DID{
@@ -410,6 +493,11 @@ func TestMetricListener_ProcessEventMessages(t *testing.T) {
 
 		mockWebhookSender.EXPECT().
 			SendWebhook(gomock.Any(), mockTrigger, gomock.Any()).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		mockRepo.EXPECT().
+			CreateWebhookDelivery(gomock.Any(), gomock.Any()).
 			Return(nil).
 			Times(1)
 
@@ -441,7 +529,7 @@ func TestMetricListener_ProcessEventMessages(t *testing.T) {
 		mockWebhookSender := NewMockWebhookSender(ctrl)
 		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
 
-		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, createTestSettings())
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
@@ -478,7 +566,533 @@ func TestMetricListener_ProcessEventMessages(t *testing.T) {
 	})
 }
 
+func TestMetricListener_HandleTriggeredWebhook(t *testing.T) {
+	t.Parallel()
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	trigger := &models.Trigger{
+		ID:         "test-trigger-id",
+		Status:     triggersrepo.StatusEnabled,
+		Service:    triggersrepo.ServiceSignal,
+		MetricName: "vss.speed",
+	}
+	unavailable := richerrors.Error{
+		Code: webhooksender.WebhookFailureCode,
+		Err:  &webhooksender.StatusError{StatusCode: http.StatusServiceUnavailable},
+	}
+	settings := createTestSettings()
+	settings.WebhookMaxAttempts = 3
+	settings.WebhookMaxPendingRetries = 10
+	settings.WebhookMaxDeadLetters = 50
+
+	t.Run("transient failure schedules retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		mockWebhookSender := NewMockWebhookSender(ctrl)
+		listener := NewMetricsListener(NewMockWebhookCache(ctrl), mockRepo, mockWebhookSender, NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), settings)
+		payload := listener.createWebhookPayload(trigger, vehicleDID)
+
+		mockWebhookSender.EXPECT().SendWebhook(gomock.Any(), trigger, payload).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusServiceUnavailable, Latency: 20 * time.Millisecond}, unavailable)
+		mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+				assert.Equal(t, payload.ID, delivery.DeliveryID)
+				assert.Equal(t, trigger.ID, delivery.TriggerID)
+				assert.Equal(t, vehicleDID.String(), delivery.AssetDid)
+				assert.Equal(t, 1, delivery.Attempt)
+				assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode.Int)
+				assert.Equal(t, 20, delivery.LatencyMillis)
+				assert.Contains(t, delivery.ErrorMessage.String, "503")
+				return nil
+			})
+		mockRepo.EXPECT().EnqueueWebhookRetry(gomock.Any(), gomock.Any(), 10).
+			DoAndReturn(func(_ context.Context, entry *models.WebhookOutbox, _ int) (bool, error) {
+				assert.Equal(t, payload.ID, entry.ID)
+				assert.Equal(t, trigger.ID, entry.TriggerID)
+				assert.Equal(t, vehicleDID.String(), entry.AssetDid)
+				assert.Equal(t, 1, entry.Attempts)
+				assert.True(t, entry.NextAttemptAt.After(time.Now()))
+				assert.JSONEq(t, `{"value":1}`, string(entry.SnapshotData))
+				return true, nil
+			})
+
+		err := listener.handleTriggeredWebhook(context.Background(), trigger, json.RawMessage(`{"value":1}`), payload)
+		require.Error(t, err)
+	})
+
+	t.Run("full outbox counts failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		mockWebhookSender := NewMockWebhookSender(ctrl)
+		listener := NewMetricsListener(NewMockWebhookCache(ctrl), mockRepo, mockWebhookSender, NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), settings)
+		payload := listener.createWebhookPayload(trigger, vehicleDID)
+
+		mockWebhookSender.EXPECT().SendWebhook(gomock.Any(), trigger, payload).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusServiceUnavailable}, unavailable)
+		mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().EnqueueWebhookRetry(gomock.Any(), gomock.Any(), 10).Return(false, nil)
+		mockRepo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).Return(true, nil)
+		mockRepo.EXPECT().IncrementTriggerFailureCount(gomock.Any(), trigger, gomock.Any(), 5).Return(nil)
+
+		err := listener.handleTriggeredWebhook(context.Background(), trigger, json.RawMessage(`{}`), payload)
+		require.Error(t, err)
+	})
+
+	t.Run("permanent failure counts failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		mockWebhookSender := NewMockWebhookSender(ctrl)
+		listener := NewMetricsListener(NewMockWebhookCache(ctrl), mockRepo, mockWebhookSender, NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), settings)
+		payload := listener.createWebhookPayload(trigger, vehicleDID)
+
+		mockWebhookSender.EXPECT().SendWebhook(gomock.Any(), trigger, payload).Return(webhook.DeliveryResult{StatusCode: http.StatusGone}, richerrors.Error{
+			Code: webhooksender.WebhookFailureCode,
+			Err:  &webhooksender.StatusError{StatusCode: http.StatusGone},
+		})
+		mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).
+			DoAndReturn(func(_ context.Context, deadLetter *models.WebhookDeadLetter, _ int) (bool, error) {
+				assert.Equal(t, payload.ID, deadLetter.ID)
+				assert.Equal(t, trigger.ID, deadLetter.TriggerID)
+				assert.Equal(t, 1, deadLetter.Attempts)
+				assert.Contains(t, deadLetter.LastError.String, "410")
+				return true, nil
+			})
+		mockRepo.EXPECT().IncrementTriggerFailureCount(gomock.Any(), trigger, gomock.Any(), 5).Return(nil)
+
+		err := listener.handleTriggeredWebhook(context.Background(), trigger, json.RawMessage(`{}`), payload)
+		require.Error(t, err)
+	})
+
+	t.Run("failed trigger keeps firing as dead letter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		listener := NewMetricsListener(NewMockWebhookCache(ctrl), mockRepo, NewMockWebhookSender(ctrl), NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), settings)
+		failedTrigger := *trigger
+		failedTrigger.Status = triggersrepo.StatusFailed
+		payload := listener.createWebhookPayload(&failedTrigger, vehicleDID)
+
+		mockRepo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).
+			DoAndReturn(func(_ context.Context, deadLetter *models.WebhookDeadLetter, _ int) (bool, error) {
+				assert.Equal(t, payload.ID, deadLetter.ID)
+				assert.Equal(t, 0, deadLetter.Attempts)
+				return true, nil
+			})
+
+		err := listener.handleTriggeredWebhook(context.Background(), &failedTrigger, json.RawMessage(`{}`), payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled trigger drops firing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		listener := NewMetricsListener(NewMockWebhookCache(ctrl), NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), settings)
+		disabledTrigger := *trigger
+		disabledTrigger.Status = triggersrepo.StatusDisabled
+		payload := listener.createWebhookPayload(&disabledTrigger, vehicleDID)
+
+		err := listener.handleTriggeredWebhook(context.Background(), &disabledTrigger, json.RawMessage(`{}`), payload)
+		require.NoError(t, err)
+	})
+}
+
 // Helper functions for creating test data
+func TestMetricListener_Absence(t *testing.T) {
+	t.Parallel()
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	anySignal := &webhookcache.Webhook{Trigger: &models.Trigger{
+		ID:         "any-signal-trigger-id",
+		Status:     triggersrepo.StatusEnabled,
+		Service:    triggersrepo.ServiceAbsence,
+		MetricName: triggersrepo.AnySignal,
+		Condition:  "true",
+		AbsentFor:  3600,
+	}}
+	speed := &webhookcache.Webhook{Trigger: &models.Trigger{
+		ID:         "speed-trigger-id",
+		Status:     triggersrepo.StatusEnabled,
+		Service:    triggersrepo.ServiceAbsence,
+		MetricName: "vss.speed",
+		Condition:  "true",
+		AbsentFor:  3600,
+	}}
+
+	t.Run("signals are recorded as seen", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		mockTracker := NewMockAbsenceTracker(ctrl)
+		listener := NewMetricsListener(mockCache, NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), NewMockTriggerEvaluator(ctrl), mockTracker, nil, newTestVehicleState(ctrl), createTestSettings())
+
+		signalCE := vss.PackSignals(cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"}, []vss.Signal{
+			{Data: vss.SignalData{Timestamp: time.Now().UTC(), Name: "speed", ValueNumber: 25.0}},
+			{Data: vss.SignalData{Timestamp: time.Now().UTC(), Name: "powertrainRange", ValueNumber: 300}},
+		})
+		signalJSON, err := json.Marshal(signalCE)
+		require.NoError(t, err)
+
+		mockCache.EXPECT().GetWebhooksIncludingPaused(vehicleDID.String(), triggersrepo.ServiceAbsence, triggersrepo.AnySignal).Return([]*webhookcache.Webhook{anySignal}).Times(1)
+		mockCache.EXPECT().GetWebhooksIncludingPaused(vehicleDID.String(), triggersrepo.ServiceAbsence, "vss.speed").Return([]*webhookcache.Webhook{speed}).Times(1)
+		mockCache.EXPECT().GetWebhooksIncludingPaused(vehicleDID.String(), triggersrepo.ServiceAbsence, "vss.powertrainRange").Return(nil).Times(1)
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, gomock.Any()).Return(nil).Times(2)
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, gomock.Any()).Return(nil).Times(2)
+		mockTracker.EXPECT().Seen(anySignal.Trigger.ID, vehicleDID.String(), gomock.Any()).Times(1)
+		mockTracker.EXPECT().Seen(speed.Trigger.ID, vehicleDID.String(), gomock.Any()).Times(1)
+
+		require.NoError(t, listener.processSignalMessage(message.NewMessage(uuid.New().String(), signalJSON)))
+	})
+
+	t.Run("offline vehicle fires the webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		mockWebhookSender := NewMockWebhookSender(ctrl)
+		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
+		lastSeen := time.Now().UTC().Add(-2 * time.Hour)
+		transition := absence.Transition{
+			TriggerID:  speed.Trigger.ID,
+			AssetDID:   vehicleDID.String(),
+			MetricName: "vss.speed",
+			Offline:    true,
+			LastSeenAt: lastSeen,
+			At:         lastSeen.Add(time.Hour),
+		}
+
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceAbsence, "vss.speed").Return([]*webhookcache.Webhook{speed})
+		mockTriggerEvaluator.EXPECT().
+			EvaluateAbsenceTrigger(gomock.Any(), speed.Trigger, gomock.Any(), &triggerevaluator.AbsenceEvaluationData{
+				VehicleDID: vehicleDID,
+				Offline:    true,
+				LastSeenAt: lastSeen,
+				SilentFor:  time.Hour,
+			}).
+			Return(&triggerevaluator.TriggerEvaluationResult{ShouldFire: true}, nil)
+		mockWebhookSender.EXPECT().
+			SendWebhook(gomock.Any(), speed.Trigger, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+				require.NotNil(t, payload.Data.Absence)
+				assert.True(t, payload.Data.Absence.Offline)
+				assert.Equal(t, lastSeen, payload.Data.Absence.LastSeenAt)
+				assert.Equal(t, float64(3600), payload.Data.Absence.SilentSeconds)
+				assert.Nil(t, payload.Data.Signal)
+				return webhook.DeliveryResult{StatusCode: http.StatusOK}, nil
+			})
+		mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ResetTriggerFailureCount(gomock.Any(), speed.Trigger).Return(nil)
+		mockRepo.EXPECT().CreateTriggerLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, listener.HandleAbsence(context.Background(), transition))
+	})
+
+	t.Run("unsubscribed vehicle is dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		listener := NewMetricsListener(mockCache, NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), NewMockTriggerEvaluator(ctrl), nil, nil, newTestVehicleState(ctrl), createTestSettings())
+
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceAbsence, triggersrepo.AnySignal).Return(nil)
+
+		require.NoError(t, listener.HandleAbsence(context.Background(), absence.Transition{
+			TriggerID:  anySignal.Trigger.ID,
+			AssetDID:   vehicleDID.String(),
+			MetricName: triggersrepo.AnySignal,
+		}))
+	})
+}
+
+func TestMetricListener_Composite(t *testing.T) {
+	t.Parallel()
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	composite := &webhookcache.Webhook{
+		Trigger: &models.Trigger{
+			ID:         "composite-trigger-id",
+			Status:     triggersrepo.StatusEnabled,
+			Service:    triggersrepo.ServiceComposite,
+			MetricName: triggersrepo.AnySignal,
+			Condition:  "signals.speed > 0 && signals.isIgnitionOn == 0",
+		},
+		Signals: []string{"isIgnitionOn", "speed"},
+	}
+	newMessage := func(t *testing.T, sigs ...vss.Signal) *message.Message {
+		t.Helper()
+		signalJSON, err := json.Marshal(vss.PackSignals(cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"}, sigs))
+		require.NoError(t, err)
+		return message.NewMessage(uuid.New().String(), signalJSON)
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	speed := vss.Signal{Data: vss.SignalData{Timestamp: now, Name: "speed", ValueNumber: 25.0}}
+	ignition := vss.Signal{Data: vss.SignalData{Timestamp: now, Name: "isIgnitionOn", ValueNumber: 0}}
+
+	ctrl := gomock.NewController(t)
+	mockCache := NewMockWebhookCache(ctrl)
+	mockRepo := NewMockTriggerRepo(ctrl)
+	mockWebhookSender := NewMockWebhookSender(ctrl)
+	mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+	listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, newTestVehicleState(ctrl), createTestSettings())
+
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, gomock.Any()).Return(nil).AnyTimes()
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").Return([]*webhookcache.Webhook{composite}).AnyTimes()
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.isIgnitionOn").Return([]*webhookcache.Webhook{composite}).AnyTimes()
+
+	// Until the ignition has been received the webhook is not evaluated.
+	require.NoError(t, listener.processSignalMessage(newMessage(t, speed)))
+
+	// Once both signals are known, a message with either of them evaluates the webhook once.
+	mockTriggerEvaluator.EXPECT().
+		EvaluateCompositeTrigger(gomock.Any(), composite.Trigger, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.Trigger, _ cel.Program, data *triggerevaluator.CompositeEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+			require.Len(t, data.Signals, 2)
+			assert.Equal(t, speed.Data, data.Signals["speed"].Data)
+			assert.Equal(t, ignition.Data, data.Signals["isIgnitionOn"].Data)
+			return &triggerevaluator.TriggerEvaluationResult{ShouldFire: true}, nil
+		}).
+		Times(1)
+	mockWebhookSender.EXPECT().
+		SendWebhook(gomock.Any(), composite.Trigger, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+			require.Len(t, payload.Data.Signals, 2)
+			assert.Equal(t, "isIgnitionOn", payload.Data.Signals[0].Name)
+			assert.Equal(t, "speed", payload.Data.Signals[1].Name)
+			assert.Equal(t, 25.0, payload.Data.Signals[1].Value)
+			assert.Nil(t, payload.Data.Signal)
+			return webhook.DeliveryResult{StatusCode: http.StatusOK}, nil
+		})
+	mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().ResetTriggerFailureCount(gomock.Any(), composite.Trigger).Return(nil)
+	mockRepo.EXPECT().CreateTriggerLog(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, listener.processSignalMessage(newMessage(t, speed, ignition)))
+
+	// A signal older than the recorded value does not evaluate the webhook again.
+	stale := ignition
+	stale.Data.Timestamp = now.Add(-time.Minute)
+	require.NoError(t, listener.processSignalMessage(newMessage(t, stale)))
+}
+
+func TestMetricListener_EventWithSignals(t *testing.T) {
+	t.Parallel()
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	eventWebhook := &webhookcache.Webhook{
+		Trigger: &models.Trigger{
+			ID:         "event-trigger-id",
+			Status:     triggersrepo.StatusEnabled,
+			Service:    triggersrepo.ServiceEvent,
+			MetricName: "behavior.harshBraking",
+			Condition:  "signals.speed > 80",
+		},
+		Signals: []string{"speed"},
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	newEventMessage := func(t *testing.T) *message.Message {
+		t.Helper()
+		header := cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"}
+		eventJSON, err := json.Marshal(vss.PackEvents(header, []vss.Event{{
+			CloudEventHeader: header,
+			Data:             vss.EventData{Name: "behavior.harshBraking", Timestamp: now, DurationNs: 1000},
+		}}))
+		require.NoError(t, err)
+		return message.NewMessage(uuid.New().String(), eventJSON)
+	}
+	speed := vss.Signal{Data: vss.SignalData{Timestamp: now, Name: "speed", ValueNumber: 95.0}}
+
+	ctrl := gomock.NewController(t)
+	mockCache := NewMockWebhookCache(ctrl)
+	mockRepo := NewMockTriggerRepo(ctrl)
+	mockWebhookSender := NewMockWebhookSender(ctrl)
+	mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+	latestSignals := signalstate.NewStore()
+	signalListener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, latestSignals, newTestVehicleState(ctrl), createTestSettings())
+	eventListener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, latestSignals, newTestVehicleState(ctrl), createTestSettings())
+
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceEvent, "behavior.harshBraking").Return([]*webhookcache.Webhook{eventWebhook}).Times(2)
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").Return(nil)
+	mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").Return([]*webhookcache.Webhook{eventWebhook})
+
+	// Until the speed has been received the event webhook is not evaluated.
+	require.NoError(t, eventListener.processEventMessage(newEventMessage(t)))
+
+	// The signal listener records the speed without evaluating the event webhook.
+	signalJSON, err := json.Marshal(vss.PackSignals(cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"}, []vss.Signal{speed}))
+	require.NoError(t, err)
+	require.NoError(t, signalListener.processSignalMessage(message.NewMessage(uuid.New().String(), signalJSON)))
+
+	mockTriggerEvaluator.EXPECT().
+		EvaluateEventTrigger(gomock.Any(), eventWebhook.Trigger, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.Trigger, _ cel.Program, data *triggerevaluator.EventEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+			require.Len(t, data.Signals, 1)
+			assert.Equal(t, speed.Data, data.Signals["speed"].Data)
+			return &triggerevaluator.TriggerEvaluationResult{ShouldFire: true}, nil
+		})
+	mockWebhookSender.EXPECT().
+		SendWebhook(gomock.Any(), eventWebhook.Trigger, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+			require.NotNil(t, payload.Data.Event)
+			require.Len(t, payload.Data.Signals, 1)
+			assert.Equal(t, "speed", payload.Data.Signals[0].Name)
+			assert.Equal(t, 95.0, payload.Data.Signals[0].Value)
+			return webhook.DeliveryResult{StatusCode: http.StatusOK}, nil
+		})
+	mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().ResetTriggerFailureCount(gomock.Any(), eventWebhook.Trigger).Return(nil)
+	mockRepo.EXPECT().CreateTriggerLog(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, eventListener.processEventMessage(newEventMessage(t)))
+}
+
+// newTestVehicleState returns a vehicle state that has not observed any metric and accepts any observation.
+func newTestVehicleState(ctrl *gomock.Controller) *MockVehicleState {
+	vehicleState := NewMockVehicleState(ctrl)
+	vehicleState.EXPECT().GetVehicleMetricState(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	vehicleState.EXPECT().SetVehicleMetricState(gomock.Any()).AnyTimes()
+	vehicleState.EXPECT().SetVehicleMetricFired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return vehicleState
+}
+
+func TestMetricListener_PreviousValue(t *testing.T) {
+	t.Parallel()
+
+	vehicleDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	signalWebhook := &webhookcache.Webhook{
+		Trigger: &models.Trigger{
+			ID:         "signal-trigger-id",
+			Status:     triggersrepo.StatusEnabled,
+			Service:    triggersrepo.ServiceSignal,
+			MetricName: "vss.speed",
+			Condition:  "valueNumber > previousValueNumber",
+		},
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	previous := vss.Signal{Data: vss.SignalData{Timestamp: now.Add(-time.Minute), Name: "speed", ValueNumber: 20}}
+	current := vss.Signal{
+		CloudEventHeader: cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"},
+		Data:             vss.SignalData{Timestamp: now, Name: "speed", ValueNumber: 25},
+	}
+	newMessage := func(t *testing.T) *message.Message {
+		t.Helper()
+		signalJSON, err := json.Marshal(vss.PackSignals(cloudevent.CloudEventHeader{Subject: vehicleDID.String(), Source: "test-source"}, []vss.Signal{current}))
+		require.NoError(t, err)
+		return message.NewMessage(uuid.New().String(), signalJSON)
+	}
+
+	t.Run("evaluates against the last observed value and records the new one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+		mockVehicleState := NewMockVehicleState(ctrl)
+		listener := NewMetricsListener(mockCache, NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), mockTriggerEvaluator, nil, nil, mockVehicleState, createTestSettings())
+
+		previousJSON, err := json.Marshal(previous)
+		require.NoError(t, err)
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").Return([]*webhookcache.Webhook{signalWebhook})
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").Return(nil)
+		mockVehicleState.EXPECT().GetVehicleMetricState(gomock.Any(), vehicleDID, "vss.speed").Return(&models.VehicleMetricState{
+			AssetDid:     vehicleDID.String(),
+			MetricName:   "vss.speed",
+			SnapshotData: previousJSON,
+			ObservedAt:   previous.Data.Timestamp,
+		}, nil)
+		mockVehicleState.EXPECT().SetVehicleMetricState(gomock.Any()).Do(func(state *models.VehicleMetricState) {
+			assert.Equal(t, vehicleDID.String(), state.AssetDid)
+			assert.Equal(t, "vss.speed", state.MetricName)
+			assert.Equal(t, now, state.ObservedAt)
+			currentJSON, err := json.Marshal(current)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(currentJSON), string(state.SnapshotData))
+		})
+		mockTriggerEvaluator.EXPECT().
+			EvaluateSignalTrigger(gomock.Any(), signalWebhook.Trigger, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, _, _ cel.Program, data *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+				var want vss.Signal
+				require.NoError(t, json.Unmarshal(previousJSON, &want))
+				assert.Equal(t, want, data.Previous)
+				return &triggerevaluator.TriggerEvaluationResult{ConditionNotMet: true}, nil
+			})
+
+		require.NoError(t, listener.processSignalMessage(newMessage(t)))
+	})
+
+	t.Run("evaluates against the last fired value and records the fired one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		mockRepo := NewMockTriggerRepo(ctrl)
+		mockWebhookSender := NewMockWebhookSender(ctrl)
+		mockTriggerEvaluator := NewMockTriggerEvaluator(ctrl)
+		mockVehicleState := NewMockVehicleState(ctrl)
+		listener := NewMetricsListener(mockCache, mockRepo, mockWebhookSender, mockTriggerEvaluator, nil, nil, mockVehicleState, createTestSettings())
+
+		previousJSON, err := json.Marshal(previous)
+		require.NoError(t, err)
+		fired := vss.Signal{Data: vss.SignalData{Timestamp: now.Add(-time.Hour), Name: "speed", ValueNumber: 10}}
+		firedJSON, err := json.Marshal(fired)
+		require.NoError(t, err)
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").Return([]*webhookcache.Webhook{signalWebhook})
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").Return(nil)
+		mockVehicleState.EXPECT().GetVehicleMetricState(gomock.Any(), vehicleDID, "vss.speed").Return(&models.VehicleMetricState{
+			AssetDid:          vehicleDID.String(),
+			MetricName:        "vss.speed",
+			SnapshotData:      previousJSON,
+			ObservedAt:        previous.Data.Timestamp,
+			FiredSnapshotData: null.JSONFrom(firedJSON),
+			FiredAt:           null.TimeFrom(fired.Data.Timestamp),
+		}, nil)
+		mockVehicleState.EXPECT().SetVehicleMetricState(gomock.Any())
+		mockTriggerEvaluator.EXPECT().
+			EvaluateSignalTrigger(gomock.Any(), signalWebhook.Trigger, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, _, _ cel.Program, data *triggerevaluator.SignalEvaluationData) (*triggerevaluator.TriggerEvaluationResult, error) {
+				var want vss.Signal
+				require.NoError(t, json.Unmarshal(firedJSON, &want))
+				assert.Equal(t, want, data.PreviousFired)
+				return &triggerevaluator.TriggerEvaluationResult{ShouldFire: true}, nil
+			})
+		mockVehicleState.EXPECT().SetVehicleMetricFired(vehicleDID, "vss.speed", gomock.Any(), gomock.Any()).
+			Do(func(_ cloudevent.ERC721DID, _ string, data types.JSON, _ time.Time) {
+				currentJSON, err := json.Marshal(current)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(currentJSON), string(data))
+			})
+		mockWebhookSender.EXPECT().SendWebhook(gomock.Any(), signalWebhook.Trigger, gomock.Any()).Return(webhook.DeliveryResult{StatusCode: http.StatusOK}, nil)
+		mockRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ResetTriggerFailureCount(gomock.Any(), signalWebhook.Trigger).Return(nil)
+		mockRepo.EXPECT().CreateTriggerLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, listener.processSignalMessage(newMessage(t)))
+	})
+
+	t.Run("does not evaluate when the last value cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCache := NewMockWebhookCache(ctrl)
+		mockVehicleState := NewMockVehicleState(ctrl)
+		listener := NewMetricsListener(mockCache, NewMockTriggerRepo(ctrl), NewMockWebhookSender(ctrl), NewMockTriggerEvaluator(ctrl), nil, nil, mockVehicleState, createTestSettings())
+
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, "vss.speed").Return([]*webhookcache.Webhook{signalWebhook})
+		mockCache.EXPECT().GetWebhooks(vehicleDID.String(), triggersrepo.ServiceComposite, "vss.speed").Return(nil)
+		mockVehicleState.EXPECT().GetVehicleMetricState(gomock.Any(), vehicleDID, "vss.speed").Return(&models.VehicleMetricState{
+			SnapshotData: []byte(`invalid json`),
+		}, nil)
+
+		require.Error(t, listener.processSignalMessage(newMessage(t)))
+	})
+}
+
 func createTestSettings() *config.Settings {
 	return &config.Settings{
 		VehicleNFTAddress:      common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggerevaluator"
@@ -15,6 +17,7 @@ import (
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/errgroup"
 )
@@ -32,6 +35,8 @@ func (m *MetricListener) processSignalMessage(msg *message.Message) error {
 		return fmt.Errorf("failed to decode ERC721DID from envelope: %w", err)
 	}
 
+	m.recordSeen(vehicleDID, triggersrepo.AnySignal)
+
 	var errs error
 	for _, sig := range sigs {
 		sigData, err := json.Marshal(sig)
@@ -43,6 +48,9 @@ func (m *MetricListener) processSignalMessage(msg *message.Message) error {
 			errs = errors.Join(errs, err)
 		}
 	}
+	if err := m.processCompositeSignals(msg.Context(), vehicleDID, sigs); err != nil {
+		errs = errors.Join(errs, err)
+	}
 	return errs
 }
 
@@ -67,10 +75,17 @@ func (m *MetricListener) processSingleSignal(ctx context.Context, sig vss.Signal
 		RawData:    rawPayload,
 	}
 
-	webhooks := m.webhookCache.GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, signals.VSSPrefix+sig.Data.Name)
+	metricName := signals.VSSPrefix + sig.Data.Name
+	m.recordSeen(vehicleDID, metricName)
+	webhooks := m.webhookCache.GetWebhooks(vehicleDID.String(), triggersrepo.ServiceSignal, metricName)
 	if len(webhooks) == 0 {
 		return nil
 	}
+	if err := m.observe(ctx, vehicleDID, metricName, rawPayload, sig.Data.Timestamp, &sigAndRaw.Previous, &sigAndRaw.PreviousFired); err != nil {
+		return err
+	}
+	sample := celcondition.WindowSample{Timestamp: sig.Data.Timestamp, Value: sig.Data.ValueNumber}
+	sigAndRaw.Window = m.recordWindowSample(vehicleDID.String(), metricName, sample, webhooks)
 
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(100)
@@ -86,8 +101,15 @@ func (m *MetricListener) processSingleSignal(ctx context.Context, sig vss.Signal
 }
 
 func (m *MetricListener) processSignalWebhook(ctx context.Context, wh *webhookcache.Webhook, sigAndRaw *triggerevaluator.SignalEvaluationData) error {
+	if wh.Schedule != nil || wh.Params != nil {
+		forWebhook := *sigAndRaw
+		forWebhook.Schedule = wh.Schedule
+		forWebhook.Params = wh.Params
+		sigAndRaw = &forWebhook
+	}
+
 	// Evaluate the trigger using the new service
-	result, err := m.triggerEvaluator.EvaluateSignalTrigger(ctx, wh.Trigger, wh.Program, sigAndRaw)
+	result, err := m.triggerEvaluator.EvaluateSignalTrigger(ctx, wh.Trigger, wh.Program, wh.ClearProgram, sigAndRaw)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate signal trigger: %w", err)
 	}
@@ -99,10 +121,11 @@ func (m *MetricListener) processSignalWebhook(ctx context.Context, wh *webhookca
 			if err != nil {
 				return fmt.Errorf("failed to delete vehicle subscription: %w", err)
 			}
-			m.webhookCache.ScheduleRefresh(ctx)
+			m.webhookCache.RemoveSubscription(wh.Trigger.ID, sigAndRaw.VehicleDID.String())
 		}
 		return nil
 	}
+	m.vehicleState.SetVehicleMetricFired(sigAndRaw.VehicleDID, wh.Trigger.MetricName, types.JSON(sigAndRaw.RawData), time.Now())
 
 	payload, err := m.createSignalPayload(wh.Trigger, sigAndRaw)
 	if err != nil {
@@ -112,26 +135,35 @@ func (m *MetricListener) processSignalWebhook(ctx context.Context, wh *webhookca
 	return m.handleTriggeredWebhook(ctx, wh.Trigger, sigAndRaw.RawData, payload)
 }
 func (m *MetricListener) createSignalPayload(trigger *models.Trigger, sigEval *triggerevaluator.SignalEvaluationData) (*cloudevent.CloudEvent[webhook.WebhookPayload], error) {
+	signalData, err := newSignalData(sigEval.Signal, sigEval.Def)
+	if err != nil {
+		return nil, err
+	}
+	payload := m.createWebhookPayload(trigger, sigEval.VehicleDID)
+	payload.Data.Signal = signalData
+	return payload, nil
+}
+
+// newSignalData converts sig to the signal of a webhook payload, with the value of the type of its definition.
+func newSignalData(sig vss.Signal, def signals.SignalDefinition) (*webhook.SignalData, error) {
 	var signalValue any
-	switch sigEval.Def.ValueType {
+	switch def.ValueType {
 	case signals.NumberType:
-		signalValue = sigEval.Signal.Data.ValueNumber
+		signalValue = sig.Data.ValueNumber
 	case signals.StringType:
-		signalValue = sigEval.Signal.Data.ValueString
+		signalValue = sig.Data.ValueString
 	case signals.LocationType:
-		signalValue = sigEval.Signal.Data.ValueLocation
+		signalValue = sig.Data.ValueLocation
 	default:
-		return nil, fmt.Errorf("unsupported signal type: %s", sigEval.Def.ValueType)
+		return nil, fmt.Errorf("unsupported signal type: %s", def.ValueType)
 	}
-	payload := m.createWebhookPayload(trigger, sigEval.VehicleDID)
-	payload.Data.Signal = &webhook.SignalData{
-		Name:      sigEval.Signal.Data.Name,
-		Source:    sigEval.Signal.Source,
-		Units:     sigEval.Def.Unit,
-		Timestamp: sigEval.Signal.Data.Timestamp,
-		Producer:  sigEval.Signal.Producer,
-		ValueType: sigEval.Def.ValueType,
+	return &webhook.SignalData{
+		Name:      sig.Data.Name,
+		Source:    sig.Source,
+		Units:     def.Unit,
+		Timestamp: sig.Data.Timestamp,
+		Producer:  sig.Producer,
+		ValueType: def.ValueType,
 		Value:     signalValue,
-	}
-	return payload, nil
+	}, nil
 }
@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxConditionSamples bounds the samples evaluated in a single dry run.
+const maxConditionSamples = 100
+
+// ConditionController evaluates conditions without creating a webhook.
+type ConditionController struct {
+	repo GeofenceRepository
+}
+
+// NewConditionController creates a new ConditionController. The repository provides the geofences
+// conditions can refer to.
+func NewConditionController(repo GeofenceRepository) *ConditionController {
+	return &ConditionController{repo: repo}
+}
+
+// EvaluateCondition godoc
+// @Summary      Dry-run a condition
+// @Description  Evaluates a CEL condition against the given samples without saving anything, using the same variables a webhook would. Each result reports whether the webhook would have fired for the sample, the variables the condition was evaluated with and any evaluation error. Cooldowns are not applied.
+// @Tags         Conditions
+// @Accept       json
+// @Produce      json
+// @Param        request  body      EvaluateConditionRequest   true  "Condition and samples"
+// @Success      200      {object}  EvaluateConditionResponse  "Result per sample"
+// @Failure      400      "Invalid request payload, service or condition"
+// @Failure      500      "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/conditions/evaluate [post]
+func (cc *ConditionController) EvaluateCondition(c *fiber.Ctx) error {
+	var payload EvaluateConditionRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Invalid request payload",
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if len(payload.Samples) == 0 || len(payload.Samples) > maxConditionSamples {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Samples must contain between 1 and %d entries", maxConditionSamples),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	var geofences celcondition.Geofences
+	if names, err := celcondition.ConditionGeofences(payload.Service, payload.Condition); err == nil && len(names) > 0 {
+		devLicense, err := getDevLicense(c)
+		if err != nil {
+			return err
+		}
+		geofences, err = loadConditionGeofences(c.Context(), cc.repo, devLicense, payload.Service, payload.Condition)
+		if err != nil {
+			return err
+		}
+	}
+	params, _, err := validateParams(payload.Params)
+	if err != nil {
+		return err
+	}
+	prg, valueType, err := prepareCondition(payload.Service, payload.MetricName, payload.Condition, geofences, params)
+	if err != nil {
+		return err
+	}
+
+	results := make([]ConditionSampleResult, 0, len(payload.Samples))
+	for _, sample := range payload.Samples {
+		var result ConditionSampleResult
+		var evalErr error
+		switch {
+		case triggersrepo.IsAbsenceService(payload.Service):
+			silentFor := time.Duration(sample.Current.SilentSeconds * float64(time.Second))
+			result.Bindings = celcondition.AbsenceVariables(sample.Current.Offline, silentFor)
+			result.Fired, evalErr = celcondition.EvaluateAbsenceCondition(prg, sample.Current.Offline, silentFor, params)
+		case triggersrepo.IsCompositeService(payload.Service):
+			var values map[string]vss.Signal
+			values, evalErr = sample.Current.toSignals()
+			if evalErr == nil {
+				result.Bindings = celcondition.CompositeVariables(values)
+				result.Fired, evalErr = celcondition.EvaluateCompositeCondition(prg, values, params)
+			}
+		case triggersrepo.IsSignalService(payload.Service):
+			current, previous := sample.Current.toSignal(), sample.Previous.toSignal()
+			result.Bindings, evalErr = celcondition.SignalVariables(current, previous, valueType)
+			if evalErr == nil {
+				result.Fired, evalErr = celcondition.EvaluateSignalCondition(prg, current, previous, valueType, nil, params)
+			}
+		default:
+			current, previous := sample.Current.toEvent(), sample.Previous.toEvent()
+			var values map[string]vss.Signal
+			values, evalErr = sample.Current.toSignals()
+			if evalErr == nil {
+				result.Bindings, evalErr = celcondition.EventVariables(current, previous)
+			}
+			if evalErr == nil {
+				maps.Copy(result.Bindings, celcondition.CompositeVariables(values))
+				result.Fired, evalErr = celcondition.EvaluateEventCondition(prg, current, previous, nil, values, params)
+			}
+		}
+		if result.Bindings != nil && params != nil {
+			result.Bindings["params"] = map[string]any(params)
+		}
+		if evalErr != nil {
+			result.Error = evalErr.Error()
+		}
+		results = append(results, result)
+	}
+
+	return c.JSON(EvaluateConditionResponse{Results: results})
+}
+
+// toSignal converts the sample to the signal a webhook condition would see. A nil sample stays nil.
+func (s *ConditionSampleValue) toSignal() *vss.Signal {
+	if s == nil {
+		return nil
+	}
+	signal := &vss.Signal{
+		CloudEventHeader: cloudevent.CloudEventHeader{Source: s.Source},
+		Data: vss.SignalData{
+			Timestamp:   s.Timestamp,
+			ValueNumber: s.ValueNumber,
+			ValueString: s.ValueString,
+		},
+	}
+	if s.ValueLocation != nil {
+		signal.Data.ValueLocation = *s.ValueLocation
+	}
+	return signal
+}
+
+// toSignals converts the signal values of the sample to the latest signals a composite or event condition would see,
+// keyed by signal name. A value is a number, a string or a location depending on the value type of its signal.
+func (s *ConditionSampleValue) toSignals() (map[string]vss.Signal, error) {
+	values := make(map[string]vss.Signal, len(s.Signals))
+	for name, raw := range s.Signals {
+		sig := vss.Signal{Data: vss.SignalData{Name: name}}
+		var err error
+		switch signals.GetSignalDefinitionOrDefault(name, signals.NumberType).ValueType {
+		case signals.StringType:
+			err = json.Unmarshal(raw, &sig.Data.ValueString)
+		case signals.LocationType:
+			err = json.Unmarshal(raw, &sig.Data.ValueLocation)
+		default:
+			err = json.Unmarshal(raw, &sig.Data.ValueNumber)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of signal %q: %w", name, err)
+		}
+		values[name] = sig
+	}
+	return values, nil
+}
+
+// toEvent converts the sample to the event a webhook condition would see. A nil sample stays nil.
+func (s *ConditionSampleValue) toEvent() *vss.Event {
+	if s == nil {
+		return nil
+	}
+	return &vss.Event{
+		CloudEventHeader: cloudevent.CloudEventHeader{Source: s.Source},
+		Data: vss.EventData{
+			Timestamp:  s.Timestamp,
+			Name:       s.Name,
+			DurationNs: s.DurationNs,
+			Metadata:   s.Metadata,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionController_EvaluateCondition(t *testing.T) {
+	t.Parallel()
+
+	evaluate := func(t *testing.T, body string) *http.Response {
+		t.Helper()
+		app := newApp()
+		app.Post("/conditions/evaluate", NewConditionController(nil).EvaluateCondition)
+		req := httptest.NewRequest(http.MethodPost, "/conditions/evaluate", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("signal samples", func(t *testing.T) {
+		resp := evaluate(t, `{
+			"service": "signals",
+			"metricName": "vss.speed",
+			"condition": "value > 20 && previousValue <= 20",
+			"samples": [
+				{"current": {"valueNumber": 25}, "previous": {"valueNumber": 10}},
+				{"current": {"valueNumber": 25}, "previous": {"valueNumber": 30}},
+				{"current": {"valueNumber": 15}}
+			]
+		}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response EvaluateConditionResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.Len(t, response.Results, 3)
+		assert.True(t, response.Results[0].Fired)
+		assert.False(t, response.Results[1].Fired)
+		assert.False(t, response.Results[2].Fired)
+		assert.Equal(t, 25.0, response.Results[0].Bindings["value"])
+		assert.Equal(t, 10.0, response.Results[0].Bindings["previousValue"])
+		assert.Equal(t, 0.0, response.Results[2].Bindings["previousValue"])
+		assert.Empty(t, response.Results[0].Error)
+	})
+
+	t.Run("event samples", func(t *testing.T) {
+		resp := evaluate(t, `{
+			"service": "events",
+			"metricName": "behavior.harshBraking",
+			"condition": "durationNs > 1000000",
+			"samples": [
+				{"current": {"name": "behavior.harshBraking", "durationNs": 2000000}},
+				{"current": {"name": "behavior.harshBraking", "durationNs": 500}}
+			]
+		}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response EvaluateConditionResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.Len(t, response.Results, 2)
+		assert.True(t, response.Results[0].Fired)
+		assert.False(t, response.Results[1].Fired)
+		assert.Equal(t, "behavior.harshBraking", response.Results[0].Bindings["name"])
+	})
+
+	t.Run("local time of samples", func(t *testing.T) {
+		resp := evaluate(t, `{
+			"service": "signals",
+			"metricName": "vss.speed",
+			"condition": "hourOfDay('America/New_York') >= 20 && value > 0",
+			"samples": [
+				{"current": {"valueNumber": 25, "timestamp": "2025-12-02T02:00:00Z"}},
+				{"current": {"valueNumber": 25, "timestamp": "2025-12-02T12:00:00Z"}}
+			]
+		}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response EvaluateConditionResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.Len(t, response.Results, 2)
+		assert.True(t, response.Results[0].Fired)
+		assert.False(t, response.Results[1].Fired)
+	})
+
+	t.Run("runtime error is reported per sample", func(t *testing.T) {
+		resp := evaluate(t, `{
+			"service": "signals",
+			"metricName": "vss.speed",
+			"condition": "int(valueNumber) / int(valueNumber - 25) > 1",
+			"samples": [
+				{"current": {"valueNumber": 25}}
+			]
+		}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response EvaluateConditionResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.Len(t, response.Results, 1)
+		assert.False(t, response.Results[0].Fired)
+		assert.Contains(t, response.Results[0].Error, "failed to evaluate CEL condition")
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+		resp := evaluate(t, `{
+			"service": "signals",
+			"metricName": "vss.speed",
+			"condition": "value >",
+			"samples": [{"current": {"valueNumber": 25}}]
+		}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		resp := evaluate(t, `{"service": "signals", "metricName": "vss.speed", "condition": "value > 20", "samples": []}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,283 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/geofence"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// geofenceNamePattern restricts geofence names so they can be written as string literals in conditions.
+var geofenceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+type GeofenceRepository interface {
+	CreateGeofence(ctx context.Context, developerLicenseAddress common.Address, name string, geometry []byte) (*models.Geofence, error)
+	GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error)
+	GetGeofenceByIDAndDeveloperLicense(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) (*models.Geofence, error)
+	UpdateGeofence(ctx context.Context, geofence *models.Geofence) error
+	DeleteGeofence(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) error
+	GetTriggersByDeveloperLicense(ctx context.Context, developerLicense common.Address) ([]*models.Trigger, error)
+}
+
+// GeofenceController is the controller for creating and managing geofences.
+type GeofenceController struct {
+	repo  GeofenceRepository
+	cache WebhookCache
+}
+
+// NewGeofenceController creates a new GeofenceController.
+func NewGeofenceController(repo GeofenceRepository, cache WebhookCache) *GeofenceController {
+	return &GeofenceController{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// ListGeofences godoc
+// @Summary      List geofences
+// @Description  Lists the geofences of the developer license ordered by name.
+// @Tags         Geofences
+// @Produce      json
+// @Success      200  {array}   GeofenceView  "Geofences"
+// @Failure      500  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/geofences [get]
+func (g *GeofenceController) ListGeofences(c *fiber.Ctx) error {
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	geofences, err := g.repo.GetGeofencesByDeveloperLicense(c.Context(), devLicense)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve geofences: %w", err)
+	}
+	views := make([]GeofenceView, 0, len(geofences))
+	for _, gf := range geofences {
+		views = append(views, geofenceView(gf))
+	}
+	return c.JSON(views)
+}
+
+// CreateGeofence godoc
+// @Summary      Create a geofence
+// @Description  Creates a named GeoJSON polygon that signal conditions on vss.currentLocationCoordinates can refer to with inGeofence, enteredGeofence and exitedGeofence.
+// @Tags         Geofences
+// @Accept       json
+// @Produce      json
+// @Param        request  body      CreateGeofenceRequest  true  "Geofence"
+// @Success      201      {object}  GeofenceView           "Geofence created"
+// @Failure      400      "Invalid name or geometry"
+// @Failure      409      "A geofence with the name already exists"
+// @Failure      500      "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/geofences [post]
+func (g *GeofenceController) CreateGeofence(c *fiber.Ctx) error {
+	var payload CreateGeofenceRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Invalid request payload",
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if err := validateGeofenceName(payload.Name); err != nil {
+		return err
+	}
+	if err := validateGeofenceGeometry(payload.Geometry); err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	gf, err := g.repo.CreateGeofence(c.Context(), devLicense, payload.Name, payload.Geometry)
+	if err != nil {
+		return fmt.Errorf("failed to create geofence: %w", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(geofenceView(gf))
+}
+
+// GetGeofence godoc
+// @Summary      Get a geofence
+// @Description  Returns a geofence by its ID.
+// @Tags         Geofences
+// @Produce      json
+// @Param        geofenceId  path      string        true  "Geofence ID"
+// @Success      200         {object}  GeofenceView  "Geofence"
+// @Failure      400         "Invalid geofence id"
+// @Failure      404         "Geofence not found"
+// @Failure      500         "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/geofences/{geofenceId} [get]
+func (g *GeofenceController) GetGeofence(c *fiber.Ctx) error {
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	gf, err := g.repo.GetGeofenceByIDAndDeveloperLicense(c.Context(), c.Params("geofenceId"), devLicense)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve geofence: %w", err)
+	}
+	return c.JSON(geofenceView(gf))
+}
+
+// UpdateGeofence godoc
+// @Summary      Update a geofence
+// @Description  Updates the name or geometry of a geofence. Webhooks using the geofence are evaluated against the new geometry. A geofence used by a webhook condition can not be renamed.
+// @Tags         Geofences
+// @Accept       json
+// @Produce      json
+// @Param        geofenceId  path      string                 true  "Geofence ID"
+// @Param        request     body      UpdateGeofenceRequest  true  "Geofence fields to update"
+// @Success      200         {object}  GeofenceView           "Geofence updated"
+// @Failure      400         "Invalid request payload, name or geometry"
+// @Failure      404         "Geofence not found"
+// @Failure      409         "The name is taken or the geofence is used by a webhook"
+// @Failure      500         "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/geofences/{geofenceId} [put]
+func (g *GeofenceController) UpdateGeofence(c *fiber.Ctx) error {
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	gf, err := g.repo.GetGeofenceByIDAndDeveloperLicense(c.Context(), c.Params("geofenceId"), devLicense)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve geofence: %w", err)
+	}
+
+	var payload UpdateGeofenceRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Invalid request payload: " + err.Error(),
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if payload.Name != nil && *payload.Name != gf.Name {
+		if err := validateGeofenceName(*payload.Name); err != nil {
+			return err
+		}
+		if err := g.checkGeofenceUnused(c.Context(), devLicense, gf.Name, "renamed"); err != nil {
+			return err
+		}
+		gf.Name = *payload.Name
+	}
+	if payload.Geometry != nil {
+		if err := validateGeofenceGeometry(payload.Geometry); err != nil {
+			return err
+		}
+		gf.Geometry = []byte(payload.Geometry)
+	}
+	gf.UpdatedAt = time.Now().UTC()
+
+	if err := g.repo.UpdateGeofence(c.Context(), gf); err != nil {
+		return fmt.Errorf("failed to update geofence: %w", err)
+	}
+	g.cache.ScheduleRefresh(c.Context())
+
+	return c.JSON(geofenceView(gf))
+}
+
+// DeleteGeofence godoc
+// @Summary      Delete a geofence
+// @Description  Deletes a geofence by its ID. A geofence used by a webhook condition can not be deleted.
+// @Tags         Geofences
+// @Produce      json
+// @Param        geofenceId  path  string  true  "Geofence ID"
+// @Success      200  {object}  GenericResponse  "Geofence deleted successfully"
+// @Failure      400  "Invalid geofence id"
+// @Failure      404  "Geofence not found"
+// @Failure      409  "The geofence is used by a webhook"
+// @Failure      500  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/geofences/{geofenceId} [delete]
+func (g *GeofenceController) DeleteGeofence(c *fiber.Ctx) error {
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	geofenceID := c.Params("geofenceId")
+	gf, err := g.repo.GetGeofenceByIDAndDeveloperLicense(c.Context(), geofenceID, devLicense)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve geofence: %w", err)
+	}
+	if err := g.checkGeofenceUnused(c.Context(), devLicense, gf.Name, "deleted"); err != nil {
+		return err
+	}
+
+	if err := g.repo.DeleteGeofence(c.Context(), geofenceID, devLicense); err != nil {
+		return fmt.Errorf("failed to delete geofence: %w", err)
+	}
+	g.cache.ScheduleRefresh(c.Context())
+
+	return c.Status(fiber.StatusOK).JSON(GenericResponse{Message: "Geofence deleted successfully"})
+}
+
+// checkGeofenceUnused returns a conflict error if a webhook of the developer license refers to the geofence
+// in its condition or clear condition.
+func (g *GeofenceController) checkGeofenceUnused(ctx context.Context, devLicense common.Address, name, action string) error {
+	triggers, err := g.repo.GetTriggersByDeveloperLicense(ctx, devLicense)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+	var users []string
+	for _, trigger := range triggers {
+		for _, condition := range []string{trigger.Condition, trigger.ClearCondition.String} {
+			names, err := celcondition.ConditionGeofences(trigger.Service, condition)
+			if err == nil && slices.Contains(names, name) {
+				users = append(users, trigger.DisplayName)
+				break
+			}
+		}
+	}
+	if len(users) > 0 {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Geofence %q can not be %s while it is used by webhooks: %s", name, action, strings.Join(users, ", ")),
+			Code:        fiber.StatusConflict,
+		}
+	}
+	return nil
+}
+
+func validateGeofenceName(name string) error {
+	if !geofenceNamePattern.MatchString(name) {
+		return richerrors.Error{
+			ExternalMsg: "Geofence name must be 1 to 64 letters, digits, '_', '.' or '-' and start with a letter or digit",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+func validateGeofenceGeometry(geometry []byte) error {
+	if _, err := geofence.ParsePolygon(geometry); err != nil {
+		err := fmt.Errorf("invalid geofence geometry: %w", err)
+		return richerrors.Error{
+			ExternalMsg: err.Error(),
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+func geofenceView(gf *models.Geofence) GeofenceView {
+	return GeofenceView{
+		ID:        gf.ID,
+		Name:      gf.Name,
+		Geometry:  []byte(gf.Geometry),
+		CreatedAt: gf.CreatedAt,
+		UpdatedAt: gf.UpdatedAt,
+	}
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geofence_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source=geofence_controller.go -destination=geofence_controller_mock_test.go -package=webhook
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGeofenceRepository is a mock of GeofenceRepository interface.
+type MockGeofenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeofenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGeofenceRepositoryMockRecorder is the mock recorder for MockGeofenceRepository.
+type MockGeofenceRepositoryMockRecorder struct {
+	mock *MockGeofenceRepository
+}
+
+// NewMockGeofenceRepository creates a new mock instance.
+func NewMockGeofenceRepository(ctrl *gomock.Controller) *MockGeofenceRepository {
+	mock := &MockGeofenceRepository{ctrl: ctrl}
+	mock.recorder = &MockGeofenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeofenceRepository) EXPECT() *MockGeofenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGeofence mocks base method.
+func (m *MockGeofenceRepository) CreateGeofence(ctx context.Context, developerLicenseAddress common.Address, name string, geometry []byte) (*models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGeofence", ctx, developerLicenseAddress, name, geometry)
+	ret0, _ := ret[0].(*models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGeofence indicates an expected call of CreateGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) CreateGeofence(ctx, developerLicenseAddress, name, geometry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).CreateGeofence), ctx, developerLicenseAddress, name, geometry)
+}
+
+// DeleteGeofence mocks base method.
+func (m *MockGeofenceRepository) DeleteGeofence(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofence", ctx, geofenceID, developerLicenseAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofence indicates an expected call of DeleteGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) DeleteGeofence(ctx, geofenceID, developerLicenseAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).DeleteGeofence), ctx, geofenceID, developerLicenseAddress)
+}
+
+// GetGeofenceByIDAndDeveloperLicense mocks base method.
+func (m *MockGeofenceRepository) GetGeofenceByIDAndDeveloperLicense(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) (*models.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofenceByIDAndDeveloperLicense", ctx, geofenceID, developerLicenseAddress)
+	ret0, _ := ret[0].(*models.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofenceByIDAndDeveloperLicense indicates an expected call of GetGeofenceByIDAndDeveloperLicense.
+func (mr *MockGeofenceRepositoryMockRecorder) GetGeofenceByIDAndDeveloperLicense(ctx, geofenceID, developerLicenseAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofenceByIDAndDeveloperLicense", reflect.TypeOf((*MockGeofenceRepository)(nil).GetGeofenceByIDAndDeveloperLicense), ctx, geofenceID, developerLicenseAddress)
+}
+
+// GetGeofencesByDeveloperLicense mocks base method.
+func (m *MockGeofenceRepository) GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByDeveloperLicense", ctx, developerLicenseAddress)
+	ret0, _ := ret[0].(models.GeofenceSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByDeveloperLicense indicates an expected call of GetGeofencesByDeveloperLicense.
+func (mr *MockGeofenceRepositoryMockRecorder) GetGeofencesByDeveloperLicense(ctx, developerLicenseAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByDeveloperLicense", reflect.TypeOf((*MockGeofenceRepository)(nil).GetGeofencesByDeveloperLicense), ctx, developerLicenseAddress)
+}
+
+// GetTriggersByDeveloperLicense mocks base method.
+func (m *MockGeofenceRepository) GetTriggersByDeveloperLicense(ctx context.Context, developerLicense common.Address) ([]*models.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggersByDeveloperLicense", ctx, developerLicense)
+	ret0, _ := ret[0].([]*models.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggersByDeveloperLicense indicates an expected call of GetTriggersByDeveloperLicense.
+func (mr *MockGeofenceRepositoryMockRecorder) GetTriggersByDeveloperLicense(ctx, developerLicense any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersByDeveloperLicense", reflect.TypeOf((*MockGeofenceRepository)(nil).GetTriggersByDeveloperLicense), ctx, developerLicense)
+}
+
+// UpdateGeofence mocks base method.
+func (m *MockGeofenceRepository) UpdateGeofence(ctx context.Context, geofence *models.Geofence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGeofence", ctx, geofence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGeofence indicates an expected call of UpdateGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) UpdateGeofence(ctx, geofence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).UpdateGeofence), ctx, geofence)
+}
@@ -0,0 +1,196 @@
+//go:generate go tool mockgen -source=geofence_controller.go -destination=geofence_controller_mock_test.go -package=webhook
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testGeofenceGeometry = `{"type":"Polygon","coordinates":[[[-74.02,40.70],[-73.98,40.70],[-73.98,40.74],[-74.02,40.74],[-74.02,40.70]]]}`
+
+func TestGeofenceController_CreateGeofence(t *testing.T) {
+	t.Parallel()
+
+	create := func(t *testing.T, controller *GeofenceController, body string) *http.Response {
+		t.Helper()
+		app := newApp()
+		app.Use(tokenInjector(common.HexToAddress("0x1234567890abcdef")))
+		app.Post("/geofences", controller.CreateGeofence)
+		req := httptest.NewRequest(http.MethodPost, "/geofences", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("successful create", func(t *testing.T) {
+		controller, mockRepo, _ := newGeofenceControllerAndMocks(t)
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		now := time.Now().UTC()
+
+		mockRepo.EXPECT().
+			CreateGeofence(gomock.Any(), devLicense, "depot", gomock.Any()).
+			DoAndReturn(func(_ any, _ common.Address, name string, geometry []byte) (*models.Geofence, error) {
+				return &models.Geofence{ID: uuid.New().String(), Name: name, Geometry: geometry, CreatedAt: now, UpdatedAt: now}, nil
+			}).
+			Times(1)
+
+		resp := create(t, controller, `{"name":"depot","geometry":`+testGeofenceGeometry+`}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var view GeofenceView
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&view))
+		assert.Equal(t, "depot", view.Name)
+		assert.JSONEq(t, testGeofenceGeometry, string(view.Geometry))
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		controller, _, _ := newGeofenceControllerAndMocks(t)
+
+		resp := create(t, controller, `{"name":"my depot","geometry":`+testGeofenceGeometry+`}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid geometry", func(t *testing.T) {
+		controller, _, _ := newGeofenceControllerAndMocks(t)
+
+		resp := create(t, controller, `{"name":"depot","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), "ring 0 is not closed")
+	})
+}
+
+func TestGeofenceController_UpdateGeofence(t *testing.T) {
+	t.Parallel()
+
+	devLicense := common.HexToAddress("0x1234567890abcdef")
+	geofenceID := uuid.New().String()
+	update := func(t *testing.T, controller *GeofenceController, body string) *http.Response {
+		t.Helper()
+		app := newApp()
+		app.Use(tokenInjector(devLicense))
+		app.Put("/geofences/:geofenceId", controller.UpdateGeofence)
+		req := httptest.NewRequest(http.MethodPut, "/geofences/"+geofenceID, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+	stored := func() *models.Geofence {
+		return &models.Geofence{ID: geofenceID, Name: "depot", Geometry: []byte(testGeofenceGeometry)}
+	}
+
+	t.Run("update geometry", func(t *testing.T) {
+		controller, mockRepo, mockCache := newGeofenceControllerAndMocks(t)
+		geometry := `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`
+
+		mockRepo.EXPECT().GetGeofenceByIDAndDeveloperLicense(gomock.Any(), geofenceID, devLicense).Return(stored(), nil)
+		mockRepo.EXPECT().
+			UpdateGeofence(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, gf *models.Geofence) error {
+				assert.Equal(t, "depot", gf.Name)
+				assert.JSONEq(t, geometry, string(gf.Geometry))
+				return nil
+			})
+		mockCache.EXPECT().ScheduleRefresh(gomock.Any()).Times(1)
+
+		resp := update(t, controller, `{"geometry":`+geometry+`}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("rename a geofence used by a webhook", func(t *testing.T) {
+		controller, mockRepo, _ := newGeofenceControllerAndMocks(t)
+
+		mockRepo.EXPECT().GetGeofenceByIDAndDeveloperLicense(gomock.Any(), geofenceID, devLicense).Return(stored(), nil)
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), devLicense).
+			Return([]*models.Trigger{
+				{Service: triggersrepo.ServiceSignal, DisplayName: "Speeding", Condition: "valueNumber > 55"},
+				{Service: triggersrepo.ServiceSignal, DisplayName: "Arrivals", Condition: `value.hdop < 2`, FireMode: triggersrepo.FireModeEdge, ClearCondition: null.StringFrom(`exitedGeofence("depot")`)},
+			}, nil)
+
+		resp := update(t, controller, `{"name":"yard"}`)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), "Arrivals")
+		assert.NotContains(t, string(respBody), "Speeding")
+	})
+}
+
+func TestGeofenceController_DeleteGeofence(t *testing.T) {
+	t.Parallel()
+
+	devLicense := common.HexToAddress("0x1234567890abcdef")
+	geofenceID := uuid.New().String()
+	remove := func(t *testing.T, controller *GeofenceController) *http.Response {
+		t.Helper()
+		app := newApp()
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/geofences/:geofenceId", controller.DeleteGeofence)
+		resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/geofences/"+geofenceID, nil))
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("successful delete", func(t *testing.T) {
+		controller, mockRepo, mockCache := newGeofenceControllerAndMocks(t)
+
+		mockRepo.EXPECT().GetGeofenceByIDAndDeveloperLicense(gomock.Any(), geofenceID, devLicense).Return(&models.Geofence{ID: geofenceID, Name: "depot"}, nil)
+		mockRepo.EXPECT().GetTriggersByDeveloperLicense(gomock.Any(), devLicense).Return(nil, nil)
+		mockRepo.EXPECT().DeleteGeofence(gomock.Any(), geofenceID, devLicense).Return(nil)
+		mockCache.EXPECT().ScheduleRefresh(gomock.Any()).Times(1)
+
+		resp := remove(t, controller)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("geofence used by a webhook", func(t *testing.T) {
+		controller, mockRepo, _ := newGeofenceControllerAndMocks(t)
+
+		mockRepo.EXPECT().GetGeofenceByIDAndDeveloperLicense(gomock.Any(), geofenceID, devLicense).Return(&models.Geofence{ID: geofenceID, Name: "depot"}, nil)
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), devLicense).
+			Return([]*models.Trigger{{Service: triggersrepo.ServiceSignal, DisplayName: "Arrivals", Condition: `enteredGeofence("depot")`}}, nil)
+
+		resp := remove(t, controller)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
+
+func newGeofenceControllerAndMocks(t *testing.T) (*GeofenceController, *MockGeofenceRepository, *MockWebhookCache) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockGeofenceRepository(ctrl)
+	mockCache := NewMockWebhookCache(ctrl)
+	return NewGeofenceController(mockRepo, mockCache), mockRepo, mockCache
+}
@@ -1,24 +1,59 @@
 package webhook
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
 )
 
 // RegisterWebhookRequest represents the payload to create a webhook trigger.
 // It defines what to monitor, how often to notify, and where to send callbacks.
 type RegisterWebhookRequest struct {
-	// Service is the subsystem producing the metric: "signals" or "events".
+	// Service is the subsystem producing the metric: "signals", "events", "absence" or "composite".
 	// This field can not be updated after the webhook is created.
 	Service string `json:"service" validate:"required" example:"signals"`
 	// MetricName is the fully qualified event/signal to monitor (e.g. "vss.speed" for signals, "behavior.harshBraking" for events).
+	// Absence webhooks watch a signal (e.g. "vss.speed") or "*" for all signals of the vehicle. Composite webhooks use "*".
 	// This field can not be updated after the webhook is created.
 	MetricName string `json:"metricName" validate:"required" example:"vss.speed"`
 	// Condition is a CEL expression evaluated against the metric to decide when to fire.
 	Condition string `json:"condition" validate:"required" example:"valueNumber > 55"`
 	// CoolDownPeriod is the minimum number of seconds between successive firings.
 	CoolDownPeriod int `json:"coolDownPeriod" validate:"required" example:"30"`
+	// SustainFor is the number of seconds a signal condition must hold continuously before the webhook fires. 0 fires immediately.
+	SustainFor int `json:"sustainFor" example:"60"`
+	// FireMode is "level" (default) to fire on every matching signal, subject to the cool down period, or "edge"
+	// to fire only when the condition turns from false to true for a vehicle. Edge is only supported for signals.
+	FireMode string `json:"fireMode" example:"edge"`
+	// ClearCondition is an optional CEL expression for edge webhooks. Once fired, the webhook fires again only after
+	// a signal matches the clear condition and a later one matches the condition. Defaults to the negated condition.
+	ClearCondition string `json:"clearCondition" example:"valueNumber < 50"`
+	// AbsentFor is the number of seconds without a signal after which an absence webhook fires for a vehicle.
+	// Required for absence webhooks, which fire again with offline false once the vehicle sends a signal.
+	AbsentFor int `json:"absentFor" example:"3600"`
+	// PreviousScope selects the value conditions read as previous for signal and event webhooks: "lastObserved"
+	// (default) for the last value the vehicle sent, "lastFiredByThisTrigger" for the value this webhook last fired
+	// for, or "lastFiredForMetric" for the value any webhook of the same metric last fired for.
+	PreviousScope string `json:"previousScope" example:"lastObserved"`
+	// MaxFirings is the maximum number of times the webhook fires for a vehicle within FiringWindow. 0 disables the limit.
+	MaxFirings int `json:"maxFirings" example:"5"`
+	// GlobalMaxFirings is the maximum number of times the webhook fires across all vehicles within FiringWindow.
+	// 0 disables the limit.
+	GlobalMaxFirings int `json:"globalMaxFirings" example:"1000"`
+	// FiringWindow is the number of seconds MaxFirings and GlobalMaxFirings apply to, counted back from each firing.
+	// Required with either limit.
+	FiringWindow int `json:"firingWindow" example:"3600"`
+	// Schedule restricts the times the webhook fires at. The webhook fires at any time when omitted.
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// Params are the default values of the parameters the conditions read, e.g. params.limit. Values are numbers,
+	// strings or booleans. Vehicles can override them when they are subscribed from a list.
+	Params map[string]any `json:"params,omitempty" swaggertype:"object"`
+	// SubscriptionMode is "explicit" (default) to subscribe vehicles through the subscribe endpoints, or "allShared"
+	// to keep the webhook subscribed to every vehicle shared with the developer license that has the permissions
+	// it needs. Vehicles shared later are subscribed, and vehicles whose permissions are revoked are unsubscribed.
+	SubscriptionMode string `json:"subscriptionMode" example:"allShared"`
 	// Description is an optional human-friendly explanation of the webhook.
 	Description string `json:"description" example:"This webhook is used to notify when the speed of the vehicle exceeds 55 mph."`
 	// DisplayName is a user-friendly unique name per developer license.
@@ -38,6 +73,56 @@ type RegisterWebhookResponse struct {
 	ID string `json:"id"`
 	// Message provides a brief status message for the operation.
 	Message string `json:"message"`
+	// SigningSecret is the secret used to sign deliveries of this webhook.
+	// It is only returned once; store it securely or rotate it to obtain a new one.
+	SigningSecret string `json:"signingSecret" example:"whsec_4f1c..."`
+}
+
+// RotateWebhookSecretRequest configures how a webhook signing secret is re-issued.
+type RotateWebhookSecretRequest struct {
+	// OverlapSeconds is how long the previous secret keeps signing deliveries alongside the new one.
+	// Defaults to 24 hours when omitted; 0 revokes the previous secret immediately.
+	OverlapSeconds *int `json:"overlapSeconds" example:"86400"`
+}
+
+// RotateWebhookSecretResponse is returned after a webhook signing secret is re-issued.
+type RotateWebhookSecretResponse struct {
+	// ID is the unique identifier of the webhook.
+	ID string `json:"id"`
+	// SigningSecret is the new secret used to sign deliveries of this webhook.
+	// It is only returned once; store it securely.
+	SigningSecret string `json:"signingSecret" example:"whsec_4f1c..."`
+	// PreviousSecretExpiresAt is when the previous secret stops signing deliveries, if it is still active.
+	PreviousSecretExpiresAt *time.Time `json:"previousSecretExpiresAt,omitempty"`
+}
+
+// PauseRequest sets the time a webhook is snoozed, or a vehicle muted, until.
+type PauseRequest struct {
+	// Until is when the webhook fires again. It must be in the future and at most 90 days ahead.
+	Until time.Time `json:"until" validate:"required" example:"2025-07-01T08:00:00Z"`
+}
+
+// Schedule restricts a webhook to weekly windows of local time, except on blackout dates. Signals, events and
+// composite conditions are checked against the time they were observed at, absences against the time they are
+// detected at.
+type Schedule struct {
+	// Timezone is the IANA time zone of the windows and blackout dates.
+	Timezone string `json:"timezone" example:"America/New_York"`
+	// Windows are the weekly windows the webhook fires in. The webhook fires all week when omitted.
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+	// BlackoutDates are local dates, YYYY-MM-DD, the webhook does not fire on.
+	BlackoutDates []string `json:"blackoutDates,omitempty" example:"2025-12-25"`
+}
+
+// ScheduleWindow is a weekly window a webhook fires in.
+type ScheduleWindow struct {
+	// Days are the days the window starts on, "sun" to "sat". The window starts every day when omitted.
+	Days []string `json:"days,omitempty" example:"mon,tue,wed,thu,fri"`
+	// Start is the local time the window starts at, HH:MM.
+	Start string `json:"start" example:"20:00"`
+	// End is the local time the window ends at, HH:MM or 24:00. A window that ends before it starts ends on the
+	// next day.
+	End string `json:"end" example:"06:00"`
 }
 
 // UpdateWebhookRequest represents the fields that can be modified on an existing webhook.
@@ -47,6 +132,31 @@ type UpdateWebhookRequest struct {
 	Condition *string `json:"condition"`
 	// CoolDownPeriod updates the minimum number of seconds between firings.
 	CoolDownPeriod *int `json:"coolDownPeriod"`
+	// SustainFor updates the number of seconds a signal condition must hold continuously before firing.
+	SustainFor *int `json:"sustainFor"`
+	// FireMode updates whether the webhook fires on every matching signal ("level") or on transitions ("edge").
+	// Switching to "level" removes the clear condition.
+	FireMode *string `json:"fireMode"`
+	// ClearCondition updates the CEL expression that re-arms an edge webhook. An empty string removes it.
+	ClearCondition *string `json:"clearCondition"`
+	// AbsentFor updates the number of seconds without a signal after which an absence webhook fires.
+	AbsentFor *int `json:"absentFor"`
+	// PreviousScope updates which value conditions read as previous. An empty string resets it to "lastObserved".
+	PreviousScope *string `json:"previousScope"`
+	// MaxFirings updates the maximum number of firings per vehicle within the firing window. 0 removes the limit.
+	MaxFirings *int `json:"maxFirings"`
+	// GlobalMaxFirings updates the maximum number of firings across all vehicles within the firing window. 0 removes the limit.
+	GlobalMaxFirings *int `json:"globalMaxFirings"`
+	// FiringWindow updates the number of seconds the firing limits apply to.
+	FiringWindow *int `json:"firingWindow"`
+	// Schedule replaces the schedule of the webhook. An empty schedule, {}, removes it.
+	Schedule *Schedule `json:"schedule"`
+	// Params replaces the parameter defaults of the webhook. An empty object, {}, removes them. Overrides of
+	// vehicles for parameters that are removed or change type are ignored.
+	Params map[string]any `json:"params" swaggertype:"object"`
+	// SubscriptionMode updates how vehicles are subscribed. Switching to "explicit" keeps the vehicles that are
+	// subscribed at the time.
+	SubscriptionMode *string `json:"subscriptionMode"`
 	// TargetURL updates the HTTPS endpoint that will receive callbacks.
 	TargetURL *string `json:"targetURL"`
 	// Status updates the current state of the webhook (e.g. "enabled" or "Disabled").
@@ -77,7 +187,7 @@ type GenericResponse struct {
 type WebhookView struct {
 	// ID is the unique identifier of the webhook.
 	ID string `json:"id"`
-	// Service is the subsystem producing the metric: "signals" or "events".
+	// Service is the subsystem producing the metric: "signals", "events", "absence" or "composite".
 	Service string `json:"service"`
 	// MetricName is the fully qualified signal/metric monitored by the webhook.
 	MetricName string `json:"metricName"`
@@ -87,6 +197,34 @@ type WebhookView struct {
 	TargetURL string `json:"targetURL"`
 	// CoolDownPeriod is the minimum number of seconds between successive firings.
 	CoolDownPeriod int `json:"coolDownPeriod"`
+	// SustainFor is the number of seconds a signal condition must hold continuously before the webhook fires.
+	SustainFor int `json:"sustainFor"`
+	// FireMode is "level" to fire on every matching signal or "edge" to fire only on transitions.
+	FireMode string `json:"fireMode"`
+	// ClearCondition is the CEL expression that re-arms an edge webhook, empty if it has none.
+	ClearCondition string `json:"clearCondition,omitempty"`
+	// AbsentFor is the number of seconds without a signal after which an absence webhook fires.
+	AbsentFor int `json:"absentFor,omitempty"`
+	// PreviousScope is the value conditions read as previous: "lastObserved", "lastFiredByThisTrigger" or "lastFiredForMetric".
+	PreviousScope string `json:"previousScope,omitempty"`
+	// MaxFirings is the maximum number of firings per vehicle within FiringWindow, 0 if unlimited.
+	MaxFirings int `json:"maxFirings,omitempty"`
+	// GlobalMaxFirings is the maximum number of firings across all vehicles within FiringWindow, 0 if unlimited.
+	GlobalMaxFirings int `json:"globalMaxFirings,omitempty"`
+	// FiringWindow is the number of seconds the firing limits apply to.
+	FiringWindow int `json:"firingWindow,omitempty"`
+	// Schedule restricts the times the webhook fires at, nil if it fires at any time.
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// Params are the default values of the parameters the conditions read.
+	Params map[string]any `json:"params,omitempty" swaggertype:"object"`
+	// SubscriptionMode is "explicit" when vehicles are subscribed through the subscribe endpoints, or "allShared"
+	// when the webhook is kept subscribed to the vehicles shared with the developer license.
+	SubscriptionMode string `json:"subscriptionMode"`
+	// Reconciliation is the outcome of the last sync of an allShared webhook with the shared vehicles, omitted
+	// until the webhook is synced.
+	Reconciliation *ReconciliationView `json:"reconciliation,omitempty"`
+	// SnoozedUntil is when the webhook fires again, if it is snoozed.
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
 	// Status is the current state of the webhook (e.g. "enabled" or "Disabled").
 	Status string `json:"status"`
 	// Description is an optional human-friendly explanation of the webhook.
@@ -101,6 +239,166 @@ type WebhookView struct {
 	DisplayName string `json:"displayName"`
 }
 
+// ReconciliationView is the outcome of syncing the subscriptions of an allShared webhook with the vehicles shared
+// with the developer license.
+type ReconciliationView struct {
+	// ReconciledAt is when the subscriptions were last synced.
+	ReconciledAt time.Time `json:"reconciledAt"`
+	// Added is the number of vehicles that were subscribed by the sync.
+	Added int `json:"added"`
+	// Removed is the number of vehicles that were unsubscribed by the sync.
+	Removed int `json:"removed"`
+	// Error describes what could not be synced, e.g. vehicles whose permissions could not be checked. The sync
+	// is retried at the next interval.
+	Error string `json:"error,omitempty"`
+}
+
+// DeadLetterView is a webhook delivery that was given up on.
+type DeadLetterView struct {
+	// ID is the CloudEvent id of the delivery; a replay sends the same id.
+	ID string `json:"id"`
+	// AssetDid is the DID of the asset the delivery is about.
+	AssetDid string `json:"assetDid"`
+	// Payload is the CloudEvent that was sent to the webhook endpoint.
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	// Attempts is the number of delivery attempts made before giving up.
+	Attempts int `json:"attempts"`
+	// LastError is the error of the last attempt.
+	LastError string `json:"lastError,omitempty"`
+	// CreatedAt is when the delivery was given up on.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ReplayDeadLettersRequest selects the dead letters to send again.
+type ReplayDeadLettersRequest struct {
+	// IDs are the dead letters to replay. All dead letters of the webhook are replayed when omitted.
+	IDs []string `json:"ids"`
+}
+
+// ReplayDeadLettersResponse is returned after dead letters are queued for delivery.
+type ReplayDeadLettersResponse struct {
+	// Replayed is the number of dead letters queued for delivery.
+	Replayed int64 `json:"replayed"`
+	// Message provides a brief status message for the operation.
+	Message string `json:"message"`
+}
+
+// DeliveryView is a single attempt to deliver a webhook.
+type DeliveryView struct {
+	// ID identifies the attempt.
+	ID string `json:"id"`
+	// DeliveryID is the CloudEvent id of the delivery; all attempts of a delivery share it.
+	DeliveryID string `json:"deliveryId"`
+	// AssetDid is the DID of the asset the delivery is about.
+	AssetDid string `json:"assetDid"`
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt int `json:"attempt"`
+	// StatusCode is the HTTP status returned by the endpoint. It is omitted when no response was received.
+	StatusCode *int `json:"statusCode,omitempty"`
+	// LatencyMs is how long the endpoint took to respond, in milliseconds.
+	LatencyMs int `json:"latencyMs"`
+	// Error describes why the attempt failed, including the start of the response body.
+	Error string `json:"error,omitempty"`
+	// CreatedAt is when the attempt was made.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ListDeliveriesResponse is a page of delivery attempts.
+type ListDeliveriesResponse struct {
+	// Deliveries are the attempts on this page, newest first.
+	Deliveries []DeliveryView `json:"deliveries"`
+	// NextCursor fetches the next page. It is omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// TestWebhookResponse reports how the endpoint answered a test delivery.
+type TestWebhookResponse struct {
+	// Success is true when the endpoint answered with a 2xx or 3xx status.
+	Success bool `json:"success"`
+	// StatusCode is the HTTP status returned by the endpoint. It is omitted when no response was received.
+	StatusCode *int `json:"statusCode,omitempty"`
+	// LatencyMs is how long the endpoint took to respond, in milliseconds.
+	LatencyMs int64 `json:"latencyMs"`
+	// Error describes why the delivery failed, including the start of the response body.
+	Error string `json:"error,omitempty"`
+	// Payload is the CloudEvent that was sent to the webhook endpoint.
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+// EvaluateConditionRequest is a condition to dry-run against samples.
+type EvaluateConditionRequest struct {
+	// Service is the service the condition is written for, "signals", "events", "absence" or "composite".
+	Service string `json:"service"`
+	// MetricName is the signal or event name; for signals it selects the value type of the value variables.
+	MetricName string `json:"metricName"`
+	// Condition is the CEL expression to evaluate.
+	Condition string `json:"condition"`
+	// Params are the parameter values the condition is evaluated with, e.g. params.limit.
+	Params map[string]any `json:"params,omitempty" swaggertype:"object"`
+	// Samples are evaluated one by one, in order.
+	Samples []ConditionSample `json:"samples"`
+}
+
+// ConditionSample is a signal or event together with the one received before it.
+type ConditionSample struct {
+	// Current is the signal or event the condition is evaluated for.
+	Current ConditionSampleValue `json:"current"`
+	// Previous is the signal or event received before Current. The previous variables are zero when omitted.
+	Previous *ConditionSampleValue `json:"previous,omitempty"`
+}
+
+// ConditionSampleValue is a single signal or event. Signals use the source and value fields,
+// events the source, name, durationNs, metadata and signals fields, absences the offline and silentSeconds fields,
+// and composites the signals field.
+type ConditionSampleValue struct {
+	// Source is the oracle the signal or event came from.
+	Source string `json:"source,omitempty"`
+	// Timestamp is when the signal or event was observed, read by hourOfDay and dayOfWeek.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// ValueNumber is the value of a numeric signal.
+	ValueNumber float64 `json:"valueNumber,omitempty"`
+	// ValueString is the value of a string signal.
+	ValueString string `json:"valueString,omitempty"`
+	// ValueLocation is the value of a location signal.
+	ValueLocation *vss.Location `json:"valueLocation,omitempty"`
+	// Name is the name of the event.
+	Name string `json:"name,omitempty"`
+	// DurationNs is the duration of the event in nanoseconds.
+	DurationNs uint64 `json:"durationNs,omitempty"`
+	// Metadata is the metadata of the event.
+	Metadata string `json:"metadata,omitempty"`
+	// Offline is true when the vehicle went silent and false when it came back online.
+	Offline bool `json:"offline,omitempty"`
+	// SilentSeconds is how long the vehicle was silent.
+	SilentSeconds float64 `json:"silentSeconds,omitempty"`
+	// Signals maps signal names to their latest value: a number, a string or a location object.
+	Signals map[string]json.RawMessage `json:"signals,omitempty" swaggertype:"object"`
+}
+
+// EvaluateConditionResponse holds the outcome of a dry run.
+type EvaluateConditionResponse struct {
+	// Results holds one result per sample, in the order of the samples.
+	Results []ConditionSampleResult `json:"results"`
+}
+
+// ConditionSampleResult is the outcome of evaluating a condition for one sample.
+type ConditionSampleResult struct {
+	// Fired is true when the condition evaluated to true, i.e. the webhook would have fired.
+	Fired bool `json:"fired"`
+	// Bindings are the variables the condition was evaluated with.
+	Bindings map[string]any `json:"bindings"`
+	// Error is the error raised while evaluating the condition, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// DeliveryResult describes how the endpoint answered a webhook delivery.
+type DeliveryResult struct {
+	// StatusCode is the HTTP status code returned by the endpoint, zero if no response was received.
+	StatusCode int
+	// Latency is how long the endpoint took to answer.
+	Latency time.Duration
+}
+
 // WebhookPayload represents the standardized payload sent to webhook endpoints.
 // This structure follows industry best practices and includes only essential information
 // while providing proper context and metadata for the triggered event.
@@ -128,6 +426,12 @@ type WebhookPayload struct {
 
 	// Event contains the event data that triggered the webhook
 	Event *EventData `json:"event,omitempty"`
+
+	// Absence contains the silence of the vehicle that triggered an absence webhook
+	Absence *AbsenceData `json:"absence,omitempty"`
+
+	// Signals contains the latest values of the signals read by a composite or event webhook, ordered by name
+	Signals []*SignalData `json:"signals,omitempty"`
 }
 
 // SignalData contains the signal information that triggered the webhook
@@ -164,6 +468,16 @@ type EventData struct {
 	Metadata string `json:"metadata,omitempty"`
 }
 
+// AbsenceData describes a vehicle that went silent or came back online
+type AbsenceData struct {
+	// Offline is true when the vehicle went silent and false when it sent a signal again
+	Offline bool `json:"offline"`
+	// LastSeenAt is the timestamp of the last signal before the vehicle went silent
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	// SilentSeconds is how long the vehicle has been silent, or was silent before coming back online
+	SilentSeconds float64 `json:"silentSeconds"`
+}
+
 // SubscriptionView describes a vehicle's subscription to a webhook.
 type SubscriptionView struct {
 	// webhookID is the identifier of the webhook trigger.
@@ -174,6 +488,12 @@ type SubscriptionView struct {
 	CreatedAt time.Time `json:"createdAt"`
 	// Description is the optional description from the webhook trigger.
 	Description string `json:"description"`
+	// MutedUntil is when the webhook fires for the vehicle again, if the subscription is muted.
+	MutedUntil *time.Time `json:"mutedUntil,omitempty"`
+	// Params are the parameter values the vehicle overrides the defaults of the webhook with.
+	Params map[string]any `json:"params,omitempty" swaggertype:"object"`
+	// SnoozedUntil is when the webhook fires again, if it is snoozed.
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
 }
 
 // FailedSubscription is a single failed subscription.
@@ -193,4 +513,38 @@ type FailedSubscriptionResponse struct {
 type VehicleListRequest struct {
 	// AssetDIDs is the list of asset DIDs to subscribe to the webhook.
 	AssetDIDs []cloudevent.ERC721DID `json:"assetDIDs"`
+	// Params overrides the parameter defaults of the webhook per vehicle, keyed by asset DID. Vehicles without
+	// overrides use the defaults. Only used when subscribing.
+	Params map[string]map[string]any `json:"params,omitempty" swaggertype:"object"`
+}
+
+// CreateGeofenceRequest is the payload to create a named geofence.
+type CreateGeofenceRequest struct {
+	// Name identifies the geofence in conditions, e.g. inGeofence("depot"). It must be unique per developer license.
+	Name string `json:"name" validate:"required" example:"depot"`
+	// Geometry is a GeoJSON Polygon, or a Feature with a Polygon geometry. Positions are [longitude, latitude].
+	Geometry json.RawMessage `json:"geometry" validate:"required" swaggertype:"object"`
+}
+
+// UpdateGeofenceRequest represents the fields that can be modified on an existing geofence.
+// All fields are optional; only provided fields will be updated.
+type UpdateGeofenceRequest struct {
+	// Name updates the name of the geofence. A geofence used by a webhook condition can not be renamed.
+	Name *string `json:"name" example:"depot"`
+	// Geometry updates the GeoJSON polygon of the geofence.
+	Geometry json.RawMessage `json:"geometry" swaggertype:"object"`
+}
+
+// GeofenceView is a named geofence.
+type GeofenceView struct {
+	// ID is the unique identifier of the geofence.
+	ID string `json:"id"`
+	// Name identifies the geofence in conditions.
+	Name string `json:"name" example:"depot"`
+	// Geometry is the GeoJSON polygon of the geofence.
+	Geometry json.RawMessage `json:"geometry" swaggertype:"object"`
+	// CreatedAt is when the geofence was created.
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is when the geofence was last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
 }
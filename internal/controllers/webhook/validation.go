@@ -3,18 +3,27 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/schedule"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/cel-go/cel"
 )
 
 // verifyWebhookURL verifies that the target URL is valid and returns the verification token.
@@ -88,32 +97,83 @@ func validateTargetURL(targetURL string) error {
 	return nil
 }
 
-func validateServiceAndMetricNameAndCondition(serviceName string, metricName string, condition string) error {
+func validateServiceAndMetricNameAndCondition(serviceName string, metricName string, condition string, geofences celcondition.Geofences, params celcondition.Params) error {
+	_, _, err := prepareCondition(serviceName, metricName, condition, geofences, params)
+	return err
+}
+
+// prepareCondition compiles condition for the service and metric name, and returns the program
+// together with the value type of the metric. The value type is only set for signals. params are the default
+// values of the parameters the condition may read.
+func prepareCondition(serviceName string, metricName string, condition string, geofences celcondition.Geofences, params celcondition.Params) (cel.Program, string, error) {
+	var valueType string
 	switch {
 	case triggersrepo.IsSignalService(serviceName):
-		valueType := signals.GetSignalDefinitionOrDefault(signals.BareSignalName(metricName), signals.NumberType).ValueType
-		return validateCondition(serviceName, condition, valueType)
+		valueType = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(metricName), signals.NumberType).ValueType
 	case triggersrepo.IsEventService(serviceName):
-		return validateCondition(serviceName, condition, "")
+	case triggersrepo.IsAbsenceService(serviceName):
+		if metricName != triggersrepo.AnySignal && !strings.HasPrefix(metricName, signals.VSSPrefix) {
+			return nil, "", richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Absence webhooks must watch a signal (e.g. %q) or all signals (%q)", signals.VSSPrefix+"speed", triggersrepo.AnySignal),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+	case triggersrepo.IsCompositeService(serviceName):
+		if metricName != triggersrepo.AnySignal {
+			return nil, "", richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Composite webhooks must use metric name %q, they watch the signals read by their condition", triggersrepo.AnySignal),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
 	default:
-		return richerrors.Error{
+		return nil, "", richerrors.Error{
 			ExternalMsg: fmt.Sprintf("Invalid service: %s", serviceName),
 			Code:        fiber.StatusBadRequest,
 		}
 	}
-}
-
-func validateCondition(serviceName, condition, valueType string) error {
-	_, err := celcondition.PrepareCondition(serviceName, condition, valueType)
+	prg, err := celcondition.PrepareCondition(serviceName, condition, valueType, geofences, params)
 	if err != nil {
 		err := fmt.Errorf("invalid CEL condition: %w", err)
-		return richerrors.Error{
+		return nil, "", richerrors.Error{
 			ExternalMsg: err.Error(),
 			Err:         err,
 			Code:        fiber.StatusBadRequest,
 		}
 	}
-	return nil
+	return prg, valueType, nil
+}
+
+// geofenceLoader loads the geofences of a developer license.
+type geofenceLoader interface {
+	GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error)
+}
+
+// loadConditionGeofences returns the geofences of the developer license if any of the conditions refer to one.
+// Conditions that fail to parse are left for prepareCondition to report.
+func loadConditionGeofences(ctx context.Context, repo geofenceLoader, developerLicenseAddress common.Address, service string, conditions ...string) (celcondition.Geofences, error) {
+	referenced := false
+	for _, condition := range conditions {
+		if names, err := celcondition.ConditionGeofences(service, condition); err == nil && len(names) > 0 {
+			referenced = true
+			break
+		}
+	}
+	if !referenced {
+		return nil, nil
+	}
+	stored, err := repo.GetGeofencesByDeveloperLicense(ctx, developerLicenseAddress)
+	if err != nil {
+		return nil, err
+	}
+	geofences, err := celcondition.ParseGeofences(stored)
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error loading geofences",
+			Err:         err,
+			Code:        fiber.StatusInternalServerError,
+		}
+	}
+	return geofences, nil
 }
 
 func validateCoolDownPeriod(coolDownPeriod int) error {
@@ -126,6 +186,314 @@ func validateCoolDownPeriod(coolDownPeriod int) error {
 	return nil
 }
 
+// maxSustainFor bounds how long a condition may be required to hold before a webhook fires.
+const maxSustainFor = 24 * 60 * 60
+
+// validateSustainFor validates the sustain period of a webhook. Only signal webhooks can be sustained,
+// since events are instantaneous.
+func validateSustainFor(service string, sustainFor int) error {
+	if sustainFor < 0 || sustainFor > maxSustainFor {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Sustain period must be between 0 and %d seconds", maxSustainFor),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if sustainFor > 0 && !triggersrepo.IsSignalService(service) {
+		return richerrors.Error{
+			ExternalMsg: "Sustain period is only supported for signal webhooks",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+const (
+	// minAbsentFor keeps absence webhooks from firing on the normal gaps between signals.
+	minAbsentFor = 60
+	// maxAbsentFor bounds how long a vehicle may be silent before an absence webhook fires.
+	maxAbsentFor = 30 * 24 * 60 * 60
+)
+
+// validateAbsentFor validates how many seconds a vehicle must be silent before an absence webhook fires.
+// It is required for absence webhooks and not supported for other services.
+func validateAbsentFor(service string, absentFor int) error {
+	if !triggersrepo.IsAbsenceService(service) {
+		if absentFor != 0 {
+			return richerrors.Error{
+				ExternalMsg: "Absent for is only supported for absence webhooks",
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		return nil
+	}
+	if absentFor < minAbsentFor || absentFor > maxAbsentFor {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Absent for must be between %d and %d seconds", minAbsentFor, maxAbsentFor),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+const (
+	// maxMaxFirings bounds the firings per vehicle, whose times are kept in the state of the vehicle.
+	maxMaxFirings = 1000
+	// maxGlobalMaxFirings bounds the firings across all vehicles, which are counted on every firing.
+	maxGlobalMaxFirings = 10000
+	// maxFiringWindow bounds how far back firings are counted.
+	maxFiringWindow = 30 * 24 * 60 * 60
+)
+
+// validateRateLimit validates the limits on the number of firings of a webhook within its firing window. Absence
+// webhooks fire once per silence of a vehicle and are not rate limited.
+func validateRateLimit(service string, maxFirings, globalMaxFirings, firingWindow int) error {
+	if maxFirings < 0 || maxFirings > maxMaxFirings {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Max firings must be between 0 and %d", maxMaxFirings),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if globalMaxFirings < 0 || globalMaxFirings > maxGlobalMaxFirings {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Global max firings must be between 0 and %d", maxGlobalMaxFirings),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if firingWindow < 0 || firingWindow > maxFiringWindow {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Firing window must be between 0 and %d seconds", maxFiringWindow),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if maxFirings == 0 && globalMaxFirings == 0 {
+		return nil
+	}
+	if firingWindow == 0 {
+		return richerrors.Error{
+			ExternalMsg: "Firing window is required with max firings or global max firings",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if triggersrepo.IsAbsenceService(service) {
+		return richerrors.Error{
+			ExternalMsg: "Max firings are not supported for absence webhooks",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// validateSchedule validates the schedule of a webhook and returns it as stored, or nil if s is nil or empty.
+func validateSchedule(s *Schedule) (json.RawMessage, error) {
+	if s == nil || (s.Timezone == "" && len(s.Windows) == 0 && len(s.BlackoutDates) == 0) {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	if _, err := schedule.Parse(data); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Invalid schedule: " + err.Error(),
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return data, nil
+}
+
+// validatePreviousScope validates which value the conditions of a webhook read as previous. Only signal and event
+// conditions read a previous value.
+func validatePreviousScope(service, previousScope string) error {
+	if previousScope == "" || previousScope == triggersrepo.PreviousScopeLastObserved {
+		return nil
+	}
+	if !triggersrepo.IsPreviousScope(previousScope) {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Previous scope must be %q, %q or %q", triggersrepo.PreviousScopeLastObserved,
+				triggersrepo.PreviousScopeLastFiredByThisTrigger, triggersrepo.PreviousScopeLastFiredForMetric),
+			Code: fiber.StatusBadRequest,
+		}
+	}
+	if !triggersrepo.IsSignalService(service) && !triggersrepo.IsEventService(service) {
+		return richerrors.Error{
+			ExternalMsg: "Previous scope is only supported for signal and event webhooks",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// validateFireMode validates the fire mode of a webhook together with its optional clear condition.
+// Edge firing tracks the condition per vehicle across signals, so it is only supported for signal webhooks.
+func validateFireMode(service, metricName, fireMode, clearCondition string, geofences celcondition.Geofences, params celcondition.Params) error {
+	switch fireMode {
+	case "", triggersrepo.FireModeLevel:
+		if clearCondition != "" {
+			return richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Clear condition requires fire mode %q", triggersrepo.FireModeEdge),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		return nil
+	case triggersrepo.FireModeEdge:
+		if !triggersrepo.IsSignalService(service) {
+			return richerrors.Error{
+				ExternalMsg: "Edge fire mode is only supported for signal webhooks",
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		if clearCondition == "" {
+			return nil
+		}
+		valueType := signals.GetSignalDefinitionOrDefault(signals.BareSignalName(metricName), signals.NumberType).ValueType
+		if _, err := celcondition.PrepareCondition(service, clearCondition, valueType, geofences, params); err != nil {
+			err := fmt.Errorf("invalid CEL clear condition: %w", err)
+			return richerrors.Error{
+				ExternalMsg: err.Error(),
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		return nil
+	default:
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Fire mode must be %q or %q", triggersrepo.FireModeLevel, triggersrepo.FireModeEdge),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+}
+
+// validateParams validates the parameter defaults of a webhook and returns them parsed and as stored, or nil if
+// params is empty.
+func validateParams(params map[string]any) (celcondition.Params, json.RawMessage, error) {
+	if len(params) == 0 {
+		return nil, nil, nil
+	}
+	parsed := celcondition.Params(params)
+	if err := parsed.Validate(); err != nil {
+		return nil, nil, richerrors.Error{
+			ExternalMsg: "Invalid params: " + err.Error(),
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	data, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+	return parsed, data, nil
+}
+
+// validateSubscriptionParams validates the parameter overrides of the vehicles subscribed from a list against the
+// defaults of the trigger, and returns them as stored keyed by asset DID. Overrides may only be given for vehicles
+// of the list, and must name parameters of the trigger with values of the same type.
+func validateSubscriptionParams(trigger *models.Trigger, assetDIDs []cloudevent.ERC721DID, overrides map[string]map[string]any) (map[string]null.JSON, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	defaults, err := celcondition.ParseParams(trigger.Params.JSON)
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Failed to load webhook params",
+			Err:         err,
+			Code:        fiber.StatusInternalServerError,
+		}
+	}
+	listed := make(map[string]bool, len(assetDIDs))
+	for _, assetDid := range assetDIDs {
+		listed[assetDid.String()] = true
+	}
+	stored := make(map[string]null.JSON, len(overrides))
+	for key, values := range overrides {
+		assetDid, err := cloudevent.DecodeERC721DID(key)
+		if err != nil || !listed[assetDid.String()] {
+			return nil, richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Params are given for %s, which is not in assetDIDs", key),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		params := celcondition.Params(values)
+		err = params.Validate()
+		if err == nil {
+			_, err = defaults.Override(params)
+		}
+		if err != nil {
+			return nil, richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Invalid params for %s: %s", key, err),
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params: %w", err)
+		}
+		stored[assetDid.String()] = null.JSONFrom(data)
+	}
+	return stored, nil
+}
+
+// paramsView returns the stored parameter values of a webhook or subscription, or nil if there are none.
+func paramsView(data null.JSON) map[string]any {
+	params, err := celcondition.ParseParams(data.JSON)
+	if err != nil || len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+const (
+	// defaultSecretOverlap is how long a rotated signing secret keeps signing deliveries when no overlap is requested.
+	defaultSecretOverlap = 24 * time.Hour
+	// maxSecretOverlap bounds how long a rotated signing secret may stay valid.
+	maxSecretOverlap = 7 * 24 * time.Hour
+)
+
+// validateSubscriptionMode validates how the vehicles of a webhook are subscribed.
+func validateSubscriptionMode(mode string) error {
+	if mode == "" || triggersrepo.IsSubscriptionMode(mode) {
+		return nil
+	}
+	return richerrors.Error{
+		ExternalMsg: fmt.Sprintf("Subscription mode must be %q or %q", triggersrepo.SubscriptionModeExplicit, triggersrepo.SubscriptionModeAllShared),
+		Code:        fiber.StatusBadRequest,
+	}
+}
+
+// validateSecretOverlap validates the requested signing secret overlap and converts it to a duration.
+// A nil overlap falls back to the default overlap.
+func validateSecretOverlap(overlapSeconds *int) (time.Duration, error) {
+	if overlapSeconds == nil {
+		return defaultSecretOverlap, nil
+	}
+	overlap := time.Duration(*overlapSeconds) * time.Second
+	if *overlapSeconds < 0 || overlap > maxSecretOverlap {
+		return 0, richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Overlap must be between 0 and %d seconds", int(maxSecretOverlap.Seconds())),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return overlap, nil
+}
+
+// maxPause bounds how far ahead a webhook can be snoozed or a vehicle muted.
+const maxPause = 90 * 24 * time.Hour
+
+// validatePauseUntil validates the time a snooze or mute ends at. It must be in the future, within maxPause.
+func validatePauseUntil(until, now time.Time) error {
+	if !until.After(now) || until.After(now.Add(maxPause)) {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("Until must be in the future and at most %d days ahead", int(maxPause.Hours()/24)),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
 // validateStatus validates the status of the webhook.
 // It must be either "enabled" or "disabled".
 func validateStatus(status string) error {
@@ -137,3 +505,85 @@ func validateStatus(status string) error {
 	}
 	return nil
 }
+
+const (
+	// defaultDeliveryLimit is the page size of the delivery log when no limit is requested.
+	defaultDeliveryLimit = 100
+	// maxDeliveryLimit bounds the page size of the delivery log.
+	maxDeliveryLimit = 500
+)
+
+// getDeliveryFilter validates the query parameters of the delivery log and converts them to a filter.
+func getDeliveryFilter(c *fiber.Ctx) (triggersrepo.DeliveryFilter, error) {
+	filter := triggersrepo.DeliveryFilter{Limit: defaultDeliveryLimit}
+	if assetDidStr := c.Query("assetDid"); assetDidStr != "" {
+		assetDid, err := cloudevent.DecodeERC721DID(assetDidStr)
+		if err != nil {
+			return filter, richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Invalid asset DID format: %s", err),
+				Err:         fmt.Errorf("invalid asset DID: %w", err),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		filter.AssetDid = assetDid.String()
+	}
+	for name, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Invalid %s, must be an RFC 3339 timestamp", name),
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		*dst = parsed
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxDeliveryLimit {
+			return filter, richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Invalid limit, must be between 1 and %d", maxDeliveryLimit),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		filter.Limit = limit
+	}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := decodeDeliveryCursor(cursorStr)
+		if err != nil {
+			return filter, richerrors.Error{
+				ExternalMsg: "Invalid cursor",
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		filter.Cursor = &cursor
+	}
+	return filter, nil
+}
+
+// encodeDeliveryCursor turns the position of a delivery attempt into an opaque page cursor.
+func encodeDeliveryCursor(cursor triggersrepo.DeliveryCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID))
+}
+
+// decodeDeliveryCursor reverses encodeDeliveryCursor.
+func decodeDeliveryCursor(cursor string) (triggersrepo.DeliveryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return triggersrepo.DeliveryCursor{}, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+	createdAtStr, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return triggersrepo.DeliveryCursor{}, fmt.Errorf("malformed cursor %q", raw)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return triggersrepo.DeliveryCursor{}, fmt.Errorf("malformed cursor time: %w", err)
+	}
+	return triggersrepo.DeliveryCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -7,20 +7,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/auth"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
-	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-var defaultPermissions = signals.DefaultPermissions
-
 type IdentityClient interface {
 	GetSharedVehicles(ctx context.Context, developerLicense []byte) ([]cloudevent.ERC721DID, error)
 }
@@ -78,10 +78,10 @@ func (v *VehicleSubscriptionController) AssignVehicleToWebhook(c *fiber.Ctx) err
 	if err != nil {
 		return err
 	}
-	permissions := defaultPermissions
-	if triggersrepo.IsSignalService(trigger.Service) {
-		permissions = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).Permissions
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
 	}
+	permissions := celcondition.TriggerPermissions(trigger)
 
 	hasPerm, err := v.tokenExchangeClient.HasVehiclePermissions(c.Context(), assetDid, dl, permissions)
 	if err != nil {
@@ -98,22 +98,23 @@ func (v *VehicleSubscriptionController) AssignVehicleToWebhook(c *fiber.Ctx) err
 		}
 	}
 
-	_, err = v.repo.CreateVehicleSubscription(c.Context(), assetDid, webhookID)
+	sub, err := v.repo.CreateVehicleSubscription(c.Context(), assetDid, webhookID, null.JSON{})
 	if err != nil {
 		return fmt.Errorf("failed to assign vehicle: %w", err)
 	}
 
-	v.cache.ScheduleRefresh(c.Context())
+	v.cache.AddSubscription(c.Context(), sub)
 	return c.Status(http.StatusCreated).JSON(GenericResponse{Message: "Vehicle assigned successfully"})
 }
 
 // SubscribeVehiclesFromList godoc
 // @Summary      Assign multiple vehicles to a webhook from a list
-// @Description  Subscribes each assetDID to the webhook.
+// @Description  Subscribes each assetDID to the webhook. params optionally overrides the parameter defaults of the webhook per vehicle, keyed by asset DID; overrides must name parameters of the webhook with values of the same type.
 // @Tags         Webhooks
 // @Accept       json
 // @Produce      json
 // @Param        webhookId  path  string  true  "Webhook ID"
+// @Param        request    body  VehicleListRequest  true  "Vehicles and their parameter overrides"
 // @Success      201        {object}  GenericResponse  "Count of subscribed vehicles"
 // @Failure      400        {object}  map[string]string  "Bad request"
 // @Failure      401        {object}  map[string]string  "Unauthorized"
@@ -143,12 +144,16 @@ func (v *VehicleSubscriptionController) SubscribeVehiclesFromList(c *fiber.Ctx)
 	if err != nil {
 		return err
 	}
-	permissions := defaultPermissions
-	if triggersrepo.IsSignalService(trigger.Service) {
-		permissions = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).Permissions
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
+	}
+	params, err := validateSubscriptionParams(trigger, req.AssetDIDs, req.Params)
+	if err != nil {
+		return err
 	}
+	permissions := celcondition.TriggerPermissions(trigger)
 
-	return v.subscribeMultipleVehiclesToWebhook(c, webhookID, dl, req.AssetDIDs, permissions)
+	return v.subscribeMultipleVehiclesToWebhook(c, webhookID, dl, req.AssetDIDs, params, permissions)
 }
 
 // UnsubscribeVehiclesFromList godoc
@@ -173,10 +178,13 @@ func (v *VehicleSubscriptionController) UnsubscribeVehiclesFromList(c *fiber.Ctx
 	if err != nil {
 		return err
 	}
-	_, err = ownerCheck(c.Context(), v.repo, webhookID, dl)
+	trigger, err := ownerCheck(c.Context(), v.repo, webhookID, dl)
 	if err != nil {
 		return err
 	}
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
+	}
 
 	var req VehicleListRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -200,13 +208,14 @@ func (v *VehicleSubscriptionController) UnsubscribeVehiclesFromList(c *fiber.Ctx
 				AssetDid: assetDid,
 				Message:  errMsg,
 			})
+			continue
 		}
+		v.cache.RemoveSubscription(webhookID, assetDid.String())
 	}
 
 	if len(failedUnSubscriptions) > 0 {
 		return c.JSON(FailedSubscriptionResponse{FailedSubscriptions: failedUnSubscriptions})
 	}
-	v.cache.ScheduleRefresh(c.Context())
 	return c.JSON(GenericResponse{Message: fmt.Sprintf("Unsubscribed %d assets", len(req.AssetDIDs)-len(failedUnSubscriptions))})
 
 }
@@ -237,22 +246,113 @@ func (v *VehicleSubscriptionController) RemoveVehicleFromWebhook(c *fiber.Ctx) e
 	if err != nil {
 		return err
 	}
-	_, err = ownerCheck(c.Context(), v.repo, webhookID, dl)
+	trigger, err := ownerCheck(c.Context(), v.repo, webhookID, dl)
 	if err != nil {
 		return err
 	}
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
+	}
 
 	_, err = v.repo.DeleteVehicleSubscription(c.Context(), webhookID, assetDid)
 	if err != nil {
 		return richerrors.Error{ExternalMsg: "Failed to unsubscribe", Err: err, Code: http.StatusInternalServerError}
 	}
-	v.cache.ScheduleRefresh(c.Context())
+	v.cache.RemoveSubscription(webhookID, assetDid.String())
 	return c.JSON(GenericResponse{Message: "Vehicle unsubscribed successfully"})
 }
 
+// MuteVehicle godoc
+// @Summary      Mute a vehicle of a webhook
+// @Description  Stops a webhook from firing for a single subscribed vehicle until the given time, without unsubscribing it. The mute expires on its own; the webhook fires for the vehicle again afterwards without another call.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhookId  path      string        true  "Webhook ID"
+// @Param        assetDID   path      string        true  "Asset DID"
+// @Param        request    body      PauseRequest  true  "Mute end"
+// @Success      200        {object}  GenericResponse  "Vehicle muted"
+// @Failure      400        {object}  map[string]string  "Bad request"
+// @Failure      401        {object}  map[string]string  "Unauthorized"
+// @Failure      404        {object}  map[string]string  "Vehicle not subscribed"
+// @Failure      500        {object}  map[string]string  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/mute/{assetDID} [post]
+func (v *VehicleSubscriptionController) MuteVehicle(c *fiber.Ctx) error {
+	var req PauseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Invalid request body",
+			Err:         err,
+			Code:        http.StatusBadRequest,
+		}
+	}
+	if err := validatePauseUntil(req.Until, time.Now()); err != nil {
+		return err
+	}
+	if err := v.muteVehicle(c, null.TimeFrom(req.Until.UTC())); err != nil {
+		return err
+	}
+	return c.JSON(GenericResponse{Message: "Vehicle muted until " + req.Until.UTC().Format(time.RFC3339)})
+}
+
+// UnmuteVehicle godoc
+// @Summary      Unmute a vehicle of a webhook
+// @Description  Ends the mute of a subscribed vehicle so that the webhook fires for it again right away.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhookId  path      string  true  "Webhook ID"
+// @Param        assetDID   path      string  true  "Asset DID"
+// @Success      200        {object}  GenericResponse  "Vehicle unmuted"
+// @Failure      400        {object}  map[string]string  "Bad request"
+// @Failure      401        {object}  map[string]string  "Unauthorized"
+// @Failure      404        {object}  map[string]string  "Vehicle not subscribed"
+// @Failure      500        {object}  map[string]string  "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/mute/{assetDID} [delete]
+func (v *VehicleSubscriptionController) UnmuteVehicle(c *fiber.Ctx) error {
+	if err := v.muteVehicle(c, null.Time{}); err != nil {
+		return err
+	}
+	return c.JSON(GenericResponse{Message: "Vehicle unmuted successfully"})
+}
+
+// muteVehicle sets the time the subscription of the vehicle in the path is muted until.
+func (v *VehicleSubscriptionController) muteVehicle(c *fiber.Ctx, until null.Time) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	assetDid, err := getAssetDID(c)
+	if err != nil {
+		return err
+	}
+	dl, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	_, err = ownerCheck(c.Context(), v.repo, webhookID, dl)
+	if err != nil {
+		return err
+	}
+
+	count, err := v.repo.MuteVehicleSubscription(c.Context(), webhookID, assetDid, until)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return richerrors.Error{
+			ExternalMsg: "Vehicle is not subscribed to the webhook",
+			Code:        http.StatusNotFound,
+		}
+	}
+	v.cache.MuteSubscription(c.Context(), webhookID, assetDid.String(), until)
+	return nil
+}
+
 // SubscribeAllVehiclesToWebhook godoc
 // @Summary      Subscribe all shared vehicles
-// @Description  Subscribes every vehicle shared with this developer to the webhook.
+// @Description  Subscribes every vehicle shared with this developer to the webhook. Vehicles shared later are not subscribed, use the allShared subscription mode to keep them in sync.
 // @Tags         Webhooks
 // @Produce      json
 // @Param        webhookId  path  string  true  "Webhook ID"
@@ -275,17 +375,17 @@ func (v *VehicleSubscriptionController) SubscribeAllVehiclesToWebhook(c *fiber.C
 	if err != nil {
 		return err
 	}
-	permissions := defaultPermissions
-	if triggersrepo.IsSignalService(trigger.Service) {
-		permissions = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).Permissions
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
 	}
+	permissions := celcondition.TriggerPermissions(trigger)
 
 	vehicles, err := v.identityClient.GetSharedVehicles(c.Context(), dl.Bytes())
 	if err != nil {
 		return fmt.Errorf("failed to fetch shared vehicles: %w", err)
 	}
 
-	return v.subscribeMultipleVehiclesToWebhook(c, webhookID, dl, vehicles, permissions)
+	return v.subscribeMultipleVehiclesToWebhook(c, webhookID, dl, vehicles, nil, permissions)
 }
 
 // UnsubscribeAllVehiclesFromWebhook godoc
@@ -309,16 +409,19 @@ func (v *VehicleSubscriptionController) UnsubscribeAllVehiclesFromWebhook(c *fib
 	if err != nil {
 		return err
 	}
-	_, err = ownerCheck(c.Context(), v.repo, webhookID, dl)
+	trigger, err := ownerCheck(c.Context(), v.repo, webhookID, dl)
 	if err != nil {
 		return err
 	}
+	if err := requireExplicitSubscriptions(trigger); err != nil {
+		return err
+	}
 
 	res, err := v.repo.DeleteAllVehicleSubscriptionsForTrigger(c.Context(), webhookID)
 	if err != nil {
 		return fmt.Errorf("failed to unsubscribe all vehicles: %w", err)
 	}
-	v.cache.ScheduleRefresh(c.Context())
+	v.cache.RemoveTrigger(webhookID)
 	return c.JSON(GenericResponse{Message: fmt.Sprintf("Unsubscribed %d vehicles", res)})
 }
 
@@ -348,18 +451,24 @@ func (v *VehicleSubscriptionController) ListSubscriptions(c *fiber.Ctx) error {
 		return fmt.Errorf("failed to fetch subscriptions: %w", err)
 	}
 
+	now := time.Now()
 	out := make([]SubscriptionView, 0, len(subs))
 	for _, s := range subs {
 		desc := ""
+		var snoozedUntil *time.Time
 		if s.R != nil && s.R.Trigger != nil {
 			desc = s.R.Trigger.Description.String
+			snoozedUntil = pausedUntil(s.R.Trigger.SnoozedUntil, now)
 		}
 		did, _ := cloudevent.DecodeERC721DID(s.AssetDid)
 		out = append(out, SubscriptionView{
-			WebhookID:   s.TriggerID,
-			AssetDid:    did,
-			CreatedAt:   s.CreatedAt,
-			Description: desc,
+			WebhookID:    s.TriggerID,
+			AssetDid:     did,
+			CreatedAt:    s.CreatedAt,
+			Description:  desc,
+			MutedUntil:   pausedUntil(s.MutedUntil, now),
+			Params:       paramsView(s.Params),
+			SnoozedUntil: snoozedUntil,
 		})
 	}
 	return c.JSON(out)
@@ -403,7 +512,9 @@ func (v *VehicleSubscriptionController) ListVehiclesForWebhook(c *fiber.Ctx) err
 	return c.JSON(assetDIDs)
 }
 
-func (v *VehicleSubscriptionController) subscribeMultipleVehiclesToWebhook(c *fiber.Ctx, webhookID string, developerLicense common.Address, assetDIDs []cloudevent.ERC721DID, permissions []string) error {
+// subscribeMultipleVehiclesToWebhook subscribes the vehicles to the webhook once they all have the permissions.
+// params holds the stored parameter overrides of the vehicles keyed by asset DID.
+func (v *VehicleSubscriptionController) subscribeMultipleVehiclesToWebhook(c *fiber.Ctx, webhookID string, developerLicense common.Address, assetDIDs []cloudevent.ERC721DID, params map[string]null.JSON, permissions []string) error {
 	for _, assetDid := range assetDIDs {
 		hasPerm, err := v.tokenExchangeClient.HasVehiclePermissions(c.Context(), assetDid, developerLicense, permissions)
 		if err != nil {
@@ -424,7 +535,7 @@ func (v *VehicleSubscriptionController) subscribeMultipleVehiclesToWebhook(c *fi
 	var failedSubscriptions []FailedSubscription
 
 	for _, assetDid := range assetDIDs {
-		_, err := v.repo.CreateVehicleSubscription(c.Context(), assetDid, webhookID)
+		sub, err := v.repo.CreateVehicleSubscription(c.Context(), assetDid, webhookID, params[assetDid.String()])
 		if err != nil {
 			errMsg := "failed to subscribe asset"
 			if richErr, ok := richerrors.AsRichError(err); ok {
@@ -434,13 +545,14 @@ func (v *VehicleSubscriptionController) subscribeMultipleVehiclesToWebhook(c *fi
 				AssetDid: assetDid,
 				Message:  errMsg,
 			})
+			continue
 		}
+		v.cache.AddSubscription(c.Context(), sub)
 	}
 
 	if len(failedSubscriptions) > 0 {
 		return c.JSON(FailedSubscriptionResponse{FailedSubscriptions: failedSubscriptions})
 	}
-	v.cache.ScheduleRefresh(c.Context())
 	return c.JSON(GenericResponse{Message: fmt.Sprintf("Subscribed %d assets", len(assetDIDs)-len(failedSubscriptions))})
 }
 
@@ -476,6 +588,18 @@ func ownerCheck(ctx context.Context, repo Repository, webhookID string, develope
 	return trigger, nil
 }
 
+// requireExplicitSubscriptions rejects changes to the subscriptions of a trigger that are kept in sync with the
+// vehicles shared with its developer license.
+func requireExplicitSubscriptions(trigger *models.Trigger) error {
+	if trigger.SubscriptionMode != triggersrepo.SubscriptionModeAllShared {
+		return nil
+	}
+	return richerrors.Error{
+		ExternalMsg: fmt.Sprintf("Vehicles are subscribed automatically to webhooks with subscription mode %q", triggersrepo.SubscriptionModeAllShared),
+		Code:        http.StatusBadRequest,
+	}
+}
+
 func getAssetDID(c *fiber.Ctx) (cloudevent.ERC721DID, error) {
 	assetDidStr := c.Params("assetDID")
 	if assetDidStr == "" {
@@ -6,11 +6,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
@@ -18,6 +20,7 @@ import (
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhookcache"
 	"github.com/DIMO-Network/vehicle-triggers-api/tests"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,12 +98,12 @@ func TestVehicleSubscriptionController_AssignVehicleToWebhook(t *testing.T) {
 			AssetDid:  assetDid.String(),
 		}
 		testCtrl.mockRepo.EXPECT().
-			CreateVehicleSubscription(gomock.Any(), assetDid, webhookID).
+			CreateVehicleSubscription(gomock.Any(), assetDid, webhookID, null.JSON{}).
 			Return(expectedSubscription, nil).
 			Times(1)
 
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
+			AddSubscription(gomock.Any(), expectedSubscription).
 			Times(1)
 		path, err := url.JoinPath("/webhooks", webhookID, "subscribe", assetDid.String())
 		require.NoError(t, err)
@@ -157,12 +160,12 @@ func TestVehicleSubscriptionController_AssignVehicleToWebhook(t *testing.T) {
 			AssetDid:  assetDid.String(),
 		}
 		testCtrl.mockRepo.EXPECT().
-			CreateVehicleSubscription(gomock.Any(), assetDid, webhookID).
+			CreateVehicleSubscription(gomock.Any(), assetDid, webhookID, null.JSON{}).
 			Return(expectedSubscription, nil).
 			Times(1)
 
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
+			AddSubscription(gomock.Any(), expectedSubscription).
 			Times(1)
 		path, err := url.JoinPath("/webhooks", webhookID, "subscribe", url.PathEscape(assetDid.String()))
 		require.NoError(t, err)
@@ -380,14 +383,14 @@ func TestVehicleSubscriptionController_SubscribeVehiclesFromList(t *testing.T) {
 				AssetDid:  assetDid.String(),
 			}
 			testCtrl.mockRepo.EXPECT().
-				CreateVehicleSubscription(gomock.Any(), assetDid, webhookID).
+				CreateVehicleSubscription(gomock.Any(), assetDid, webhookID, null.JSON{}).
 				Return(expectedSubscription, nil).
 				Times(1)
 		}
 
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
-			Times(1)
+			AddSubscription(gomock.Any(), gomock.Any()).
+			Times(len(assetDIDs))
 
 		body, _ := json.Marshal(payload)
 		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/subscribe/list", bytes.NewReader(body))
@@ -405,6 +408,98 @@ func TestVehicleSubscriptionController_SubscribeVehiclesFromList(t *testing.T) {
 		assert.Equal(t, "Subscribed 2 assets", response.Message)
 	})
 
+	t.Run("subscription with params", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/subscribe/list", testCtrl.controller.SubscribeVehiclesFromList)
+
+		webhookID := "550e8400-e29b-41d4-a716-446655440000"
+		truck := cloudevent.ERC721DID{ChainID: 137, ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"), TokenID: big.NewInt(12345)}
+		car := cloudevent.ERC721DID{ChainID: 137, ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"), TokenID: big.NewInt(67890)}
+		payload := VehicleListRequest{
+			AssetDIDs: []cloudevent.ERC721DID{truck, car},
+			Params:    map[string]map[string]any{truck.String(): {"limit": 70}},
+		}
+
+		testCtrl.mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), webhookID, devLicense).
+			Return(&models.Trigger{
+				ID:                      webhookID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Service:                 triggersrepo.ServiceSignal,
+				MetricName:              "vss.speed",
+				Params:                  null.JSONFrom([]byte(`{"limit": 55}`)),
+			}, nil)
+		testCtrl.mockTokenExchange.EXPECT().
+			HasVehiclePermissions(gomock.Any(), gomock.Any(), devLicense, gomock.Any()).
+			Return(true, nil).
+			Times(2)
+		testCtrl.mockRepo.EXPECT().
+			CreateVehicleSubscription(gomock.Any(), truck, webhookID, null.JSONFrom([]byte(`{"limit":70}`))).
+			Return(&models.VehicleSubscription{}, nil)
+		testCtrl.mockRepo.EXPECT().
+			CreateVehicleSubscription(gomock.Any(), car, webhookID, null.JSON{}).
+			Return(&models.VehicleSubscription{}, nil)
+		testCtrl.mockCache.EXPECT().AddSubscription(gomock.Any(), gomock.Any()).Times(2)
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/subscribe/list", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		truck := cloudevent.ERC721DID{ChainID: 137, ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"), TokenID: big.NewInt(12345)}
+		other := cloudevent.ERC721DID{ChainID: 137, ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"), TokenID: big.NewInt(1)}
+		cases := []struct {
+			name   string
+			params map[string]map[string]any
+		}{
+			{name: "unknown param", params: map[string]map[string]any{truck.String(): {"max": 70}}},
+			{name: "wrong type", params: map[string]map[string]any{truck.String(): {"limit": "fast"}}},
+			{name: "vehicle not in list", params: map[string]map[string]any{other.String(): {"limit": 70}}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+				app := newApp()
+				devLicense := tests.RandomAddr(t)
+				app.Use(tokenInjector(devLicense))
+				app.Post("/webhooks/:webhookId/subscribe/list", testCtrl.controller.SubscribeVehiclesFromList)
+
+				webhookID := "550e8400-e29b-41d4-a716-446655440000"
+				testCtrl.mockRepo.EXPECT().
+					GetTriggerByIDAndDeveloperLicense(gomock.Any(), webhookID, devLicense).
+					Return(&models.Trigger{
+						ID:                      webhookID,
+						DeveloperLicenseAddress: devLicense.Bytes(),
+						Service:                 triggersrepo.ServiceSignal,
+						MetricName:              "vss.speed",
+						Params:                  null.JSONFrom([]byte(`{"limit": 55}`)),
+					}, nil)
+
+				body, _ := json.Marshal(VehicleListRequest{AssetDIDs: []cloudevent.ERC721DID{truck}, Params: tc.params})
+				req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/subscribe/list", bytes.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				resp, err := app.Test(req)
+				require.NoError(t, err)
+				defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
 	t.Run("invalid request body", func(t *testing.T) {
 		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
 
@@ -462,7 +557,7 @@ func TestVehicleSubscriptionController_RemoveVehicleFromWebhook(t *testing.T) {
 			Times(1)
 
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
+			RemoveSubscription(webhookID, assetDid.String()).
 			Times(1)
 
 		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+webhookID+"/unsubscribe/"+assetDid.String(), nil)
@@ -480,6 +575,130 @@ func TestVehicleSubscriptionController_RemoveVehicleFromWebhook(t *testing.T) {
 	})
 }
 
+func TestVehicleSubscriptionController_MuteVehicle(t *testing.T) {
+	t.Parallel()
+
+	webhookID := "550e8400-e29b-41d4-a716-446655440000"
+	assetDid := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(12345),
+	}
+	expectOwnerCheck := func(testCtrl ControllerWithMocks, devLicense common.Address) {
+		testCtrl.mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), webhookID, devLicense).
+			Return(&models.Trigger{
+				ID:                      webhookID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Service:                 triggersrepo.ServiceSignal,
+				MetricName:              "vss.speed",
+			}, nil).
+			Times(1)
+	}
+
+	t.Run("mute", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/mute/:assetDID", testCtrl.controller.MuteVehicle)
+
+		until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		expectOwnerCheck(testCtrl, devLicense)
+		testCtrl.mockRepo.EXPECT().
+			MuteVehicleSubscription(gomock.Any(), webhookID, assetDid, null.TimeFrom(until)).
+			Return(int64(1), nil).
+			Times(1)
+		testCtrl.mockCache.EXPECT().
+			MuteSubscription(gomock.Any(), webhookID, assetDid.String(), null.TimeFrom(until)).
+			Times(1)
+
+		body := fmt.Sprintf(`{"until":%q}`, until.Format(time.RFC3339))
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/mute/"+assetDid.String(), bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response GenericResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "Vehicle muted until "+until.Format(time.RFC3339), response.Message)
+	})
+
+	t.Run("unmute", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/webhooks/:webhookId/mute/:assetDID", testCtrl.controller.UnmuteVehicle)
+
+		expectOwnerCheck(testCtrl, devLicense)
+		testCtrl.mockRepo.EXPECT().
+			MuteVehicleSubscription(gomock.Any(), webhookID, assetDid, null.Time{}).
+			Return(int64(1), nil).
+			Times(1)
+		testCtrl.mockCache.EXPECT().
+			MuteSubscription(gomock.Any(), webhookID, assetDid.String(), null.Time{}).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+webhookID+"/mute/"+assetDid.String(), nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("vehicle not subscribed", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/webhooks/:webhookId/mute/:assetDID", testCtrl.controller.UnmuteVehicle)
+
+		expectOwnerCheck(testCtrl, devLicense)
+		testCtrl.mockRepo.EXPECT().
+			MuteVehicleSubscription(gomock.Any(), webhookID, assetDid, null.Time{}).
+			Return(int64(0), nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+webhookID+"/mute/"+assetDid.String(), nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("until in the past", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/mute/:assetDID", testCtrl.controller.MuteVehicle)
+
+		body := fmt.Sprintf(`{"until":%q}`, time.Now().Add(-time.Hour).Format(time.RFC3339))
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/mute/"+assetDid.String(), bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 func TestVehicleSubscriptionController_SubscribeAllVehiclesToWebhook(t *testing.T) {
 	t.Parallel()
 
@@ -540,13 +759,13 @@ func TestVehicleSubscriptionController_SubscribeAllVehiclesToWebhook(t *testing.
 				AssetDid:  assetDid.String(),
 			}
 			testCtrl.mockRepo.EXPECT().
-				CreateVehicleSubscription(gomock.Any(), assetDid, webhookID).
+				CreateVehicleSubscription(gomock.Any(), assetDid, webhookID, null.JSON{}).
 				Return(expectedSubscription, nil).
 				Times(1)
 		}
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
-			Times(1)
+			AddSubscription(gomock.Any(), gomock.Any()).
+			Times(len(assetDIDs))
 
 		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/subscribe/all", nil)
 
@@ -597,6 +816,39 @@ func TestVehicleSubscriptionController_SubscribeAllVehiclesToWebhook(t *testing.
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 	})
+
+	t.Run("allShared webhook", func(t *testing.T) {
+		testCtrl := NewVehicleSubscriptionControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := tests.RandomAddr(t)
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/subscribe/all", testCtrl.controller.SubscribeAllVehiclesToWebhook)
+		app.Delete("/webhooks/:webhookId/unsubscribe/all", testCtrl.controller.UnsubscribeAllVehiclesFromWebhook)
+
+		webhookID := "550e8400-e29b-41d4-a716-446655440000"
+		testCtrl.mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), webhookID, devLicense).
+			Return(&models.Trigger{
+				ID:                      webhookID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Service:                 triggersrepo.ServiceSignal,
+				MetricName:              "vss.speed",
+				SubscriptionMode:        triggersrepo.SubscriptionModeAllShared,
+			}, nil).
+			Times(2)
+
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodPost, "/webhooks/"+webhookID+"/subscribe/all", nil),
+			httptest.NewRequest(http.MethodDelete, "/webhooks/"+webhookID+"/unsubscribe/all", nil),
+		} {
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		}
+	})
 }
 
 func TestVehicleSubscriptionController_UnsubscribeAllVehiclesFromWebhook(t *testing.T) {
@@ -629,7 +881,7 @@ func TestVehicleSubscriptionController_UnsubscribeAllVehiclesFromWebhook(t *test
 			Times(1)
 
 		testCtrl.mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
+			RemoveTrigger(webhookID).
 			Times(1)
 
 		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+webhookID+"/unsubscribe/all", nil)
@@ -658,6 +910,8 @@ func TestVehicleSubscriptionController_ListSubscriptions(t *testing.T) {
 		app.Use(tokenInjector(devLicense))
 		app.Get("/webhooks/vehicles/:assetDID", testCtrl.controller.ListSubscriptions)
 
+		mutedUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
 		assetDid := cloudevent.ERC721DID{
 			ChainID:         137,
 			ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
@@ -666,12 +920,14 @@ func TestVehicleSubscriptionController_ListSubscriptions(t *testing.T) {
 
 		subscriptions := []*models.VehicleSubscription{
 			{
-				TriggerID: "webhook-1",
-				AssetDid:  assetDid.String(),
+				TriggerID:  "webhook-1",
+				AssetDid:   assetDid.String(),
+				MutedUntil: null.TimeFrom(mutedUntil),
 			},
 			{
-				TriggerID: "webhook-2",
-				AssetDid:  assetDid.String(),
+				TriggerID:  "webhook-2",
+				AssetDid:   assetDid.String(),
+				MutedUntil: null.TimeFrom(time.Now().Add(-time.Hour)),
 			},
 		}
 
@@ -694,6 +950,9 @@ func TestVehicleSubscriptionController_ListSubscriptions(t *testing.T) {
 		assert.Len(t, subscriptionViews, 2)
 		assert.Equal(t, "webhook-1", subscriptionViews[0].WebhookID)
 		assert.Equal(t, "webhook-2", subscriptionViews[1].WebhookID)
+		require.NotNil(t, subscriptionViews[0].MutedUntil)
+		assert.True(t, mutedUntil.Equal(*subscriptionViews[0].MutedUntil))
+		assert.Nil(t, subscriptionViews[1].MutedUntil)
 	})
 }
 
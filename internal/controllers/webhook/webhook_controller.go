@@ -2,17 +2,23 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/model-garage/pkg/vss"
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/auth"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
 	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type Repository interface {
@@ -21,17 +27,40 @@ type Repository interface {
 	GetTriggerByIDAndDeveloperLicense(ctx context.Context, triggerID string, developerLicense common.Address) (*models.Trigger, error)
 	UpdateTrigger(ctx context.Context, trigger *models.Trigger) error
 	DeleteTrigger(ctx context.Context, triggerID string, developerLicense common.Address) error
+	RotateTriggerSecret(ctx context.Context, triggerID string, developerLicense common.Address, overlap time.Duration) (*models.Trigger, error)
+	SnoozeTrigger(ctx context.Context, triggerID string, developerLicense common.Address, until null.Time) (*models.Trigger, error)
+	RequestTriggerReconciliation(ctx context.Context, triggerID string) error
+
+	// dead letters
+	GetDeadLettersByTriggerID(ctx context.Context, triggerID string) (models.WebhookDeadLetterSlice, error)
+	ReplayDeadLetters(ctx context.Context, triggerID string, ids []string) (int64, error)
+
+	// delivery log
+	GetWebhookDeliveries(ctx context.Context, triggerID string, filter triggersrepo.DeliveryFilter) (models.WebhookDeliverySlice, error)
 
 	// subscriptions
-	CreateVehicleSubscription(ctx context.Context, assetDID cloudevent.ERC721DID, triggerID string) (*models.VehicleSubscription, error)
+	CreateVehicleSubscription(ctx context.Context, assetDID cloudevent.ERC721DID, triggerID string, params null.JSON) (*models.VehicleSubscription, error)
 	GetVehicleSubscriptionsByTriggerID(ctx context.Context, triggerID string) ([]*models.VehicleSubscription, error)
 	GetVehicleSubscriptionsByVehicleAndDeveloperLicense(ctx context.Context, assetDID cloudevent.ERC721DID, developerLicense common.Address) ([]*models.VehicleSubscription, error)
 	DeleteVehicleSubscription(ctx context.Context, triggerID string, assetDID cloudevent.ERC721DID) (int64, error)
 	DeleteAllVehicleSubscriptionsForTrigger(ctx context.Context, triggerID string) (int64, error)
+	MuteVehicleSubscription(ctx context.Context, triggerID string, assetDID cloudevent.ERC721DID, until null.Time) (int64, error)
+
+	// geofences
+	GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error)
 }
 
 type WebhookCache interface {
 	ScheduleRefresh(ctx context.Context)
+	AddSubscription(ctx context.Context, sub *models.VehicleSubscription)
+	RemoveSubscription(triggerID, assetDID string)
+	MuteSubscription(ctx context.Context, triggerID, assetDID string, until null.Time)
+	ReplaceTrigger(ctx context.Context, trigger *models.Trigger)
+	RemoveTrigger(triggerID string)
+}
+
+type WebhookSender interface {
+	SendTestWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[WebhookPayload]) (DeliveryResult, error)
 }
 
 // WebhookController is the controller for creating and managing webhooks.
@@ -39,14 +68,16 @@ type WebhookController struct {
 	repo       Repository
 	signalDefs []signals.SignalDefinition
 	cache      WebhookCache
+	sender     WebhookSender
 }
 
 // NewWebhookController creates a new WebhookController.
-func NewWebhookController(repo Repository, cache WebhookCache) (*WebhookController, error) {
+func NewWebhookController(repo Repository, cache WebhookCache, sender WebhookSender) (*WebhookController, error) {
 	return &WebhookController{
 		repo:       repo,
 		signalDefs: signals.GetAllSignalDefinitions(),
 		cache:      cache,
+		sender:     sender,
 	}, nil
 }
 
@@ -76,7 +107,22 @@ func (w *WebhookController) RegisterWebhook(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := validateServiceAndMetricNameAndCondition(payload.Service, payload.MetricName, payload.Condition); err != nil {
+	token, err := auth.GetDexJWT(c)
+	if err != nil {
+		return err
+	}
+
+	geofences, err := loadConditionGeofences(c.Context(), w.repo, token.EthereumAddress, payload.Service, payload.Condition, payload.ClearCondition)
+	if err != nil {
+		return err
+	}
+
+	params, storedParams, err := validateParams(payload.Params)
+	if err != nil {
+		return err
+	}
+
+	if err := validateServiceAndMetricNameAndCondition(payload.Service, payload.MetricName, payload.Condition, geofences, params); err != nil {
 		return err
 	}
 
@@ -84,19 +130,43 @@ func (w *WebhookController) RegisterWebhook(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := validateStatus(payload.Status); err != nil {
+	if err := validateSustainFor(payload.Service, payload.SustainFor); err != nil {
 		return err
 	}
 
-	if err := verifyWebhookURL(c.Context(), payload.TargetURL, payload.VerificationToken); err != nil {
+	if err := validateFireMode(payload.Service, payload.MetricName, payload.FireMode, payload.ClearCondition, geofences, params); err != nil {
 		return err
 	}
 
-	token, err := auth.GetDexJWT(c)
+	if err := validateAbsentFor(payload.Service, payload.AbsentFor); err != nil {
+		return err
+	}
+
+	if err := validatePreviousScope(payload.Service, payload.PreviousScope); err != nil {
+		return err
+	}
+
+	if err := validateRateLimit(payload.Service, payload.MaxFirings, payload.GlobalMaxFirings, payload.FiringWindow); err != nil {
+		return err
+	}
+
+	activeSchedule, err := validateSchedule(payload.Schedule)
 	if err != nil {
 		return err
 	}
 
+	if err := validateSubscriptionMode(payload.SubscriptionMode); err != nil {
+		return err
+	}
+
+	if err := validateStatus(payload.Status); err != nil {
+		return err
+	}
+
+	if err := verifyWebhookURL(c.Context(), payload.TargetURL, payload.VerificationToken); err != nil {
+		return err
+	}
+
 	req := triggersrepo.CreateTriggerRequest{
 		Service:                 payload.Service,
 		MetricName:              payload.MetricName,
@@ -105,6 +175,17 @@ func (w *WebhookController) RegisterWebhook(c *fiber.Ctx) error {
 		Status:                  payload.Status,
 		Description:             payload.Description,
 		CooldownPeriod:          payload.CoolDownPeriod,
+		SustainFor:              payload.SustainFor,
+		FireMode:                payload.FireMode,
+		ClearCondition:          payload.ClearCondition,
+		AbsentFor:               payload.AbsentFor,
+		PreviousScope:           payload.PreviousScope,
+		MaxFirings:              payload.MaxFirings,
+		GlobalMaxFirings:        payload.GlobalMaxFirings,
+		FiringWindow:            payload.FiringWindow,
+		Schedule:                activeSchedule,
+		Params:                  storedParams,
+		SubscriptionMode:        payload.SubscriptionMode,
 		DeveloperLicenseAddress: token.EthereumAddress,
 		DisplayName:             payload.DisplayName,
 	}
@@ -118,7 +199,11 @@ func (w *WebhookController) RegisterWebhook(c *fiber.Ctx) error {
 		}
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(RegisterWebhookResponse{ID: trigger.ID, Message: "Webhook registered successfully"})
+	return c.Status(fiber.StatusCreated).JSON(RegisterWebhookResponse{
+		ID:            trigger.ID,
+		Message:       "Webhook registered successfully",
+		SigningSecret: trigger.SigningSecret,
+	})
 }
 
 // ListWebhooks godoc
@@ -142,6 +227,7 @@ func (w *WebhookController) ListWebhooks(c *fiber.Ctx) error {
 		return fmt.Errorf("failed to retrieve webhooks: %w", err)
 	}
 
+	now := time.Now()
 	out := make([]WebhookView, 0, len(triggers))
 	for _, t := range triggers {
 		desc := ""
@@ -149,23 +235,70 @@ func (w *WebhookController) ListWebhooks(c *fiber.Ctx) error {
 			desc = t.Description.String
 		}
 		out = append(out, WebhookView{
-			ID:             t.ID,
-			Service:        t.Service,
-			MetricName:     t.MetricName,
-			Condition:      t.Condition,
-			TargetURL:      t.TargetURI,
-			CoolDownPeriod: t.CooldownPeriod,
-			Status:         t.Status,
-			Description:    desc,
-			CreatedAt:      t.CreatedAt,
-			UpdatedAt:      t.UpdatedAt,
-			FailureCount:   t.FailureCount,
-			DisplayName:    t.DisplayName,
+			ID:               t.ID,
+			Service:          t.Service,
+			MetricName:       t.MetricName,
+			Condition:        t.Condition,
+			TargetURL:        t.TargetURI,
+			CoolDownPeriod:   t.CooldownPeriod,
+			SustainFor:       t.SustainFor,
+			FireMode:         t.FireMode,
+			ClearCondition:   t.ClearCondition.String,
+			AbsentFor:        t.AbsentFor,
+			PreviousScope:    t.PreviousScope,
+			MaxFirings:       t.MaxFirings,
+			GlobalMaxFirings: t.GlobalMaxFirings,
+			FiringWindow:     t.FiringWindow,
+			Schedule:         scheduleView(t.Schedule),
+			Params:           paramsView(t.Params),
+			SubscriptionMode: t.SubscriptionMode,
+			Reconciliation:   reconciliationView(t),
+			SnoozedUntil:     pausedUntil(t.SnoozedUntil, now),
+			Status:           t.Status,
+			Description:      desc,
+			CreatedAt:        t.CreatedAt,
+			UpdatedAt:        t.UpdatedAt,
+			FailureCount:     t.FailureCount,
+			DisplayName:      t.DisplayName,
 		})
 	}
 	return c.JSON(out)
 }
 
+// scheduleView returns the stored schedule of a webhook, or nil if it has none.
+func scheduleView(data null.JSON) *Schedule {
+	if !data.Valid {
+		return nil
+	}
+	var s Schedule
+	if err := json.Unmarshal(data.JSON, &s); err != nil {
+		return nil
+	}
+	return &s
+}
+
+// reconciliationView returns the outcome of the last reconciliation of an allShared webhook, or nil if it has not
+// been reconciled.
+func reconciliationView(t *models.Trigger) *ReconciliationView {
+	if t.SubscriptionMode != triggersrepo.SubscriptionModeAllShared || !t.ReconciledAt.Valid {
+		return nil
+	}
+	return &ReconciliationView{
+		ReconciledAt: t.ReconciledAt.Time,
+		Added:        t.ReconciledAdded,
+		Removed:      t.ReconciledRemoved,
+		Error:        t.ReconcileError.String,
+	}
+}
+
+// pausedUntil returns the end of a snooze or mute, or nil if there is none or it has expired.
+func pausedUntil(until null.Time, now time.Time) *time.Time {
+	if !until.Valid || !now.Before(until.Time) {
+		return nil
+	}
+	return &until.Time
+}
+
 // UpdateWebhook godoc
 // @Summary      Update a webhook
 // @Description  Updates the configuration of a webhook by its ID. The failure count is reset to 0 when updating a webhook.
@@ -213,11 +346,41 @@ func (w *WebhookController) UpdateWebhook(c *fiber.Ctx) error {
 		}
 		event.Status = *payload.Status
 	}
-	if payload.Condition != nil {
-		if err := validateServiceAndMetricNameAndCondition(event.Service, event.MetricName, *payload.Condition); err != nil {
+	params, err := celcondition.ParseParams(event.Params.JSON)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook params: %w", err)
+	}
+	if payload.Params != nil {
+		var storedParams json.RawMessage
+		params, storedParams, err = validateParams(payload.Params)
+		if err != nil {
+			return err
+		}
+		event.Params = null.NewJSON(storedParams, storedParams != nil)
+	}
+	var geofences celcondition.Geofences
+	if payload.Condition != nil || payload.FireMode != nil || payload.ClearCondition != nil || payload.Params != nil {
+		condition, clearCondition := event.Condition, event.ClearCondition.String
+		if payload.Condition != nil {
+			condition = *payload.Condition
+		}
+		if payload.ClearCondition != nil {
+			clearCondition = *payload.ClearCondition
+		}
+		geofences, err = loadConditionGeofences(c.Context(), w.repo, devLicense, event.Service, condition, clearCondition)
+		if err != nil {
 			return err
 		}
-		event.Condition = *payload.Condition
+	}
+	if payload.Condition != nil || payload.Params != nil {
+		condition := event.Condition
+		if payload.Condition != nil {
+			condition = *payload.Condition
+		}
+		if err := validateServiceAndMetricNameAndCondition(event.Service, event.MetricName, condition, geofences, params); err != nil {
+			return err
+		}
+		event.Condition = condition
 	}
 	if payload.CoolDownPeriod != nil {
 		if err := validateCoolDownPeriod(*payload.CoolDownPeriod); err != nil {
@@ -225,6 +388,75 @@ func (w *WebhookController) UpdateWebhook(c *fiber.Ctx) error {
 		}
 		event.CooldownPeriod = *payload.CoolDownPeriod
 	}
+	if payload.SustainFor != nil {
+		if err := validateSustainFor(event.Service, *payload.SustainFor); err != nil {
+			return err
+		}
+		event.SustainFor = *payload.SustainFor
+	}
+	if payload.FireMode != nil || payload.ClearCondition != nil || payload.Params != nil {
+		if payload.FireMode != nil {
+			event.FireMode = *payload.FireMode
+			if event.FireMode == "" {
+				event.FireMode = triggersrepo.FireModeLevel
+			}
+			if event.FireMode != triggersrepo.FireModeEdge && payload.ClearCondition == nil {
+				event.ClearCondition = null.String{}
+			}
+		}
+		if payload.ClearCondition != nil {
+			event.ClearCondition = null.NewString(*payload.ClearCondition, *payload.ClearCondition != "")
+		}
+		if err := validateFireMode(event.Service, event.MetricName, event.FireMode, event.ClearCondition.String, geofences, params); err != nil {
+			return err
+		}
+	}
+	if payload.AbsentFor != nil {
+		if err := validateAbsentFor(event.Service, *payload.AbsentFor); err != nil {
+			return err
+		}
+		event.AbsentFor = *payload.AbsentFor
+	}
+	if payload.PreviousScope != nil {
+		if err := validatePreviousScope(event.Service, *payload.PreviousScope); err != nil {
+			return err
+		}
+		event.PreviousScope = *payload.PreviousScope
+		if event.PreviousScope == "" {
+			event.PreviousScope = triggersrepo.PreviousScopeLastObserved
+		}
+	}
+	if payload.MaxFirings != nil || payload.GlobalMaxFirings != nil || payload.FiringWindow != nil {
+		if payload.MaxFirings != nil {
+			event.MaxFirings = *payload.MaxFirings
+		}
+		if payload.GlobalMaxFirings != nil {
+			event.GlobalMaxFirings = *payload.GlobalMaxFirings
+		}
+		if payload.FiringWindow != nil {
+			event.FiringWindow = *payload.FiringWindow
+		}
+		if err := validateRateLimit(event.Service, event.MaxFirings, event.GlobalMaxFirings, event.FiringWindow); err != nil {
+			return err
+		}
+	}
+	if payload.Schedule != nil {
+		activeSchedule, err := validateSchedule(payload.Schedule)
+		if err != nil {
+			return err
+		}
+		event.Schedule = null.NewJSON(activeSchedule, activeSchedule != nil)
+	}
+	previousMode := event.SubscriptionMode
+	if payload.SubscriptionMode != nil {
+		if err := validateSubscriptionMode(*payload.SubscriptionMode); err != nil {
+			return err
+		}
+		event.SubscriptionMode = *payload.SubscriptionMode
+		if event.SubscriptionMode == "" {
+			event.SubscriptionMode = triggersrepo.SubscriptionModeExplicit
+		}
+	}
 	if payload.Description != nil {
 		event.Description = null.StringFrom(*payload.Description)
 	}
@@ -237,8 +469,15 @@ func (w *WebhookController) UpdateWebhook(c *fiber.Ctx) error {
 	if err := w.repo.UpdateTrigger(c.Context(), event); err != nil {
 		return fmt.Errorf("failed to update webhook: %w", err)
 	}
+	// The vehicles of an allShared webhook are synced right away when it becomes allShared, or when its condition,
+	// and with it the permissions it needs, changes.
+	if event.SubscriptionMode == triggersrepo.SubscriptionModeAllShared && (previousMode != event.SubscriptionMode || payload.Condition != nil) {
+		if err := w.repo.RequestTriggerReconciliation(c.Context(), event.ID); err != nil {
+			return err
+		}
+	}
 
-	w.cache.ScheduleRefresh(c.Context())
+	w.cache.ReplaceTrigger(c.Context(), event)
 
 	return c.Status(fiber.StatusOK).JSON(UpdateWebhookResponse{ID: event.ID, Message: "Webhook updated successfully"})
 }
@@ -272,11 +511,450 @@ func (w *WebhookController) DeleteWebhook(c *fiber.Ctx) error {
 	if err := w.repo.DeleteTrigger(c.Context(), webhookID, devLicense); err != nil {
 		return fmt.Errorf("failed to delete webhook: %w", err)
 	}
-	w.cache.ScheduleRefresh(c.Context())
+	w.cache.RemoveTrigger(webhookID)
 
 	return c.Status(fiber.StatusOK).JSON(GenericResponse{Message: "Webhook deleted successfully"})
 }
 
+// RotateWebhookSecret godoc
+// @Summary      Rotate a webhook signing secret
+// @Description  Issues a new signing secret for a webhook. The previous secret keeps signing deliveries alongside the new one for the requested overlap (24 hours by default) so receivers can switch without dropping requests. The new secret is only returned once.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhookId  path      string                       true   "Webhook ID"
+// @Param        request    body      RotateWebhookSecretRequest   false  "Rotation options"
+// @Success      200        {object}  RotateWebhookSecretResponse  "Signing secret rotated successfully"
+// @Failure      400        "Invalid request payload"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/secret [post]
+func (w *WebhookController) RotateWebhookSecret(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	var payload RotateWebhookSecretRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return richerrors.Error{
+				ExternalMsg: "Invalid request payload",
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+	}
+	overlap, err := validateSecretOverlap(payload.OverlapSeconds)
+	if err != nil {
+		return err
+	}
+
+	// RotateTriggerSecret locks the trigger by id and developer license, so it doubles as the owner check.
+	trigger, err := w.repo.RotateTriggerSecret(c.Context(), webhookID, devLicense, overlap)
+	if err != nil {
+		return fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+	w.cache.ReplaceTrigger(c.Context(), trigger)
+
+	resp := RotateWebhookSecretResponse{
+		ID:            trigger.ID,
+		SigningSecret: trigger.SigningSecret,
+	}
+	if trigger.PreviousSigningSecretExpiresAt.Valid {
+		resp.PreviousSecretExpiresAt = &trigger.PreviousSigningSecretExpiresAt.Time
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// SnoozeWebhook godoc
+// @Summary      Snooze a webhook
+// @Description  Stops a webhook from firing for any vehicle until the given time. The snooze expires on its own; the webhook fires again afterwards without another call. Snoozing a snoozed webhook replaces the time.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhookId  path      string        true  "Webhook ID"
+// @Param        request    body      PauseRequest  true  "Snooze end"
+// @Success      200        {object}  GenericResponse  "Webhook snoozed"
+// @Failure      400        "Invalid request payload"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/snooze [post]
+func (w *WebhookController) SnoozeWebhook(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	var payload PauseRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Invalid request payload",
+			Err:         err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	if err := validatePauseUntil(payload.Until, time.Now()); err != nil {
+		return err
+	}
+
+	// SnoozeTrigger locks the trigger by id and developer license, so it doubles as the owner check.
+	trigger, err := w.repo.SnoozeTrigger(c.Context(), webhookID, devLicense, null.TimeFrom(payload.Until.UTC()))
+	if err != nil {
+		return fmt.Errorf("failed to snooze webhook: %w", err)
+	}
+	w.cache.ReplaceTrigger(c.Context(), trigger)
+
+	return c.JSON(GenericResponse{Message: "Webhook snoozed until " + trigger.SnoozedUntil.Time.Format(time.RFC3339)})
+}
+
+// UnsnoozeWebhook godoc
+// @Summary      Unsnooze a webhook
+// @Description  Ends the snooze of a webhook so that it fires again right away.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhookId  path      string  true  "Webhook ID"
+// @Success      200        {object}  GenericResponse  "Webhook unsnoozed"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/snooze [delete]
+func (w *WebhookController) UnsnoozeWebhook(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := w.repo.SnoozeTrigger(c.Context(), webhookID, devLicense, null.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to unsnooze webhook: %w", err)
+	}
+	w.cache.ReplaceTrigger(c.Context(), trigger)
+
+	return c.JSON(GenericResponse{Message: "Webhook unsnoozed successfully"})
+}
+
+// ListDeadLetters godoc
+// @Summary      List dead letters of a webhook
+// @Description  Lists the deliveries of a webhook that were given up on, newest first. A delivery is given up on when its retries are exhausted, the endpoint rejects it, or the webhook has failed.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhookId  path   string          true  "Webhook ID"
+// @Success      200        {array}  DeadLetterView  "List of dead letters"
+// @Failure      400        "Invalid webhook id"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/dead-letters [get]
+func (w *WebhookController) ListDeadLetters(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = ownerCheck(c.Context(), w.repo, webhookID, devLicense)
+	if err != nil {
+		return err
+	}
+
+	deadLetters, err := w.repo.GetDeadLettersByTriggerID(c.Context(), webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to get dead letters: %w", err)
+	}
+
+	out := make([]DeadLetterView, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		out = append(out, DeadLetterView{
+			ID:        deadLetter.ID,
+			AssetDid:  deadLetter.AssetDid,
+			Payload:   json.RawMessage(deadLetter.Payload),
+			Attempts:  deadLetter.Attempts,
+			LastError: deadLetter.LastError.String,
+			CreatedAt: deadLetter.CreatedAt,
+		})
+	}
+	return c.JSON(out)
+}
+
+// ReplayDeadLetters godoc
+// @Summary      Replay dead letters of a webhook
+// @Description  Queues the selected dead letters, or all of them when no ids are given, for delivery again with a fresh set of retries. The webhook must be enabled.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhookId  path      string                     true   "Webhook ID"
+// @Param        request    body      ReplayDeadLettersRequest   false  "Dead letters to replay"
+// @Success      200        {object}  ReplayDeadLettersResponse  "Dead letters queued for delivery"
+// @Failure      400        "Invalid request payload or webhook not enabled"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/dead-letters/replay [post]
+func (w *WebhookController) ReplayDeadLetters(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	var payload ReplayDeadLettersRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return richerrors.Error{
+				ExternalMsg: "Invalid request payload",
+				Err:         err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+	}
+	for _, id := range payload.IDs {
+		if uuid.Validate(id) != nil {
+			return richerrors.Error{
+				ExternalMsg: fmt.Sprintf("Invalid dead letter id '%s'", id),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+	}
+
+	trigger, err := ownerCheck(c.Context(), w.repo, webhookID, devLicense)
+	if err != nil {
+		return err
+	}
+	// Replayed deliveries of a webhook that is not enabled would be dropped or dead-lettered again right away.
+	if trigger.Status != triggersrepo.StatusEnabled {
+		return richerrors.Error{
+			ExternalMsg: "Webhook must be enabled to replay dead letters",
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	replayed, err := w.repo.ReplayDeadLetters(c.Context(), webhookID, payload.IDs)
+	if err != nil {
+		return fmt.Errorf("failed to replay dead letters: %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ReplayDeadLettersResponse{
+		Replayed: replayed,
+		Message:  fmt.Sprintf("Replaying %d dead letters", replayed),
+	})
+}
+
+// ListDeliveries godoc
+// @Summary      List delivery attempts of a webhook
+// @Description  Lists every attempt to deliver the webhook, including retries, newest first. Attempts are kept for the configured retention. Pass the returned nextCursor as cursor to fetch the next page.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhookId  path      string                  true   "Webhook ID"
+// @Param        assetDid   query     string                  false  "Only return attempts for this asset DID"
+// @Param        from       query     string                  false  "Only return attempts made at or after this time (RFC 3339)"
+// @Param        to         query     string                  false  "Only return attempts made before this time (RFC 3339)"
+// @Param        limit      query     int                     false  "Maximum number of attempts to return (default 100, max 500)"
+// @Param        cursor     query     string                  false  "Cursor returned by the previous page"
+// @Success      200        {object}  ListDeliveriesResponse  "Page of delivery attempts"
+// @Failure      400        "Invalid webhook id or query parameters"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/deliveries [get]
+func (w *WebhookController) ListDeliveries(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+	filter, err := getDeliveryFilter(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = ownerCheck(c.Context(), w.repo, webhookID, devLicense)
+	if err != nil {
+		return err
+	}
+
+	// Fetch one more attempt than requested to know whether there is a next page.
+	limit := filter.Limit
+	filter.Limit++
+	deliveries, err := w.repo.GetWebhookDeliveries(c.Context(), webhookID, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	resp := ListDeliveriesResponse{Deliveries: make([]DeliveryView, 0, min(len(deliveries), limit))}
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		last := deliveries[limit-1]
+		resp.NextCursor = encodeDeliveryCursor(triggersrepo.DeliveryCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	for _, delivery := range deliveries {
+		view := DeliveryView{
+			ID:         delivery.ID,
+			DeliveryID: delivery.DeliveryID,
+			AssetDid:   delivery.AssetDid,
+			Attempt:    delivery.Attempt,
+			LatencyMs:  delivery.LatencyMillis,
+			Error:      delivery.ErrorMessage.String,
+			CreatedAt:  delivery.CreatedAt,
+		}
+		if delivery.StatusCode.Valid {
+			view.StatusCode = &delivery.StatusCode.Int
+		}
+		resp.Deliveries = append(resp.Deliveries, view)
+	}
+	return c.JSON(resp)
+}
+
+// TestWebhook godoc
+// @Summary      Send a test delivery to a webhook
+// @Description  Sends a synthetic payload for the webhook's service and metric to its target URI, signed like a real delivery and marked with the X-DIMO-Webhook-Test header, and reports how the endpoint answered. Test deliveries are sent regardless of the webhook's status, are not retried and do not count toward its failure threshold.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhookId  path      string               true  "Webhook ID"
+// @Success      200        {object}  TestWebhookResponse  "Outcome of the test delivery"
+// @Failure      400        "Invalid webhook id"
+// @Failure      404        "Webhook not found"
+// @Failure      500        "Internal server error"
+// @Security     BearerAuth
+// @Router       /v1/webhooks/{webhookId}/test [post]
+func (w *WebhookController) TestWebhook(c *fiber.Ctx) error {
+	webhookID, err := getWebhookID(c)
+	if err != nil {
+		return err
+	}
+	devLicense, err := getDevLicense(c)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := ownerCheck(c.Context(), w.repo, webhookID, devLicense)
+	if err != nil {
+		return err
+	}
+
+	payload := newTestPayload(trigger, time.Now().UTC())
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test payload: %w", err)
+	}
+
+	result, sendErr := w.sender.SendTestWebhook(c.Context(), trigger, payload)
+	resp := TestWebhookResponse{
+		Success:   sendErr == nil,
+		LatencyMs: result.Latency.Milliseconds(),
+		Payload:   body,
+	}
+	if result.StatusCode != 0 {
+		resp.StatusCode = &result.StatusCode
+	}
+	if sendErr != nil {
+		resp.Error = sendErr.Error()
+	}
+	return c.JSON(resp)
+}
+
+// sampleAssetDID is the vehicle named in test deliveries.
+var sampleAssetDID = cloudevent.ERC721DID{
+	ChainID:         137,
+	ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+	TokenID:         big.NewInt(1),
+}
+
+// newTestPayload builds a payload shaped like a real firing of trigger, with sample signal, event or absence data.
+func newTestPayload(trigger *models.Trigger, now time.Time) *cloudevent.CloudEvent[WebhookPayload] {
+	payload := &cloudevent.CloudEvent[WebhookPayload]{
+		CloudEventHeader: cloudevent.CloudEventHeader{
+			ID:              uuid.New().String(),
+			Source:          "vehicle-triggers-api",
+			Subject:         sampleAssetDID.String(),
+			Time:            now,
+			DataContentType: "application/json",
+			DataVersion:     trigger.Service + "/v1.0",
+			Type:            "dimo.trigger",
+			SpecVersion:     "1.0",
+		},
+		Data: WebhookPayload{
+			Service:     trigger.Service,
+			MetricName:  trigger.MetricName,
+			WebhookId:   trigger.ID,
+			WebhookName: trigger.DisplayName,
+			AssetDID:    sampleAssetDID,
+			Condition:   trigger.Condition,
+		},
+	}
+
+	if triggersrepo.IsAbsenceService(trigger.Service) {
+		payload.Data.Absence = &AbsenceData{
+			Offline:       true,
+			LastSeenAt:    now.Add(-time.Duration(trigger.AbsentFor) * time.Second),
+			SilentSeconds: float64(trigger.AbsentFor),
+		}
+		return payload
+	}
+	if triggersrepo.IsCompositeService(trigger.Service) {
+		names, _ := celcondition.ConditionSignals(trigger.Service, trigger.Condition)
+		for _, name := range names {
+			payload.Data.Signals = append(payload.Data.Signals, sampleSignalData(name, now))
+		}
+		return payload
+	}
+	if !triggersrepo.IsSignalService(trigger.Service) {
+		payload.Data.Event = &EventData{
+			Name:       trigger.MetricName,
+			Timestamp:  now,
+			DurationNs: uint64(time.Second),
+		}
+		return payload
+	}
+
+	payload.Data.Signal = sampleSignalData(signals.BareSignalName(trigger.MetricName), now)
+	return payload
+}
+
+// sampleSignalData returns a sample value of the named signal for test payloads.
+func sampleSignalData(name string, now time.Time) *SignalData {
+	def := signals.GetSignalDefinitionOrDefault(name, signals.NumberType)
+	var value any
+	switch def.ValueType {
+	case signals.StringType:
+		value = "sample"
+	case signals.LocationType:
+		value = vss.Location{Latitude: 40.7128, Longitude: -74.0060, HDOP: 1}
+	default:
+		value = 42.0
+	}
+	return &SignalData{
+		Name:      name,
+		Units:     def.Unit,
+		Timestamp: now,
+		ValueType: def.ValueType,
+		Value:     value,
+	}
+}
+
 // GetSignalNames godoc
 // @Summary      Get signal names
 // @Description  Fetches the list of signal names available for the data field.
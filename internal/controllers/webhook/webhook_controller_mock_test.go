@@ -12,10 +12,12 @@ package webhook
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	cloudevent "github.com/DIMO-Network/cloudevent"
 	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	triggersrepo "github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	null "github.com/aarondl/null/v8"
 	common "github.com/ethereum/go-ethereum/common"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -60,18 +62,18 @@ func (mr *MockRepositoryMockRecorder) CreateTrigger(ctx, req any) *gomock.Call {
 }
 
 // CreateVehicleSubscription mocks base method.
-func (m *MockRepository) CreateVehicleSubscription(ctx context.Context, assetDID cloudevent.ERC721DID, triggerID string) (*models.VehicleSubscription, error) {
+func (m *MockRepository) CreateVehicleSubscription(ctx context.Context, assetDID cloudevent.ERC721DID, triggerID string, params null.JSON) (*models.VehicleSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVehicleSubscription", ctx, assetDID, triggerID)
+	ret := m.ctrl.Call(m, "CreateVehicleSubscription", ctx, assetDID, triggerID, params)
 	ret0, _ := ret[0].(*models.VehicleSubscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateVehicleSubscription indicates an expected call of CreateVehicleSubscription.
-func (mr *MockRepositoryMockRecorder) CreateVehicleSubscription(ctx, assetDID, triggerID any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateVehicleSubscription(ctx, assetDID, triggerID, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).CreateVehicleSubscription), ctx, assetDID, triggerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).CreateVehicleSubscription), ctx, assetDID, triggerID, params)
 }
 
 // DeleteAllVehicleSubscriptionsForTrigger mocks base method.
@@ -118,6 +120,36 @@ func (mr *MockRepositoryMockRecorder) DeleteVehicleSubscription(ctx, triggerID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).DeleteVehicleSubscription), ctx, triggerID, assetDID)
 }
 
+// GetDeadLettersByTriggerID mocks base method.
+func (m *MockRepository) GetDeadLettersByTriggerID(ctx context.Context, triggerID string) (models.WebhookDeadLetterSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLettersByTriggerID", ctx, triggerID)
+	ret0, _ := ret[0].(models.WebhookDeadLetterSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLettersByTriggerID indicates an expected call of GetDeadLettersByTriggerID.
+func (mr *MockRepositoryMockRecorder) GetDeadLettersByTriggerID(ctx, triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLettersByTriggerID", reflect.TypeOf((*MockRepository)(nil).GetDeadLettersByTriggerID), ctx, triggerID)
+}
+
+// GetGeofencesByDeveloperLicense mocks base method.
+func (m *MockRepository) GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByDeveloperLicense", ctx, developerLicenseAddress)
+	ret0, _ := ret[0].(models.GeofenceSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByDeveloperLicense indicates an expected call of GetGeofencesByDeveloperLicense.
+func (mr *MockRepositoryMockRecorder) GetGeofencesByDeveloperLicense(ctx, developerLicenseAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByDeveloperLicense", reflect.TypeOf((*MockRepository)(nil).GetGeofencesByDeveloperLicense), ctx, developerLicenseAddress)
+}
+
 // GetTriggerByIDAndDeveloperLicense mocks base method.
 func (m *MockRepository) GetTriggerByIDAndDeveloperLicense(ctx context.Context, triggerID string, developerLicense common.Address) (*models.Trigger, error) {
 	m.ctrl.T.Helper()
@@ -178,6 +210,95 @@ func (mr *MockRepositoryMockRecorder) GetVehicleSubscriptionsByVehicleAndDevelop
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSubscriptionsByVehicleAndDeveloperLicense", reflect.TypeOf((*MockRepository)(nil).GetVehicleSubscriptionsByVehicleAndDeveloperLicense), ctx, assetDID, developerLicense)
 }
 
+// GetWebhookDeliveries mocks base method.
+func (m *MockRepository) GetWebhookDeliveries(ctx context.Context, triggerID string, filter triggersrepo.DeliveryFilter) (models.WebhookDeliverySlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, triggerID, filter)
+	ret0, _ := ret[0].(models.WebhookDeliverySlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockRepositoryMockRecorder) GetWebhookDeliveries(ctx, triggerID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockRepository)(nil).GetWebhookDeliveries), ctx, triggerID, filter)
+}
+
+// MuteVehicleSubscription mocks base method.
+func (m *MockRepository) MuteVehicleSubscription(ctx context.Context, triggerID string, assetDID cloudevent.ERC721DID, until null.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteVehicleSubscription", ctx, triggerID, assetDID, until)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MuteVehicleSubscription indicates an expected call of MuteVehicleSubscription.
+func (mr *MockRepositoryMockRecorder) MuteVehicleSubscription(ctx, triggerID, assetDID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).MuteVehicleSubscription), ctx, triggerID, assetDID, until)
+}
+
+// ReplayDeadLetters mocks base method.
+func (m *MockRepository) ReplayDeadLetters(ctx context.Context, triggerID string, ids []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetters", ctx, triggerID, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDeadLetters indicates an expected call of ReplayDeadLetters.
+func (mr *MockRepositoryMockRecorder) ReplayDeadLetters(ctx, triggerID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetters", reflect.TypeOf((*MockRepository)(nil).ReplayDeadLetters), ctx, triggerID, ids)
+}
+
+// RequestTriggerReconciliation mocks base method.
+func (m *MockRepository) RequestTriggerReconciliation(ctx context.Context, triggerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestTriggerReconciliation", ctx, triggerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestTriggerReconciliation indicates an expected call of RequestTriggerReconciliation.
+func (mr *MockRepositoryMockRecorder) RequestTriggerReconciliation(ctx, triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTriggerReconciliation", reflect.TypeOf((*MockRepository)(nil).RequestTriggerReconciliation), ctx, triggerID)
+}
+
+// RotateTriggerSecret mocks base method.
+func (m *MockRepository) RotateTriggerSecret(ctx context.Context, triggerID string, developerLicense common.Address, overlap time.Duration) (*models.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateTriggerSecret", ctx, triggerID, developerLicense, overlap)
+	ret0, _ := ret[0].(*models.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateTriggerSecret indicates an expected call of RotateTriggerSecret.
+func (mr *MockRepositoryMockRecorder) RotateTriggerSecret(ctx, triggerID, developerLicense, overlap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateTriggerSecret", reflect.TypeOf((*MockRepository)(nil).RotateTriggerSecret), ctx, triggerID, developerLicense, overlap)
+}
+
+// SnoozeTrigger mocks base method.
+func (m *MockRepository) SnoozeTrigger(ctx context.Context, triggerID string, developerLicense common.Address, until null.Time) (*models.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeTrigger", ctx, triggerID, developerLicense, until)
+	ret0, _ := ret[0].(*models.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeTrigger indicates an expected call of SnoozeTrigger.
+func (mr *MockRepositoryMockRecorder) SnoozeTrigger(ctx, triggerID, developerLicense, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeTrigger", reflect.TypeOf((*MockRepository)(nil).SnoozeTrigger), ctx, triggerID, developerLicense, until)
+}
+
 // UpdateTrigger mocks base method.
 func (m *MockRepository) UpdateTrigger(ctx context.Context, trigger *models.Trigger) error {
 	m.ctrl.T.Helper()
@@ -216,6 +337,66 @@ func (m *MockWebhookCache) EXPECT() *MockWebhookCacheMockRecorder {
 	return m.recorder
 }
 
+// AddSubscription mocks base method.
+func (m *MockWebhookCache) AddSubscription(ctx context.Context, sub *models.VehicleSubscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubscription", ctx, sub)
+}
+
+// AddSubscription indicates an expected call of AddSubscription.
+func (mr *MockWebhookCacheMockRecorder) AddSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscription", reflect.TypeOf((*MockWebhookCache)(nil).AddSubscription), ctx, sub)
+}
+
+// MuteSubscription mocks base method.
+func (m *MockWebhookCache) MuteSubscription(ctx context.Context, triggerID, assetDID string, until null.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MuteSubscription", ctx, triggerID, assetDID, until)
+}
+
+// MuteSubscription indicates an expected call of MuteSubscription.
+func (mr *MockWebhookCacheMockRecorder) MuteSubscription(ctx, triggerID, assetDID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteSubscription", reflect.TypeOf((*MockWebhookCache)(nil).MuteSubscription), ctx, triggerID, assetDID, until)
+}
+
+// RemoveSubscription mocks base method.
+func (m *MockWebhookCache) RemoveSubscription(triggerID, assetDID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveSubscription", triggerID, assetDID)
+}
+
+// RemoveSubscription indicates an expected call of RemoveSubscription.
+func (mr *MockWebhookCacheMockRecorder) RemoveSubscription(triggerID, assetDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockWebhookCache)(nil).RemoveSubscription), triggerID, assetDID)
+}
+
+// RemoveTrigger mocks base method.
+func (m *MockWebhookCache) RemoveTrigger(triggerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveTrigger", triggerID)
+}
+
+// RemoveTrigger indicates an expected call of RemoveTrigger.
+func (mr *MockWebhookCacheMockRecorder) RemoveTrigger(triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrigger", reflect.TypeOf((*MockWebhookCache)(nil).RemoveTrigger), triggerID)
+}
+
+// ReplaceTrigger mocks base method.
+func (m *MockWebhookCache) ReplaceTrigger(ctx context.Context, trigger *models.Trigger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReplaceTrigger", ctx, trigger)
+}
+
+// ReplaceTrigger indicates an expected call of ReplaceTrigger.
+func (mr *MockWebhookCacheMockRecorder) ReplaceTrigger(ctx, trigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTrigger", reflect.TypeOf((*MockWebhookCache)(nil).ReplaceTrigger), ctx, trigger)
+}
+
 // ScheduleRefresh mocks base method.
 func (m *MockWebhookCache) ScheduleRefresh(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -227,3 +408,42 @@ func (mr *MockWebhookCacheMockRecorder) ScheduleRefresh(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRefresh", reflect.TypeOf((*MockWebhookCache)(nil).ScheduleRefresh), ctx)
 }
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// SendTestWebhook mocks base method.
+func (m *MockWebhookSender) SendTestWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[WebhookPayload]) (DeliveryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTestWebhook", ctx, trigger, payload)
+	ret0, _ := ret[0].(DeliveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTestWebhook indicates an expected call of SendTestWebhook.
+func (mr *MockWebhookSenderMockRecorder) SendTestWebhook(ctx, trigger, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTestWebhook", reflect.TypeOf((*MockWebhookSender)(nil).SendTestWebhook), ctx, trigger, payload)
+}
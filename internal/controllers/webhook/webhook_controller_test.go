@@ -5,7 +5,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -13,8 +15,11 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/server-garage/pkg/fibercommon"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/auth"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
@@ -50,7 +55,7 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 	t.Parallel()
 
 	t.Run("successful webhook registration", func(t *testing.T) {
-		controller, mockRepo, _ := newWebhookControllerAndMocks(t)
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
 
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
@@ -86,6 +91,7 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 			Description:    null.StringFrom("Speed alert webhook"),
 			CooldownPeriod: 30,
 			DisplayName:    "Speed Alert",
+			SigningSecret:  "whsec_test",
 		}
 
 		mockRepo.EXPECT().
@@ -112,10 +118,11 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "test-trigger-id", response.ID)
 		assert.Equal(t, "Webhook registered successfully", response.Message)
+		assert.Equal(t, "whsec_test", response.SigningSecret)
 	})
 
 	t.Run("invalid request payload", func(t *testing.T) {
-		controller, _, _ := newWebhookControllerAndMocks(t)
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
 
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
@@ -133,7 +140,7 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 	})
 
 	t.Run("invalid target URL", func(t *testing.T) {
-		controller, _, _ := newWebhookControllerAndMocks(t)
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
 
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
@@ -166,7 +173,7 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 	})
 
 	t.Run("invalid service name", func(t *testing.T) {
-		controller, _, _ := newWebhookControllerAndMocks(t)
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
 
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
@@ -194,196 +201,1662 @@ func TestWebhookController_RegisterWebhook(t *testing.T) {
 		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("aggregate window longer than the limit", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceSignal,
+			MetricName:        "speed",
+			Condition:         `avg(valueNumber, "48h") > 55`,
+			CoolDownPeriod:    30,
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), "window must be greater than 0 and at most 24h0m0s")
+	})
+
+	t.Run("unknown geofence", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		mockRepo.EXPECT().
+			GetGeofencesByDeveloperLicense(gomock.Any(), devLicense).
+			Return(models.GeofenceSlice{{
+				Name:     "depot",
+				Geometry: []byte(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`),
+			}}, nil).
+			Times(1)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceSignal,
+			MetricName:        "vss.currentLocationCoordinates",
+			Condition:         `enteredGeofence("yard")`,
+			CoolDownPeriod:    30,
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), `unknown geofence \"yard\"`)
+	})
+
+	t.Run("absence webhook registration", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, "test-token")
+		}))
+		defer testServer.Close()
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceAbsence,
+			MetricName:        triggersrepo.AnySignal,
+			Condition:         "offline",
+			AbsentFor:         3600,
+			TargetURL:         testServer.URL,
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		mockRepo.EXPECT().
+			CreateTrigger(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, req triggersrepo.CreateTriggerRequest) (*models.Trigger, error) {
+				assert.Equal(t, 3600, req.AbsentFor)
+				return &models.Trigger{ID: "test-trigger-id", SigningSecret: "whsec_test"}, nil
+			}).
+			Times(1)
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("absence webhook without absentFor", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceAbsence,
+			MetricName:        "vss.speed",
+			Condition:         "offline",
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), "Absent for must be between")
+	})
+
+	t.Run("composite webhook registration", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, "test-token")
+		}))
+		defer testServer.Close()
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceComposite,
+			MetricName:        triggersrepo.AnySignal,
+			Condition:         "signals.speed > 0 && signals.isIgnitionOn == 0",
+			CoolDownPeriod:    600,
+			TargetURL:         testServer.URL,
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		mockRepo.EXPECT().
+			CreateTrigger(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, req triggersrepo.CreateTriggerRequest) (*models.Trigger, error) {
+				assert.Equal(t, triggersrepo.ServiceComposite, req.Service)
+				assert.Equal(t, triggersrepo.AnySignal, req.MetricName)
+				return &models.Trigger{ID: "test-trigger-id", SigningSecret: "whsec_test"}, nil
+			}).
+			Times(1)
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("allShared webhook", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, "test-token")
+		}))
+		defer testServer.Close()
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceSignal,
+			MetricName:        "vss.speed",
+			Condition:         "valueNumber > 55",
+			CoolDownPeriod:    30,
+			SubscriptionMode:  triggersrepo.SubscriptionModeAllShared,
+			TargetURL:         testServer.URL,
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		mockRepo.EXPECT().
+			CreateTrigger(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, req triggersrepo.CreateTriggerRequest) (*models.Trigger, error) {
+				assert.Equal(t, triggersrepo.SubscriptionModeAllShared, req.SubscriptionMode)
+				return &models.Trigger{ID: "test-trigger-id", SigningSecret: "whsec_test"}, nil
+			}).
+			Times(1)
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		payload.SubscriptionMode = "everything"
+		body, _ = json.Marshal(payload)
+		req = httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err = app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("composite webhook with a signal metric name", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceComposite,
+			MetricName:        "vss.speed",
+			Condition:         "signals.speed > 0",
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(respBody), "Composite webhooks must use metric name")
+	})
+
 	t.Run("webhook verification failure", func(t *testing.T) {
-		controller, _, _ := newWebhookControllerAndMocks(t)
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		// Setup test server that returns wrong token
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, "wrong-token")
+		}))
+		defer testServer.Close()
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceSignal,
+			MetricName:        "vss.speed",
+			Condition:         "valueNumber > 55",
+			CoolDownPeriod:    30,
+			TargetURL:         testServer.URL,
+			Status:            "enabled",
+			VerificationToken: "expected-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("clear condition without edge fire mode", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceSignal,
+			MetricName:        "vss.speed",
+			Condition:         "valueNumber > 55",
+			ClearCondition:    "valueNumber < 50",
+			CoolDownPeriod:    30,
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("sustain period on event webhook", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks", controller.RegisterWebhook)
+
+		payload := RegisterWebhookRequest{
+			Service:           triggersrepo.ServiceEvent,
+			MetricName:        "behavior.harshBraking",
+			Condition:         "true",
+			CoolDownPeriod:    30,
+			SustainFor:        60,
+			TargetURL:         "https://example.com",
+			Status:            "enabled",
+			VerificationToken: "test-token",
+		}
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestWebhookController_ListWebhooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful list", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Get("/webhooks", controller.ListWebhooks)
+
+		triggers := []*models.Trigger{
+			{
+				ID:             "trigger-1",
+				Service:        triggersrepo.ServiceSignal,
+				MetricName:     "vss.speed",
+				Condition:      "valueNumber > 55",
+				TargetURI:      "https://example.com/webhook",
+				Status:         "enabled",
+				Description:    null.StringFrom("Speed alert"),
+				CooldownPeriod: 30,
+				DisplayName:    "Speed Alert",
+				FailureCount:   0,
+			},
+		}
+
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
+			Return(triggers, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var webhooks []WebhookView
+		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		require.NoError(t, err)
+		assert.Len(t, webhooks, 1)
+		assert.Equal(t, "trigger-1", webhooks[0].ID)
+		assert.Equal(t, "vss.speed", webhooks[0].MetricName)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Get("/webhooks", controller.ListWebhooks)
+
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
+			Return([]*models.Trigger{}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var webhooks []WebhookView
+		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		require.NoError(t, err)
+		assert.Len(t, webhooks, 0)
+	})
+
+	t.Run("shows snoozes until they expire", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Get("/webhooks", controller.ListWebhooks)
+
+		snoozedUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
+			Return([]*models.Trigger{
+				{ID: "snoozed", SnoozedUntil: null.TimeFrom(snoozedUntil)},
+				{ID: "snooze-expired", SnoozedUntil: null.TimeFrom(time.Now().Add(-time.Hour))},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		var webhooks []WebhookView
+		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		require.NotNil(t, webhooks[0].SnoozedUntil)
+		assert.True(t, snoozedUntil.Equal(*webhooks[0].SnoozedUntil))
+		assert.Nil(t, webhooks[1].SnoozedUntil)
+	})
+
+	t.Run("shows the last reconciliation of allShared webhooks", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Get("/webhooks", controller.ListWebhooks)
+
+		reconciledAt := time.Now().UTC().Truncate(time.Second)
+		mockRepo.EXPECT().
+			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
+			Return([]*models.Trigger{
+				{
+					ID:                "reconciled",
+					SubscriptionMode:  triggersrepo.SubscriptionModeAllShared,
+					ReconciledAt:      null.TimeFrom(reconciledAt),
+					ReconciledAdded:   2,
+					ReconciledRemoved: 1,
+					ReconcileError:    null.StringFrom("Failed to sync 1 vehicles"),
+				},
+				{ID: "not-reconciled", SubscriptionMode: triggersrepo.SubscriptionModeAllShared},
+				{ID: "explicit", SubscriptionMode: triggersrepo.SubscriptionModeExplicit, ReconciledAt: null.TimeFrom(reconciledAt)},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		var webhooks []WebhookView
+		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 3)
+		assert.Equal(t, triggersrepo.SubscriptionModeAllShared, webhooks[0].SubscriptionMode)
+		require.NotNil(t, webhooks[0].Reconciliation)
+		assert.True(t, reconciledAt.Equal(webhooks[0].Reconciliation.ReconciledAt))
+		assert.Equal(t, 2, webhooks[0].Reconciliation.Added)
+		assert.Equal(t, 1, webhooks[0].Reconciliation.Removed)
+		assert.Equal(t, "Failed to sync 1 vehicles", webhooks[0].Reconciliation.Error)
+		assert.Nil(t, webhooks[1].Reconciliation)
+		assert.Nil(t, webhooks[2].Reconciliation)
+	})
+}
+
+func TestWebhookController_UpdateWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful update", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+		triggerID := uuid.New().String()
+		existingTrigger := &models.Trigger{
+			ID:             triggerID,
+			Service:        triggersrepo.ServiceSignal,
+			MetricName:     "vss.speed",
+			Condition:      "valueNumber > 55",
+			TargetURI:      "https://example.com/webhook",
+			Status:         "enabled",
+			CooldownPeriod: 30,
+			FailureCount:   5,
+		}
+
+		newCondition := "valueNumber > 60"
+		payload := UpdateWebhookRequest{
+			Condition: &newCondition,
+		}
+
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(existingTrigger, nil).
+			Times(1)
+
+		mockRepo.EXPECT().
+			UpdateTrigger(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+				assert.Equal(t, newCondition, trigger.Condition)
+				assert.Equal(t, 0, trigger.FailureCount) // Should be reset
+				return nil
+			}).
+			Times(1)
+
+		mockCache.EXPECT().
+			ReplaceTrigger(gomock.Any(), gomock.Any()).
+			Times(1)
+
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		if !assert.Equal(t, fiber.StatusOK, resp.StatusCode) {
+			body, _ := io.ReadAll(resp.Body)
+			t.Log(string(body))
+			return
+		}
+
+		var response UpdateWebhookResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, triggerID, response.ID)
+		assert.Equal(t, "Webhook updated successfully", response.Message)
+	})
+
+	t.Run("sustain period", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			service    string
+			sustainFor int
+			wantStatus int
+		}{
+			{name: "signal webhook", service: triggersrepo.ServiceSignal, sustainFor: 120, wantStatus: fiber.StatusOK},
+			{name: "disable on event webhook", service: triggersrepo.ServiceEvent, sustainFor: 0, wantStatus: fiber.StatusOK},
+			{name: "event webhook", service: triggersrepo.ServiceEvent, sustainFor: 120, wantStatus: fiber.StatusBadRequest},
+			{name: "negative", service: triggersrepo.ServiceSignal, sustainFor: -1, wantStatus: fiber.StatusBadRequest},
+			{name: "too long", service: triggersrepo.ServiceSignal, sustainFor: maxSustainFor + 1, wantStatus: fiber.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+				app := newApp()
+				devLicense := common.HexToAddress("0x1234567890abcdef")
+				app.Use(tokenInjector(devLicense))
+				app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+				triggerID := uuid.New().String()
+				existingTrigger := &models.Trigger{
+					ID:        triggerID,
+					Service:   tt.service,
+					Condition: "valueNumber > 55",
+					Status:    "enabled",
+				}
+
+				mockRepo.EXPECT().
+					GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+					Return(existingTrigger, nil).
+					Times(1)
+				if tt.wantStatus == fiber.StatusOK {
+					mockRepo.EXPECT().
+						UpdateTrigger(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+							assert.Equal(t, tt.sustainFor, trigger.SustainFor)
+							return nil
+						}).
+						Times(1)
+					mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any()).Times(1)
+				}
+
+				body, _ := json.Marshal(UpdateWebhookRequest{SustainFor: &tt.sustainFor})
+				req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				resp, err := app.Test(req)
+				require.NoError(t, err)
+				defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+				assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			})
+		}
+	})
+}
+
+func TestWebhookController_UpdateWebhookFireMode(t *testing.T) {
+	t.Parallel()
+
+	edge := triggersrepo.FireModeEdge
+	level := triggersrepo.FireModeLevel
+	clearCond := "valueNumber < 50"
+	noClear := ""
+	invalid := "valueNumber <"
+	tests := []struct {
+		name               string
+		service            string
+		existingMode       string
+		existingClear      string
+		fireMode           *string
+		clearCondition     *string
+		wantStatus         int
+		wantFireMode       string
+		wantClearCondition string
+	}{
+		{name: "edge with clear condition", service: triggersrepo.ServiceSignal, existingMode: level, fireMode: &edge, clearCondition: &clearCond, wantStatus: fiber.StatusOK, wantFireMode: edge, wantClearCondition: clearCond},
+		{name: "clear condition on edge webhook", service: triggersrepo.ServiceSignal, existingMode: edge, clearCondition: &clearCond, wantStatus: fiber.StatusOK, wantFireMode: edge, wantClearCondition: clearCond},
+		{name: "remove clear condition", service: triggersrepo.ServiceSignal, existingMode: edge, existingClear: clearCond, clearCondition: &noClear, wantStatus: fiber.StatusOK, wantFireMode: edge},
+		{name: "back to level drops clear condition", service: triggersrepo.ServiceSignal, existingMode: edge, existingClear: clearCond, fireMode: &level, wantStatus: fiber.StatusOK, wantFireMode: level},
+		{name: "clear condition on level webhook", service: triggersrepo.ServiceSignal, existingMode: level, clearCondition: &clearCond, wantStatus: fiber.StatusBadRequest},
+		{name: "invalid clear condition", service: triggersrepo.ServiceSignal, existingMode: edge, clearCondition: &invalid, wantStatus: fiber.StatusBadRequest},
+		{name: "edge on event webhook", service: triggersrepo.ServiceEvent, existingMode: level, fireMode: &edge, wantStatus: fiber.StatusBadRequest},
+		{name: "unknown fire mode", service: triggersrepo.ServiceSignal, existingMode: level, fireMode: &invalid, wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:             triggerID,
+				Service:        tt.service,
+				MetricName:     "vss.speed",
+				Condition:      "valueNumber > 55",
+				Status:         "enabled",
+				FireMode:       tt.existingMode,
+				ClearCondition: null.NewString(tt.existingClear, tt.existingClear != ""),
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						assert.Equal(t, tt.wantFireMode, trigger.FireMode)
+						assert.Equal(t, tt.wantClearCondition, trigger.ClearCondition.String)
+						assert.Equal(t, tt.wantClearCondition != "", trigger.ClearCondition.Valid)
+						return nil
+					})
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(UpdateWebhookRequest{FireMode: tt.fireMode, ClearCondition: tt.clearCondition})
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_UpdateWebhookPreviousScope(t *testing.T) {
+	t.Parallel()
+
+	lastFired := triggersrepo.PreviousScopeLastFiredByThisTrigger
+	reset := ""
+	invalid := "lastSeen"
+	tests := []struct {
+		name              string
+		service           string
+		previousScope     *string
+		wantStatus        int
+		wantPreviousScope string
+	}{
+		{name: "last fired by this trigger", service: triggersrepo.ServiceSignal, previousScope: &lastFired, wantStatus: fiber.StatusOK, wantPreviousScope: lastFired},
+		{name: "last fired on event webhook", service: triggersrepo.ServiceEvent, previousScope: &lastFired, wantStatus: fiber.StatusOK, wantPreviousScope: lastFired},
+		{name: "empty resets to last observed", service: triggersrepo.ServiceSignal, previousScope: &reset, wantStatus: fiber.StatusOK, wantPreviousScope: triggersrepo.PreviousScopeLastObserved},
+		{name: "last fired on absence webhook", service: triggersrepo.ServiceAbsence, previousScope: &lastFired, wantStatus: fiber.StatusBadRequest},
+		{name: "unknown previous scope", service: triggersrepo.ServiceSignal, previousScope: &invalid, wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:            triggerID,
+				Service:       tt.service,
+				MetricName:    "vss.speed",
+				Condition:     "valueNumber > 55",
+				Status:        "enabled",
+				FireMode:      triggersrepo.FireModeLevel,
+				PreviousScope: triggersrepo.PreviousScopeLastFiredForMetric,
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						assert.Equal(t, tt.wantPreviousScope, trigger.PreviousScope)
+						return nil
+					})
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(UpdateWebhookRequest{PreviousScope: tt.previousScope})
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_UpdateWebhookRateLimit(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(v int) *int { return &v }
+	tests := []struct {
+		name                 string
+		service              string
+		maxFirings           *int
+		globalMaxFirings     *int
+		firingWindow         *int
+		wantStatus           int
+		wantMaxFirings       int
+		wantGlobalMaxFirings int
+		wantFiringWindow     int
+	}{
+		{name: "vehicle limit", service: triggersrepo.ServiceSignal, maxFirings: intPtr(5), firingWindow: intPtr(3600), wantStatus: fiber.StatusOK, wantMaxFirings: 5, wantFiringWindow: 3600},
+		{name: "global limit", service: triggersrepo.ServiceEvent, globalMaxFirings: intPtr(100), firingWindow: intPtr(60), wantStatus: fiber.StatusOK, wantMaxFirings: 3, wantGlobalMaxFirings: 100, wantFiringWindow: 60},
+		{name: "zero disables the limit", service: triggersrepo.ServiceSignal, maxFirings: intPtr(0), wantStatus: fiber.StatusOK, wantFiringWindow: 600},
+		{name: "limit without window", service: triggersrepo.ServiceSignal, globalMaxFirings: intPtr(100), firingWindow: intPtr(0), wantStatus: fiber.StatusBadRequest},
+		{name: "negative limit", service: triggersrepo.ServiceSignal, maxFirings: intPtr(-1), wantStatus: fiber.StatusBadRequest},
+		{name: "limit too high", service: triggersrepo.ServiceSignal, maxFirings: intPtr(maxMaxFirings + 1), wantStatus: fiber.StatusBadRequest},
+		{name: "window too long", service: triggersrepo.ServiceSignal, firingWindow: intPtr(maxFiringWindow + 1), wantStatus: fiber.StatusBadRequest},
+		{name: "limit on absence webhook", service: triggersrepo.ServiceAbsence, maxFirings: intPtr(5), firingWindow: intPtr(3600), wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:           triggerID,
+				Service:      tt.service,
+				MetricName:   "vss.speed",
+				Condition:    "valueNumber > 55",
+				Status:       "enabled",
+				FireMode:     triggersrepo.FireModeLevel,
+				MaxFirings:   3,
+				FiringWindow: 600,
+			}
+			if tt.service == triggersrepo.ServiceAbsence {
+				existingTrigger.MaxFirings = 0
+				existingTrigger.FiringWindow = 0
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						assert.Equal(t, tt.wantMaxFirings, trigger.MaxFirings)
+						assert.Equal(t, tt.wantGlobalMaxFirings, trigger.GlobalMaxFirings)
+						assert.Equal(t, tt.wantFiringWindow, trigger.FiringWindow)
+						return nil
+					})
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(UpdateWebhookRequest{
+				MaxFirings:       tt.maxFirings,
+				GlobalMaxFirings: tt.globalMaxFirings,
+				FiringWindow:     tt.firingWindow,
+			})
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_UpdateWebhookSchedule(t *testing.T) {
+	t.Parallel()
+
+	afterHours := &Schedule{
+		Timezone: "America/New_York",
+		Windows:  []ScheduleWindow{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "20:00", End: "06:00"}},
+	}
+	tests := []struct {
+		name         string
+		schedule     *Schedule
+		wantStatus   int
+		wantSchedule string
+	}{
+		{name: "after hours", schedule: afterHours, wantStatus: fiber.StatusOK, wantSchedule: `{"timezone":"America/New_York","windows":[{"days":["mon","tue","wed","thu","fri"],"start":"20:00","end":"06:00"}]}`},
+		{name: "blackout dates only", schedule: &Schedule{Timezone: "UTC", BlackoutDates: []string{"2025-12-25"}}, wantStatus: fiber.StatusOK, wantSchedule: `{"timezone":"UTC","blackoutDates":["2025-12-25"]}`},
+		{name: "empty schedule removes it", schedule: &Schedule{}, wantStatus: fiber.StatusOK},
+		{name: "unknown timezone", schedule: &Schedule{Timezone: "Mars/Olympus"}, wantStatus: fiber.StatusBadRequest},
+		{name: "window without timezone", schedule: &Schedule{Windows: afterHours.Windows}, wantStatus: fiber.StatusBadRequest},
+		{name: "invalid window", schedule: &Schedule{Timezone: "UTC", Windows: []ScheduleWindow{{Start: "8pm", End: "06:00"}}}, wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:         triggerID,
+				Service:    triggersrepo.ServiceSignal,
+				MetricName: "vss.speed",
+				Condition:  "valueNumber > 55",
+				Status:     "enabled",
+				FireMode:   triggersrepo.FireModeLevel,
+				Schedule:   null.JSONFrom([]byte(`{"timezone":"UTC"}`)),
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						if tt.wantSchedule == "" {
+							assert.False(t, trigger.Schedule.Valid)
+							return nil
+						}
+						assert.JSONEq(t, tt.wantSchedule, string(trigger.Schedule.JSON))
+						return nil
+					})
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(UpdateWebhookRequest{Schedule: tt.schedule})
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_UpdateWebhookParams(t *testing.T) {
+	t.Parallel()
+
+	maxSpeed := "valueNumber > params.max"
+	tests := []struct {
+		name       string
+		request    UpdateWebhookRequest
+		wantStatus int
+		wantParams string
+	}{
+		{name: "new default", request: UpdateWebhookRequest{Params: map[string]any{"limit": 70}}, wantStatus: fiber.StatusOK, wantParams: `{"limit":70}`},
+		{name: "added param", request: UpdateWebhookRequest{Params: map[string]any{"limit": 55, "zone": "north"}}, wantStatus: fiber.StatusOK, wantParams: `{"limit":55,"zone":"north"}`},
+		{name: "condition with its param", request: UpdateWebhookRequest{Condition: &maxSpeed, Params: map[string]any{"max": 90}}, wantStatus: fiber.StatusOK, wantParams: `{"max":90}`},
+		{name: "removing a param the condition reads", request: UpdateWebhookRequest{Params: map[string]any{}}, wantStatus: fiber.StatusBadRequest},
+		{name: "condition reading a param without default", request: UpdateWebhookRequest{Condition: &maxSpeed}, wantStatus: fiber.StatusBadRequest},
+		{name: "invalid value", request: UpdateWebhookRequest{Params: map[string]any{"limit": []int{1}}}, wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:         triggerID,
+				Service:    triggersrepo.ServiceSignal,
+				MetricName: "vss.speed",
+				Condition:  "valueNumber > params.limit",
+				Status:     "enabled",
+				FireMode:   triggersrepo.FireModeLevel,
+				Params:     null.JSONFrom([]byte(`{"limit":55}`)),
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						assert.JSONEq(t, tt.wantParams, string(trigger.Params.JSON))
+						return nil
+					})
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_UpdateWebhookSubscriptionMode(t *testing.T) {
+	t.Parallel()
+
+	allShared := triggersrepo.SubscriptionModeAllShared
+	explicit := triggersrepo.SubscriptionModeExplicit
+	invalid := "some"
+	condition := "valueNumber > 70"
+	tests := []struct {
+		name          string
+		currentMode   string
+		request       UpdateWebhookRequest
+		wantStatus    int
+		wantMode      string
+		wantReconcile bool
+	}{
+		{name: "switch to allShared", currentMode: explicit, request: UpdateWebhookRequest{SubscriptionMode: &allShared}, wantStatus: fiber.StatusOK, wantMode: allShared, wantReconcile: true},
+		{name: "switch to explicit", currentMode: allShared, request: UpdateWebhookRequest{SubscriptionMode: &explicit}, wantStatus: fiber.StatusOK, wantMode: explicit},
+		{name: "condition of allShared webhook", currentMode: allShared, request: UpdateWebhookRequest{Condition: &condition}, wantStatus: fiber.StatusOK, wantMode: allShared, wantReconcile: true},
+		{name: "condition of explicit webhook", currentMode: explicit, request: UpdateWebhookRequest{Condition: &condition}, wantStatus: fiber.StatusOK, wantMode: explicit},
+		{name: "invalid mode", currentMode: explicit, request: UpdateWebhookRequest{SubscriptionMode: &invalid}, wantStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			devLicense := common.HexToAddress("0x1234567890abcdef")
+			app.Use(tokenInjector(devLicense))
+			app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
+			triggerID := uuid.New().String()
+			existingTrigger := &models.Trigger{
+				ID:               triggerID,
+				Service:          triggersrepo.ServiceSignal,
+				MetricName:       "vss.speed",
+				Condition:        "valueNumber > 55",
+				Status:           "enabled",
+				FireMode:         triggersrepo.FireModeLevel,
+				SubscriptionMode: tt.currentMode,
+			}
+
+			mockRepo.EXPECT().
+				GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+				Return(existingTrigger, nil)
+			if tt.wantStatus == fiber.StatusOK {
+				mockRepo.EXPECT().
+					UpdateTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
+						assert.Equal(t, tt.wantMode, trigger.SubscriptionMode)
+						return nil
+					})
+				if tt.wantReconcile {
+					mockRepo.EXPECT().RequestTriggerReconciliation(gomock.Any(), triggerID).Return(nil)
+				}
+				mockCache.EXPECT().ReplaceTrigger(gomock.Any(), gomock.Any())
+			}
+
+			body, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookController_DeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful delete", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/webhooks/:webhookId", controller.DeleteWebhook)
+
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+			}, nil).
+			Times(1)
+
+		mockRepo.EXPECT().
+			DeleteTrigger(gomock.Any(), triggerID, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockCache.EXPECT().
+			RemoveTrigger(triggerID).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+triggerID, nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response GenericResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "Webhook deleted successfully", response.Message)
+	})
+}
+
+func TestWebhookController_RotateWebhookSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default overlap", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/secret", controller.RotateWebhookSecret)
+
+		expiresAt := time.Now().Add(defaultSecretOverlap).UTC().Truncate(time.Second)
+		mockRepo.EXPECT().
+			RotateTriggerSecret(gomock.Any(), triggerID, devLicense, defaultSecretOverlap).
+			Return(&models.Trigger{
+				ID:                             triggerID,
+				SigningSecret:                  "whsec_new",
+				PreviousSigningSecret:          null.StringFrom("whsec_old"),
+				PreviousSigningSecretExpiresAt: null.TimeFrom(expiresAt),
+			}, nil).
+			Times(1)
+
+		mockCache.EXPECT().
+			ReplaceTrigger(gomock.Any(), gomock.Any()).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/secret", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response RotateWebhookSecretResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, triggerID, response.ID)
+		assert.Equal(t, "whsec_new", response.SigningSecret)
+		require.NotNil(t, response.PreviousSecretExpiresAt)
+		assert.True(t, expiresAt.Equal(*response.PreviousSecretExpiresAt))
+	})
+
+	t.Run("immediate revocation", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/secret", controller.RotateWebhookSecret)
+
+		mockRepo.EXPECT().
+			RotateTriggerSecret(gomock.Any(), triggerID, devLicense, time.Duration(0)).
+			Return(&models.Trigger{ID: triggerID, SigningSecret: "whsec_new"}, nil).
+			Times(1)
+
+		mockCache.EXPECT().
+			ReplaceTrigger(gomock.Any(), gomock.Any()).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/secret", bytes.NewReader([]byte(`{"overlapSeconds":0}`)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response RotateWebhookSecretResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "whsec_new", response.SigningSecret)
+		assert.Nil(t, response.PreviousSecretExpiresAt)
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/secret", controller.RotateWebhookSecret)
+
+		mockRepo.EXPECT().
+			RotateTriggerSecret(gomock.Any(), triggerID, devLicense, defaultSecretOverlap).
+			Return(nil, richerrors.Error{
+				ExternalMsg: "Webhook not found",
+				Err:         sql.ErrNoRows,
+				Code:        http.StatusNotFound,
+			}).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/secret", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("overlap too long", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/secret", controller.RotateWebhookSecret)
+
+		body := fmt.Sprintf(`{"overlapSeconds":%d}`, int(maxSecretOverlap.Seconds())+1)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/secret", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestWebhookController_SnoozeWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("snooze", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/snooze", controller.SnoozeWebhook)
+
+		until := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+		mockRepo.EXPECT().
+			SnoozeTrigger(gomock.Any(), triggerID, devLicense, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ common.Address, snoozedUntil null.Time) (*models.Trigger, error) {
+				assert.True(t, snoozedUntil.Valid)
+				assert.True(t, until.Equal(snoozedUntil.Time))
+				return &models.Trigger{ID: triggerID, SnoozedUntil: snoozedUntil}, nil
+			}).
+			Times(1)
+
+		mockCache.EXPECT().
+			ReplaceTrigger(gomock.Any(), gomock.Any()).
+			Times(1)
+
+		body := fmt.Sprintf(`{"until":%q}`, until.Format(time.RFC3339))
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/snooze", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response GenericResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "Webhook snoozed until "+until.Format(time.RFC3339), response.Message)
+	})
+
+	t.Run("unsnooze", func(t *testing.T) {
+		controller, mockRepo, mockCache, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/webhooks/:webhookId/snooze", controller.UnsnoozeWebhook)
+
+		mockRepo.EXPECT().
+			SnoozeTrigger(gomock.Any(), triggerID, devLicense, null.Time{}).
+			Return(&models.Trigger{ID: triggerID}, nil).
+			Times(1)
+
+		mockCache.EXPECT().
+			ReplaceTrigger(gomock.Any(), gomock.Any()).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+triggerID+"/snooze", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("invalid until", func(t *testing.T) {
+		for name, until := range map[string]time.Time{
+			"missing":     {},
+			"in the past": time.Now().Add(-time.Minute),
+			"too far":     time.Now().Add(maxPause + time.Hour),
+		} {
+			t.Run(name, func(t *testing.T) {
+				controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+				triggerID := uuid.New().String()
+				app := newApp()
+				devLicense := common.HexToAddress("0x1234567890abcdef")
+				app.Use(tokenInjector(devLicense))
+				app.Post("/webhooks/:webhookId/snooze", controller.SnoozeWebhook)
+
+				body := fmt.Sprintf(`{"until":%q}`, until.Format(time.RFC3339))
+				req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/snooze", bytes.NewReader([]byte(body)))
+				req.Header.Set("Content-Type", "application/json")
+
+				resp, err := app.Test(req)
+				require.NoError(t, err)
+				defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Delete("/webhooks/:webhookId/snooze", controller.UnsnoozeWebhook)
+
+		mockRepo.EXPECT().
+			SnoozeTrigger(gomock.Any(), triggerID, devLicense, null.Time{}).
+			Return(nil, richerrors.Error{
+				ExternalMsg: "Webhook not found",
+				Err:         sql.ErrNoRows,
+				Code:        http.StatusNotFound,
+			}).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+triggerID+"/snooze", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestWebhookController_ListDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful list", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		deadLetterID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Get("/webhooks/:webhookId/dead-letters", controller.ListDeadLetters)
+
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+			}, nil).
+			Times(1)
+
+		mockRepo.EXPECT().
+			GetDeadLettersByTriggerID(gomock.Any(), triggerID).
+			Return(models.WebhookDeadLetterSlice{
+				{
+					ID:        deadLetterID,
+					TriggerID: triggerID,
+					AssetDid:  "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1",
+					Payload:   []byte(`{"id":"` + deadLetterID + `"}`),
+					Attempts:  6,
+					LastError: null.StringFrom("webhook returned status 503"),
+				},
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/"+triggerID+"/dead-letters", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
 
+		var response []DeadLetterView
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		require.Len(t, response, 1)
+		assert.Equal(t, deadLetterID, response[0].ID)
+		assert.Equal(t, 6, response[0].Attempts)
+		assert.Equal(t, "webhook returned status 503", response[0].LastError)
+		assert.JSONEq(t, `{"id":"`+deadLetterID+`"}`, string(response[0].Payload))
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
 		app.Use(tokenInjector(devLicense))
-		app.Post("/webhooks", controller.RegisterWebhook)
-
-		// Setup test server that returns wrong token
-		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = fmt.Fprint(w, "wrong-token")
-		}))
-		defer testServer.Close()
+		app.Get("/webhooks/:webhookId/dead-letters", controller.ListDeadLetters)
 
-		payload := RegisterWebhookRequest{
-			Service:           triggersrepo.ServiceSignal,
-			MetricName:        "vss.speed",
-			Condition:         "valueNumber > 55",
-			CoolDownPeriod:    30,
-			TargetURL:         testServer.URL,
-			Status:            "enabled",
-			VerificationToken: "expected-token",
-		}
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(nil, sql.ErrNoRows).
+			Times(1)
 
-		body, _ := json.Marshal(payload)
-		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/"+triggerID+"/dead-letters", nil)
 
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		defer resp.Body.Close() //nolint:errcheck // fine for tests
 
-		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 	})
 }
 
-func TestWebhookController_ListWebhooks(t *testing.T) {
+func TestWebhookController_ReplayDeadLetters(t *testing.T) {
 	t.Parallel()
 
-	t.Run("successful list", func(t *testing.T) {
-		controller, mockRepo, _ := newWebhookControllerAndMocks(t)
+	t.Run("replay selected", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
 
+		triggerID := uuid.New().String()
+		deadLetterID := uuid.New().String()
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
 		app.Use(tokenInjector(devLicense))
-		app.Get("/webhooks", controller.ListWebhooks)
+		app.Post("/webhooks/:webhookId/dead-letters/replay", controller.ReplayDeadLetters)
 
-		triggers := []*models.Trigger{
-			{
-				ID:             "trigger-1",
-				Service:        triggersrepo.ServiceSignal,
-				MetricName:     "vss.speed",
-				Condition:      "valueNumber > 55",
-				TargetURI:      "https://example.com/webhook",
-				Status:         "enabled",
-				Description:    null.StringFrom("Speed alert"),
-				CooldownPeriod: 30,
-				DisplayName:    "Speed Alert",
-				FailureCount:   0,
-			},
-		}
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Status:                  triggersrepo.StatusEnabled,
+			}, nil).
+			Times(1)
 
 		mockRepo.EXPECT().
-			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
-			Return(triggers, nil).
+			ReplayDeadLetters(gomock.Any(), triggerID, []string{deadLetterID}).
+			Return(int64(1), nil).
 			Times(1)
 
-		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		body := fmt.Sprintf(`{"ids":[%q]}`, deadLetterID)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/dead-letters/replay", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
 
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		defer resp.Body.Close() //nolint:errcheck // fine for tests
 
-		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-		var webhooks []WebhookView
-		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		var response ReplayDeadLettersResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, webhooks, 1)
-		assert.Equal(t, "trigger-1", webhooks[0].ID)
-		assert.Equal(t, "vss.speed", webhooks[0].MetricName)
+		assert.Equal(t, int64(1), response.Replayed)
 	})
 
-	t.Run("empty list", func(t *testing.T) {
-		controller, mockRepo, _ := newWebhookControllerAndMocks(t)
+	t.Run("replay all", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
 
+		triggerID := uuid.New().String()
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
 		app.Use(tokenInjector(devLicense))
-		app.Get("/webhooks", controller.ListWebhooks)
+		app.Post("/webhooks/:webhookId/dead-letters/replay", controller.ReplayDeadLetters)
 
 		mockRepo.EXPECT().
-			GetTriggersByDeveloperLicense(gomock.Any(), gomock.Any()).
-			Return([]*models.Trigger{}, nil).
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Status:                  triggersrepo.StatusEnabled,
+			}, nil).
 			Times(1)
 
-		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		mockRepo.EXPECT().
+			ReplayDeadLetters(gomock.Any(), triggerID, gomock.Nil()).
+			Return(int64(3), nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/dead-letters/replay", nil)
 
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		defer resp.Body.Close() //nolint:errcheck // fine for tests
 
-		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-		var webhooks []WebhookView
-		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		var response ReplayDeadLettersResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Len(t, webhooks, 0)
+		assert.Equal(t, int64(3), response.Replayed)
+	})
+
+	t.Run("webhook not enabled", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/dead-letters/replay", controller.ReplayDeadLetters)
+
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+				Status:                  triggersrepo.StatusFailed,
+			}, nil).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/dead-letters/replay", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid dead letter id", func(t *testing.T) {
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/dead-letters/replay", controller.ReplayDeadLetters)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/dead-letters/replay", bytes.NewReader([]byte(`{"ids":["not-a-uuid"]}`)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 	})
 }
 
-func TestWebhookController_UpdateWebhook(t *testing.T) {
+func TestWebhookController_ListDeliveries(t *testing.T) {
 	t.Parallel()
 
-	t.Run("successful update", func(t *testing.T) {
-		controller, mockRepo, mockCache := newWebhookControllerAndMocks(t)
+	assetDid := "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1"
 
+	t.Run("first page with next cursor", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
 		app.Use(tokenInjector(devLicense))
-		app.Put("/webhooks/:webhookId", controller.UpdateWebhook)
-		triggerID := uuid.New().String()
-		existingTrigger := &models.Trigger{
-			ID:             triggerID,
-			Service:        triggersrepo.ServiceSignal,
-			MetricName:     "vss.speed",
-			Condition:      "valueNumber > 55",
-			TargetURI:      "https://example.com/webhook",
-			Status:         "enabled",
-			CooldownPeriod: 30,
-			FailureCount:   5,
-		}
-
-		newCondition := "valueNumber > 60"
-		payload := UpdateWebhookRequest{
-			Condition: &newCondition,
-		}
+		app.Get("/webhooks/:webhookId/deliveries", controller.ListDeliveries)
 
 		mockRepo.EXPECT().
 			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
-			Return(existingTrigger, nil).
+			Return(&models.Trigger{
+				ID:                      triggerID,
+				DeveloperLicenseAddress: devLicense.Bytes(),
+			}, nil).
 			Times(1)
 
+		newer := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		deliveries := models.WebhookDeliverySlice{
+			{
+				ID:            uuid.New().String(),
+				DeliveryID:    uuid.New().String(),
+				TriggerID:     triggerID,
+				AssetDid:      assetDid,
+				Attempt:       2,
+				StatusCode:    null.IntFrom(http.StatusServiceUnavailable),
+				LatencyMillis: 120,
+				ErrorMessage:  null.StringFrom("webhook returned status 503"),
+				CreatedAt:     newer,
+			},
+			{
+				ID:         uuid.New().String(),
+				DeliveryID: uuid.New().String(),
+				TriggerID:  triggerID,
+				AssetDid:   assetDid,
+				Attempt:    1,
+				CreatedAt:  newer.Add(-time.Minute),
+			},
+		}
 		mockRepo.EXPECT().
-			UpdateTrigger(gomock.Any(), gomock.Any()).
-			DoAndReturn(func(ctx context.Context, trigger *models.Trigger) error {
-				assert.Equal(t, newCondition, trigger.Condition)
-				assert.Equal(t, 0, trigger.FailureCount) // Should be reset
-				return nil
+			GetWebhookDeliveries(gomock.Any(), triggerID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, filter triggersrepo.DeliveryFilter) (models.WebhookDeliverySlice, error) {
+				assert.Equal(t, assetDid, filter.AssetDid)
+				assert.True(t, filter.From.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+				assert.True(t, filter.To.IsZero())
+				assert.Nil(t, filter.Cursor)
+				assert.Equal(t, 2, filter.Limit)
+				return deliveries, nil
 			}).
 			Times(1)
 
-		mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
-			Times(1)
-
-		body, _ := json.Marshal(payload)
-		req := httptest.NewRequest(http.MethodPut, "/webhooks/"+triggerID, bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/"+triggerID+"/deliveries?limit=1&from=2025-06-01T00:00:00Z&assetDid="+assetDid, nil)
 
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		defer resp.Body.Close() //nolint:errcheck // fine for tests
 
-		if !assert.Equal(t, fiber.StatusOK, resp.StatusCode) {
-			body, _ := io.ReadAll(resp.Body)
-			t.Log(string(body))
-			return
-		}
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-		var response UpdateWebhookResponse
+		var response ListDeliveriesResponse
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Equal(t, triggerID, response.ID)
-		assert.Equal(t, "Webhook updated successfully", response.Message)
+		require.Len(t, response.Deliveries, 1)
+		assert.Equal(t, deliveries[0].ID, response.Deliveries[0].ID)
+		assert.Equal(t, 2, response.Deliveries[0].Attempt)
+		require.NotNil(t, response.Deliveries[0].StatusCode)
+		assert.Equal(t, http.StatusServiceUnavailable, *response.Deliveries[0].StatusCode)
+		assert.Equal(t, 120, response.Deliveries[0].LatencyMs)
+		assert.Equal(t, "webhook returned status 503", response.Deliveries[0].Error)
+
+		cursor, err := decodeDeliveryCursor(response.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, deliveries[0].ID, cursor.ID)
+		assert.True(t, cursor.CreatedAt.Equal(newer))
 	})
-}
-
-func TestWebhookController_DeleteWebhook(t *testing.T) {
-	t.Parallel()
 
-	t.Run("successful delete", func(t *testing.T) {
-		controller, mockRepo, mockCache := newWebhookControllerAndMocks(t)
+	t.Run("last page has no cursor", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
 
 		triggerID := uuid.New().String()
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
 		app.Use(tokenInjector(devLicense))
-		app.Delete("/webhooks/:webhookId", controller.DeleteWebhook)
+		app.Get("/webhooks/:webhookId/deliveries", controller.ListDeliveries)
 
 		mockRepo.EXPECT().
 			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
@@ -393,27 +1866,182 @@ func TestWebhookController_DeleteWebhook(t *testing.T) {
 			}, nil).
 			Times(1)
 
+		cursor := triggersrepo.DeliveryCursor{CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 123000, time.UTC), ID: uuid.New().String()}
 		mockRepo.EXPECT().
-			DeleteTrigger(gomock.Any(), triggerID, gomock.Any()).
-			Return(nil).
+			GetWebhookDeliveries(gomock.Any(), triggerID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, filter triggersrepo.DeliveryFilter) (models.WebhookDeliverySlice, error) {
+				require.NotNil(t, filter.Cursor)
+				assert.Equal(t, cursor.ID, filter.Cursor.ID)
+				assert.True(t, cursor.CreatedAt.Equal(filter.Cursor.CreatedAt))
+				assert.Equal(t, defaultDeliveryLimit+1, filter.Limit)
+				return models.WebhookDeliverySlice{}, nil
+			}).
 			Times(1)
 
-		mockCache.EXPECT().
-			ScheduleRefresh(gomock.Any()).
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/"+triggerID+"/deliveries?cursor="+encodeDeliveryCursor(cursor), nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response ListDeliveriesResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Empty(t, response.Deliveries)
+		assert.Empty(t, response.NextCursor)
+	})
+
+	t.Run("invalid query parameters", func(t *testing.T) {
+		for _, query := range []string{"limit=0", "limit=501", "from=yesterday", "assetDid=vehicle", "cursor=bm9wZQ"} {
+			controller, _, _, _ := newWebhookControllerAndMocks(t)
+
+			app := newApp()
+			app.Use(tokenInjector(common.HexToAddress("0x1234567890abcdef")))
+			app.Get("/webhooks/:webhookId/deliveries", controller.ListDeliveries)
+
+			req := httptest.NewRequest(http.MethodGet, "/webhooks/"+uuid.New().String()+"/deliveries?"+query, nil)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			resp.Body.Close() //nolint:errcheck // fine for tests
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestWebhookController_TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signal webhook answered", func(t *testing.T) {
+		controller, mockRepo, _, mockSender := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/test", controller.TestWebhook)
+
+		trigger := &models.Trigger{
+			ID:                      triggerID,
+			Service:                 triggersrepo.ServiceSignal,
+			MetricName:              "vss.speed",
+			Condition:               "valueNumber > 20",
+			Status:                  triggersrepo.StatusDisabled,
+			DeveloperLicenseAddress: devLicense.Bytes(),
+		}
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(trigger, nil).
 			Times(1)
 
-		req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+triggerID, nil)
+		mockSender.EXPECT().
+			SendTestWebhook(gomock.Any(), trigger, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[WebhookPayload]) (DeliveryResult, error) {
+				assert.Equal(t, triggerID, payload.Data.WebhookId)
+				assert.Equal(t, "valueNumber > 20", payload.Data.Condition)
+				require.NotNil(t, payload.Data.Signal)
+				assert.Nil(t, payload.Data.Event)
+				assert.Equal(t, "speed", payload.Data.Signal.Name)
+				assert.Equal(t, signals.NumberType, payload.Data.Signal.ValueType)
+				assert.IsType(t, float64(0), payload.Data.Signal.Value)
+				return DeliveryResult{StatusCode: http.StatusOK, Latency: 35 * time.Millisecond}, nil
+			}).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/test", nil)
 
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		defer resp.Body.Close() //nolint:errcheck // fine for tests
 
-		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
 
-		var response GenericResponse
+		var response TestWebhookResponse
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		require.NoError(t, err)
-		assert.Equal(t, "Webhook deleted successfully", response.Message)
+		assert.True(t, response.Success)
+		require.NotNil(t, response.StatusCode)
+		assert.Equal(t, http.StatusOK, *response.StatusCode)
+		assert.Equal(t, int64(35), response.LatencyMs)
+		assert.Empty(t, response.Error)
+
+		var sent cloudevent.CloudEvent[WebhookPayload]
+		require.NoError(t, json.Unmarshal(response.Payload, &sent))
+		assert.Equal(t, triggerID, sent.Data.WebhookId)
+	})
+
+	t.Run("event webhook rejected", func(t *testing.T) {
+		controller, mockRepo, _, mockSender := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		devLicense := common.HexToAddress("0x1234567890abcdef")
+		app.Use(tokenInjector(devLicense))
+		app.Post("/webhooks/:webhookId/test", controller.TestWebhook)
+
+		trigger := &models.Trigger{
+			ID:                      triggerID,
+			Service:                 triggersrepo.ServiceEvent,
+			MetricName:              "behavior.harshBraking",
+			Status:                  triggersrepo.StatusEnabled,
+			DeveloperLicenseAddress: devLicense.Bytes(),
+		}
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(trigger, nil).
+			Times(1)
+
+		mockSender.EXPECT().
+			SendTestWebhook(gomock.Any(), trigger, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[WebhookPayload]) (DeliveryResult, error) {
+				require.NotNil(t, payload.Data.Event)
+				assert.Nil(t, payload.Data.Signal)
+				assert.Equal(t, "behavior.harshBraking", payload.Data.Event.Name)
+				return DeliveryResult{StatusCode: http.StatusBadRequest, Latency: 10 * time.Millisecond},
+					errors.New("webhook returned status code 400: unexpected field")
+			}).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/test", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response TestWebhookResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.False(t, response.Success)
+		require.NotNil(t, response.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, *response.StatusCode)
+		assert.Equal(t, "webhook returned status code 400: unexpected field", response.Error)
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		controller, mockRepo, _, _ := newWebhookControllerAndMocks(t)
+
+		triggerID := uuid.New().String()
+		app := newApp()
+		app.Use(tokenInjector(common.HexToAddress("0x1234567890abcdef")))
+		app.Post("/webhooks/:webhookId/test", controller.TestWebhook)
+
+		mockRepo.EXPECT().
+			GetTriggerByIDAndDeveloperLicense(gomock.Any(), triggerID, gomock.Any()).
+			Return(nil, sql.ErrNoRows).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/"+triggerID+"/test", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // fine for tests
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 	})
 }
 
@@ -421,7 +2049,7 @@ func TestWebhookController_GetSignalNames(t *testing.T) {
 	t.Parallel()
 
 	t.Run("successful get signal names", func(t *testing.T) {
-		controller, _, _ := newWebhookControllerAndMocks(t)
+		controller, _, _, _ := newWebhookControllerAndMocks(t)
 
 		app := newApp()
 		devLicense := common.HexToAddress("0x1234567890abcdef")
@@ -469,11 +2097,12 @@ func newApp() *fiber.App {
 	return app
 }
 
-func newWebhookControllerAndMocks(t *testing.T) (*WebhookController, *MockRepository, *MockWebhookCache) {
+func newWebhookControllerAndMocks(t *testing.T) (*WebhookController, *MockRepository, *MockWebhookCache, *MockWebhookSender) {
 	ctrl := gomock.NewController(t)
 	mockRepo := NewMockRepository(ctrl)
 	mockCache := NewMockWebhookCache(ctrl)
-	controller, err := NewWebhookController(mockRepo, mockCache)
+	mockSender := NewMockWebhookSender(ctrl)
+	controller, err := NewWebhookController(mockRepo, mockCache, mockSender)
 	require.NoError(t, err)
-	return controller, mockRepo, mockCache
+	return controller, mockRepo, mockCache, mockSender
 }
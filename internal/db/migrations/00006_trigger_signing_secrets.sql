@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Per-trigger secret used to HMAC-sign webhook deliveries.
+-- Existing triggers get a fresh random secret; owners can re-issue it through the API to learn its value.
+ALTER TABLE triggers ADD COLUMN signing_secret text;
+UPDATE triggers SET signing_secret = 'whsec_' || replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', '');
+ALTER TABLE triggers ALTER COLUMN signing_secret SET NOT NULL;
+
+-- After a rotation the previous secret keeps signing deliveries until it expires,
+-- so receivers can roll over to the new secret without rejecting requests.
+ALTER TABLE triggers ADD COLUMN previous_signing_secret text;
+ALTER TABLE triggers ADD COLUMN previous_signing_secret_expires_at timestamp with time zone;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE triggers DROP COLUMN previous_signing_secret_expires_at;
+ALTER TABLE triggers DROP COLUMN previous_signing_secret;
+ALTER TABLE triggers DROP COLUMN signing_secret;
+
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deliveries that failed with a retryable error and are waiting for another attempt.
+-- payload holds the CloudEvent exactly as it is sent so retries deliver the same event id.
+CREATE TABLE webhook_outbox (
+    id uuid NOT NULL,
+    trigger_id uuid NOT NULL,
+    asset_did text NOT NULL,
+    payload jsonb NOT NULL,
+    snapshot_data jsonb NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp with time zone NOT NULL,
+    locked_until timestamp with time zone,
+    last_error text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT webhook_outbox_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_outbox_trigger_id_fkey FOREIGN KEY (trigger_id) REFERENCES triggers(id)
+);
+
+-- Supports the worker query: entries due for an attempt, oldest first.
+CREATE INDEX idx_webhook_outbox_next_attempt_at ON webhook_outbox USING btree (next_attempt_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE webhook_outbox;
+
+-- +goose StatementEnd
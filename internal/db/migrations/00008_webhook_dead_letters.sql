@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deliveries that were given up on: retries exhausted, rejected by the endpoint, or skipped
+-- because the trigger had failed. Kept so they can be inspected and replayed.
+CREATE TABLE webhook_dead_letters (
+    id uuid NOT NULL,
+    trigger_id uuid NOT NULL,
+    asset_did text NOT NULL,
+    payload jsonb NOT NULL,
+    snapshot_data jsonb NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT webhook_dead_letters_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_dead_letters_trigger_id_fkey FOREIGN KEY (trigger_id) REFERENCES triggers(id)
+);
+
+CREATE INDEX idx_webhook_dead_letters_trigger_created ON webhook_dead_letters USING btree (trigger_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE webhook_dead_letters;
+
+-- +goose StatementEnd
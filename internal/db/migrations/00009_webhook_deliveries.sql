@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- One row per delivery attempt, successful or not. delivery_id is the CloudEvent id, so all attempts
+-- of the same firing share it. status_code is NULL when the endpoint did not answer.
+CREATE TABLE webhook_deliveries (
+    id uuid NOT NULL,
+    delivery_id uuid NOT NULL,
+    trigger_id uuid NOT NULL,
+    asset_did text NOT NULL,
+    attempt integer NOT NULL,
+    status_code integer,
+    latency_millis integer NOT NULL,
+    error_message text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_deliveries_trigger_id_fkey FOREIGN KEY (trigger_id) REFERENCES triggers(id)
+);
+
+-- Supports listing the deliveries of a webhook, optionally for one asset, newest first.
+CREATE INDEX idx_webhook_deliveries_trigger_created ON webhook_deliveries USING btree (trigger_id, created_at DESC, id DESC);
+CREATE INDEX idx_webhook_deliveries_trigger_asset_created ON webhook_deliveries USING btree (trigger_id, asset_did, created_at DESC, id DESC);
+-- Supports pruning deliveries past the retention period.
+CREATE INDEX idx_webhook_deliveries_created ON webhook_deliveries USING btree (created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE webhook_deliveries;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Number of seconds a signal condition must hold continuously before the trigger fires. 0 fires immediately.
+ALTER TABLE triggers ADD COLUMN sustain_for integer DEFAULT 0 NOT NULL;
+
+-- Evaluation state kept per trigger and vehicle. condition_true_since is the timestamp of the first signal
+-- of the current run of matching signals, and NULL while the condition does not hold.
+CREATE TABLE trigger_vehicle_state (
+    trigger_id uuid NOT NULL,
+    asset_did text NOT NULL,
+    condition_true_since timestamp with time zone,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT trigger_vehicle_state_pkey PRIMARY KEY (trigger_id, asset_did),
+    CONSTRAINT trigger_vehicle_state_trigger_id_fkey FOREIGN KEY (trigger_id) REFERENCES triggers(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE trigger_vehicle_state;
+ALTER TABLE triggers DROP COLUMN sustain_for;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- fire_mode is 'level' to fire on every matching signal, subject to the cooldown, or 'edge' to fire only when the
+-- condition turns from false to true. clear_condition optionally decides when an edge trigger is re-armed.
+ALTER TABLE triggers ADD COLUMN fire_mode text DEFAULT 'level' NOT NULL;
+ALTER TABLE triggers ADD COLUMN clear_condition text;
+
+-- Whether the condition held, taking the clear condition into account, for the last evaluated signal.
+ALTER TABLE trigger_vehicle_state ADD COLUMN last_condition_result boolean DEFAULT false NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE trigger_vehicle_state DROP COLUMN last_condition_result;
+ALTER TABLE triggers DROP COLUMN clear_condition;
+ALTER TABLE triggers DROP COLUMN fire_mode;
+
+-- +goose StatementEnd
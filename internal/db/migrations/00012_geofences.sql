@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Named polygons a developer license can refer to from signal conditions. geometry is the GeoJSON Polygon.
+CREATE TABLE geofences (
+    id uuid NOT NULL,
+    developer_license_address bytea NOT NULL,
+    name text NOT NULL,
+    geometry jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT geofences_pkey PRIMARY KEY (id),
+    CONSTRAINT geofences_devaddr_name_uniq UNIQUE (developer_license_address, name)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE geofences;
+
+-- +goose StatementEnd
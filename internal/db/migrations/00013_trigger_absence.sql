@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Number of seconds without a signal after which an absence trigger fires for a vehicle. Only used by absence triggers.
+ALTER TABLE triggers ADD COLUMN absent_for integer DEFAULT 0 NOT NULL;
+
+-- last_seen_at is the timestamp of the last signal an absence trigger saw for the vehicle, or when the vehicle was
+-- subscribed if it has not sent one since. absent_since is set when the trigger fires for the vehicle going silent
+-- and cleared when a signal arrives again.
+ALTER TABLE trigger_vehicle_state ADD COLUMN last_seen_at timestamp with time zone;
+ALTER TABLE trigger_vehicle_state ADD COLUMN absent_since timestamp with time zone;
+
+CREATE INDEX trigger_vehicle_state_last_seen_at_idx ON trigger_vehicle_state (last_seen_at)
+    WHERE last_seen_at IS NOT NULL AND absent_since IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX trigger_vehicle_state_last_seen_at_idx;
+ALTER TABLE trigger_vehicle_state DROP COLUMN absent_since;
+ALTER TABLE trigger_vehicle_state DROP COLUMN last_seen_at;
+ALTER TABLE triggers DROP COLUMN absent_for;
+
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Last observed signal or event of each vehicle and metric, written for every evaluation. Conditions read it as the
+-- previous value. observed_at is the timestamp of the signal or event.
+CREATE TABLE vehicle_metric_state (
+    asset_did text NOT NULL,
+    metric_name text NOT NULL,
+    snapshot_data jsonb NOT NULL,
+    observed_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT vehicle_metric_state_pkey PRIMARY KEY (asset_did, metric_name)
+);
+
+-- When the trigger last fired for the vehicle, used for the cooldown, and when it was last evaluated.
+ALTER TABLE trigger_vehicle_state ADD COLUMN last_triggered_at timestamp with time zone;
+ALTER TABLE trigger_vehicle_state ADD COLUMN last_evaluated_at timestamp with time zone;
+
+-- Carry over the cooldowns and previous values of the existing trigger logs.
+INSERT INTO trigger_vehicle_state (trigger_id, asset_did, last_triggered_at)
+SELECT trigger_id, asset_did, max(last_triggered_at)
+FROM trigger_logs
+GROUP BY trigger_id, asset_did
+ON CONFLICT (trigger_id, asset_did) DO UPDATE SET last_triggered_at = EXCLUDED.last_triggered_at;
+
+INSERT INTO vehicle_metric_state (asset_did, metric_name, snapshot_data, observed_at)
+SELECT DISTINCT ON (l.asset_did, t.metric_name) l.asset_did, t.metric_name, l.snapshot_data, l.last_triggered_at
+FROM trigger_logs l
+JOIN triggers t ON t.id = l.trigger_id
+WHERE t.service IN ('signals', 'events')
+ORDER BY l.asset_did, t.metric_name, l.last_triggered_at DESC;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE trigger_vehicle_state DROP COLUMN last_evaluated_at;
+ALTER TABLE trigger_vehicle_state DROP COLUMN last_triggered_at;
+DROP TABLE vehicle_metric_state;
+
+-- +goose StatementEnd
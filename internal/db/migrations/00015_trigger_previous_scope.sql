@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- previous_scope decides which value conditions read as the previous value: 'lastObserved' for the last value
+-- the vehicle sent, 'lastFiredByThisTrigger' for the value this trigger last fired for, or 'lastFiredForMetric'
+-- for the value any trigger of the same metric last fired for.
+ALTER TABLE triggers ADD COLUMN previous_scope text DEFAULT 'lastObserved' NOT NULL;
+
+-- The signal or event the trigger last fired for the vehicle.
+ALTER TABLE trigger_vehicle_state ADD COLUMN last_triggered_data jsonb;
+
+-- The signal or event any trigger of the metric last fired for the vehicle, and when.
+ALTER TABLE vehicle_metric_state ADD COLUMN fired_snapshot_data jsonb;
+ALTER TABLE vehicle_metric_state ADD COLUMN fired_at timestamp with time zone;
+
+-- Carry over the values of the existing trigger logs.
+UPDATE trigger_vehicle_state st
+SET last_triggered_data = l.snapshot_data
+FROM (
+    SELECT DISTINCT ON (trigger_id, asset_did) trigger_id, asset_did, snapshot_data
+    FROM trigger_logs
+    ORDER BY trigger_id, asset_did, last_triggered_at DESC
+) l
+WHERE l.trigger_id = st.trigger_id AND l.asset_did = st.asset_did;
+
+UPDATE vehicle_metric_state ms
+SET fired_snapshot_data = l.snapshot_data, fired_at = l.last_triggered_at
+FROM (
+    SELECT DISTINCT ON (l.asset_did, t.metric_name) l.asset_did, t.metric_name, l.snapshot_data, l.last_triggered_at
+    FROM trigger_logs l
+    JOIN triggers t ON t.id = l.trigger_id
+    WHERE t.service IN ('signals', 'events')
+    ORDER BY l.asset_did, t.metric_name, l.last_triggered_at DESC
+) l
+WHERE l.asset_did = ms.asset_did AND l.metric_name = ms.metric_name;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE vehicle_metric_state DROP COLUMN fired_at;
+ALTER TABLE vehicle_metric_state DROP COLUMN fired_snapshot_data;
+ALTER TABLE trigger_vehicle_state DROP COLUMN last_triggered_data;
+ALTER TABLE triggers DROP COLUMN previous_scope;
+
+-- +goose StatementEnd
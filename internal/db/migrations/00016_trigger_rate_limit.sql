@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A trigger fires at most max_firings times per vehicle, and at most global_max_firings times across all
+-- vehicles, within any firing_window seconds. 0 disables the respective cap.
+ALTER TABLE triggers ADD COLUMN max_firings integer DEFAULT 0 NOT NULL;
+ALTER TABLE triggers ADD COLUMN global_max_firings integer DEFAULT 0 NOT NULL;
+ALTER TABLE triggers ADD COLUMN firing_window integer DEFAULT 0 NOT NULL;
+
+-- JSON array of the times the trigger fired for the vehicle within its firing window, oldest first.
+ALTER TABLE trigger_vehicle_state ADD COLUMN recent_firings jsonb;
+
+-- One row per firing of a trigger with a global cap. Rows older than the firing window are deleted by the
+-- next firing of the trigger.
+CREATE TABLE trigger_firings (
+    id uuid NOT NULL,
+    trigger_id uuid NOT NULL,
+    asset_did text NOT NULL,
+    fired_at timestamp with time zone NOT NULL,
+    CONSTRAINT trigger_firings_pkey PRIMARY KEY (id),
+    CONSTRAINT trigger_firings_trigger_id_fkey FOREIGN KEY (trigger_id) REFERENCES triggers(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_trigger_firings_trigger_fired ON trigger_firings USING btree (trigger_id, fired_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE trigger_firings;
+ALTER TABLE trigger_vehicle_state DROP COLUMN recent_firings;
+ALTER TABLE triggers DROP COLUMN firing_window;
+ALTER TABLE triggers DROP COLUMN global_max_firings;
+ALTER TABLE triggers DROP COLUMN max_firings;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The weekly windows and blackout dates the trigger fires in, in an IANA time zone, as
+-- {"timezone": "...", "windows": [{"days": [...], "start": "HH:MM", "end": "HH:MM"}], "blackoutDates": [...]}.
+-- NULL for triggers that are always active.
+ALTER TABLE triggers ADD COLUMN schedule jsonb;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE triggers DROP COLUMN schedule;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The trigger does not fire for any vehicle until snoozed_until. NULL when it is not snoozed.
+ALTER TABLE triggers ADD COLUMN snoozed_until timestamp with time zone;
+
+-- The trigger does not fire for the vehicle of the subscription until muted_until. NULL when it is not muted.
+ALTER TABLE vehicle_subscriptions ADD COLUMN muted_until timestamp with time zone;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE vehicle_subscriptions DROP COLUMN muted_until;
+ALTER TABLE triggers DROP COLUMN snoozed_until;
+
+-- +goose StatementEnd
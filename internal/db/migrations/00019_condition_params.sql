@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Default values of the parameters the condition reads, e.g. {"limit": 120}. NULL when the trigger has none.
+ALTER TABLE triggers ADD COLUMN params jsonb;
+
+-- Values overriding the parameter defaults of the trigger for the vehicle of the subscription. NULL when the
+-- vehicle uses the defaults.
+ALTER TABLE vehicle_subscriptions ADD COLUMN params jsonb;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE vehicle_subscriptions DROP COLUMN params;
+ALTER TABLE triggers DROP COLUMN params;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- How the vehicles of the trigger are subscribed: 'explicit' through the API, or 'allShared' to keep the
+-- subscriptions in sync with the vehicles shared with the developer license.
+ALTER TABLE triggers ADD COLUMN subscription_mode text NOT NULL DEFAULT 'explicit';
+
+-- Outcome of the last reconciliation of an allShared trigger. reconciled_at is NULL until the trigger is
+-- reconciled, and is reset to NULL to reconcile it again right away.
+ALTER TABLE triggers ADD COLUMN reconciled_at timestamp with time zone;
+ALTER TABLE triggers ADD COLUMN reconciled_added integer NOT NULL DEFAULT 0;
+ALTER TABLE triggers ADD COLUMN reconciled_removed integer NOT NULL DEFAULT 0;
+ALTER TABLE triggers ADD COLUMN reconcile_error text;
+
+-- Other instances skip the trigger while it is being reconciled, until reconcile_locked_until.
+ALTER TABLE triggers ADD COLUMN reconcile_locked_until timestamp with time zone;
+
+CREATE INDEX idx_triggers_reconciled_at ON triggers (reconciled_at NULLS FIRST)
+    WHERE subscription_mode = 'allShared' AND status != 'deleted';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX idx_triggers_reconciled_at;
+ALTER TABLE triggers DROP COLUMN reconcile_locked_until;
+ALTER TABLE triggers DROP COLUMN reconcile_error;
+ALTER TABLE triggers DROP COLUMN reconciled_removed;
+ALTER TABLE triggers DROP COLUMN reconciled_added;
+ALTER TABLE triggers DROP COLUMN reconciled_at;
+ALTER TABLE triggers DROP COLUMN subscription_mode;
+
+-- +goose StatementEnd
@@ -4,11 +4,25 @@
 package models
 
 var TableNames = struct {
+	Geofences            string
 	TriggerLogs          string
+	TriggerVehicleState  string
 	Triggers             string
+	VehicleMetricState   string
 	VehicleSubscriptions string
+	WebhookDeadLetters   string
+	WebhookDeliveries    string
+	TriggerFirings       string
+	WebhookOutbox        string
 }{
+	Geofences:            "geofences",
 	TriggerLogs:          "trigger_logs",
+	TriggerVehicleState:  "trigger_vehicle_state",
 	Triggers:             "triggers",
+	VehicleMetricState:   "vehicle_metric_state",
 	VehicleSubscriptions: "vehicle_subscriptions",
+	WebhookDeadLetters:   "webhook_dead_letters",
+	WebhookDeliveries:    "webhook_deliveries",
+	TriggerFirings:       "trigger_firings",
+	WebhookOutbox:        "webhook_outbox",
 }
@@ -24,82 +24,182 @@ import (
 
 // Trigger is an object representing the database table.
 type Trigger struct {
-	ID                      string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Service                 string      `boil:"service" json:"service" toml:"service" yaml:"service"`
-	MetricName              string      `boil:"metric_name" json:"metric_name" toml:"metric_name" yaml:"metric_name"`
-	Condition               string      `boil:"condition" json:"condition" toml:"condition" yaml:"condition"`
-	TargetURI               string      `boil:"target_uri" json:"target_uri" toml:"target_uri" yaml:"target_uri"`
-	CooldownPeriod          int         `boil:"cooldown_period" json:"cooldown_period" toml:"cooldown_period" yaml:"cooldown_period"`
-	DeveloperLicenseAddress []byte      `boil:"developer_license_address" json:"developer_license_address" toml:"developer_license_address" yaml:"developer_license_address"`
-	CreatedAt               time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt               time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Status                  string      `boil:"status" json:"status" toml:"status" yaml:"status"`
-	Description             null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
-	FailureCount            int         `boil:"failure_count" json:"failure_count" toml:"failure_count" yaml:"failure_count"`
-	DisplayName             string      `boil:"display_name" json:"display_name" toml:"display_name" yaml:"display_name"`
+	ID                             string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Service                        string      `boil:"service" json:"service" toml:"service" yaml:"service"`
+	MetricName                     string      `boil:"metric_name" json:"metric_name" toml:"metric_name" yaml:"metric_name"`
+	Condition                      string      `boil:"condition" json:"condition" toml:"condition" yaml:"condition"`
+	TargetURI                      string      `boil:"target_uri" json:"target_uri" toml:"target_uri" yaml:"target_uri"`
+	CooldownPeriod                 int         `boil:"cooldown_period" json:"cooldown_period" toml:"cooldown_period" yaml:"cooldown_period"`
+	DeveloperLicenseAddress        []byte      `boil:"developer_license_address" json:"developer_license_address" toml:"developer_license_address" yaml:"developer_license_address"`
+	CreatedAt                      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt                      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Status                         string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Description                    null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
+	FailureCount                   int         `boil:"failure_count" json:"failure_count" toml:"failure_count" yaml:"failure_count"`
+	DisplayName                    string      `boil:"display_name" json:"display_name" toml:"display_name" yaml:"display_name"`
+	SigningSecret                  string      `boil:"signing_secret" json:"signing_secret" toml:"signing_secret" yaml:"signing_secret"`
+	PreviousSigningSecret          null.String `boil:"previous_signing_secret" json:"previous_signing_secret,omitempty" toml:"previous_signing_secret" yaml:"previous_signing_secret,omitempty"`
+	PreviousSigningSecretExpiresAt null.Time   `boil:"previous_signing_secret_expires_at" json:"previous_signing_secret_expires_at,omitempty" toml:"previous_signing_secret_expires_at" yaml:"previous_signing_secret_expires_at,omitempty"`
+	SustainFor                     int         `boil:"sustain_for" json:"sustain_for" toml:"sustain_for" yaml:"sustain_for"`
+	FireMode                       string      `boil:"fire_mode" json:"fire_mode" toml:"fire_mode" yaml:"fire_mode"`
+	ClearCondition                 null.String `boil:"clear_condition" json:"clear_condition,omitempty" toml:"clear_condition" yaml:"clear_condition,omitempty"`
+	AbsentFor                      int         `boil:"absent_for" json:"absent_for" toml:"absent_for" yaml:"absent_for"`
+	PreviousScope                  string      `boil:"previous_scope" json:"previous_scope" toml:"previous_scope" yaml:"previous_scope"`
+	MaxFirings                     int         `boil:"max_firings" json:"max_firings" toml:"max_firings" yaml:"max_firings"`
+	GlobalMaxFirings               int         `boil:"global_max_firings" json:"global_max_firings" toml:"global_max_firings" yaml:"global_max_firings"`
+	FiringWindow                   int         `boil:"firing_window" json:"firing_window" toml:"firing_window" yaml:"firing_window"`
+	Schedule                       null.JSON   `boil:"schedule" json:"schedule,omitempty" toml:"schedule" yaml:"schedule,omitempty"`
+	SnoozedUntil                   null.Time   `boil:"snoozed_until" json:"snoozed_until,omitempty" toml:"snoozed_until" yaml:"snoozed_until,omitempty"`
+	Params                         null.JSON   `boil:"params" json:"params,omitempty" toml:"params" yaml:"params,omitempty"`
+	SubscriptionMode               string      `boil:"subscription_mode" json:"subscription_mode" toml:"subscription_mode" yaml:"subscription_mode"`
+	ReconciledAt                   null.Time   `boil:"reconciled_at" json:"reconciled_at,omitempty" toml:"reconciled_at" yaml:"reconciled_at,omitempty"`
+	ReconciledAdded                int         `boil:"reconciled_added" json:"reconciled_added" toml:"reconciled_added" yaml:"reconciled_added"`
+	ReconciledRemoved              int         `boil:"reconciled_removed" json:"reconciled_removed" toml:"reconciled_removed" yaml:"reconciled_removed"`
+	ReconcileError                 null.String `boil:"reconcile_error" json:"reconcile_error,omitempty" toml:"reconcile_error" yaml:"reconcile_error,omitempty"`
+	ReconcileLockedUntil           null.Time   `boil:"reconcile_locked_until" json:"reconcile_locked_until,omitempty" toml:"reconcile_locked_until" yaml:"reconcile_locked_until,omitempty"`
 
 	R *triggerR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L triggerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var TriggerColumns = struct {
-	ID                      string
-	Service                 string
-	MetricName              string
-	Condition               string
-	TargetURI               string
-	CooldownPeriod          string
-	DeveloperLicenseAddress string
-	CreatedAt               string
-	UpdatedAt               string
-	Status                  string
-	Description             string
-	FailureCount            string
-	DisplayName             string
+	ID                             string
+	Service                        string
+	MetricName                     string
+	Condition                      string
+	TargetURI                      string
+	CooldownPeriod                 string
+	DeveloperLicenseAddress        string
+	CreatedAt                      string
+	UpdatedAt                      string
+	Status                         string
+	Description                    string
+	FailureCount                   string
+	DisplayName                    string
+	SigningSecret                  string
+	PreviousSigningSecret          string
+	PreviousSigningSecretExpiresAt string
+	SustainFor                     string
+	FireMode                       string
+	ClearCondition                 string
+	AbsentFor                      string
+	PreviousScope                  string
+	MaxFirings                     string
+	GlobalMaxFirings               string
+	FiringWindow                   string
+	Schedule                       string
+	SnoozedUntil                   string
+	Params                         string
+	SubscriptionMode               string
+	ReconciledAt                   string
+	ReconciledAdded                string
+	ReconciledRemoved              string
+	ReconcileError                 string
+	ReconcileLockedUntil           string
 }{
-	ID:                      "id",
-	Service:                 "service",
-	MetricName:              "metric_name",
-	Condition:               "condition",
-	TargetURI:               "target_uri",
-	CooldownPeriod:          "cooldown_period",
-	DeveloperLicenseAddress: "developer_license_address",
-	CreatedAt:               "created_at",
-	UpdatedAt:               "updated_at",
-	Status:                  "status",
-	Description:             "description",
-	FailureCount:            "failure_count",
-	DisplayName:             "display_name",
+	ID:                             "id",
+	Service:                        "service",
+	MetricName:                     "metric_name",
+	Condition:                      "condition",
+	TargetURI:                      "target_uri",
+	CooldownPeriod:                 "cooldown_period",
+	DeveloperLicenseAddress:        "developer_license_address",
+	CreatedAt:                      "created_at",
+	UpdatedAt:                      "updated_at",
+	Status:                         "status",
+	Description:                    "description",
+	FailureCount:                   "failure_count",
+	DisplayName:                    "display_name",
+	SigningSecret:                  "signing_secret",
+	PreviousSigningSecret:          "previous_signing_secret",
+	PreviousSigningSecretExpiresAt: "previous_signing_secret_expires_at",
+	SustainFor:                     "sustain_for",
+	FireMode:                       "fire_mode",
+	ClearCondition:                 "clear_condition",
+	AbsentFor:                      "absent_for",
+	PreviousScope:                  "previous_scope",
+	MaxFirings:                     "max_firings",
+	GlobalMaxFirings:               "global_max_firings",
+	FiringWindow:                   "firing_window",
+	Schedule:                       "schedule",
+	SnoozedUntil:                   "snoozed_until",
+	Params:                         "params",
+	SubscriptionMode:               "subscription_mode",
+	ReconciledAt:                   "reconciled_at",
+	ReconciledAdded:                "reconciled_added",
+	ReconciledRemoved:              "reconciled_removed",
+	ReconcileError:                 "reconcile_error",
+	ReconcileLockedUntil:           "reconcile_locked_until",
 }
 
 var TriggerTableColumns = struct {
-	ID                      string
-	Service                 string
-	MetricName              string
-	Condition               string
-	TargetURI               string
-	CooldownPeriod          string
-	DeveloperLicenseAddress string
-	CreatedAt               string
-	UpdatedAt               string
-	Status                  string
-	Description             string
-	FailureCount            string
-	DisplayName             string
+	ID                             string
+	Service                        string
+	MetricName                     string
+	Condition                      string
+	TargetURI                      string
+	CooldownPeriod                 string
+	DeveloperLicenseAddress        string
+	CreatedAt                      string
+	UpdatedAt                      string
+	Status                         string
+	Description                    string
+	FailureCount                   string
+	DisplayName                    string
+	SigningSecret                  string
+	PreviousSigningSecret          string
+	PreviousSigningSecretExpiresAt string
+	SustainFor                     string
+	FireMode                       string
+	ClearCondition                 string
+	AbsentFor                      string
+	PreviousScope                  string
+	MaxFirings                     string
+	GlobalMaxFirings               string
+	FiringWindow                   string
+	Schedule                       string
+	SnoozedUntil                   string
+	Params                         string
+	SubscriptionMode               string
+	ReconciledAt                   string
+	ReconciledAdded                string
+	ReconciledRemoved              string
+	ReconcileError                 string
+	ReconcileLockedUntil           string
 }{
-	ID:                      "triggers.id",
-	Service:                 "triggers.service",
-	MetricName:              "triggers.metric_name",
-	Condition:               "triggers.condition",
-	TargetURI:               "triggers.target_uri",
-	CooldownPeriod:          "triggers.cooldown_period",
-	DeveloperLicenseAddress: "triggers.developer_license_address",
-	CreatedAt:               "triggers.created_at",
-	UpdatedAt:               "triggers.updated_at",
-	Status:                  "triggers.status",
-	Description:             "triggers.description",
-	FailureCount:            "triggers.failure_count",
-	DisplayName:             "triggers.display_name",
+	ID:                             "triggers.id",
+	Service:                        "triggers.service",
+	MetricName:                     "triggers.metric_name",
+	Condition:                      "triggers.condition",
+	TargetURI:                      "triggers.target_uri",
+	CooldownPeriod:                 "triggers.cooldown_period",
+	DeveloperLicenseAddress:        "triggers.developer_license_address",
+	CreatedAt:                      "triggers.created_at",
+	UpdatedAt:                      "triggers.updated_at",
+	Status:                         "triggers.status",
+	Description:                    "triggers.description",
+	FailureCount:                   "triggers.failure_count",
+	DisplayName:                    "triggers.display_name",
+	SigningSecret:                  "triggers.signing_secret",
+	PreviousSigningSecret:          "triggers.previous_signing_secret",
+	PreviousSigningSecretExpiresAt: "triggers.previous_signing_secret_expires_at",
+	SustainFor:                     "triggers.sustain_for",
+	FireMode:                       "triggers.fire_mode",
+	ClearCondition:                 "triggers.clear_condition",
+	AbsentFor:                      "triggers.absent_for",
+	PreviousScope:                  "triggers.previous_scope",
+	MaxFirings:                     "triggers.max_firings",
+	GlobalMaxFirings:               "triggers.global_max_firings",
+	FiringWindow:                   "triggers.firing_window",
+	Schedule:                       "triggers.schedule",
+	SnoozedUntil:                   "triggers.snoozed_until",
+	Params:                         "triggers.params",
+	SubscriptionMode:               "triggers.subscription_mode",
+	ReconciledAt:                   "triggers.reconciled_at",
+	ReconciledAdded:                "triggers.reconciled_added",
+	ReconciledRemoved:              "triggers.reconciled_removed",
+	ReconcileError:                 "triggers.reconcile_error",
+	ReconcileLockedUntil:           "triggers.reconcile_locked_until",
 }
 
 // Generated where
@@ -136,49 +236,128 @@ func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.f
 func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var TriggerWhere = struct {
-	ID                      whereHelperstring
-	Service                 whereHelperstring
-	MetricName              whereHelperstring
-	Condition               whereHelperstring
-	TargetURI               whereHelperstring
-	CooldownPeriod          whereHelperint
-	DeveloperLicenseAddress whereHelper__byte
-	CreatedAt               whereHelpertime_Time
-	UpdatedAt               whereHelpertime_Time
-	Status                  whereHelperstring
-	Description             whereHelpernull_String
-	FailureCount            whereHelperint
-	DisplayName             whereHelperstring
+	ID                             whereHelperstring
+	Service                        whereHelperstring
+	MetricName                     whereHelperstring
+	Condition                      whereHelperstring
+	TargetURI                      whereHelperstring
+	CooldownPeriod                 whereHelperint
+	DeveloperLicenseAddress        whereHelper__byte
+	CreatedAt                      whereHelpertime_Time
+	UpdatedAt                      whereHelpertime_Time
+	Status                         whereHelperstring
+	Description                    whereHelpernull_String
+	FailureCount                   whereHelperint
+	DisplayName                    whereHelperstring
+	SigningSecret                  whereHelperstring
+	PreviousSigningSecret          whereHelpernull_String
+	PreviousSigningSecretExpiresAt whereHelpernull_Time
+	SustainFor                     whereHelperint
+	FireMode                       whereHelperstring
+	ClearCondition                 whereHelpernull_String
+	AbsentFor                      whereHelperint
+	PreviousScope                  whereHelperstring
+	MaxFirings                     whereHelperint
+	GlobalMaxFirings               whereHelperint
+	FiringWindow                   whereHelperint
+	Schedule                       whereHelpernull_JSON
+	SnoozedUntil                   whereHelpernull_Time
+	Params                         whereHelpernull_JSON
+	SubscriptionMode               whereHelperstring
+	ReconciledAt                   whereHelpernull_Time
+	ReconciledAdded                whereHelperint
+	ReconciledRemoved              whereHelperint
+	ReconcileError                 whereHelpernull_String
+	ReconcileLockedUntil           whereHelpernull_Time
 }{
-	ID:                      whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"id\""},
-	Service:                 whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"service\""},
-	MetricName:              whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"metric_name\""},
-	Condition:               whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"condition\""},
-	TargetURI:               whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"target_uri\""},
-	CooldownPeriod:          whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"cooldown_period\""},
-	DeveloperLicenseAddress: whereHelper__byte{field: "\"vehicle_triggers_api\".\"triggers\".\"developer_license_address\""},
-	CreatedAt:               whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"created_at\""},
-	UpdatedAt:               whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"updated_at\""},
-	Status:                  whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"status\""},
-	Description:             whereHelpernull_String{field: "\"vehicle_triggers_api\".\"triggers\".\"description\""},
-	FailureCount:            whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"failure_count\""},
-	DisplayName:             whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"display_name\""},
+	ID:                             whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"id\""},
+	Service:                        whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"service\""},
+	MetricName:                     whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"metric_name\""},
+	Condition:                      whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"condition\""},
+	TargetURI:                      whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"target_uri\""},
+	CooldownPeriod:                 whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"cooldown_period\""},
+	DeveloperLicenseAddress:        whereHelper__byte{field: "\"vehicle_triggers_api\".\"triggers\".\"developer_license_address\""},
+	CreatedAt:                      whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"created_at\""},
+	UpdatedAt:                      whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"updated_at\""},
+	Status:                         whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"status\""},
+	Description:                    whereHelpernull_String{field: "\"vehicle_triggers_api\".\"triggers\".\"description\""},
+	FailureCount:                   whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"failure_count\""},
+	DisplayName:                    whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"display_name\""},
+	SigningSecret:                  whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"signing_secret\""},
+	PreviousSigningSecret:          whereHelpernull_String{field: "\"vehicle_triggers_api\".\"triggers\".\"previous_signing_secret\""},
+	PreviousSigningSecretExpiresAt: whereHelpernull_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"previous_signing_secret_expires_at\""},
+	SustainFor:                     whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"sustain_for\""},
+	FireMode:                       whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"fire_mode\""},
+	ClearCondition:                 whereHelpernull_String{field: "\"vehicle_triggers_api\".\"triggers\".\"clear_condition\""},
+	AbsentFor:                      whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"absent_for\""},
+	PreviousScope:                  whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"previous_scope\""},
+	MaxFirings:                     whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"max_firings\""},
+	GlobalMaxFirings:               whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"global_max_firings\""},
+	FiringWindow:                   whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"firing_window\""},
+	Schedule:                       whereHelpernull_JSON{field: "\"vehicle_triggers_api\".\"triggers\".\"schedule\""},
+	SnoozedUntil:                   whereHelpernull_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"snoozed_until\""},
+	Params:                         whereHelpernull_JSON{field: "\"vehicle_triggers_api\".\"triggers\".\"params\""},
+	SubscriptionMode:               whereHelperstring{field: "\"vehicle_triggers_api\".\"triggers\".\"subscription_mode\""},
+	ReconciledAt:                   whereHelpernull_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"reconciled_at\""},
+	ReconciledAdded:                whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"reconciled_added\""},
+	ReconciledRemoved:              whereHelperint{field: "\"vehicle_triggers_api\".\"triggers\".\"reconciled_removed\""},
+	ReconcileError:                 whereHelpernull_String{field: "\"vehicle_triggers_api\".\"triggers\".\"reconcile_error\""},
+	ReconcileLockedUntil:           whereHelpernull_Time{field: "\"vehicle_triggers_api\".\"triggers\".\"reconcile_locked_until\""},
 }
 
 // TriggerRels is where relationship names are stored.
 var TriggerRels = struct {
 	TriggerLogs          string
 	VehicleSubscriptions string
+	TriggerVehicleStates string
+	WebhookDeadLetters   string
+	WebhookDeliveries    string
+	TriggerFirings       string
+	WebhookOutboxes      string
 }{
 	TriggerLogs:          "TriggerLogs",
 	VehicleSubscriptions: "VehicleSubscriptions",
+	TriggerVehicleStates: "TriggerVehicleStates",
+	WebhookDeadLetters:   "WebhookDeadLetters",
+	WebhookDeliveries:    "WebhookDeliveries",
+	TriggerFirings:       "TriggerFirings",
+	WebhookOutboxes:      "WebhookOutboxes",
 }
 
 // triggerR is where relationships are stored.
 type triggerR struct {
 	TriggerLogs          TriggerLogSlice          `boil:"TriggerLogs" json:"TriggerLogs" toml:"TriggerLogs" yaml:"TriggerLogs"`
 	VehicleSubscriptions VehicleSubscriptionSlice `boil:"VehicleSubscriptions" json:"VehicleSubscriptions" toml:"VehicleSubscriptions" yaml:"VehicleSubscriptions"`
+	TriggerVehicleStates TriggerVehicleStateSlice `boil:"TriggerVehicleStates" json:"TriggerVehicleStates" toml:"TriggerVehicleStates" yaml:"TriggerVehicleStates"`
+	WebhookDeadLetters   WebhookDeadLetterSlice   `boil:"WebhookDeadLetters" json:"WebhookDeadLetters" toml:"WebhookDeadLetters" yaml:"WebhookDeadLetters"`
+	WebhookDeliveries    WebhookDeliverySlice     `boil:"WebhookDeliveries" json:"WebhookDeliveries" toml:"WebhookDeliveries" yaml:"WebhookDeliveries"`
+	TriggerFirings       TriggerFiringSlice       `boil:"TriggerFirings" json:"TriggerFirings" toml:"TriggerFirings" yaml:"TriggerFirings"`
+	WebhookOutboxes      WebhookOutboxSlice       `boil:"WebhookOutboxes" json:"WebhookOutboxes" toml:"WebhookOutboxes" yaml:"WebhookOutboxes"`
 }
 
 // NewStruct creates a new relationship struct
@@ -210,6 +389,14 @@ func (o *Trigger) GetVehicleSubscriptions() VehicleSubscriptionSlice {
 	return o.R.GetVehicleSubscriptions()
 }
 
+func (o *Trigger) GetTriggerVehicleStates() TriggerVehicleStateSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetTriggerVehicleStates()
+}
+
 func (r *triggerR) GetVehicleSubscriptions() VehicleSubscriptionSlice {
 	if r == nil {
 		return nil
@@ -218,13 +405,85 @@ func (r *triggerR) GetVehicleSubscriptions() VehicleSubscriptionSlice {
 	return r.VehicleSubscriptions
 }
 
+func (r *triggerR) GetTriggerVehicleStates() TriggerVehicleStateSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.TriggerVehicleStates
+}
+
+func (o *Trigger) GetWebhookDeadLetters() WebhookDeadLetterSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetWebhookDeadLetters()
+}
+
+func (o *Trigger) GetWebhookDeliveries() WebhookDeliverySlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetWebhookDeliveries()
+}
+
+func (o *Trigger) GetTriggerFirings() TriggerFiringSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetTriggerFirings()
+}
+
+func (r *triggerR) GetWebhookDeadLetters() WebhookDeadLetterSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.WebhookDeadLetters
+}
+
+func (r *triggerR) GetWebhookDeliveries() WebhookDeliverySlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.WebhookDeliveries
+}
+
+func (r *triggerR) GetTriggerFirings() TriggerFiringSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.TriggerFirings
+}
+
+func (o *Trigger) GetWebhookOutboxes() WebhookOutboxSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetWebhookOutboxes()
+}
+
+func (r *triggerR) GetWebhookOutboxes() WebhookOutboxSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.WebhookOutboxes
+}
+
 // triggerL is where Load methods for each relationship are stored.
 type triggerL struct{}
 
 var (
-	triggerAllColumns            = []string{"id", "service", "metric_name", "condition", "target_uri", "cooldown_period", "developer_license_address", "created_at", "updated_at", "status", "description", "failure_count", "display_name"}
-	triggerColumnsWithoutDefault = []string{"id", "service", "metric_name", "condition", "target_uri", "developer_license_address", "status"}
-	triggerColumnsWithDefault    = []string{"cooldown_period", "created_at", "updated_at", "description", "failure_count", "display_name"}
+	triggerAllColumns            = []string{"id", "service", "metric_name", "condition", "target_uri", "cooldown_period", "developer_license_address", "created_at", "updated_at", "status", "description", "failure_count", "display_name", "signing_secret", "previous_signing_secret", "previous_signing_secret_expires_at", "sustain_for", "fire_mode", "clear_condition", "absent_for", "previous_scope", "max_firings", "global_max_firings", "firing_window", "schedule", "snoozed_until", "params", "subscription_mode", "reconciled_at", "reconciled_added", "reconciled_removed", "reconcile_error", "reconcile_locked_until"}
+	triggerColumnsWithoutDefault = []string{"id", "service", "metric_name", "condition", "target_uri", "developer_license_address", "status", "signing_secret", "clear_condition", "schedule", "snoozed_until", "params", "reconciled_at", "reconcile_error", "reconcile_locked_until"}
+	triggerColumnsWithDefault    = []string{"cooldown_period", "created_at", "updated_at", "description", "failure_count", "display_name", "previous_signing_secret", "previous_signing_secret_expires_at", "sustain_for", "fire_mode", "absent_for", "previous_scope", "max_firings", "global_max_firings", "firing_window", "subscription_mode", "reconciled_added", "reconciled_removed"}
 	triggerPrimaryKeyColumns     = []string{"id"}
 	triggerGeneratedColumns      = []string{}
 )
@@ -562,6 +821,76 @@ func (o *Trigger) VehicleSubscriptions(mods ...qm.QueryMod) vehicleSubscriptionQ
 	return VehicleSubscriptions(queryMods...)
 }
 
+// TriggerVehicleStates retrieves all the trigger_vehicle_state's TriggerVehicleStates with an executor.
+func (o *Trigger) TriggerVehicleStates(mods ...qm.QueryMod) triggerVehicleStateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"vehicle_triggers_api\".\"trigger_vehicle_state\".\"trigger_id\"=?", o.ID),
+	)
+
+	return TriggerVehicleStates(queryMods...)
+}
+
+// WebhookDeadLetters retrieves all the webhook_dead_letter's WebhookDeadLetters with an executor.
+func (o *Trigger) WebhookDeadLetters(mods ...qm.QueryMod) webhookDeadLetterQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"vehicle_triggers_api\".\"webhook_dead_letters\".\"trigger_id\"=?", o.ID),
+	)
+
+	return WebhookDeadLetters(queryMods...)
+}
+
+// WebhookDeliveries retrieves all the webhook_delivery's WebhookDeliveries with an executor.
+func (o *Trigger) WebhookDeliveries(mods ...qm.QueryMod) webhookDeliveryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"vehicle_triggers_api\".\"webhook_deliveries\".\"trigger_id\"=?", o.ID),
+	)
+
+	return WebhookDeliveries(queryMods...)
+}
+
+// TriggerFirings retrieves all the trigger_firing's TriggerFirings with an executor.
+func (o *Trigger) TriggerFirings(mods ...qm.QueryMod) triggerFiringQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"vehicle_triggers_api\".\"trigger_firings\".\"trigger_id\"=?", o.ID),
+	)
+
+	return TriggerFirings(queryMods...)
+}
+
+// WebhookOutboxes retrieves all the webhook_outbox's WebhookOutboxes with an executor.
+func (o *Trigger) WebhookOutboxes(mods ...qm.QueryMod) webhookOutboxQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"vehicle_triggers_api\".\"webhook_outbox\".\"trigger_id\"=?", o.ID),
+	)
+
+	return WebhookOutboxes(queryMods...)
+}
+
 // LoadTriggerLogs allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (triggerL) LoadTriggerLogs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
@@ -788,103 +1117,933 @@ func (triggerL) LoadVehicleSubscriptions(ctx context.Context, e boil.ContextExec
 	return nil
 }
 
-// AddTriggerLogs adds the given related objects to the existing relationships
-// of the trigger, optionally inserting them as new records.
-// Appends related to o.R.TriggerLogs.
-// Sets related.R.Trigger appropriately.
-func (o *Trigger) AddTriggerLogs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TriggerLog) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.TriggerID = o.ID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
-			}
-		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"vehicle_triggers_api\".\"trigger_logs\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
-				strmangle.WhereClause("\"", "\"", 2, triggerLogPrimaryKeyColumns),
-			)
-			values := []interface{}{o.ID, rel.ID}
+// LoadTriggerVehicleStates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (triggerL) LoadTriggerVehicleStates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
+	var slice []*Trigger
+	var object *Trigger
 
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
+	if singular {
+		var ok bool
+		object, ok = maybeTrigger.(*Trigger)
+		if !ok {
+			object = new(Trigger)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrigger))
 			}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-				return errors.Wrap(err, "failed to update foreign table")
+		}
+	} else {
+		s, ok := maybeTrigger.(*[]*Trigger)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrigger))
 			}
-
-			rel.TriggerID = o.ID
 		}
 	}
 
-	if o.R == nil {
-		o.R = &triggerR{
-			TriggerLogs: related,
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &triggerR{}
 		}
+		args[object.ID] = struct{}{}
 	} else {
-		o.R.TriggerLogs = append(o.R.TriggerLogs, related...)
-	}
-
-	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &triggerLogR{
-				Trigger: o,
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &triggerR{}
 			}
-		} else {
-			rel.R.Trigger = o
+			args[obj.ID] = struct{}{}
 		}
 	}
-	return nil
-}
 
-// AddVehicleSubscriptions adds the given related objects to the existing relationships
-// of the trigger, optionally inserting them as new records.
-// Appends related to o.R.VehicleSubscriptions.
-// Sets related.R.Trigger appropriately.
-func (o *Trigger) AddVehicleSubscriptions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleSubscription) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.TriggerID = o.ID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
-			}
-		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"vehicle_triggers_api\".\"vehicle_subscriptions\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
-				strmangle.WhereClause("\"", "\"", 2, vehicleSubscriptionPrimaryKeyColumns),
-			)
-			values := []interface{}{o.ID, rel.AssetDid, rel.TriggerID}
+	if len(args) == 0 {
+		return nil
+	}
 
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
-			}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-				return errors.Wrap(err, "failed to update foreign table")
-			}
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
 
-			rel.TriggerID = o.ID
-		}
+	query := NewQuery(
+		qm.From(`vehicle_triggers_api.trigger_vehicle_state`),
+		qm.WhereIn(`vehicle_triggers_api.trigger_vehicle_state.trigger_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
 	}
 
-	if o.R == nil {
-		o.R = &triggerR{
-			VehicleSubscriptions: related,
-		}
-	} else {
-		o.R.VehicleSubscriptions = append(o.R.VehicleSubscriptions, related...)
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trigger_vehicle_state")
 	}
 
-	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &vehicleSubscriptionR{
+	var resultSlice []*TriggerVehicleState
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trigger_vehicle_state")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trigger_vehicle_state")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trigger_vehicle_state")
+	}
+
+	if len(triggerVehicleStateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TriggerVehicleStates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &triggerVehicleStateR{}
+			}
+			foreign.R.Trigger = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TriggerID {
+				local.R.TriggerVehicleStates = append(local.R.TriggerVehicleStates, foreign)
+				if foreign.R == nil {
+					foreign.R = &triggerVehicleStateR{}
+				}
+				foreign.R.Trigger = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWebhookDeadLetters allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (triggerL) LoadWebhookDeadLetters(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
+	var slice []*Trigger
+	var object *Trigger
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrigger.(*Trigger)
+		if !ok {
+			object = new(Trigger)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrigger))
+			}
+		}
+	} else {
+		s, ok := maybeTrigger.(*[]*Trigger)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrigger))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &triggerR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &triggerR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`vehicle_triggers_api.webhook_dead_letters`),
+		qm.WhereIn(`vehicle_triggers_api.webhook_dead_letters.trigger_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load webhook_dead_letters")
+	}
+
+	var resultSlice []*WebhookDeadLetter
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice webhook_dead_letters")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on webhook_dead_letters")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook_dead_letters")
+	}
+
+	if len(webhookDeadLetterAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.WebhookDeadLetters = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &webhookDeadLetterR{}
+			}
+			foreign.R.Trigger = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TriggerID {
+				local.R.WebhookDeadLetters = append(local.R.WebhookDeadLetters, foreign)
+				if foreign.R == nil {
+					foreign.R = &webhookDeadLetterR{}
+				}
+				foreign.R.Trigger = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWebhookDeliveries allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (triggerL) LoadWebhookDeliveries(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
+	var slice []*Trigger
+	var object *Trigger
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrigger.(*Trigger)
+		if !ok {
+			object = new(Trigger)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrigger))
+			}
+		}
+	} else {
+		s, ok := maybeTrigger.(*[]*Trigger)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrigger))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &triggerR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &triggerR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`vehicle_triggers_api.webhook_deliveries`),
+		qm.WhereIn(`vehicle_triggers_api.webhook_deliveries.trigger_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load webhook_deliveries")
+	}
+
+	var resultSlice []*WebhookDelivery
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice webhook_deliveries")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on webhook_deliveries")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook_deliveries")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.WebhookDeliveries = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &webhookDeliveryR{}
+			}
+			foreign.R.Trigger = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TriggerID {
+				local.R.WebhookDeliveries = append(local.R.WebhookDeliveries, foreign)
+				if foreign.R == nil {
+					foreign.R = &webhookDeliveryR{}
+				}
+				foreign.R.Trigger = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTriggerFirings allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (triggerL) LoadTriggerFirings(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
+	var slice []*Trigger
+	var object *Trigger
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrigger.(*Trigger)
+		if !ok {
+			object = new(Trigger)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrigger))
+			}
+		}
+	} else {
+		s, ok := maybeTrigger.(*[]*Trigger)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrigger))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &triggerR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &triggerR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`vehicle_triggers_api.trigger_firings`),
+		qm.WhereIn(`vehicle_triggers_api.trigger_firings.trigger_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trigger_firings")
+	}
+
+	var resultSlice []*TriggerFiring
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trigger_firings")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trigger_firings")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trigger_firings")
+	}
+
+	if len(triggerFiringAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TriggerFirings = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &triggerFiringR{}
+			}
+			foreign.R.Trigger = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TriggerID {
+				local.R.TriggerFirings = append(local.R.TriggerFirings, foreign)
+				if foreign.R == nil {
+					foreign.R = &triggerFiringR{}
+				}
+				foreign.R.Trigger = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWebhookOutboxes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (triggerL) LoadWebhookOutboxes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrigger interface{}, mods queries.Applicator) error {
+	var slice []*Trigger
+	var object *Trigger
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrigger.(*Trigger)
+		if !ok {
+			object = new(Trigger)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrigger))
+			}
+		}
+	} else {
+		s, ok := maybeTrigger.(*[]*Trigger)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrigger)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrigger))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &triggerR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &triggerR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`vehicle_triggers_api.webhook_outbox`),
+		qm.WhereIn(`vehicle_triggers_api.webhook_outbox.trigger_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load webhook_outbox")
+	}
+
+	var resultSlice []*WebhookOutbox
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice webhook_outbox")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on webhook_outbox")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook_outbox")
+	}
+
+	if len(webhookOutboxAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.WebhookOutboxes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &webhookOutboxR{}
+			}
+			foreign.R.Trigger = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TriggerID {
+				local.R.WebhookOutboxes = append(local.R.WebhookOutboxes, foreign)
+				if foreign.R == nil {
+					foreign.R = &webhookOutboxR{}
+				}
+				foreign.R.Trigger = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddTriggerLogs adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.TriggerLogs.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddTriggerLogs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TriggerLog) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"trigger_logs\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, triggerLogPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			TriggerLogs: related,
+		}
+	} else {
+		o.R.TriggerLogs = append(o.R.TriggerLogs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &triggerLogR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddVehicleSubscriptions adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.VehicleSubscriptions.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddVehicleSubscriptions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleSubscription) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"vehicle_subscriptions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, vehicleSubscriptionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AssetDid, rel.TriggerID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			VehicleSubscriptions: related,
+		}
+	} else {
+		o.R.VehicleSubscriptions = append(o.R.VehicleSubscriptions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &vehicleSubscriptionR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddTriggerVehicleStates adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.TriggerVehicleStates.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddTriggerVehicleStates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TriggerVehicleState) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"trigger_vehicle_state\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, triggerVehicleStatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AssetDid, rel.TriggerID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			TriggerVehicleStates: related,
+		}
+	} else {
+		o.R.TriggerVehicleStates = append(o.R.TriggerVehicleStates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &triggerVehicleStateR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddWebhookDeadLetters adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.WebhookDeadLetters.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddWebhookDeadLetters(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WebhookDeadLetter) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"webhook_dead_letters\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, webhookDeadLetterPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			WebhookDeadLetters: related,
+		}
+	} else {
+		o.R.WebhookDeadLetters = append(o.R.WebhookDeadLetters, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &webhookDeadLetterR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddWebhookDeliveries adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.WebhookDeliveries.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddWebhookDeliveries(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WebhookDelivery) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"webhook_deliveries\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, webhookDeliveryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			WebhookDeliveries: related,
+		}
+	} else {
+		o.R.WebhookDeliveries = append(o.R.WebhookDeliveries, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &webhookDeliveryR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddTriggerFirings adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.TriggerFirings.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddTriggerFirings(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TriggerFiring) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"trigger_firings\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, triggerFiringPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			TriggerFirings: related,
+		}
+	} else {
+		o.R.TriggerFirings = append(o.R.TriggerFirings, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &triggerFiringR{
+				Trigger: o,
+			}
+		} else {
+			rel.R.Trigger = o
+		}
+	}
+	return nil
+}
+
+// AddWebhookOutboxes adds the given related objects to the existing relationships
+// of the trigger, optionally inserting them as new records.
+// Appends related to o.R.WebhookOutboxes.
+// Sets related.R.Trigger appropriately.
+func (o *Trigger) AddWebhookOutboxes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WebhookOutbox) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TriggerID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"vehicle_triggers_api\".\"webhook_outbox\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trigger_id"}),
+				strmangle.WhereClause("\"", "\"", 2, webhookOutboxPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TriggerID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &triggerR{
+			WebhookOutboxes: related,
+		}
+	} else {
+		o.R.WebhookOutboxes = append(o.R.WebhookOutboxes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &webhookOutboxR{
 				Trigger: o,
 			}
 		} else {
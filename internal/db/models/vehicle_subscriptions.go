@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
@@ -23,51 +24,65 @@ import (
 
 // VehicleSubscription is an object representing the database table.
 type VehicleSubscription struct {
-	TriggerID string    `boil:"trigger_id" json:"trigger_id" toml:"trigger_id" yaml:"trigger_id"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	AssetDid  string    `boil:"asset_did" json:"asset_did" toml:"asset_did" yaml:"asset_did"`
+	TriggerID  string    `boil:"trigger_id" json:"trigger_id" toml:"trigger_id" yaml:"trigger_id"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	AssetDid   string    `boil:"asset_did" json:"asset_did" toml:"asset_did" yaml:"asset_did"`
+	MutedUntil null.Time `boil:"muted_until" json:"muted_until,omitempty" toml:"muted_until" yaml:"muted_until,omitempty"`
+	Params     null.JSON `boil:"params" json:"params,omitempty" toml:"params" yaml:"params,omitempty"`
 
 	R *vehicleSubscriptionR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L vehicleSubscriptionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var VehicleSubscriptionColumns = struct {
-	TriggerID string
-	CreatedAt string
-	UpdatedAt string
-	AssetDid  string
+	TriggerID  string
+	CreatedAt  string
+	UpdatedAt  string
+	AssetDid   string
+	MutedUntil string
+	Params     string
 }{
-	TriggerID: "trigger_id",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
-	AssetDid:  "asset_did",
+	TriggerID:  "trigger_id",
+	CreatedAt:  "created_at",
+	UpdatedAt:  "updated_at",
+	AssetDid:   "asset_did",
+	MutedUntil: "muted_until",
+	Params:     "params",
 }
 
 var VehicleSubscriptionTableColumns = struct {
-	TriggerID string
-	CreatedAt string
-	UpdatedAt string
-	AssetDid  string
+	TriggerID  string
+	CreatedAt  string
+	UpdatedAt  string
+	AssetDid   string
+	MutedUntil string
+	Params     string
 }{
-	TriggerID: "vehicle_subscriptions.trigger_id",
-	CreatedAt: "vehicle_subscriptions.created_at",
-	UpdatedAt: "vehicle_subscriptions.updated_at",
-	AssetDid:  "vehicle_subscriptions.asset_did",
+	TriggerID:  "vehicle_subscriptions.trigger_id",
+	CreatedAt:  "vehicle_subscriptions.created_at",
+	UpdatedAt:  "vehicle_subscriptions.updated_at",
+	AssetDid:   "vehicle_subscriptions.asset_did",
+	MutedUntil: "vehicle_subscriptions.muted_until",
+	Params:     "vehicle_subscriptions.params",
 }
 
 // Generated where
 
 var VehicleSubscriptionWhere = struct {
-	TriggerID whereHelperstring
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
-	AssetDid  whereHelperstring
+	TriggerID  whereHelperstring
+	CreatedAt  whereHelpertime_Time
+	UpdatedAt  whereHelpertime_Time
+	AssetDid   whereHelperstring
+	MutedUntil whereHelpernull_Time
+	Params     whereHelpernull_JSON
 }{
-	TriggerID: whereHelperstring{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"trigger_id\""},
-	CreatedAt: whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"updated_at\""},
-	AssetDid:  whereHelperstring{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"asset_did\""},
+	TriggerID:  whereHelperstring{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"trigger_id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"created_at\""},
+	UpdatedAt:  whereHelpertime_Time{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"updated_at\""},
+	AssetDid:   whereHelperstring{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"asset_did\""},
+	MutedUntil: whereHelpernull_Time{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"muted_until\""},
+	Params:     whereHelpernull_JSON{field: "\"vehicle_triggers_api\".\"vehicle_subscriptions\".\"params\""},
 }
 
 // VehicleSubscriptionRels is where relationship names are stored.
@@ -107,8 +122,8 @@ func (r *vehicleSubscriptionR) GetTrigger() *Trigger {
 type vehicleSubscriptionL struct{}
 
 var (
-	vehicleSubscriptionAllColumns            = []string{"trigger_id", "created_at", "updated_at", "asset_did"}
-	vehicleSubscriptionColumnsWithoutDefault = []string{"trigger_id", "asset_did"}
+	vehicleSubscriptionAllColumns            = []string{"trigger_id", "created_at", "updated_at", "asset_did", "muted_until", "params"}
+	vehicleSubscriptionColumnsWithoutDefault = []string{"trigger_id", "asset_did", "muted_until", "params"}
 	vehicleSubscriptionColumnsWithDefault    = []string{"created_at", "updated_at"}
 	vehicleSubscriptionPrimaryKeyColumns     = []string{"asset_did", "trigger_id"}
 	vehicleSubscriptionGeneratedColumns      = []string{}
@@ -0,0 +1,103 @@
+// Package geofence parses GeoJSON polygons and tests whether locations lie inside them.
+package geofence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxVertices bounds the positions of a polygon, across all of its rings, to keep condition evaluation cheap.
+const MaxVertices = 1000
+
+// Point is a position of a polygon ring.
+type Point struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// Polygon is a GeoJSON polygon. The first ring is the exterior boundary, the others are holes.
+type Polygon struct {
+	rings [][]Point
+}
+
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates [][][]float64   `json:"coordinates"`
+	Geometry    json.RawMessage `json:"geometry"`
+}
+
+// ParsePolygon parses a GeoJSON Polygon geometry, or a Feature whose geometry is a Polygon.
+// Positions are [longitude, latitude] and every ring must be closed.
+func ParsePolygon(data []byte) (*Polygon, error) {
+	var geom geometry
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	if geom.Type == "Feature" {
+		if len(geom.Geometry) == 0 || string(geom.Geometry) == "null" {
+			return nil, errors.New("feature has no geometry")
+		}
+		return ParsePolygon(geom.Geometry)
+	}
+	if geom.Type != "Polygon" {
+		return nil, fmt.Errorf("geometry type must be Polygon, got %q", geom.Type)
+	}
+	if len(geom.Coordinates) == 0 {
+		return nil, errors.New("polygon has no rings")
+	}
+
+	poly := &Polygon{rings: make([][]Point, 0, len(geom.Coordinates))}
+	vertices := 0
+	for i, ring := range geom.Coordinates {
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("ring %d must have at least 4 positions", i)
+		}
+		vertices += len(ring)
+		if vertices > MaxVertices {
+			return nil, fmt.Errorf("polygon must have at most %d positions", MaxVertices)
+		}
+		points := make([]Point, len(ring))
+		for j, pos := range ring {
+			if len(pos) < 2 {
+				return nil, fmt.Errorf("position %d of ring %d must have a longitude and a latitude", j, i)
+			}
+			if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+				return nil, fmt.Errorf("position %d of ring %d is out of range", j, i)
+			}
+			points[j] = Point{Longitude: pos[0], Latitude: pos[1]}
+		}
+		if points[0] != points[len(points)-1] {
+			return nil, fmt.Errorf("ring %d is not closed", i)
+		}
+		poly.rings = append(poly.rings, points)
+	}
+	return poly, nil
+}
+
+// Contains reports whether the location is inside the polygon, that is inside its exterior ring and
+// outside all of its holes. Edges are treated as planar lines between positions.
+func (p *Polygon) Contains(latitude, longitude float64) bool {
+	if p == nil || len(p.rings) == 0 || !ringContains(p.rings[0], latitude, longitude) {
+		return false
+	}
+	for _, hole := range p.rings[1:] {
+		if ringContains(hole, latitude, longitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains casts a ray from the location and counts the edges of the ring it crosses.
+func ringContains(ring []Point, latitude, longitude float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > latitude) != (b.Latitude > latitude) &&
+			longitude < (b.Longitude-a.Longitude)*(latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
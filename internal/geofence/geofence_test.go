@@ -0,0 +1,60 @@
+package geofence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const depot = `{"type":"Polygon","coordinates":[
+	[[-74.02,40.70],[-73.98,40.70],[-73.98,40.74],[-74.02,40.74],[-74.02,40.70]],
+	[[-74.01,40.71],[-74.00,40.71],[-74.00,40.72],[-74.01,40.72],[-74.01,40.71]]
+]}`
+
+func TestParsePolygon(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		geoJSON     string
+		expectError bool
+	}{
+		{name: "polygon with a hole", geoJSON: depot},
+		{name: "feature", geoJSON: `{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}}`},
+		{name: "point", geoJSON: `{"type":"Point","coordinates":[0,0]}`, expectError: true},
+		{name: "feature without geometry", geoJSON: `{"type":"Feature","geometry":null}`, expectError: true},
+		{name: "no rings", geoJSON: `{"type":"Polygon","coordinates":[]}`, expectError: true},
+		{name: "ring too short", geoJSON: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`, expectError: true},
+		{name: "ring not closed", geoJSON: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`, expectError: true},
+		{name: "latitude out of range", geoJSON: `{"type":"Polygon","coordinates":[[[0,0],[1,95],[1,1],[0,0]]]}`, expectError: true},
+		{name: "position without latitude", geoJSON: `{"type":"Polygon","coordinates":[[[0,0],[1],[1,1],[0,0]]]}`, expectError: true},
+		{name: "not JSON", geoJSON: `depot`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			poly, err := ParsePolygon([]byte(tt.geoJSON))
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, poly)
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	t.Parallel()
+
+	poly, err := ParsePolygon([]byte(depot))
+	require.NoError(t, err)
+
+	assert.True(t, poly.Contains(40.73, -73.99), "inside")
+	assert.False(t, poly.Contains(40.715, -74.005), "inside the hole")
+	assert.False(t, poly.Contains(40.75, -73.99), "north of the polygon")
+	assert.False(t, poly.Contains(40.73, -73.97), "east of the polygon")
+	assert.False(t, (*Polygon)(nil).Contains(40.73, -73.99), "nil polygon")
+}
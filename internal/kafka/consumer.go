@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/IBM/sarama"
 	"github.com/ThreeDotsLabs/watermill"
@@ -30,6 +31,25 @@ type Consumer struct {
 	name        string
 	Processor   ProcessorFunc
 	maxInFlight int
+	subscribed  atomic.Bool
+	joined      atomic.Bool
+	partitions  atomic.Int32
+}
+
+// ConsumerStatus is the state of a consumer's subscription.
+type ConsumerStatus struct {
+	// Subscribed is true while the consumer is subscribed to its topic.
+	Subscribed bool `json:"subscribed"`
+	// Joined is true once the consumer joined its group after subscribing. It stays true during rebalances.
+	Joined bool `json:"joined"`
+	// Partitions is the number of partitions assigned to the consumer in the current group session.
+	Partitions int `json:"partitions"`
+}
+
+// Ready reports whether the consumer subscribed and joined its group. A consumer without partitions is ready,
+// since a group with more members than partitions leaves some of them idle.
+func (s ConsumerStatus) Ready() bool {
+	return s.Subscribed && s.Joined
 }
 
 // Name returns the consumer's identifier used in log lines.
@@ -38,18 +58,29 @@ func (c *Consumer) Name() string { return c.name }
 // Topic returns the topic this consumer subscribes to.
 func (c *Consumer) Topic() string { return c.topic }
 
+// Status returns the state of the consumer's subscription.
+func (c *Consumer) Status() ConsumerStatus {
+	return ConsumerStatus{
+		Subscribed: c.subscribed.Load(),
+		Joined:     c.joined.Load(),
+		Partitions: int(c.partitions.Load()),
+	}
+}
+
 func NewConsumer(cfg *Config) (*Consumer, error) {
 	saramaSubscriberConfig := wmkafka.DefaultSaramaSubscriberConfig()
 
 	saramaSubscriberConfig.Version = cfg.ClusterConfig.Version
 	saramaSubscriberConfig.Consumer.Offsets.Initial = cfg.ClusterConfig.Consumer.Offsets.Initial
 
+	consumer := &Consumer{}
 	subscriber, err := wmkafka.NewSubscriber(
 		wmkafka.SubscriberConfig{
 			Brokers:               cfg.BrokerAddresses,
 			Unmarshaler:           wmkafka.DefaultMarshaler{},
 			OverwriteSaramaConfig: saramaSubscriberConfig,
 			ConsumerGroup:         cfg.GroupID,
+			Tracer:                sessionTracker{consumer: consumer},
 		},
 		watermill.NewStdLogger(false, false),
 	)
@@ -67,13 +98,12 @@ func NewConsumer(cfg *Config) (*Consumer, error) {
 		name = cfg.Topic
 	}
 
-	return &Consumer{
-		subscriber:  subscriber,
-		topic:       cfg.Topic,
-		name:        name,
-		Processor:   cfg.Processor,
-		maxInFlight: maxInFlight,
-	}, nil
+	consumer.subscriber = subscriber
+	consumer.topic = cfg.Topic
+	consumer.name = name
+	consumer.Processor = cfg.Processor
+	consumer.maxInFlight = maxInFlight
+	return consumer, nil
 }
 
 func (c *Consumer) Start(ctx context.Context) error {
@@ -88,6 +118,11 @@ func (c *Consumer) Start(ctx context.Context) error {
 	if c.Processor == nil {
 		return fmt.Errorf("processor function is nil")
 	}
+	c.subscribed.Store(true)
+	defer func() {
+		c.subscribed.Store(false)
+		c.joined.Store(false)
+	}()
 
 	err = c.Processor(ctx, messages, c.maxInFlight)
 	logger.Info().Err(err).Msg("kafka consumer: processor returned")
@@ -97,3 +132,42 @@ func (c *Consumer) Start(ctx context.Context) error {
 func (c *Consumer) Stop(ctx context.Context) error {
 	return c.subscriber.Close()
 }
+
+// sessionTracker records the group sessions of a consumer by wrapping the handler of its consumer group. It is
+// passed to the subscriber as its tracer, which is the only hook into the group sessions; the rest is a no-op.
+type sessionTracker struct {
+	consumer *Consumer
+}
+
+func (t sessionTracker) WrapConsumer(consumer sarama.Consumer) sarama.Consumer {
+	return consumer
+}
+
+func (t sessionTracker) WrapPartitionConsumer(consumer sarama.PartitionConsumer) sarama.PartitionConsumer {
+	return consumer
+}
+
+func (t sessionTracker) WrapConsumerGroupHandler(handler sarama.ConsumerGroupHandler) sarama.ConsumerGroupHandler {
+	return trackedHandler{ConsumerGroupHandler: handler, consumer: t.consumer}
+}
+
+func (t sessionTracker) WrapSyncProducer(_ *sarama.Config, producer sarama.SyncProducer) sarama.SyncProducer {
+	return producer
+}
+
+// trackedHandler records the partitions claimed by each group session of the consumer.
+type trackedHandler struct {
+	sarama.ConsumerGroupHandler
+	consumer *Consumer
+}
+
+func (h trackedHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.consumer.partitions.Store(int32(len(session.Claims()[h.consumer.topic])))
+	h.consumer.joined.Store(true)
+	return h.ConsumerGroupHandler.Setup(session)
+}
+
+func (h trackedHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.consumer.partitions.Store(0)
+	return h.ConsumerGroupHandler.Cleanup(session)
+}
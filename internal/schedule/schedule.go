@@ -0,0 +1,156 @@
+// Package schedule parses the schedules triggers are active in and tests whether they are active at a time.
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	// Embed the time zone database so that schedules do not depend on the zone files of the host.
+	_ "time/tzdata"
+)
+
+const (
+	// MaxWindows bounds the weekly windows of a schedule.
+	MaxWindows = 50
+	// MaxBlackoutDates bounds the blackout dates of a schedule.
+	MaxBlackoutDates = 366
+
+	dateLayout    = "2006-01-02"
+	minutesPerDay = 24 * 60
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Schedule is the set of weekly windows a trigger is active in, minus its blackout dates, in a time zone.
+// A schedule without windows is active all week except on its blackout dates.
+type Schedule struct {
+	location *time.Location
+	windows  []window
+	blackout map[string]bool
+}
+
+// window is active from start until end minutes after midnight on the weekdays it starts on. A window whose end
+// is not after its start runs past midnight into the next day.
+type window struct {
+	days       [7]bool
+	start, end int
+}
+
+type document struct {
+	Timezone string `json:"timezone"`
+	Windows  []struct {
+		Days  []string `json:"days"`
+		Start string   `json:"start"`
+		End   string   `json:"end"`
+	} `json:"windows"`
+	BlackoutDates []string `json:"blackoutDates"`
+}
+
+// Parse parses a schedule of the form
+//
+//	{"timezone": "America/New_York", "windows": [{"days": ["mon"], "start": "20:00", "end": "06:00"}], "blackoutDates": ["2025-12-25"]}
+//
+// timezone is an IANA time zone name. Days are "sun" to "sat", and all days if omitted. Start and end are local
+// times "HH:MM", end may be "24:00". Blackout dates are local dates "YYYY-MM-DD" the schedule is inactive on.
+func Parse(data []byte) (*Schedule, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	if doc.Timezone == "" {
+		return nil, errors.New("schedule requires a timezone")
+	}
+	location, err := time.LoadLocation(doc.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", doc.Timezone)
+	}
+	if len(doc.Windows) > MaxWindows {
+		return nil, fmt.Errorf("schedule must have at most %d windows", MaxWindows)
+	}
+	if len(doc.BlackoutDates) > MaxBlackoutDates {
+		return nil, fmt.Errorf("schedule must have at most %d blackout dates", MaxBlackoutDates)
+	}
+
+	s := &Schedule{location: location, blackout: make(map[string]bool, len(doc.BlackoutDates))}
+	for i, w := range doc.Windows {
+		var parsed window
+		if len(w.Days) == 0 {
+			parsed.days = [7]bool{true, true, true, true, true, true, true}
+		}
+		for _, day := range w.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("window %d has unknown day %q", i, day)
+			}
+			parsed.days[weekday] = true
+		}
+		if parsed.start, err = parseTimeOfDay(w.Start); err != nil || parsed.start == minutesPerDay {
+			return nil, fmt.Errorf("window %d has invalid start %q, expected HH:MM", i, w.Start)
+		}
+		if parsed.end, err = parseTimeOfDay(w.End); err != nil {
+			return nil, fmt.Errorf("window %d has invalid end %q, expected HH:MM", i, w.End)
+		}
+		if parsed.start == parsed.end {
+			return nil, fmt.Errorf("window %d must end at a different time than it starts", i)
+		}
+		s.windows = append(s.windows, parsed)
+	}
+	for _, date := range doc.BlackoutDates {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return nil, fmt.Errorf("invalid blackout date %q, expected YYYY-MM-DD", date)
+		}
+		s.blackout[date] = true
+	}
+	return s, nil
+}
+
+// parseTimeOfDay parses "HH:MM", up to "24:00", into minutes after midnight.
+func parseTimeOfDay(value string) (int, error) {
+	if value == "24:00" {
+		return minutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Active reports whether the schedule is active at t. A nil schedule is always active.
+func (s *Schedule) Active(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+	local := t.In(s.location)
+	if s.blackout[local.Format(dateLayout)] {
+		return false
+	}
+	if len(s.windows) == 0 {
+		return true
+	}
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+	previousDay := (day + 6) % 7
+	for _, w := range s.windows {
+		if w.start < w.end {
+			if w.days[day] && minute >= w.start && minute < w.end {
+				return true
+			}
+			continue
+		}
+		if (w.days[day] && minute >= w.start) || (w.days[previousDay] && minute < w.end) {
+			return true
+		}
+	}
+	return false
+}
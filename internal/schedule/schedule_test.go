@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// afterHours is active from 20:00 to 06:00 New York time on weeknights, except on Christmas Eve.
+const afterHours = `{
+	"timezone": "America/New_York",
+	"windows": [{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "20:00", "end": "06:00"}],
+	"blackoutDates": ["2025-12-24"]
+}`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		schedule    string
+		expectError bool
+	}{
+		{name: "after hours", schedule: afterHours},
+		{name: "blackout dates only", schedule: `{"timezone":"UTC","blackoutDates":["2025-01-01"]}`},
+		{name: "all days until midnight", schedule: `{"timezone":"Europe/Berlin","windows":[{"start":"08:00","end":"24:00"}]}`},
+		{name: "no timezone", schedule: `{"windows":[{"start":"08:00","end":"17:00"}]}`, expectError: true},
+		{name: "unknown timezone", schedule: `{"timezone":"Mars/Olympus"}`, expectError: true},
+		{name: "unknown day", schedule: `{"timezone":"UTC","windows":[{"days":["monday"],"start":"08:00","end":"17:00"}]}`, expectError: true},
+		{name: "invalid start", schedule: `{"timezone":"UTC","windows":[{"start":"8am","end":"17:00"}]}`, expectError: true},
+		{name: "start at midnight of the next day", schedule: `{"timezone":"UTC","windows":[{"start":"24:00","end":"06:00"}]}`, expectError: true},
+		{name: "empty window", schedule: `{"timezone":"UTC","windows":[{"start":"08:00","end":"08:00"}]}`, expectError: true},
+		{name: "invalid blackout date", schedule: `{"timezone":"UTC","blackoutDates":["12/25/2025"]}`, expectError: true},
+		{name: "not JSON", schedule: `weekdays`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse([]byte(tt.schedule))
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, s)
+		})
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	t.Parallel()
+
+	s, err := Parse([]byte(afterHours))
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(date string, hour, minute int) time.Time {
+		day, err := time.ParseInLocation("2006-01-02", date, newYork)
+		require.NoError(t, err)
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	// 2025-12-01 is a Monday.
+	assert.True(t, s.Active(at("2025-12-01", 21, 0)), "monday night")
+	assert.True(t, s.Active(at("2025-12-02", 5, 59)), "tuesday early morning")
+	assert.False(t, s.Active(at("2025-12-02", 6, 0)), "tuesday after the window")
+	assert.False(t, s.Active(at("2025-12-01", 12, 0)), "monday noon")
+	assert.False(t, s.Active(at("2025-12-01", 5, 0)), "monday early morning after sunday")
+	assert.True(t, s.Active(at("2025-12-06", 3, 0)), "saturday early morning after friday night")
+	assert.False(t, s.Active(at("2025-12-06", 21, 0)), "saturday night")
+	assert.False(t, s.Active(at("2025-12-24", 21, 0)), "blackout date")
+	assert.True(t, s.Active(at("2025-12-25", 1, 0)), "after the blackout date")
+	assert.True(t, s.Active(at("2025-12-02", 2, 0).UTC()), "time in another zone")
+	assert.True(t, (*Schedule)(nil).Active(time.Now()), "nil schedule")
+}
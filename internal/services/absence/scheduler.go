@@ -0,0 +1,174 @@
+package absence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultCheckInterval = 30 * time.Second
+	// batchSize is the number of silent vehicles claimed at once.
+	batchSize = 100
+	// concurrency is the number of transitions handled in parallel.
+	concurrency = 10
+)
+
+type Repository interface {
+	TrackAbsenceSubscriptions(ctx context.Context) (int64, error)
+	RecordLastSeen(ctx context.Context, seen []triggersrepo.LastSeen) ([]triggersrepo.AbsenceState, error)
+	ClaimAbsentVehicles(ctx context.Context, limit int, grace time.Duration) ([]triggersrepo.AbsenceState, error)
+}
+
+// Transition is a vehicle of an absence trigger that went silent or came back online.
+type Transition struct {
+	TriggerID  string
+	AssetDID   string
+	MetricName string
+	// Offline is true when the vehicle went silent and false when it came back online.
+	Offline bool
+	// LastSeenAt is the timestamp of the last signal before the vehicle went silent.
+	LastSeenAt time.Time
+	// At is when the vehicle was found to be silent, or the timestamp of the signal that brought it back online.
+	At time.Time
+}
+
+// SilentFor returns how long the vehicle was silent at the time of the transition.
+func (t Transition) SilentFor() time.Duration {
+	return t.At.Sub(t.LastSeenAt)
+}
+
+// Handler fires the absence trigger of a transition.
+type Handler interface {
+	HandleAbsence(ctx context.Context, transition Transition) error
+}
+
+// Scheduler periodically stores the last seen timestamps of the Tracker and fires absence triggers for
+// vehicles that went silent or came back online.
+type Scheduler struct {
+	repo          Repository
+	tracker       *Tracker
+	handler       Handler
+	checkInterval time.Duration
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(repo Repository, tracker *Tracker, handler Handler, settings *config.Settings) *Scheduler {
+	checkInterval := settings.AbsenceCheckInterval
+	if checkInterval <= 0 {
+		checkInterval = defaultCheckInterval
+	}
+	return &Scheduler{
+		repo:          repo,
+		tracker:       tracker,
+		handler:       handler,
+		checkInterval: checkInterval,
+	}
+}
+
+// Run checks for absent vehicles until the context is canceled. The last seen timestamps collected since the
+// last check are stored one final time on the way out.
+func (s *Scheduler) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.flush(context.WithoutCancel(ctx)); err != nil {
+				logger.Error().Err(err).Msg("failed to store last seen timestamps")
+			}
+			return nil
+		case <-ticker.C:
+		}
+		if err := s.Check(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to check for absent vehicles")
+		}
+	}
+}
+
+// Check stores the collected last seen timestamps, firing for vehicles that came back online, and then fires
+// for vehicles that have been silent for longer than the absentFor of their trigger. Vehicles get one more
+// check interval, as other instances store the timestamps they collected only once per interval.
+func (s *Scheduler) Check(ctx context.Context) error {
+	if _, err := s.repo.TrackAbsenceSubscriptions(ctx); err != nil {
+		return err
+	}
+	if err := s.flush(ctx); err != nil {
+		return err
+	}
+	for {
+		states, err := s.repo.ClaimAbsentVehicles(ctx, batchSize, s.checkInterval)
+		if err != nil {
+			return err
+		}
+		transitions := make([]Transition, 0, len(states))
+		for _, state := range states {
+			transitions = append(transitions, Transition{
+				TriggerID:  state.TriggerID,
+				AssetDID:   state.AssetDid,
+				MetricName: state.MetricName,
+				Offline:    true,
+				LastSeenAt: state.LastSeenAt,
+				At:         state.AbsentSince,
+			})
+		}
+		s.handle(ctx, transitions)
+		if len(states) < batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// flush stores the last seen timestamps collected by the tracker and fires for vehicles that came back online.
+// The timestamps are handed back to the tracker if they can not be stored.
+func (s *Scheduler) flush(ctx context.Context) error {
+	seen := s.tracker.Drain()
+	if len(seen) == 0 {
+		return nil
+	}
+	states, err := s.repo.RecordLastSeen(ctx, seen)
+	if err != nil {
+		for _, ls := range seen {
+			s.tracker.Seen(ls.TriggerID, ls.AssetDid, ls.SeenAt)
+		}
+		return fmt.Errorf("failed to store %d last seen timestamps: %w", len(seen), err)
+	}
+	transitions := make([]Transition, 0, len(states))
+	for _, state := range states {
+		transitions = append(transitions, Transition{
+			TriggerID:  state.TriggerID,
+			AssetDID:   state.AssetDid,
+			MetricName: state.MetricName,
+			Offline:    false,
+			LastSeenAt: state.LastSeenAt,
+			At:         state.SeenAt.Time,
+		})
+	}
+	s.handle(ctx, transitions)
+	return nil
+}
+
+// handle fires the triggers of the transitions. Errors are only logged; a transition is not reported again.
+func (s *Scheduler) handle(ctx context.Context, transitions []Transition) {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for _, transition := range transitions {
+		group.Go(func() error {
+			if err := s.handler.HandleAbsence(groupCtx, transition); err != nil {
+				zerolog.Ctx(groupCtx).Error().Err(err).
+					Str("triggerId", transition.TriggerID).
+					Str("assetDid", transition.AssetDID).
+					Bool("offline", transition.Offline).
+					Msg("failed to handle absence")
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scheduler.go -destination=scheduler_mock_test.go -package=absence
+//
+
+// Package absence is a generated GoMock package.
+package absence
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	triggersrepo "github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimAbsentVehicles mocks base method.
+func (m *MockRepository) ClaimAbsentVehicles(ctx context.Context, limit int, grace time.Duration) ([]triggersrepo.AbsenceState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAbsentVehicles", ctx, limit, grace)
+	ret0, _ := ret[0].([]triggersrepo.AbsenceState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAbsentVehicles indicates an expected call of ClaimAbsentVehicles.
+func (mr *MockRepositoryMockRecorder) ClaimAbsentVehicles(ctx, limit, grace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAbsentVehicles", reflect.TypeOf((*MockRepository)(nil).ClaimAbsentVehicles), ctx, limit, grace)
+}
+
+// RecordLastSeen mocks base method.
+func (m *MockRepository) RecordLastSeen(ctx context.Context, seen []triggersrepo.LastSeen) ([]triggersrepo.AbsenceState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLastSeen", ctx, seen)
+	ret0, _ := ret[0].([]triggersrepo.AbsenceState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLastSeen indicates an expected call of RecordLastSeen.
+func (mr *MockRepositoryMockRecorder) RecordLastSeen(ctx, seen any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLastSeen", reflect.TypeOf((*MockRepository)(nil).RecordLastSeen), ctx, seen)
+}
+
+// TrackAbsenceSubscriptions mocks base method.
+func (m *MockRepository) TrackAbsenceSubscriptions(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackAbsenceSubscriptions", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackAbsenceSubscriptions indicates an expected call of TrackAbsenceSubscriptions.
+func (mr *MockRepositoryMockRecorder) TrackAbsenceSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackAbsenceSubscriptions", reflect.TypeOf((*MockRepository)(nil).TrackAbsenceSubscriptions), ctx)
+}
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleAbsence mocks base method.
+func (m *MockHandler) HandleAbsence(ctx context.Context, transition Transition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAbsence", ctx, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleAbsence indicates an expected call of HandleAbsence.
+func (mr *MockHandlerMockRecorder) HandleAbsence(ctx, transition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAbsence", reflect.TypeOf((*MockHandler)(nil).HandleAbsence), ctx, transition)
+}
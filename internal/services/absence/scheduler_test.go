@@ -0,0 +1,113 @@
+//go:generate go tool mockgen -source=scheduler.go -destination=scheduler_mock_test.go -package=absence
+package absence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testTriggerID = "test-trigger-id"
+	testAssetDID  = "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1"
+)
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTracker()
+	now := time.Now()
+	tracker.Seen(testTriggerID, testAssetDID, now)
+	tracker.Seen(testTriggerID, testAssetDID, now.Add(-time.Minute))
+	tracker.Seen("other-trigger-id", testAssetDID, now)
+
+	seen := tracker.Drain()
+	require.Len(t, seen, 2)
+	for _, ls := range seen {
+		assert.Equal(t, testAssetDID, ls.AssetDid)
+		assert.True(t, now.Equal(ls.SeenAt))
+	}
+	assert.Empty(t, tracker.Drain())
+}
+
+func TestScheduler_Check(t *testing.T) {
+	t.Parallel()
+
+	lastSeen := time.Now().Add(-2 * time.Hour)
+	newScheduler := func(t *testing.T) (*Scheduler, *Tracker, *MockRepository, *MockHandler) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepository(ctrl)
+		handler := NewMockHandler(ctrl)
+		tracker := NewTracker()
+		return NewScheduler(repo, tracker, handler, &config.Settings{}), tracker, repo, handler
+	}
+
+	t.Run("vehicles go offline and come back online", func(t *testing.T) {
+		scheduler, tracker, repo, handler := newScheduler(t)
+		ctx := context.Background()
+		seenAt := time.Now()
+		absentSince := lastSeen.Add(time.Hour)
+		tracker.Seen(testTriggerID, testAssetDID, seenAt)
+
+		repo.EXPECT().TrackAbsenceSubscriptions(gomock.Any()).Return(int64(0), nil)
+		repo.EXPECT().
+			RecordLastSeen(gomock.Any(), []triggersrepo.LastSeen{{TriggerID: testTriggerID, AssetDid: testAssetDID, SeenAt: seenAt}}).
+			Return([]triggersrepo.AbsenceState{{TriggerID: testTriggerID, AssetDid: testAssetDID, MetricName: triggersrepo.AnySignal, LastSeenAt: lastSeen, AbsentSince: absentSince, SeenAt: null.TimeFrom(seenAt)}}, nil)
+		repo.EXPECT().
+			ClaimAbsentVehicles(gomock.Any(), batchSize, defaultCheckInterval).
+			Return([]triggersrepo.AbsenceState{{TriggerID: "other-trigger-id", AssetDid: testAssetDID, MetricName: "vss.speed", LastSeenAt: lastSeen, AbsentSince: absentSince}}, nil)
+
+		handler.EXPECT().
+			HandleAbsence(gomock.Any(), Transition{TriggerID: testTriggerID, AssetDID: testAssetDID, MetricName: triggersrepo.AnySignal, Offline: false, LastSeenAt: lastSeen, At: seenAt}).
+			Return(nil)
+		handler.EXPECT().
+			HandleAbsence(gomock.Any(), Transition{TriggerID: "other-trigger-id", AssetDID: testAssetDID, MetricName: "vss.speed", Offline: true, LastSeenAt: lastSeen, At: absentSince}).
+			DoAndReturn(func(_ context.Context, transition Transition) error {
+				assert.Equal(t, time.Hour, transition.SilentFor())
+				return errors.New("delivery failed")
+			})
+
+		require.NoError(t, scheduler.Check(ctx))
+		assert.Empty(t, tracker.Drain())
+	})
+
+	t.Run("last seen timestamps are kept when they can not be stored", func(t *testing.T) {
+		scheduler, tracker, repo, _ := newScheduler(t)
+		ctx := context.Background()
+		seenAt := time.Now()
+		tracker.Seen(testTriggerID, testAssetDID, seenAt)
+
+		repo.EXPECT().TrackAbsenceSubscriptions(gomock.Any()).Return(int64(0), nil)
+		repo.EXPECT().RecordLastSeen(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		require.Error(t, scheduler.Check(ctx))
+		assert.Equal(t, []triggersrepo.LastSeen{{TriggerID: testTriggerID, AssetDid: testAssetDID, SeenAt: seenAt}}, tracker.Drain())
+	})
+
+	t.Run("claims until the backlog is drained", func(t *testing.T) {
+		scheduler, _, repo, handler := newScheduler(t)
+		ctx := context.Background()
+		full := make([]triggersrepo.AbsenceState, batchSize)
+		for i := range full {
+			full[i] = triggersrepo.AbsenceState{TriggerID: testTriggerID, AssetDid: testAssetDID, LastSeenAt: lastSeen, AbsentSince: time.Now()}
+		}
+
+		repo.EXPECT().TrackAbsenceSubscriptions(gomock.Any()).Return(int64(1), nil)
+		gomock.InOrder(
+			repo.EXPECT().ClaimAbsentVehicles(gomock.Any(), batchSize, defaultCheckInterval).Return(full, nil),
+			repo.EXPECT().ClaimAbsentVehicles(gomock.Any(), batchSize, defaultCheckInterval).Return(nil, nil),
+		)
+		handler.EXPECT().HandleAbsence(gomock.Any(), gomock.Any()).Return(nil).Times(batchSize)
+
+		require.NoError(t, scheduler.Check(ctx))
+	})
+}
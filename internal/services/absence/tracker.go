@@ -0,0 +1,51 @@
+// Package absence detects vehicles that stop sending signals to absence triggers, and reports when they send
+// signals again.
+package absence
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+)
+
+type seenKey struct {
+	triggerID string
+	assetDID  string
+}
+
+// Tracker collects the latest time each absence trigger saw a signal of a vehicle in memory, so the signal
+// consumer does not write to the database for every signal. The Scheduler stores the collected timestamps.
+type Tracker struct {
+	mu   sync.Mutex
+	seen map[seenKey]time.Time
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{seen: make(map[seenKey]time.Time)}
+}
+
+// Seen records that the absence trigger saw a signal of the vehicle at the given time.
+func (t *Tracker) Seen(triggerID, assetDID string, at time.Time) {
+	key := seenKey{triggerID: triggerID, assetDID: assetDID}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at.After(t.seen[key]) {
+		t.seen[key] = at
+	}
+}
+
+// Drain returns the timestamps recorded since the last drain and forgets them.
+func (t *Tracker) Drain() []triggersrepo.LastSeen {
+	t.mu.Lock()
+	seen := t.seen
+	t.seen = make(map[seenKey]time.Time, len(seen))
+	t.mu.Unlock()
+
+	out := make([]triggersrepo.LastSeen, 0, len(seen))
+	for key, at := range seen {
+		out = append(out, triggersrepo.LastSeen{TriggerID: key.triggerID, AssetDid: key.assetDID, SeenAt: at})
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+package cachesync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	// pingInterval is how often the connection is checked while no notifications arrive, so that a connection
+	// that died silently is noticed and re-established.
+	pingInterval = 90 * time.Second
+)
+
+type Repository interface {
+	InternalGetVehicleSubscription(ctx context.Context, triggerID string, assetDid string) (*models.VehicleSubscription, error)
+	InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error)
+}
+
+type WebhookCache interface {
+	AddSubscription(ctx context.Context, sub *models.VehicleSubscription)
+	RemoveSubscription(triggerID, assetDID string)
+	ReplaceTrigger(ctx context.Context, trigger *models.Trigger)
+	RemoveTrigger(triggerID string)
+	ScheduleRefresh(ctx context.Context)
+}
+
+// Listener applies the changes notified by the repository of any replica to the webhook cache of this replica.
+// A replica also receives its own changes, which it has already applied; applying them again reloads the same
+// rows. Changes written while the connection is down are lost, so the cache is rebuilt once it is re-established.
+type Listener struct {
+	listener *pq.Listener
+	repo     Repository
+	cache    WebhookCache
+}
+
+// NewListener connects to the database and starts listening for changes. The changes are held until Run is
+// called, so the ones written while the cache is populated are applied afterwards.
+func NewListener(ctx context.Context, repo Repository, cache WebhookCache, settings *config.Settings) (*Listener, error) {
+	logger := zerolog.Ctx(ctx)
+	listener := pq.NewListener(settings.DB.BuildConnectionString(true), minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Warn().Err(err).Int("event", int(event)).Msg("change listener connection failed")
+		}
+	})
+	if err := listener.Listen(triggersrepo.ChangesChannel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to listen for changes: %w", err)
+	}
+	return &Listener{
+		listener: listener,
+		repo:     repo,
+		cache:    cache,
+	}, nil
+}
+
+// Run applies the notified changes until the context is canceled.
+func (l *Listener) Run(ctx context.Context) error {
+	defer func() {
+		_ = l.listener.Close()
+	}()
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-l.listener.Notify:
+			l.handle(ctx, notification)
+		case <-ticker.C:
+			go func() {
+				// A failed ping makes the listener reconnect.
+				_ = l.listener.Ping()
+			}()
+		}
+	}
+}
+
+// handle applies a notification to the cache. The listener sends a nil notification after it reconnected, in
+// which case the cache is rebuilt. A change that can not be applied schedules a rebuild as well.
+func (l *Listener) handle(ctx context.Context, notification *pq.Notification) {
+	logger := zerolog.Ctx(ctx)
+	if notification == nil {
+		logger.Warn().Msg("change listener reconnected, rebuilding webhook cache")
+		l.cache.ScheduleRefresh(ctx)
+		return
+	}
+	var change triggersrepo.Change
+	if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+		logger.Error().Err(err).Str("payload", notification.Extra).Msg("failed to parse change, rebuilding webhook cache")
+		l.cache.ScheduleRefresh(ctx)
+		return
+	}
+	if err := l.apply(ctx, change); err != nil {
+		logger.Error().Err(err).Str("operation", change.Operation).Str("trigger_id", change.TriggerID).Msg("failed to apply change, rebuilding webhook cache")
+		l.cache.ScheduleRefresh(ctx)
+	}
+}
+
+// apply updates the cache with the current state of the changed rows, so that changes arriving out of date do
+// not undo later ones.
+func (l *Listener) apply(ctx context.Context, change triggersrepo.Change) error {
+	switch change.Operation {
+	case triggersrepo.ChangeSubscriptionSaved:
+		sub, err := l.repo.InternalGetVehicleSubscription(ctx, change.TriggerID, change.AssetDID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The vehicle was unsubscribed since.
+			l.cache.RemoveSubscription(change.TriggerID, change.AssetDID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		l.cache.AddSubscription(ctx, sub)
+	case triggersrepo.ChangeSubscriptionDeleted:
+		l.cache.RemoveSubscription(change.TriggerID, change.AssetDID)
+	case triggersrepo.ChangeSubscriptionsDeleted, triggersrepo.ChangeTriggerDeleted:
+		l.cache.RemoveTrigger(change.TriggerID)
+	case triggersrepo.ChangeTriggerUpdated:
+		trigger, err := l.repo.InternalGetTriggerByID(ctx, change.TriggerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The trigger was deleted since.
+			l.cache.RemoveTrigger(change.TriggerID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		l.cache.ReplaceTrigger(ctx, trigger)
+	case triggersrepo.ChangeGeofencesUpdated:
+		// Geofences are compiled into the conditions of every trigger of the developer license.
+		l.cache.ScheduleRefresh(ctx)
+	default:
+		return fmt.Errorf("unknown change operation %q", change.Operation)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: listener.go
+//
+// Generated by this command:
+//
+//	mockgen -source=listener.go -destination=listener_mock_test.go -package=cachesync
+//
+
+// Package cachesync is a generated GoMock package.
+package cachesync
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// InternalGetTriggerByID mocks base method.
+func (m *MockRepository) InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalGetTriggerByID", ctx, triggerID)
+	ret0, _ := ret[0].(*models.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InternalGetTriggerByID indicates an expected call of InternalGetTriggerByID.
+func (mr *MockRepositoryMockRecorder) InternalGetTriggerByID(ctx, triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetTriggerByID", reflect.TypeOf((*MockRepository)(nil).InternalGetTriggerByID), ctx, triggerID)
+}
+
+// InternalGetVehicleSubscription mocks base method.
+func (m *MockRepository) InternalGetVehicleSubscription(ctx context.Context, triggerID, assetDid string) (*models.VehicleSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalGetVehicleSubscription", ctx, triggerID, assetDid)
+	ret0, _ := ret[0].(*models.VehicleSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InternalGetVehicleSubscription indicates an expected call of InternalGetVehicleSubscription.
+func (mr *MockRepositoryMockRecorder) InternalGetVehicleSubscription(ctx, triggerID, assetDid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).InternalGetVehicleSubscription), ctx, triggerID, assetDid)
+}
+
+// MockWebhookCache is a mock of WebhookCache interface.
+type MockWebhookCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookCacheMockRecorder is the mock recorder for MockWebhookCache.
+type MockWebhookCacheMockRecorder struct {
+	mock *MockWebhookCache
+}
+
+// NewMockWebhookCache creates a new mock instance.
+func NewMockWebhookCache(ctrl *gomock.Controller) *MockWebhookCache {
+	mock := &MockWebhookCache{ctrl: ctrl}
+	mock.recorder = &MockWebhookCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookCache) EXPECT() *MockWebhookCacheMockRecorder {
+	return m.recorder
+}
+
+// AddSubscription mocks base method.
+func (m *MockWebhookCache) AddSubscription(ctx context.Context, sub *models.VehicleSubscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubscription", ctx, sub)
+}
+
+// AddSubscription indicates an expected call of AddSubscription.
+func (mr *MockWebhookCacheMockRecorder) AddSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscription", reflect.TypeOf((*MockWebhookCache)(nil).AddSubscription), ctx, sub)
+}
+
+// RemoveSubscription mocks base method.
+func (m *MockWebhookCache) RemoveSubscription(triggerID, assetDID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveSubscription", triggerID, assetDID)
+}
+
+// RemoveSubscription indicates an expected call of RemoveSubscription.
+func (mr *MockWebhookCacheMockRecorder) RemoveSubscription(triggerID, assetDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockWebhookCache)(nil).RemoveSubscription), triggerID, assetDID)
+}
+
+// RemoveTrigger mocks base method.
+func (m *MockWebhookCache) RemoveTrigger(triggerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveTrigger", triggerID)
+}
+
+// RemoveTrigger indicates an expected call of RemoveTrigger.
+func (mr *MockWebhookCacheMockRecorder) RemoveTrigger(triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrigger", reflect.TypeOf((*MockWebhookCache)(nil).RemoveTrigger), triggerID)
+}
+
+// ReplaceTrigger mocks base method.
+func (m *MockWebhookCache) ReplaceTrigger(ctx context.Context, trigger *models.Trigger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReplaceTrigger", ctx, trigger)
+}
+
+// ReplaceTrigger indicates an expected call of ReplaceTrigger.
+func (mr *MockWebhookCacheMockRecorder) ReplaceTrigger(ctx, trigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTrigger", reflect.TypeOf((*MockWebhookCache)(nil).ReplaceTrigger), ctx, trigger)
+}
+
+// ScheduleRefresh mocks base method.
+func (m *MockWebhookCache) ScheduleRefresh(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ScheduleRefresh", ctx)
+}
+
+// ScheduleRefresh indicates an expected call of ScheduleRefresh.
+func (mr *MockWebhookCacheMockRecorder) ScheduleRefresh(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRefresh", reflect.TypeOf((*MockWebhookCache)(nil).ScheduleRefresh), ctx)
+}
@@ -0,0 +1,137 @@
+//go:generate go tool mockgen -source=listener.go -destination=listener_mock_test.go -package=cachesync
+package cachesync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/lib/pq"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListener_Handle(t *testing.T) {
+	t.Parallel()
+
+	const (
+		triggerID = "trigger-1"
+		assetDID  = "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:42"
+	)
+	notification := func(payload string) *pq.Notification {
+		return &pq.Notification{Channel: triggersrepo.ChangesChannel, Extra: payload}
+	}
+
+	tests := []struct {
+		name         string
+		notification *pq.Notification
+		expect       func(repo *MockRepository, cache *MockWebhookCache)
+	}{
+		{
+			name:         "saved subscription is added",
+			notification: notification(`{"op":"subscriptionSaved","triggerId":"trigger-1","assetDid":"` + assetDID + `"}`),
+			expect: func(repo *MockRepository, cache *MockWebhookCache) {
+				sub := &models.VehicleSubscription{TriggerID: triggerID, AssetDid: assetDID}
+				repo.EXPECT().InternalGetVehicleSubscription(gomock.Any(), triggerID, assetDID).Return(sub, nil)
+				cache.EXPECT().AddSubscription(gomock.Any(), sub)
+			},
+		},
+		{
+			name:         "saved subscription that was deleted since is removed",
+			notification: notification(`{"op":"subscriptionSaved","triggerId":"trigger-1","assetDid":"` + assetDID + `"}`),
+			expect: func(repo *MockRepository, cache *MockWebhookCache) {
+				repo.EXPECT().InternalGetVehicleSubscription(gomock.Any(), triggerID, assetDID).Return(nil, sql.ErrNoRows)
+				cache.EXPECT().RemoveSubscription(triggerID, assetDID)
+			},
+		},
+		{
+			name:         "deleted subscription is removed",
+			notification: notification(`{"op":"subscriptionDeleted","triggerId":"trigger-1","assetDid":"` + assetDID + `"}`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().RemoveSubscription(triggerID, assetDID)
+			},
+		},
+		{
+			name:         "deleted subscriptions remove the trigger",
+			notification: notification(`{"op":"subscriptionsDeleted","triggerId":"trigger-1"}`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().RemoveTrigger(triggerID)
+			},
+		},
+		{
+			name:         "deleted trigger is removed",
+			notification: notification(`{"op":"triggerDeleted","triggerId":"trigger-1"}`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().RemoveTrigger(triggerID)
+			},
+		},
+		{
+			name:         "updated trigger is replaced",
+			notification: notification(`{"op":"triggerUpdated","triggerId":"trigger-1"}`),
+			expect: func(repo *MockRepository, cache *MockWebhookCache) {
+				trigger := &models.Trigger{ID: triggerID}
+				repo.EXPECT().InternalGetTriggerByID(gomock.Any(), triggerID).Return(trigger, nil)
+				cache.EXPECT().ReplaceTrigger(gomock.Any(), trigger)
+			},
+		},
+		{
+			name:         "updated trigger that was deleted since is removed",
+			notification: notification(`{"op":"triggerUpdated","triggerId":"trigger-1"}`),
+			expect: func(repo *MockRepository, cache *MockWebhookCache) {
+				repo.EXPECT().InternalGetTriggerByID(gomock.Any(), triggerID).Return(nil, sql.ErrNoRows)
+				cache.EXPECT().RemoveTrigger(triggerID)
+			},
+		},
+		{
+			name:         "failed lookup rebuilds the cache",
+			notification: notification(`{"op":"triggerUpdated","triggerId":"trigger-1"}`),
+			expect: func(repo *MockRepository, cache *MockWebhookCache) {
+				repo.EXPECT().InternalGetTriggerByID(gomock.Any(), triggerID).Return(nil, errors.New("db down"))
+				cache.EXPECT().ScheduleRefresh(gomock.Any())
+			},
+		},
+		{
+			name:         "updated geofences rebuild the cache",
+			notification: notification(`{"op":"geofencesUpdated"}`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().ScheduleRefresh(gomock.Any())
+			},
+		},
+		{
+			name:         "unknown operation rebuilds the cache",
+			notification: notification(`{"op":"somethingElse"}`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().ScheduleRefresh(gomock.Any())
+			},
+		},
+		{
+			name:         "malformed payload rebuilds the cache",
+			notification: notification(`not json`),
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().ScheduleRefresh(gomock.Any())
+			},
+		},
+		{
+			name:         "reconnect rebuilds the cache",
+			notification: nil,
+			expect: func(_ *MockRepository, cache *MockWebhookCache) {
+				cache.EXPECT().ScheduleRefresh(gomock.Any())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repo := NewMockRepository(ctrl)
+			cache := NewMockWebhookCache(ctrl)
+			tt.expect(repo, cache)
+
+			listener := &Listener{repo: repo, cache: cache}
+			listener.handle(context.Background(), tt.notification)
+		})
+	}
+}
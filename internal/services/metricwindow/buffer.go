@@ -0,0 +1,102 @@
+package metricwindow
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+)
+
+const (
+	defaultMaxSamples = 1000
+	// pruneInterval is how often series that stopped receiving samples are dropped.
+	pruneInterval = 10 * time.Minute
+)
+
+type seriesKey struct {
+	vehicleDID string
+	metric     string
+}
+
+type series struct {
+	samples []celcondition.WindowSample
+	window  time.Duration
+	// lastAdded is the wall clock time a sample was last added, used to drop idle series.
+	lastAdded time.Time
+}
+
+// Buffer keeps the recent samples of every (vehicle, metric) pair that a condition aggregates over.
+// It is safe for concurrent use.
+type Buffer struct {
+	mu         sync.Mutex
+	series     map[seriesKey]*series
+	maxSamples int
+	lastPrune  time.Time
+	now        func() time.Time
+}
+
+// NewBuffer creates a Buffer that keeps at most maxSamples samples per vehicle and metric.
+func NewBuffer(maxSamples int) *Buffer {
+	if maxSamples < 1 {
+		maxSamples = defaultMaxSamples
+	}
+	return &Buffer{
+		series:     make(map[seriesKey]*series),
+		maxSamples: maxSamples,
+		now:        time.Now,
+	}
+}
+
+// Add records a sample of metric for the vehicle and returns a copy of the samples of the series, including the
+// new one. Samples older than window before the latest sample are dropped.
+func (b *Buffer) Add(vehicleDID, metric string, sample celcondition.WindowSample, window time.Duration) []celcondition.WindowSample {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.pruneLocked(now)
+
+	key := seriesKey{vehicleDID: vehicleDID, metric: metric}
+	s, ok := b.series[key]
+	if !ok {
+		s = &series{}
+		b.series[key] = s
+	}
+	s.window = window
+	s.lastAdded = now
+
+	// Samples can arrive out of order, keep them sorted by timestamp.
+	i := sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].Timestamp.After(sample.Timestamp)
+	})
+	s.samples = append(s.samples, celcondition.WindowSample{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = sample
+
+	latest := s.samples[len(s.samples)-1].Timestamp
+	start := sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].Timestamp.After(latest.Add(-window))
+	})
+	start = max(start, len(s.samples)-b.maxSamples)
+	if start > 0 {
+		s.samples = append(s.samples[:0], s.samples[start:]...)
+	}
+
+	out := make([]celcondition.WindowSample, len(s.samples))
+	copy(out, s.samples)
+	return out
+}
+
+// pruneLocked drops the series that did not receive a sample during their window.
+func (b *Buffer) pruneLocked(now time.Time) {
+	if now.Sub(b.lastPrune) < pruneInterval {
+		return
+	}
+	b.lastPrune = now
+	for key, s := range b.series {
+		if now.Sub(s.lastAdded) > s.window {
+			delete(b.series, key)
+		}
+	}
+}
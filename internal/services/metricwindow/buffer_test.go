@@ -0,0 +1,74 @@
+package metricwindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration, v float64) celcondition.WindowSample {
+		return celcondition.WindowSample{Timestamp: start.Add(d), Value: v}
+	}
+
+	t.Run("keeps samples within the window in order", func(t *testing.T) {
+		t.Parallel()
+		b := NewBuffer(100)
+		b.Add("did:1", "speed", at(0, 1), 5*time.Minute)
+		b.Add("did:1", "speed", at(2*time.Minute, 3), 5*time.Minute)
+		got := b.Add("did:1", "speed", at(time.Minute, 2), 5*time.Minute)
+		assert.Equal(t, []celcondition.WindowSample{at(0, 1), at(time.Minute, 2), at(2*time.Minute, 3)}, got)
+
+		got = b.Add("did:1", "speed", at(6*time.Minute, 4), 5*time.Minute)
+		assert.Equal(t, []celcondition.WindowSample{at(2*time.Minute, 3), at(6*time.Minute, 4)}, got)
+	})
+
+	t.Run("series are separate per vehicle and metric", func(t *testing.T) {
+		t.Parallel()
+		b := NewBuffer(100)
+		b.Add("did:1", "speed", at(0, 1), time.Hour)
+		b.Add("did:2", "speed", at(0, 2), time.Hour)
+		got := b.Add("did:1", "fuel", at(time.Second, 3), time.Hour)
+		assert.Equal(t, []celcondition.WindowSample{at(time.Second, 3)}, got)
+	})
+
+	t.Run("caps the samples per series", func(t *testing.T) {
+		t.Parallel()
+		b := NewBuffer(3)
+		var got []celcondition.WindowSample
+		for i := range 5 {
+			got = b.Add("did:1", "speed", at(time.Duration(i)*time.Second, float64(i)), time.Hour)
+		}
+		assert.Equal(t, []celcondition.WindowSample{at(2*time.Second, 2), at(3*time.Second, 3), at(4*time.Second, 4)}, got)
+	})
+
+	t.Run("returns a copy", func(t *testing.T) {
+		t.Parallel()
+		b := NewBuffer(100)
+		got := b.Add("did:1", "speed", at(0, 1), time.Hour)
+		got[0].Value = 42
+		got = b.Add("did:1", "speed", at(time.Second, 2), time.Hour)
+		assert.Equal(t, float64(1), got[0].Value)
+	})
+
+	t.Run("drops idle series", func(t *testing.T) {
+		t.Parallel()
+		b := NewBuffer(100)
+		now := start
+		b.now = func() time.Time { return now }
+		b.Add("did:1", "speed", at(0, 1), time.Minute)
+		b.Add("did:2", "speed", at(0, 1), time.Hour)
+		require.Len(t, b.series, 2)
+
+		now = now.Add(pruneInterval + time.Second)
+		b.Add("did:3", "speed", at(0, 1), time.Minute)
+		assert.Len(t, b.series, 2)
+		assert.NotContains(t, b.series, seriesKey{vehicleDID: "did:1", metric: "speed"})
+	})
+}
@@ -0,0 +1,56 @@
+// Package signalstate keeps the latest value of the signals of each vehicle that composite conditions read.
+package signalstate
+
+import (
+	"sync"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+)
+
+// Store is an in-memory map: assetDID -> signal name -> latest signal. It only holds the signals it is given, so
+// callers only record signals that a condition reads. It is safe for concurrent use.
+type Store struct {
+	mu       sync.RWMutex
+	vehicles map[string]map[string]vss.Signal
+}
+
+// NewStore creates a new Store.
+func NewStore() *Store {
+	return &Store{vehicles: make(map[string]map[string]vss.Signal)}
+}
+
+// Update records sig as the latest value of its signal for the vehicle, unless a signal with a later timestamp
+// was already recorded. It reports whether sig was recorded.
+func (s *Store) Update(vehicleDID string, sig vss.Signal) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latest, ok := s.vehicles[vehicleDID]
+	if !ok {
+		latest = make(map[string]vss.Signal)
+		s.vehicles[vehicleDID] = latest
+	}
+	if current, ok := latest[sig.Data.Name]; ok && current.Data.Timestamp.After(sig.Data.Timestamp) {
+		return false
+	}
+	latest[sig.Data.Name] = sig
+	return true
+}
+
+// Latest returns the latest values of the named signals of the vehicle keyed by signal name, and whether all of
+// them have been recorded.
+func (s *Store) Latest(vehicleDID string, names []string) (map[string]vss.Signal, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	latest := s.vehicles[vehicleDID]
+	out := make(map[string]vss.Signal, len(names))
+	for _, name := range names {
+		sig, ok := latest[name]
+		if !ok {
+			return nil, false
+		}
+		out[name] = sig
+	}
+	return out, true
+}
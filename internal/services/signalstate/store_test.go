@@ -0,0 +1,54 @@
+package signalstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signal := func(name string, d time.Duration, v float64) vss.Signal {
+		return vss.Signal{Data: vss.SignalData{Name: name, Timestamp: start.Add(d), ValueNumber: v}}
+	}
+
+	t.Run("returns the latest values once all signals are recorded", func(t *testing.T) {
+		t.Parallel()
+		s := NewStore()
+		assert.True(t, s.Update("did:1", signal("speed", 0, 10)))
+		_, ok := s.Latest("did:1", []string{"speed", "isIgnitionOn"})
+		assert.False(t, ok)
+
+		assert.True(t, s.Update("did:1", signal("isIgnitionOn", 0, 0)))
+		assert.True(t, s.Update("did:1", signal("speed", time.Minute, 20)))
+		got, ok := s.Latest("did:1", []string{"speed", "isIgnitionOn"})
+		require.True(t, ok)
+		assert.Equal(t, map[string]vss.Signal{
+			"speed":        signal("speed", time.Minute, 20),
+			"isIgnitionOn": signal("isIgnitionOn", 0, 0),
+		}, got)
+	})
+
+	t.Run("ignores signals older than the recorded one", func(t *testing.T) {
+		t.Parallel()
+		s := NewStore()
+		assert.True(t, s.Update("did:1", signal("speed", time.Minute, 20)))
+		assert.False(t, s.Update("did:1", signal("speed", 0, 10)))
+		got, ok := s.Latest("did:1", []string{"speed"})
+		require.True(t, ok)
+		assert.InDelta(t, 20, got["speed"].Data.ValueNumber, 0)
+	})
+
+	t.Run("vehicles are separate", func(t *testing.T) {
+		t.Parallel()
+		s := NewStore()
+		s.Update("did:1", signal("speed", 0, 10))
+		_, ok := s.Latest("did:2", []string{"speed"})
+		assert.False(t, ok)
+	})
+}
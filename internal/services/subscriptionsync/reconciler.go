@@ -0,0 +1,186 @@
+package subscriptionsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultReconcileInterval = 15 * time.Minute
+	// pollInterval is how often the reconciler looks for triggers that are due, so that triggers that just became
+	// allShared are synced soon.
+	pollInterval = 30 * time.Second
+	// batchSize is the number of triggers claimed at once.
+	batchSize = 10
+	// lease is how long other instances skip a claimed trigger if the reconciler dies before recording the outcome.
+	lease = 10 * time.Minute
+)
+
+type Repository interface {
+	ClaimTriggersToReconcile(ctx context.Context, limit int, interval, lease time.Duration) (models.TriggerSlice, error)
+	RecordTriggerReconciliation(ctx context.Context, triggerID string, reconciliation triggersrepo.Reconciliation) error
+	GetVehicleSubscriptionsByTriggerID(ctx context.Context, triggerID string) ([]*models.VehicleSubscription, error)
+	CreateVehicleSubscription(ctx context.Context, assetDid cloudevent.ERC721DID, triggerID string, params null.JSON) (*models.VehicleSubscription, error)
+	DeleteVehicleSubscription(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (int64, error)
+}
+
+type IdentityClient interface {
+	GetSharedVehicles(ctx context.Context, developerLicense []byte) ([]cloudevent.ERC721DID, error)
+}
+
+type TokenExchangeClient interface {
+	HasVehiclePermissions(ctx context.Context, assetDid cloudevent.ERC721DID, developerLicense common.Address, permissions []string) (bool, error)
+}
+
+type WebhookCache interface {
+	AddSubscription(ctx context.Context, sub *models.VehicleSubscription)
+	RemoveSubscription(triggerID, assetDID string)
+}
+
+// Reconciler keeps the subscriptions of allShared triggers in sync with the vehicles shared with their developer
+// license. Vehicles that are shared later are subscribed, and vehicles that are no longer shared or lost the
+// permissions the trigger needs are unsubscribed.
+type Reconciler struct {
+	repo                Repository
+	identityClient      IdentityClient
+	tokenExchangeClient TokenExchangeClient
+	cache               WebhookCache
+	interval            time.Duration
+}
+
+// NewReconciler creates a new Reconciler.
+func NewReconciler(repo Repository, identityClient IdentityClient, tokenExchangeClient TokenExchangeClient, cache WebhookCache, settings *config.Settings) *Reconciler {
+	interval := settings.SubscriptionReconcileInterval
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	return &Reconciler{
+		repo:                repo,
+		identityClient:      identityClient,
+		tokenExchangeClient: tokenExchangeClient,
+		cache:               cache,
+		interval:            interval,
+	}
+}
+
+// Run reconciles the triggers that are due until the context is canceled.
+func (r *Reconciler) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := r.Reconcile(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to reconcile subscriptions")
+		}
+	}
+}
+
+// Reconcile syncs the subscriptions of the allShared triggers that have not been synced within the reconcile
+// interval, or since they were changed, and records the outcome on each trigger.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	for {
+		triggers, err := r.repo.ClaimTriggersToReconcile(ctx, batchSize, r.interval, lease)
+		if err != nil {
+			return err
+		}
+		for _, trigger := range triggers {
+			reconciliation := r.reconcileTrigger(ctx, trigger)
+			if err := r.repo.RecordTriggerReconciliation(ctx, trigger.ID, reconciliation); err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", trigger.ID).Msg("failed to record reconciliation")
+			}
+		}
+		if len(triggers) < batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// reconcileTrigger subscribes the shared vehicles that have the permissions the trigger needs, and unsubscribes
+// the subscribed vehicles that do not. A vehicle whose permissions can not be checked stays as it is until the
+// next reconciliation, and nothing is unsubscribed if the shared vehicles can not be fetched.
+func (r *Reconciler) reconcileTrigger(ctx context.Context, trigger *models.Trigger) triggersrepo.Reconciliation {
+	logger := zerolog.Ctx(ctx).With().Str("triggerId", trigger.ID).Logger()
+	var reconciliation triggersrepo.Reconciliation
+
+	shared, err := r.identityClient.GetSharedVehicles(ctx, trigger.DeveloperLicenseAddress)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get shared vehicles")
+		reconciliation.Error = "Failed to get the vehicles shared with the developer license"
+		return reconciliation
+	}
+	subs, err := r.repo.GetVehicleSubscriptionsByTriggerID(ctx, trigger.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get subscribed vehicles")
+		reconciliation.Error = "Failed to get the subscribed vehicles"
+		return reconciliation
+	}
+	subscribed := make(map[string]bool, len(subs))
+	for _, sub := range subs {
+		subscribed[sub.AssetDid] = true
+	}
+
+	developerLicense := common.BytesToAddress(trigger.DeveloperLicenseAddress)
+	permissions := celcondition.TriggerPermissions(trigger)
+	keep := make(map[string]bool, len(shared))
+	failed := 0
+	for _, assetDid := range shared {
+		did := assetDid.String()
+		hasPerm, err := r.tokenExchangeClient.HasVehiclePermissions(ctx, assetDid, developerLicense, permissions)
+		if err != nil {
+			logger.Warn().Err(err).Str("assetDid", did).Msg("failed to check vehicle permissions")
+			keep[did] = true
+			failed++
+			continue
+		}
+		if !hasPerm {
+			continue
+		}
+		keep[did] = true
+		if subscribed[did] {
+			continue
+		}
+		sub, err := r.repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
+		if err != nil {
+			logger.Warn().Err(err).Str("assetDid", did).Msg("failed to subscribe shared vehicle")
+			failed++
+			continue
+		}
+		r.cache.AddSubscription(ctx, sub)
+		reconciliation.Added++
+	}
+	for _, sub := range subs {
+		if keep[sub.AssetDid] {
+			continue
+		}
+		assetDid, err := cloudevent.DecodeERC721DID(sub.AssetDid)
+		if err == nil {
+			_, err = r.repo.DeleteVehicleSubscription(ctx, trigger.ID, assetDid)
+		}
+		if err != nil {
+			logger.Warn().Err(err).Str("assetDid", sub.AssetDid).Msg("failed to unsubscribe vehicle")
+			failed++
+			continue
+		}
+		r.cache.RemoveSubscription(trigger.ID, sub.AssetDid)
+		reconciliation.Removed++
+	}
+	if failed > 0 {
+		reconciliation.Error = fmt.Sprintf("Failed to sync %d vehicles", failed)
+	}
+	return reconciliation
+}
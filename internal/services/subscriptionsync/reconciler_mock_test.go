@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reconciler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reconciler.go -destination=reconciler_mock_test.go -package=subscriptionsync
+//
+
+// Package subscriptionsync is a generated GoMock package.
+package subscriptionsync
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	triggersrepo "github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	null "github.com/aarondl/null/v8"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimTriggersToReconcile mocks base method.
+func (m *MockRepository) ClaimTriggersToReconcile(ctx context.Context, limit int, interval, lease time.Duration) (models.TriggerSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTriggersToReconcile", ctx, limit, interval, lease)
+	ret0, _ := ret[0].(models.TriggerSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTriggersToReconcile indicates an expected call of ClaimTriggersToReconcile.
+func (mr *MockRepositoryMockRecorder) ClaimTriggersToReconcile(ctx, limit, interval, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTriggersToReconcile", reflect.TypeOf((*MockRepository)(nil).ClaimTriggersToReconcile), ctx, limit, interval, lease)
+}
+
+// CreateVehicleSubscription mocks base method.
+func (m *MockRepository) CreateVehicleSubscription(ctx context.Context, assetDid cloudevent.ERC721DID, triggerID string, params null.JSON) (*models.VehicleSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVehicleSubscription", ctx, assetDid, triggerID, params)
+	ret0, _ := ret[0].(*models.VehicleSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVehicleSubscription indicates an expected call of CreateVehicleSubscription.
+func (mr *MockRepositoryMockRecorder) CreateVehicleSubscription(ctx, assetDid, triggerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).CreateVehicleSubscription), ctx, assetDid, triggerID, params)
+}
+
+// DeleteVehicleSubscription mocks base method.
+func (m *MockRepository) DeleteVehicleSubscription(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVehicleSubscription", ctx, triggerID, assetDid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVehicleSubscription indicates an expected call of DeleteVehicleSubscription.
+func (mr *MockRepositoryMockRecorder) DeleteVehicleSubscription(ctx, triggerID, assetDid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVehicleSubscription", reflect.TypeOf((*MockRepository)(nil).DeleteVehicleSubscription), ctx, triggerID, assetDid)
+}
+
+// GetVehicleSubscriptionsByTriggerID mocks base method.
+func (m *MockRepository) GetVehicleSubscriptionsByTriggerID(ctx context.Context, triggerID string) ([]*models.VehicleSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleSubscriptionsByTriggerID", ctx, triggerID)
+	ret0, _ := ret[0].([]*models.VehicleSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleSubscriptionsByTriggerID indicates an expected call of GetVehicleSubscriptionsByTriggerID.
+func (mr *MockRepositoryMockRecorder) GetVehicleSubscriptionsByTriggerID(ctx, triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSubscriptionsByTriggerID", reflect.TypeOf((*MockRepository)(nil).GetVehicleSubscriptionsByTriggerID), ctx, triggerID)
+}
+
+// RecordTriggerReconciliation mocks base method.
+func (m *MockRepository) RecordTriggerReconciliation(ctx context.Context, triggerID string, reconciliation triggersrepo.Reconciliation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTriggerReconciliation", ctx, triggerID, reconciliation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordTriggerReconciliation indicates an expected call of RecordTriggerReconciliation.
+func (mr *MockRepositoryMockRecorder) RecordTriggerReconciliation(ctx, triggerID, reconciliation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTriggerReconciliation", reflect.TypeOf((*MockRepository)(nil).RecordTriggerReconciliation), ctx, triggerID, reconciliation)
+}
+
+// MockIdentityClient is a mock of IdentityClient interface.
+type MockIdentityClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentityClientMockRecorder is the mock recorder for MockIdentityClient.
+type MockIdentityClientMockRecorder struct {
+	mock *MockIdentityClient
+}
+
+// NewMockIdentityClient creates a new mock instance.
+func NewMockIdentityClient(ctrl *gomock.Controller) *MockIdentityClient {
+	mock := &MockIdentityClient{ctrl: ctrl}
+	mock.recorder = &MockIdentityClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityClient) EXPECT() *MockIdentityClientMockRecorder {
+	return m.recorder
+}
+
+// GetSharedVehicles mocks base method.
+func (m *MockIdentityClient) GetSharedVehicles(ctx context.Context, developerLicense []byte) ([]cloudevent.ERC721DID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedVehicles", ctx, developerLicense)
+	ret0, _ := ret[0].([]cloudevent.ERC721DID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedVehicles indicates an expected call of GetSharedVehicles.
+func (mr *MockIdentityClientMockRecorder) GetSharedVehicles(ctx, developerLicense any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedVehicles", reflect.TypeOf((*MockIdentityClient)(nil).GetSharedVehicles), ctx, developerLicense)
+}
+
+// MockTokenExchangeClient is a mock of TokenExchangeClient interface.
+type MockTokenExchangeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenExchangeClientMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenExchangeClientMockRecorder is the mock recorder for MockTokenExchangeClient.
+type MockTokenExchangeClientMockRecorder struct {
+	mock *MockTokenExchangeClient
+}
+
+// NewMockTokenExchangeClient creates a new mock instance.
+func NewMockTokenExchangeClient(ctrl *gomock.Controller) *MockTokenExchangeClient {
+	mock := &MockTokenExchangeClient{ctrl: ctrl}
+	mock.recorder = &MockTokenExchangeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenExchangeClient) EXPECT() *MockTokenExchangeClientMockRecorder {
+	return m.recorder
+}
+
+// HasVehiclePermissions mocks base method.
+func (m *MockTokenExchangeClient) HasVehiclePermissions(ctx context.Context, assetDid cloudevent.ERC721DID, developerLicense common.Address, permissions []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasVehiclePermissions", ctx, assetDid, developerLicense, permissions)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasVehiclePermissions indicates an expected call of HasVehiclePermissions.
+func (mr *MockTokenExchangeClientMockRecorder) HasVehiclePermissions(ctx, assetDid, developerLicense, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasVehiclePermissions", reflect.TypeOf((*MockTokenExchangeClient)(nil).HasVehiclePermissions), ctx, assetDid, developerLicense, permissions)
+}
+
+// MockWebhookCache is a mock of WebhookCache interface.
+type MockWebhookCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookCacheMockRecorder is the mock recorder for MockWebhookCache.
+type MockWebhookCacheMockRecorder struct {
+	mock *MockWebhookCache
+}
+
+// NewMockWebhookCache creates a new mock instance.
+func NewMockWebhookCache(ctrl *gomock.Controller) *MockWebhookCache {
+	mock := &MockWebhookCache{ctrl: ctrl}
+	mock.recorder = &MockWebhookCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookCache) EXPECT() *MockWebhookCacheMockRecorder {
+	return m.recorder
+}
+
+// AddSubscription mocks base method.
+func (m *MockWebhookCache) AddSubscription(ctx context.Context, sub *models.VehicleSubscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubscription", ctx, sub)
+}
+
+// AddSubscription indicates an expected call of AddSubscription.
+func (mr *MockWebhookCacheMockRecorder) AddSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscription", reflect.TypeOf((*MockWebhookCache)(nil).AddSubscription), ctx, sub)
+}
+
+// RemoveSubscription mocks base method.
+func (m *MockWebhookCache) RemoveSubscription(triggerID, assetDID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveSubscription", triggerID, assetDID)
+}
+
+// RemoveSubscription indicates an expected call of RemoveSubscription.
+func (mr *MockWebhookCacheMockRecorder) RemoveSubscription(triggerID, assetDID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockWebhookCache)(nil).RemoveSubscription), triggerID, assetDID)
+}
@@ -0,0 +1,123 @@
+//go:generate go tool mockgen -source=reconciler.go -destination=reconciler_mock_test.go -package=subscriptionsync
+package subscriptionsync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testTriggerID = "test-trigger-id"
+
+var testDevLicense = common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+func testAssetDID(tokenID int64) cloudevent.ERC721DID {
+	return cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(tokenID),
+	}
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	trigger := &models.Trigger{
+		ID:                      testTriggerID,
+		Service:                 triggersrepo.ServiceEvent,
+		MetricName:              "behavior.harshBraking",
+		DeveloperLicenseAddress: testDevLicense.Bytes(),
+		SubscriptionMode:        triggersrepo.SubscriptionModeAllShared,
+	}
+	newReconciler := func(t *testing.T) (*Reconciler, *MockRepository, *MockIdentityClient, *MockTokenExchangeClient, *MockWebhookCache) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepository(ctrl)
+		identityClient := NewMockIdentityClient(ctrl)
+		tokenExchangeClient := NewMockTokenExchangeClient(ctrl)
+		cache := NewMockWebhookCache(ctrl)
+		return NewReconciler(repo, identityClient, tokenExchangeClient, cache, &config.Settings{}), repo, identityClient, tokenExchangeClient, cache
+	}
+
+	t.Run("subscribes shared vehicles and unsubscribes revoked ones", func(t *testing.T) {
+		reconciler, repo, identityClient, tokenExchangeClient, cache := newReconciler(t)
+		ctx := context.Background()
+		subscribed, added, noPermission, revoked := testAssetDID(1), testAssetDID(2), testAssetDID(3), testAssetDID(4)
+
+		repo.EXPECT().ClaimTriggersToReconcile(gomock.Any(), batchSize, defaultReconcileInterval, lease).Return(models.TriggerSlice{trigger}, nil)
+		identityClient.EXPECT().GetSharedVehicles(gomock.Any(), testDevLicense.Bytes()).Return([]cloudevent.ERC721DID{subscribed, added, noPermission}, nil)
+		repo.EXPECT().GetVehicleSubscriptionsByTriggerID(gomock.Any(), testTriggerID).Return([]*models.VehicleSubscription{
+			{TriggerID: testTriggerID, AssetDid: subscribed.String()},
+			{TriggerID: testTriggerID, AssetDid: revoked.String()},
+		}, nil)
+		tokenExchangeClient.EXPECT().HasVehiclePermissions(gomock.Any(), subscribed, testDevLicense, signals.DefaultPermissions).Return(true, nil)
+		tokenExchangeClient.EXPECT().HasVehiclePermissions(gomock.Any(), added, testDevLicense, signals.DefaultPermissions).Return(true, nil)
+		tokenExchangeClient.EXPECT().HasVehiclePermissions(gomock.Any(), noPermission, testDevLicense, signals.DefaultPermissions).Return(false, nil)
+		addedSub := &models.VehicleSubscription{TriggerID: testTriggerID, AssetDid: added.String()}
+		repo.EXPECT().CreateVehicleSubscription(gomock.Any(), added, testTriggerID, null.JSON{}).Return(addedSub, nil)
+		cache.EXPECT().AddSubscription(gomock.Any(), addedSub)
+		repo.EXPECT().DeleteVehicleSubscription(gomock.Any(), testTriggerID, revoked).Return(int64(1), nil)
+		cache.EXPECT().RemoveSubscription(testTriggerID, revoked.String())
+		repo.EXPECT().RecordTriggerReconciliation(gomock.Any(), testTriggerID, triggersrepo.Reconciliation{Added: 1, Removed: 1}).Return(nil)
+
+		require.NoError(t, reconciler.Reconcile(ctx))
+	})
+
+	t.Run("keeps vehicles whose permissions can not be checked", func(t *testing.T) {
+		reconciler, repo, identityClient, tokenExchangeClient, _ := newReconciler(t)
+		ctx := context.Background()
+		subscribed, unchecked := testAssetDID(1), testAssetDID(2)
+
+		repo.EXPECT().ClaimTriggersToReconcile(gomock.Any(), batchSize, defaultReconcileInterval, lease).Return(models.TriggerSlice{trigger}, nil)
+		identityClient.EXPECT().GetSharedVehicles(gomock.Any(), testDevLicense.Bytes()).Return([]cloudevent.ERC721DID{subscribed, unchecked}, nil)
+		repo.EXPECT().GetVehicleSubscriptionsByTriggerID(gomock.Any(), testTriggerID).Return([]*models.VehicleSubscription{
+			{TriggerID: testTriggerID, AssetDid: subscribed.String()},
+		}, nil)
+		tokenExchangeClient.EXPECT().HasVehiclePermissions(gomock.Any(), subscribed, testDevLicense, signals.DefaultPermissions).Return(false, errors.New("token exchange down"))
+		tokenExchangeClient.EXPECT().HasVehiclePermissions(gomock.Any(), unchecked, testDevLicense, signals.DefaultPermissions).Return(false, errors.New("token exchange down"))
+		repo.EXPECT().RecordTriggerReconciliation(gomock.Any(), testTriggerID, triggersrepo.Reconciliation{Error: "Failed to sync 2 vehicles"}).Return(nil)
+
+		require.NoError(t, reconciler.Reconcile(ctx))
+	})
+
+	t.Run("unsubscribes nothing when the shared vehicles can not be fetched", func(t *testing.T) {
+		reconciler, repo, identityClient, _, _ := newReconciler(t)
+		ctx := context.Background()
+
+		repo.EXPECT().ClaimTriggersToReconcile(gomock.Any(), batchSize, defaultReconcileInterval, lease).Return(models.TriggerSlice{trigger}, nil)
+		identityClient.EXPECT().GetSharedVehicles(gomock.Any(), testDevLicense.Bytes()).Return(nil, errors.New("identity api down"))
+		repo.EXPECT().RecordTriggerReconciliation(gomock.Any(), testTriggerID, triggersrepo.Reconciliation{Error: "Failed to get the vehicles shared with the developer license"}).Return(nil)
+
+		require.NoError(t, reconciler.Reconcile(ctx))
+	})
+
+	t.Run("claims until no trigger is due", func(t *testing.T) {
+		reconciler, repo, identityClient, _, _ := newReconciler(t)
+		ctx := context.Background()
+		full := make(models.TriggerSlice, batchSize)
+		for i := range full {
+			full[i] = trigger
+		}
+
+		gomock.InOrder(
+			repo.EXPECT().ClaimTriggersToReconcile(gomock.Any(), batchSize, defaultReconcileInterval, lease).Return(full, nil),
+			repo.EXPECT().ClaimTriggersToReconcile(gomock.Any(), batchSize, defaultReconcileInterval, lease).Return(nil, nil),
+		)
+		identityClient.EXPECT().GetSharedVehicles(gomock.Any(), gomock.Any()).Return(nil, nil).Times(batchSize)
+		repo.EXPECT().GetVehicleSubscriptionsByTriggerID(gomock.Any(), testTriggerID).Return(nil, nil).Times(batchSize)
+		repo.EXPECT().RecordTriggerReconciliation(gomock.Any(), testTriggerID, triggersrepo.Reconciliation{}).Return(nil).Times(batchSize)
+
+		require.NoError(t, reconciler.Reconcile(ctx))
+	})
+}
@@ -2,10 +2,11 @@ package triggerevaluator
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
-	"errors"
+	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/DIMO-Network/cloudevent"
@@ -13,29 +14,83 @@ import (
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/schedule"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/cel-go/cel"
 )
 
+// TriggerRepo holds the evaluation state of triggers per vehicle, and the firings of triggers with a global cap.
 type TriggerRepo interface {
-	GetLastLogValue(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerLog, error)
-	GetLastLogForMetric(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.TriggerLog, error)
+	GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error)
+	UpsertTriggerVehicleState(ctx context.Context, state *models.TriggerVehicleState) error
+	ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error)
 }
 
 // SignalEvaluationData is a struct that contains the data needed to evaluate a signal trigger.
 type SignalEvaluationData struct {
-	Signal     vss.Signal
-	VehicleDID cloudevent.ERC721DID
-	Def        signals.SignalDefinition
-	RawData    json.RawMessage
+	Signal vss.Signal
+	// Previous is the last signal of the same name observed for the vehicle, or the zero signal if there is none.
+	Previous vss.Signal
+	// PreviousFired is the signal of the same name that a trigger last fired for, or the zero signal if there is none.
+	PreviousFired vss.Signal
+	VehicleDID    cloudevent.ERC721DID
+	Def           signals.SignalDefinition
+	RawData       json.RawMessage
+	// Window holds the recent samples of the signal for aggregate functions; nil when no condition aggregates.
+	Window []celcondition.WindowSample
+	// Schedule is the schedule the trigger is active in, nil if it is always active.
+	Schedule *schedule.Schedule
+	// Params are the parameter values of the vehicle the conditions are evaluated with.
+	Params celcondition.Params
 }
 
 // EventEvaluationData is a struct that contains the data needed to evaluate an event trigger.
 type EventEvaluationData struct {
-	Event      vss.Event
+	Event vss.Event
+	// Previous is the last event of the same name observed for the vehicle, or the zero event if there is none.
+	Previous vss.Event
+	// PreviousFired is the event of the same name that a trigger last fired for, or the zero event if there is none.
+	PreviousFired vss.Event
+	VehicleDID    cloudevent.ERC721DID
+	RawData       json.RawMessage
+	// Window holds the recent events of the same name for aggregate functions; nil when no condition aggregates.
+	Window []celcondition.WindowSample
+	// Signals holds the latest values of the signals the condition reads keyed by signal name, nil if it reads none.
+	Signals map[string]vss.Signal
+	// Schedule is the schedule the trigger is active in, nil if it is always active.
+	Schedule *schedule.Schedule
+	// Params are the parameter values of the vehicle the conditions are evaluated with.
+	Params celcondition.Params
+}
+
+// AbsenceEvaluationData is a struct that contains the data needed to evaluate an absence trigger.
+type AbsenceEvaluationData struct {
+	VehicleDID cloudevent.ERC721DID
+	// Offline is true when the vehicle went silent and false when it came back online.
+	Offline bool
+	// LastSeenAt is the timestamp of the last signal before the vehicle went silent.
+	LastSeenAt time.Time
+	// SilentFor is how long the vehicle has been silent, or was silent before coming back online.
+	SilentFor time.Duration
+	// Schedule is the schedule the trigger is active in, nil if it is always active.
+	Schedule *schedule.Schedule
+	// Params are the parameter values of the vehicle the conditions are evaluated with.
+	Params celcondition.Params
+}
+
+// CompositeEvaluationData is a struct that contains the data needed to evaluate a composite trigger.
+type CompositeEvaluationData struct {
 	VehicleDID cloudevent.ERC721DID
-	RawData    json.RawMessage
+	// Signals are the latest values of the signals read by the condition, keyed by signal name.
+	Signals map[string]vss.Signal
+	RawData json.RawMessage
+	// Schedule is the schedule the trigger is active in, nil if it is always active.
+	Schedule *schedule.Schedule
+	// Params are the parameter values of the vehicle the conditions are evaluated with.
+	Params celcondition.Params
 }
 
 // TriggerEvaluator handles trigger condition evaluation and related logic
@@ -49,6 +104,16 @@ type TriggerEvaluationResult struct {
 	CoolDownNotMet   bool
 	PermissionDenied bool
 	ConditionNotMet  bool
+	// SustainNotMet is set when the condition holds but has not held for the trigger's sustain period yet.
+	SustainNotMet bool
+	// EdgeNotMet is set for edge triggers whose condition already held for the previous signal.
+	EdgeNotMet bool
+	// RateLimited is set when the trigger would fire but already fired its maximum number of times within its
+	// firing window, for the vehicle or across all vehicles.
+	RateLimited bool
+	// OutsideSchedule is set when the schedule of the trigger is not active at the time of the signal or event.
+	// The condition is not evaluated then.
+	OutsideSchedule bool
 }
 
 // TokenExchangeClient interface for permission checking
@@ -62,7 +127,12 @@ func NewTriggerEvaluator(r TriggerRepo, t TokenExchangeClient) *TriggerEvaluator
 }
 
 // EvaluateSignalTrigger evaluates a signal trigger and returns whether it should fire return true if it should fire, false if not.
-func (t *TriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, signal *SignalEvaluationData) (*TriggerEvaluationResult, error) {
+// clearProgram is the compiled clear condition of an edge trigger, or nil if it has none.
+func (t *TriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *models.Trigger, program, clearProgram cel.Program, signal *SignalEvaluationData) (*TriggerEvaluationResult, error) {
+	if !signal.Schedule.Active(observedAt(signal.Signal.Data.Timestamp)) {
+		return &TriggerEvaluationResult{OutsideSchedule: true}, nil
+	}
+
 	// Check permissions first
 	hasPerm, err := t.tokenClient.HasVehiclePermissions(ctx, signal.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), signal.Def.Permissions)
 	if err != nil {
@@ -79,18 +149,15 @@ func (t *TriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *m
 		}, nil
 	}
 
-	// Get last trigger log for cooldown (per-trigger)
-	lastTrigger, err := t.getLastLogValue(ctx, trigger.ID, signal.VehicleDID)
+	state, err := t.getTriggerVehicleState(ctx, trigger.ID, signal.VehicleDID)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to retrieve trigger logs for signal trigger",
+			ExternalMsg: "failed to retrieve vehicle state for signal trigger",
 		}
 	}
-
-	// Check cooldown
-	cooldownPassed, err := t.checkCooldown(trigger, lastTrigger.LastTriggeredAt)
+	cooldownPassed, err := t.checkCooldown(trigger, state.LastTriggeredAt.Time)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
@@ -98,58 +165,91 @@ func (t *TriggerEvaluator) EvaluateSignalTrigger(ctx context.Context, trigger *m
 			ExternalMsg: "failed to check cooldown",
 		}
 	}
-	if !cooldownPassed {
-		return &TriggerEvaluationResult{
-			ShouldFire:     false,
-			CoolDownNotMet: true,
-		}, nil
-	}
 
-	// Previous signal for condition: use last log for this (vehicle, metric) so transition-to-zero
-	// conditions see the prior value (e.g. 1) from when another trigger fired, not only this trigger's log.
-	lastLogForMetric, err := t.repo.GetLastLogForMetric(ctx, signal.VehicleDID, trigger.MetricName)
+	previous, err := previousValue(trigger, state, &signal.Previous, &signal.PreviousFired)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to read previous value for signal trigger",
+		}
+	}
+	conditionMet, err := celcondition.EvaluateSignalCondition(program, &signal.Signal, previous, signal.Def.ValueType, signal.Window, signal.Params)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to retrieve last signal for metric",
+			ExternalMsg: "failed to evaluate CEL condition for signal trigger",
+		}
+	}
+
+	// A sustained condition only counts as met once it has held for the whole sustain period.
+	sustained := true
+	if trigger.SustainFor > 0 {
+		sustained = checkSustain(state, trigger, signal, conditionMet)
+	}
+
+	// An edge trigger stays active until its condition, or its clear condition if it has one, says otherwise
+	// and only fires when it becomes active.
+	wasActive := state.LastConditionResult
+	active := conditionMet && sustained
+	if trigger.FireMode == triggersrepo.FireModeEdge && wasActive && clearProgram != nil {
+		cleared, err := celcondition.EvaluateSignalCondition(clearProgram, &signal.Signal, previous, signal.Def.ValueType, signal.Window, signal.Params)
+		if err != nil {
+			return nil, richerrors.Error{
+				Code:        http.StatusInternalServerError,
+				Err:         err,
+				ExternalMsg: "failed to evaluate CEL clear condition for signal trigger",
+			}
 		}
+		active = !cleared
 	}
-	var previousSignal vss.Signal
-	if lastLogForMetric != nil {
-		if err := json.Unmarshal(lastLogForMetric.SnapshotData, &previousSignal); err != nil {
+
+	var result TriggerEvaluationResult
+	switch {
+	case conditionMet && !sustained:
+		result.SustainNotMet = true
+	case !active:
+		result.ConditionNotMet = true
+	case trigger.FireMode == triggersrepo.FireModeEdge && wasActive:
+		result.EdgeNotMet = true
+	case !cooldownPassed:
+		result.CoolDownNotMet = true
+	default:
+		result.ShouldFire = true
+	}
+	if result.ShouldFire {
+		withinLimit, err := t.checkRateLimit(ctx, trigger, state, signal.VehicleDID)
+		if err != nil {
 			return nil, richerrors.Error{
 				Code:        http.StatusInternalServerError,
 				Err:         err,
-				ExternalMsg: "failed to unmarshal previous signal",
+				ExternalMsg: "failed to check rate limit for signal trigger",
 			}
 		}
+		if !withinLimit {
+			result = TriggerEvaluationResult{RateLimited: true}
+		}
 	}
 
-	conditionMet, err := celcondition.EvaluateSignalCondition(program, &signal.Signal, &previousSignal, signal.Def.ValueType)
-	if err != nil {
+	if err := t.recordEvaluation(ctx, trigger, state, active, result.ShouldFire, signal.RawData); err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to evaluate CEL condition for signal trigger",
+			ExternalMsg: "failed to save vehicle state for signal trigger",
 		}
 	}
-	if !conditionMet {
-		return &TriggerEvaluationResult{
-			ShouldFire:      false,
-			ConditionNotMet: true,
-		}, nil
-	}
-
-	return &TriggerEvaluationResult{
-		ShouldFire: true,
-	}, nil
+	return &result, nil
 }
 
 // EvaluateEventTrigger evaluates an event trigger and returns whether it should fire
 // Returns: shouldFire, permissionDenied, cooldownActive, error
 func (t *TriggerEvaluator) EvaluateEventTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, ev *EventEvaluationData) (*TriggerEvaluationResult, error) {
-	// Check permissions for events (use standard permissions)
+	if !ev.Schedule.Active(observedAt(ev.Event.Data.Timestamp)) {
+		return &TriggerEvaluationResult{OutsideSchedule: true}, nil
+	}
+
+	// Check permissions for events (use standard permissions, which cover every signal the condition may read)
 	hasPerm, err := t.tokenClient.HasVehiclePermissions(ctx, ev.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), signals.DefaultPermissions)
 	if err != nil {
 		return nil, richerrors.Error{
@@ -165,18 +265,15 @@ func (t *TriggerEvaluator) EvaluateEventTrigger(ctx context.Context, trigger *mo
 		}, nil
 	}
 
-	// Get last trigger log for cooldown and condition evaluation
-	lastTrigger, err := t.getLastLogValue(ctx, trigger.ID, ev.VehicleDID)
+	state, err := t.getTriggerVehicleState(ctx, trigger.ID, ev.VehicleDID)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to retrieve trigger logs for event trigger",
+			ExternalMsg: "failed to retrieve vehicle state for event trigger",
 		}
 	}
-
-	// Check cooldown
-	cooldownPassed, err := t.checkCooldown(trigger, lastTrigger.LastTriggeredAt)
+	cooldownPassed, err := t.checkCooldown(trigger, state.LastTriggeredAt.Time)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
@@ -184,29 +281,80 @@ func (t *TriggerEvaluator) EvaluateEventTrigger(ctx context.Context, trigger *mo
 			ExternalMsg: "failed to check cooldown for event trigger",
 		}
 	}
-	if !cooldownPassed {
-		return &TriggerEvaluationResult{
-			ShouldFire:     false,
-			CoolDownNotMet: true,
-		}, nil
+
+	previous, err := previousValue(trigger, state, &ev.Previous, &ev.PreviousFired)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to read previous value for event trigger",
+		}
+	}
+	conditionMet, err := celcondition.EvaluateEventCondition(program, &ev.Event, previous, ev.Window, ev.Signals, ev.Params)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to evaluate CEL condition for event trigger",
+		}
 	}
 
-	// Evaluate condition
-	var previousEvent vss.Event
-	if err := json.Unmarshal(lastTrigger.SnapshotData, &previousEvent); err != nil {
+	result := conditionResult(conditionMet, cooldownPassed)
+	if result.ShouldFire {
+		withinLimit, err := t.checkRateLimit(ctx, trigger, state, ev.VehicleDID)
+		if err != nil {
+			return nil, richerrors.Error{
+				Code:        http.StatusInternalServerError,
+				Err:         err,
+				ExternalMsg: "failed to check rate limit for event trigger",
+			}
+		}
+		if !withinLimit {
+			result = &TriggerEvaluationResult{RateLimited: true}
+		}
+	}
+	if err := t.recordEvaluation(ctx, trigger, state, conditionMet, result.ShouldFire, ev.RawData); err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to unmarshal previous event",
+			ExternalMsg: "failed to save vehicle state for event trigger",
 		}
 	}
+	return result, nil
+}
+
+// EvaluateAbsenceTrigger evaluates an absence trigger for a vehicle that went silent or came back online.
+// The scheduler reports each silence only once, so the cooldown is not applied.
+func (t *TriggerEvaluator) EvaluateAbsenceTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, absence *AbsenceEvaluationData) (*TriggerEvaluationResult, error) {
+	if !absence.Schedule.Active(time.Now()) {
+		return &TriggerEvaluationResult{OutsideSchedule: true}, nil
+	}
 
-	conditionMet, err := celcondition.EvaluateEventCondition(program, &ev.Event, &previousEvent)
+	permissions := signals.DefaultPermissions
+	if trigger.MetricName != triggersrepo.AnySignal {
+		permissions = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).Permissions
+	}
+	hasPerm, err := t.tokenClient.HasVehiclePermissions(ctx, absence.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), permissions)
 	if err != nil {
 		return nil, richerrors.Error{
 			Code:        http.StatusInternalServerError,
 			Err:         err,
-			ExternalMsg: "failed to evaluate CEL condition for event trigger",
+			ExternalMsg: "failed to check permissions for absence trigger",
+		}
+	}
+	if !hasPerm {
+		return &TriggerEvaluationResult{
+			ShouldFire:       false,
+			PermissionDenied: true,
+		}, nil
+	}
+
+	conditionMet, err := celcondition.EvaluateAbsenceCondition(program, absence.Offline, absence.SilentFor, absence.Params)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to evaluate CEL condition for absence trigger",
 		}
 	}
 	if !conditionMet {
@@ -221,6 +369,93 @@ func (t *TriggerEvaluator) EvaluateEventTrigger(ctx context.Context, trigger *mo
 	}, nil
 }
 
+// EvaluateCompositeTrigger evaluates a composite trigger against the latest values of the signals its condition
+// reads. The developer license needs the permissions of all of those signals.
+func (t *TriggerEvaluator) EvaluateCompositeTrigger(ctx context.Context, trigger *models.Trigger, program cel.Program, composite *CompositeEvaluationData) (*TriggerEvaluationResult, error) {
+	var latest time.Time
+	for _, signal := range composite.Signals {
+		if signal.Data.Timestamp.After(latest) {
+			latest = signal.Data.Timestamp
+		}
+	}
+	if !composite.Schedule.Active(observedAt(latest)) {
+		return &TriggerEvaluationResult{OutsideSchedule: true}, nil
+	}
+
+	permissions := signals.GetPermissions(slices.Sorted(maps.Keys(composite.Signals)))
+	hasPerm, err := t.tokenClient.HasVehiclePermissions(ctx, composite.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), permissions)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to check permissions for composite trigger",
+		}
+	}
+	if !hasPerm {
+		return &TriggerEvaluationResult{
+			ShouldFire:       false,
+			PermissionDenied: true,
+		}, nil
+	}
+
+	state, err := t.getTriggerVehicleState(ctx, trigger.ID, composite.VehicleDID)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to retrieve vehicle state for composite trigger",
+		}
+	}
+	cooldownPassed, err := t.checkCooldown(trigger, state.LastTriggeredAt.Time)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to check cooldown for composite trigger",
+		}
+	}
+
+	conditionMet, err := celcondition.EvaluateCompositeCondition(program, composite.Signals, composite.Params)
+	if err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to evaluate CEL condition for composite trigger",
+		}
+	}
+
+	result := conditionResult(conditionMet, cooldownPassed)
+	if result.ShouldFire {
+		withinLimit, err := t.checkRateLimit(ctx, trigger, state, composite.VehicleDID)
+		if err != nil {
+			return nil, richerrors.Error{
+				Code:        http.StatusInternalServerError,
+				Err:         err,
+				ExternalMsg: "failed to check rate limit for composite trigger",
+			}
+		}
+		if !withinLimit {
+			result = &TriggerEvaluationResult{RateLimited: true}
+		}
+	}
+	if err := t.recordEvaluation(ctx, trigger, state, conditionMet, result.ShouldFire, composite.RawData); err != nil {
+		return nil, richerrors.Error{
+			Code:        http.StatusInternalServerError,
+			Err:         err,
+			ExternalMsg: "failed to save vehicle state for composite trigger",
+		}
+	}
+	return result, nil
+}
+
+// observedAt returns the timestamp of a signal or event, or the current time if it has none.
+func observedAt(timestamp time.Time) time.Time {
+	if timestamp.IsZero() {
+		return time.Now()
+	}
+	return timestamp
+}
+
 // checkCooldown checks if the cooldown period has passed since the last trigger
 func (e *TriggerEvaluator) checkCooldown(t *models.Trigger, lastTriggeredAt time.Time) (bool, error) {
 	if lastTriggeredAt.IsZero() {
@@ -230,19 +465,143 @@ func (e *TriggerEvaluator) checkCooldown(t *models.Trigger, lastTriggeredAt time
 	return time.Since(lastTriggeredAt) >= cooldown, nil
 }
 
-// getLastLogValue retrieves the last trigger log for a given trigger and vehicle
-func (t *TriggerEvaluator) getLastLogValue(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerLog, error) {
-	lastTrigger, err := t.repo.GetLastLogValue(ctx, triggerID, assetDid)
-	if err != nil {
-		// If no previous log exists, create a default one
-		if errors.Is(err, sql.ErrNoRows) {
-			return &models.TriggerLog{
-				SnapshotData: []byte("{}"),
-				AssetDid:     assetDid.String(),
-				TriggerID:    triggerID,
-			}, nil
+// checkRateLimit reports whether firing trigger for the vehicle now stays within its rate limits: MaxFirings
+// within the firing window for the vehicle, as recorded in state, and GlobalMaxFirings across all vehicles. A
+// firing within the global cap is counted right away, so it must only be checked once the trigger would fire
+// otherwise.
+func (t *TriggerEvaluator) checkRateLimit(ctx context.Context, trigger *models.Trigger, state *models.TriggerVehicleState, assetDid cloudevent.ERC721DID) (bool, error) {
+	now := time.Now()
+	if trigger.MaxFirings > 0 {
+		firings, err := recentFirings(trigger, state, now)
+		if err != nil {
+			return false, err
+		}
+		if len(firings) >= trigger.MaxFirings {
+			return false, nil
+		}
+	}
+	if trigger.GlobalMaxFirings > 0 {
+		reserved, err := t.repo.ReserveTriggerFiring(ctx, trigger, assetDid, now)
+		if err != nil {
+			return false, fmt.Errorf("failed to reserve firing: %w", err)
+		}
+		return reserved, nil
+	}
+	return true, nil
+}
+
+// recentFirings returns the times recorded in state at which trigger fired for the vehicle within its firing
+// window before now, oldest first.
+func recentFirings(trigger *models.Trigger, state *models.TriggerVehicleState, now time.Time) ([]time.Time, error) {
+	if !state.RecentFirings.Valid {
+		return nil, nil
+	}
+	var firings []time.Time
+	if err := json.Unmarshal(state.RecentFirings.JSON, &firings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recent firings: %w", err)
+	}
+	windowStart := now.Add(-time.Duration(trigger.FiringWindow) * time.Second)
+	return slices.DeleteFunc(firings, func(firedAt time.Time) bool {
+		return !firedAt.After(windowStart)
+	}), nil
+}
+
+// conditionResult returns the result of a trigger without sustain period or fire mode.
+func conditionResult(conditionMet, cooldownPassed bool) *TriggerEvaluationResult {
+	switch {
+	case !conditionMet:
+		return &TriggerEvaluationResult{
+			ShouldFire:      false,
+			ConditionNotMet: true,
+		}
+	case !cooldownPassed:
+		return &TriggerEvaluationResult{
+			ShouldFire:     false,
+			CoolDownNotMet: true,
 		}
+	}
+	return &TriggerEvaluationResult{
+		ShouldFire: true,
+	}
+}
+
+// recordEvaluation stores the outcome of an evaluation in the state of the trigger for the vehicle. The cooldown
+// starts with the firing, rawData is kept as the value the trigger last fired for, and the firing is counted
+// towards the per-vehicle rate limit of the trigger.
+func (t *TriggerEvaluator) recordEvaluation(ctx context.Context, trigger *models.Trigger, state *models.TriggerVehicleState, conditionResult, fired bool, rawData json.RawMessage) error {
+	now := time.Now()
+	state.LastConditionResult = conditionResult
+	state.LastEvaluatedAt = null.TimeFrom(now)
+	if fired {
+		state.LastTriggeredAt = null.TimeFrom(now)
+		state.LastTriggeredData = null.JSONFrom(rawData)
+		if trigger.MaxFirings > 0 {
+			firings, err := recentFirings(trigger, state, now)
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(append(firings, now))
+			if err != nil {
+				return fmt.Errorf("failed to marshal recent firings: %w", err)
+			}
+			state.RecentFirings = null.JSONFrom(data)
+		} else {
+			state.RecentFirings = null.JSON{}
+		}
+	}
+	return t.repo.UpsertTriggerVehicleState(ctx, state)
+}
+
+// previousValue returns the value the condition of trigger reads as previous according to its previous scope:
+// observed, the value the trigger last fired for as recorded in state, or fired. The zero value is returned when
+// the trigger has not fired yet.
+func previousValue[T any](trigger *models.Trigger, state *models.TriggerVehicleState, observed, fired *T) (*T, error) {
+	switch trigger.PreviousScope {
+	case triggersrepo.PreviousScopeLastFiredByThisTrigger:
+		var previous T
+		if state.LastTriggeredData.Valid {
+			if err := json.Unmarshal(state.LastTriggeredData.JSON, &previous); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal value the trigger last fired for: %w", err)
+			}
+		}
+		return &previous, nil
+	case triggersrepo.PreviousScopeLastFiredForMetric:
+		return fired, nil
+	default:
+		return observed, nil
+	}
+}
+
+// getTriggerVehicleState returns the recorded state of a trigger for a vehicle, or an empty state if nothing
+// has been recorded yet.
+func (t *TriggerEvaluator) getTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error) {
+	state, err := t.repo.GetTriggerVehicleState(ctx, triggerID, assetDid)
+	if err != nil {
 		return nil, err
 	}
-	return lastTrigger, nil
+	if state == nil {
+		state = &models.TriggerVehicleState{
+			TriggerID: triggerID,
+			AssetDid:  assetDid.String(),
+		}
+	}
+	return state, nil
+}
+
+// checkSustain records in state whether the condition of a sustained trigger holds and reports whether it has
+// held continuously for at least the trigger's sustain period. The period starts
+// at the timestamp of the first matching signal and is reset by the first signal that does not match. It is not
+// reset by firing, so a level trigger whose condition keeps holding fires again once the cooldown has passed.
+func checkSustain(state *models.TriggerVehicleState, trigger *models.Trigger, signal *SignalEvaluationData, conditionMet bool) bool {
+	if !conditionMet {
+		state.ConditionTrueSince = null.Time{}
+		return false
+	}
+
+	observedAt := observedAt(signal.Signal.Data.Timestamp)
+	if !state.ConditionTrueSince.Valid {
+		state.ConditionTrueSince = null.TimeFrom(observedAt)
+	}
+	sustainFor := time.Duration(trigger.SustainFor) * time.Second
+	return observedAt.Sub(state.ConditionTrueSince.Time) >= sustainFor
 }
@@ -12,6 +12,7 @@ package triggerevaluator
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	cloudevent "github.com/DIMO-Network/cloudevent"
 	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
@@ -43,34 +44,48 @@ func (m *MockTriggerRepo) EXPECT() *MockTriggerRepoMockRecorder {
 	return m.recorder
 }
 
-// GetLastLogForMetric mocks base method.
-func (m *MockTriggerRepo) GetLastLogForMetric(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.TriggerLog, error) {
+// GetTriggerVehicleState mocks base method.
+func (m *MockTriggerRepo) GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastLogForMetric", ctx, assetDid, metricName)
-	ret0, _ := ret[0].(*models.TriggerLog)
+	ret := m.ctrl.Call(m, "GetTriggerVehicleState", ctx, triggerID, assetDid)
+	ret0, _ := ret[0].(*models.TriggerVehicleState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLastLogForMetric indicates an expected call of GetLastLogForMetric.
-func (mr *MockTriggerRepoMockRecorder) GetLastLogForMetric(ctx, assetDid, metricName any) *gomock.Call {
+// GetTriggerVehicleState indicates an expected call of GetTriggerVehicleState.
+func (mr *MockTriggerRepoMockRecorder) GetTriggerVehicleState(ctx, triggerID, assetDid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastLogForMetric", reflect.TypeOf((*MockTriggerRepo)(nil).GetLastLogForMetric), ctx, assetDid, metricName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerVehicleState", reflect.TypeOf((*MockTriggerRepo)(nil).GetTriggerVehicleState), ctx, triggerID, assetDid)
 }
 
-// GetLastLogValue mocks base method.
-func (m *MockTriggerRepo) GetLastLogValue(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerLog, error) {
+// ReserveTriggerFiring mocks base method.
+func (m *MockTriggerRepo) ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastLogValue", ctx, triggerID, assetDid)
-	ret0, _ := ret[0].(*models.TriggerLog)
+	ret := m.ctrl.Call(m, "ReserveTriggerFiring", ctx, trigger, assetDid, firedAt)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLastLogValue indicates an expected call of GetLastLogValue.
-func (mr *MockTriggerRepoMockRecorder) GetLastLogValue(ctx, triggerID, assetDid any) *gomock.Call {
+// ReserveTriggerFiring indicates an expected call of ReserveTriggerFiring.
+func (mr *MockTriggerRepoMockRecorder) ReserveTriggerFiring(ctx, trigger, assetDid, firedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveTriggerFiring", reflect.TypeOf((*MockTriggerRepo)(nil).ReserveTriggerFiring), ctx, trigger, assetDid, firedAt)
+}
+
+// UpsertTriggerVehicleState mocks base method.
+func (m *MockTriggerRepo) UpsertTriggerVehicleState(ctx context.Context, state *models.TriggerVehicleState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTriggerVehicleState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertTriggerVehicleState indicates an expected call of UpsertTriggerVehicleState.
+func (mr *MockTriggerRepoMockRecorder) UpsertTriggerVehicleState(ctx, state any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastLogValue", reflect.TypeOf((*MockTriggerRepo)(nil).GetLastLogValue), ctx, triggerID, assetDid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTriggerVehicleState", reflect.TypeOf((*MockTriggerRepo)(nil).UpsertTriggerVehicleState), ctx, state)
 }
 
 // MockTokenExchangeClient is a mock of TokenExchangeClient interface.
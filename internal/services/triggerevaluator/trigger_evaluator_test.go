@@ -4,7 +4,6 @@ package triggerevaluator
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -17,8 +16,12 @@ import (
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/schedule"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/cel-go/cel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -54,7 +57,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -63,28 +66,15 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromSignal(t, vss.Signal{
-				Data: vss.SignalData{
-					Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour), // previous value 1 hour ago
-					ValueNumber: 59,
-				},
-			}),
-			AssetDid:        signalData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-time.Hour), // Cooldown passed
+		signalData.Previous = vss.Signal{
+			Data: vss.SignalData{
+				Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour), // previous value 1 hour ago
+				ValueNumber: 59,
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
-		mockRepo.EXPECT().
-			GetLastLogForMetric(ctx, signalData.VehicleDID, trigger.MetricName).
-			Return(lastLog, nil).
-			Times(1)
+		saved := expectVehicleState(mockRepo, trigger.ID, signalData.VehicleDID, time.Now().Add(-time.Hour)) // Cooldown passed
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -92,6 +82,10 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		assert.False(t, result.PermissionDenied)
 		assert.False(t, result.CoolDownNotMet)
 		assert.False(t, result.ConditionNotMet)
+		// The cooldown starts with this firing.
+		assert.WithinDuration(t, time.Now(), saved.LastTriggeredAt.Time, time.Minute)
+		assert.True(t, saved.LastConditionResult)
+		assert.True(t, saved.LastEvaluatedAt.Valid)
 	})
 
 	t.Run("permission denied", func(t *testing.T) {
@@ -105,7 +99,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - denied
@@ -114,7 +108,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(false, nil).
 			Times(1)
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -135,7 +129,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - error
@@ -144,7 +138,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(false, errors.New("permission service error")).
 			Times(1)
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -166,7 +160,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		trigger := createTestTrigger()
 		trigger.CooldownPeriod = int(time.Hour.Seconds()) // 1 hour cooldown
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -175,24 +169,16 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - recent trigger (cooldown not passed; GetLastLogForMetric not called)
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromSignal(t, vss.Signal{
-				Data: vss.SignalData{
-					Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour), // previous value 1 hour ago
-					ValueNumber: 59,
-				},
-			}),
-			AssetDid:        signalData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-30 * time.Minute), // Cooldown not passed
+		signalData.Previous = vss.Signal{
+			Data: vss.SignalData{
+				Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour), // previous value 1 hour ago
+				ValueNumber: 59,
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
+		lastFiredAt := time.Now().Add(-30 * time.Minute) // Cooldown not passed
+		saved := expectVehicleState(mockRepo, trigger.ID, signalData.VehicleDID, lastFiredAt)
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -200,6 +186,9 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		assert.False(t, result.PermissionDenied)
 		assert.True(t, result.CoolDownNotMet)
 		assert.False(t, result.ConditionNotMet)
+		// The evaluation is recorded, but the cooldown keeps counting from the last firing.
+		assert.Equal(t, lastFiredAt, saved.LastTriggeredAt.Time)
+		assert.True(t, saved.LastConditionResult)
 	})
 
 	t.Run("condition not met", func(t *testing.T) {
@@ -213,7 +202,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -222,28 +211,15 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromSignal(t, vss.Signal{
-				Data: vss.SignalData{
-					Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour),
-					ValueNumber: 60, // value the same as current
-				},
-			}),
-			AssetDid:        signalData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-time.Hour), // Cooldown passed
+		signalData.Previous = vss.Signal{
+			Data: vss.SignalData{
+				Timestamp:   signalData.Signal.Data.Timestamp.Add(-time.Hour),
+				ValueNumber: 60, // value the same as current
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
-		mockRepo.EXPECT().
-			GetLastLogForMetric(ctx, signalData.VehicleDID, trigger.MetricName).
-			Return(lastLog, nil).
-			Times(1)
+		saved := expectVehicleState(mockRepo, trigger.ID, signalData.VehicleDID, time.Now().Add(-time.Hour)) // Cooldown passed
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -251,9 +227,11 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		assert.False(t, result.PermissionDenied)
 		assert.False(t, result.CoolDownNotMet)
 		assert.True(t, result.ConditionNotMet)
+		assert.False(t, saved.LastConditionResult)
+		assert.True(t, saved.LastEvaluatedAt.Valid)
 	})
 
-	t.Run("no previous log - first trigger", func(t *testing.T) {
+	t.Run("no previous state - first trigger", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -264,7 +242,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -273,17 +251,10 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - no rows (first trigger)
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
-			Return(nil, sql.ErrNoRows).
-			Times(1)
-		mockRepo.EXPECT().
-			GetLastLogForMetric(ctx, signalData.VehicleDID, trigger.MetricName).
-			Return(nil, nil).
-			Times(1)
+		// No state recorded yet (first trigger)
+		saved := expectVehicleState(mockRepo, trigger.ID, signalData.VehicleDID, time.Time{})
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -291,6 +262,9 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		assert.False(t, result.PermissionDenied)
 		assert.False(t, result.CoolDownNotMet)
 		assert.False(t, result.ConditionNotMet)
+		assert.Equal(t, trigger.ID, saved.TriggerID)
+		assert.Equal(t, signalData.VehicleDID.String(), saved.AssetDid)
+		assert.True(t, saved.LastTriggeredAt.Valid)
 	})
 
 	t.Run("database error", func(t *testing.T) {
@@ -304,7 +278,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -313,13 +287,13 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - database error
+		// Mock state retrieval - database error
 		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
+			GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).
 			Return(nil, errors.New("database connection error")).
 			Times(1)
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -328,7 +302,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, richErr.Code)
 	})
 
-	t.Run("invalid previous signal JSON", func(t *testing.T) {
+	t.Run("state save error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -339,7 +313,7 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		signalData := createTestSignalData()
-		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType)
+		program, err := celcondition.PrepareSignalCondition("value > previousValue", signalData.Def.ValueType, nil, nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -348,23 +322,16 @@ func TestTriggerEvaluator_EvaluateSignalTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - cooldown passed; previous from GetLastLogForMetric has invalid JSON
-		lastLog := &models.TriggerLog{
-			SnapshotData:    []byte(`invalid json`),
-			AssetDid:        signalData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-time.Hour),
-		}
 		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, signalData.VehicleDID).
-			Return(lastLog, nil).
+			GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).
+			Return(nil, nil).
 			Times(1)
 		mockRepo.EXPECT().
-			GetLastLogForMetric(ctx, signalData.VehicleDID, trigger.MetricName).
-			Return(lastLog, nil).
+			UpsertTriggerVehicleState(ctx, gomock.Any()).
+			Return(errors.New("database connection error")).
 			Times(1)
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 
 		require.Error(t, err)
 		assert.Nil(t, result)
@@ -391,7 +358,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-ignition-on", Service: "signals", MetricName: "vss.isIgnitionOn",
-			Condition: "valueNumber == 1 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 1 && valueNumber != previousValueNumber",
 			CooldownPeriod: 600, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -402,16 +369,15 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "isIgnitionOn"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(&models.TriggerLog{LastTriggeredAt: time.Now().Add(-2 * time.Hour)}, nil).Times(1)
-		prevLog := &models.TriggerLog{SnapshotData: snapShotFromSignal(t, vss.Signal{Data: vss.SignalData{ValueNumber: 0}})}
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.isIgnitionOn").Return(prevLog, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Now().Add(-2*time.Hour))
+		signalData.Previous = vss.Signal{Data: vss.SignalData{ValueNumber: 0}}
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.True(t, result.ShouldFire, "isIgnitionOn==1 with previous 0 should fire")
@@ -425,7 +391,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-ignition-on", Service: "signals", MetricName: "vss.isIgnitionOn",
-			Condition: "valueNumber == 1 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 1 && valueNumber != previousValueNumber",
 			CooldownPeriod: 600, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -436,15 +402,14 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "isIgnitionOn"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(nil, sql.ErrNoRows).Times(1)
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.isIgnitionOn").Return(nil, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Time{})
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.True(t, result.ShouldFire, "isIgnitionOn==1 with no previous (0) should fire: 1 != 0")
@@ -458,7 +423,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-ignition-off", Service: "signals", MetricName: "vss.isIgnitionOn",
-			Condition: "valueNumber == 0 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 0 && valueNumber != previousValueNumber",
 			CooldownPeriod: 600, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -469,16 +434,15 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "isIgnitionOn"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(&models.TriggerLog{LastTriggeredAt: time.Now().Add(-2 * time.Hour)}, nil).Times(1)
-		prevLog := &models.TriggerLog{SnapshotData: snapShotFromSignal(t, vss.Signal{Data: vss.SignalData{ValueNumber: 1}})}
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.isIgnitionOn").Return(prevLog, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Now().Add(-2*time.Hour))
+		signalData.Previous = vss.Signal{Data: vss.SignalData{ValueNumber: 1}}
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.True(t, result.ShouldFire, "isIgnitionOn==0 with previous 1 should fire (transition to off)")
@@ -492,7 +456,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-ignition-off", Service: "signals", MetricName: "vss.isIgnitionOn",
-			Condition: "valueNumber == 0 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 0 && valueNumber != previousValueNumber",
 			CooldownPeriod: 600, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -503,15 +467,14 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "isIgnitionOn"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(nil, sql.ErrNoRows).Times(1)
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.isIgnitionOn").Return(nil, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Time{})
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.False(t, result.ShouldFire, "isIgnitionOn==0 with no previous (0) should not fire: 0 == 0")
@@ -526,7 +489,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-obd-unplugged", Service: "signals", MetricName: "vss.obdisPluggedin",
-			Condition: "valueNumber == 0 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 0 && valueNumber != previousValueNumber",
 			CooldownPeriod: 60, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -537,16 +500,15 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "obdisPluggedin"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(&models.TriggerLog{LastTriggeredAt: time.Now().Add(-2 * time.Minute)}, nil).Times(1)
-		prevLog := &models.TriggerLog{SnapshotData: snapShotFromSignal(t, vss.Signal{Data: vss.SignalData{ValueNumber: 1}})}
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.obdisPluggedin").Return(prevLog, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Now().Add(-2*time.Minute))
+		signalData.Previous = vss.Signal{Data: vss.SignalData{ValueNumber: 1}}
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.True(t, result.ShouldFire, "obdIsPluggedIn==0 with previous 1 should fire (transition to unplugged)")
@@ -560,7 +522,7 @@ func TestTransitionConditions(t *testing.T) {
 
 		trigger := &models.Trigger{
 			ID: "trigger-obd-unplugged", Service: "signals", MetricName: "vss.obdisPluggedin",
-			Condition: "valueNumber == 0 && valueNumber != previousValueNumber",
+			Condition:      "valueNumber == 0 && valueNumber != previousValueNumber",
 			CooldownPeriod: 60, DeveloperLicenseAddress: common.HexToAddress("0x1234567890abcdef").Bytes(),
 		}
 		signalData := &SignalEvaluationData{
@@ -571,15 +533,14 @@ func TestTransitionConditions(t *testing.T) {
 		}
 		signalData.Def.Name = "obdisPluggedin"
 
-		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType)
+		program, err := celcondition.PrepareSignalCondition(trigger.Condition, signals.NumberType, nil, nil)
 		require.NoError(t, err)
 
 		ctx := context.Background()
 		mockTokenClient.EXPECT().HasVehiclePermissions(ctx, vehicleDID, gomock.Any(), perm).Return(true, nil).Times(1)
-		mockRepo.EXPECT().GetLastLogValue(ctx, trigger.ID, vehicleDID).Return(nil, sql.ErrNoRows).Times(1)
-		mockRepo.EXPECT().GetLastLogForMetric(ctx, vehicleDID, "vss.obdisPluggedin").Return(nil, nil).Times(1)
+		expectVehicleState(mockRepo, trigger.ID, vehicleDID, time.Time{})
 
-		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, signalData)
+		result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.False(t, result.ShouldFire, "obdIsPluggedIn==0 with no previous (0) should not fire")
@@ -587,6 +548,455 @@ func TestTransitionConditions(t *testing.T) {
 	})
 }
 
+func TestTriggerEvaluator_SustainedSignalTrigger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		value          float64
+		trueSinceAgo   time.Duration // 0 when the condition was not holding before
+		lastFiredAgo   time.Duration // 0 when the trigger never fired
+		wantShouldFire bool
+		wantSustain    bool
+		wantCondition  bool
+		wantCooldown   bool
+	}{
+		{
+			name:        "first matching signal starts the period",
+			value:       60,
+			wantSustain: true,
+		},
+		{
+			name:         "period not elapsed",
+			value:        60,
+			trueSinceAgo: 30 * time.Second,
+			wantSustain:  true,
+		},
+		{
+			name:           "period elapsed",
+			value:          60,
+			trueSinceAgo:   2 * time.Minute,
+			wantShouldFire: true,
+		},
+		{
+			name:         "period elapsed during cooldown",
+			value:        60,
+			trueSinceAgo: 2 * time.Minute,
+			lastFiredAgo: time.Minute,
+			wantCooldown: true,
+		},
+		{
+			name:          "non matching signal resets the period",
+			value:         50,
+			trueSinceAgo:  2 * time.Minute,
+			lastFiredAgo:  time.Minute,
+			wantCondition: true,
+		},
+		{
+			name:          "non matching signal without a running period",
+			value:         50,
+			wantCondition: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+
+			ctx := context.Background()
+			trigger := createTestTrigger()
+			trigger.SustainFor = 60
+			signalData := createTestSignalData()
+			signalData.Signal.Data.ValueNumber = tt.value
+			observedAt := signalData.Signal.Data.Timestamp
+			program, err := celcondition.PrepareSignalCondition(trigger.Condition, signalData.Def.ValueType, nil, nil)
+			require.NoError(t, err)
+
+			mockTokenClient.EXPECT().
+				HasVehiclePermissions(ctx, signalData.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), signalData.Def.Permissions).
+				Return(true, nil)
+			var state *models.TriggerVehicleState
+			if tt.trueSinceAgo != 0 || tt.lastFiredAgo != 0 {
+				state = &models.TriggerVehicleState{
+					TriggerID: trigger.ID,
+					AssetDid:  signalData.VehicleDID.String(),
+				}
+				if tt.trueSinceAgo != 0 {
+					state.ConditionTrueSince = null.TimeFrom(observedAt.Add(-tt.trueSinceAgo))
+				}
+				if tt.lastFiredAgo != 0 {
+					state.LastTriggeredAt = null.TimeFrom(time.Now().Add(-tt.lastFiredAgo))
+				}
+			}
+			mockRepo.EXPECT().GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).Return(state, nil)
+			mockRepo.EXPECT().
+				UpsertTriggerVehicleState(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, state *models.TriggerVehicleState) error {
+					assert.Equal(t, trigger.ID, state.TriggerID)
+					assert.Equal(t, signalData.VehicleDID.String(), state.AssetDid)
+					switch {
+					case tt.wantCondition:
+						assert.False(t, state.ConditionTrueSince.Valid)
+					case tt.trueSinceAgo == 0:
+						assert.Equal(t, null.TimeFrom(observedAt), state.ConditionTrueSince)
+					default:
+						assert.Equal(t, null.TimeFrom(observedAt.Add(-tt.trueSinceAgo)), state.ConditionTrueSince)
+					}
+					assert.Equal(t, tt.wantShouldFire || tt.lastFiredAgo != 0, state.LastTriggeredAt.Valid)
+					return nil
+				})
+
+			result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShouldFire, result.ShouldFire)
+			assert.Equal(t, tt.wantSustain, result.SustainNotMet)
+			assert.Equal(t, tt.wantCondition, result.ConditionNotMet)
+			assert.Equal(t, tt.wantCooldown, result.CoolDownNotMet)
+		})
+	}
+}
+
+func TestTriggerEvaluator_EdgeSignalTrigger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		value          float64
+		clearCondition string
+		wasActive      bool
+		lastFiredAgo   time.Duration // 0 when the trigger never fired
+		wantChange     bool          // whether the trigger becomes active or inactive
+		wantShouldFire bool
+		wantEdge       bool
+		wantCondition  bool
+		wantCooldown   bool
+	}{
+		{
+			name:           "condition turns true",
+			value:          60,
+			wantChange:     true,
+			wantShouldFire: true,
+		},
+		{
+			name:      "condition keeps holding",
+			value:     60,
+			wasActive: true,
+			wantEdge:  true,
+		},
+		{
+			name:          "condition turns false",
+			value:         50,
+			wasActive:     true,
+			wantChange:    true,
+			wantCondition: true,
+		},
+		{
+			name:          "condition stays false",
+			value:         50,
+			wantCondition: true,
+		},
+		{
+			name:           "clear condition not met",
+			value:          50,
+			clearCondition: "valueNumber < 40",
+			wasActive:      true,
+			wantEdge:       true,
+		},
+		{
+			name:           "clear condition met",
+			value:          30,
+			clearCondition: "valueNumber < 40",
+			wasActive:      true,
+			wantChange:     true,
+			wantCondition:  true,
+		},
+		{
+			name:         "condition turns true during cooldown",
+			value:        60,
+			lastFiredAgo: time.Minute,
+			wantChange:   true,
+			wantCooldown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+
+			ctx := context.Background()
+			trigger := createTestTrigger()
+			trigger.FireMode = triggersrepo.FireModeEdge
+			signalData := createTestSignalData()
+			signalData.Signal.Data.ValueNumber = tt.value
+			program, err := celcondition.PrepareSignalCondition(trigger.Condition, signalData.Def.ValueType, nil, nil)
+			require.NoError(t, err)
+			var clearProgram cel.Program
+			if tt.clearCondition != "" {
+				trigger.ClearCondition = null.StringFrom(tt.clearCondition)
+				clearProgram, err = celcondition.PrepareSignalCondition(tt.clearCondition, signalData.Def.ValueType, nil, nil)
+				require.NoError(t, err)
+			}
+
+			mockTokenClient.EXPECT().
+				HasVehiclePermissions(ctx, signalData.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), signalData.Def.Permissions).
+				Return(true, nil)
+			state := &models.TriggerVehicleState{
+				TriggerID:           trigger.ID,
+				AssetDid:            signalData.VehicleDID.String(),
+				LastConditionResult: tt.wasActive,
+			}
+			if tt.lastFiredAgo != 0 {
+				state.LastTriggeredAt = null.TimeFrom(time.Now().Add(-tt.lastFiredAgo))
+			}
+			mockRepo.EXPECT().GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).Return(state, nil)
+			mockRepo.EXPECT().
+				UpsertTriggerVehicleState(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, state *models.TriggerVehicleState) error {
+					assert.Equal(t, tt.wasActive != tt.wantChange, state.LastConditionResult)
+					return nil
+				})
+
+			result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, clearProgram, signalData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShouldFire, result.ShouldFire)
+			assert.Equal(t, tt.wantEdge, result.EdgeNotMet)
+			assert.Equal(t, tt.wantCondition, result.ConditionNotMet)
+			assert.Equal(t, tt.wantCooldown, result.CoolDownNotMet)
+		})
+	}
+}
+
+func TestTriggerEvaluator_PreviousScope(t *testing.T) {
+	t.Parallel()
+
+	observed := vss.Signal{Data: vss.SignalData{Name: "speed", ValueNumber: 60}}
+	fired := vss.Signal{Data: vss.SignalData{Name: "speed", ValueNumber: 50}}
+	firedJSON, err := json.Marshal(fired)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		scope          string
+		wantShouldFire bool
+	}{
+		{
+			name:  "last observed value",
+			scope: triggersrepo.PreviousScopeLastObserved,
+		},
+		{
+			name:           "value last fired by this trigger",
+			scope:          triggersrepo.PreviousScopeLastFiredByThisTrigger,
+			wantShouldFire: true,
+		},
+		{
+			name:           "value last fired for the metric",
+			scope:          triggersrepo.PreviousScopeLastFiredForMetric,
+			wantShouldFire: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+
+			ctx := context.Background()
+			trigger := createTestTrigger()
+			trigger.Condition = "valueNumber > previousValueNumber"
+			trigger.PreviousScope = tt.scope
+			signalData := createTestSignalData()
+			signalData.Previous = observed
+			signalData.PreviousFired = fired
+			program, err := celcondition.PrepareSignalCondition(trigger.Condition, signalData.Def.ValueType, nil, nil)
+			require.NoError(t, err)
+
+			mockTokenClient.EXPECT().HasVehiclePermissions(ctx, signalData.VehicleDID, gomock.Any(), gomock.Any()).Return(true, nil)
+			mockRepo.EXPECT().GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).Return(&models.TriggerVehicleState{
+				TriggerID:         trigger.ID,
+				AssetDid:          signalData.VehicleDID.String(),
+				LastTriggeredAt:   null.TimeFrom(time.Now().Add(-time.Hour)),
+				LastTriggeredData: null.JSONFrom(firedJSON),
+			}, nil)
+			mockRepo.EXPECT().
+				UpsertTriggerVehicleState(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, state *models.TriggerVehicleState) error {
+					if tt.wantShouldFire {
+						assert.JSONEq(t, string(signalData.RawData), string(state.LastTriggeredData.JSON))
+					} else {
+						assert.Equal(t, null.JSONFrom(firedJSON), state.LastTriggeredData)
+					}
+					return nil
+				})
+
+			result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShouldFire, result.ShouldFire)
+		})
+	}
+}
+
+func TestTriggerEvaluator_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		maxFirings       int
+		globalMaxFirings int
+		firedAgo         []time.Duration
+		reserved         bool
+		wantShouldFire   bool
+		wantRateLimited  bool
+		wantFirings      int
+	}{
+		{
+			name:           "no limits",
+			wantShouldFire: true,
+		},
+		{
+			name:           "below vehicle limit",
+			maxFirings:     2,
+			firedAgo:       []time.Duration{30 * time.Minute},
+			wantShouldFire: true,
+			wantFirings:    2,
+		},
+		{
+			name:            "vehicle limit reached",
+			maxFirings:      2,
+			firedAgo:        []time.Duration{50 * time.Minute, 30 * time.Minute},
+			wantRateLimited: true,
+			wantFirings:     2,
+		},
+		{
+			name:           "firings leave the window",
+			maxFirings:     2,
+			firedAgo:       []time.Duration{2 * time.Hour, 30 * time.Minute},
+			wantShouldFire: true,
+			wantFirings:    2,
+		},
+		{
+			name:             "below global limit",
+			globalMaxFirings: 10,
+			reserved:         true,
+			wantShouldFire:   true,
+		},
+		{
+			name:             "global limit reached",
+			globalMaxFirings: 10,
+			wantRateLimited:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+
+			ctx := context.Background()
+			trigger := createTestTrigger()
+			trigger.CooldownPeriod = 0
+			trigger.MaxFirings = tt.maxFirings
+			trigger.GlobalMaxFirings = tt.globalMaxFirings
+			trigger.FiringWindow = 3600
+			signalData := createTestSignalData()
+			program, err := celcondition.PrepareSignalCondition(trigger.Condition, signalData.Def.ValueType, nil, nil)
+			require.NoError(t, err)
+
+			state := &models.TriggerVehicleState{TriggerID: trigger.ID, AssetDid: signalData.VehicleDID.String()}
+			if len(tt.firedAgo) > 0 {
+				var firings []time.Time
+				for _, ago := range tt.firedAgo {
+					firings = append(firings, time.Now().Add(-ago))
+				}
+				data, err := json.Marshal(firings)
+				require.NoError(t, err)
+				state.RecentFirings = null.JSONFrom(data)
+				state.LastTriggeredAt = null.TimeFrom(firings[len(firings)-1])
+			}
+
+			mockTokenClient.EXPECT().HasVehiclePermissions(ctx, signalData.VehicleDID, gomock.Any(), gomock.Any()).Return(true, nil)
+			mockRepo.EXPECT().GetTriggerVehicleState(ctx, trigger.ID, signalData.VehicleDID).Return(state, nil)
+			if tt.globalMaxFirings > 0 {
+				mockRepo.EXPECT().ReserveTriggerFiring(ctx, trigger, signalData.VehicleDID, gomock.Any()).Return(tt.reserved, nil)
+			}
+			mockRepo.EXPECT().
+				UpsertTriggerVehicleState(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, state *models.TriggerVehicleState) error {
+					if tt.wantFirings == 0 {
+						assert.False(t, state.RecentFirings.Valid)
+						return nil
+					}
+					var firings []time.Time
+					require.NoError(t, json.Unmarshal(state.RecentFirings.JSON, &firings))
+					assert.Len(t, firings, tt.wantFirings)
+					return nil
+				})
+
+			result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShouldFire, result.ShouldFire)
+			assert.Equal(t, tt.wantRateLimited, result.RateLimited)
+		})
+	}
+}
+
+func TestTriggerEvaluator_Schedule(t *testing.T) {
+	t.Parallel()
+
+	// Active from 20:00 to 06:00 UTC.
+	nights, err := schedule.Parse([]byte(`{"timezone":"UTC","windows":[{"start":"20:00","end":"06:00"}]}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		observedAt          time.Time
+		wantShouldFire      bool
+		wantOutsideSchedule bool
+	}{
+		{name: "inside the schedule", observedAt: time.Date(2025, 12, 2, 23, 0, 0, 0, time.UTC), wantShouldFire: true},
+		{name: "outside the schedule", observedAt: time.Date(2025, 12, 2, 12, 0, 0, 0, time.UTC), wantOutsideSchedule: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+
+			ctx := context.Background()
+			trigger := createTestTrigger()
+			signalData := createTestSignalData()
+			signalData.Signal.Data.Timestamp = tt.observedAt
+			signalData.Schedule = nights
+			program, err := celcondition.PrepareSignalCondition(trigger.Condition, signalData.Def.ValueType, nil, nil)
+			require.NoError(t, err)
+
+			// Outside of the schedule neither permissions nor state are read.
+			if !tt.wantOutsideSchedule {
+				mockTokenClient.EXPECT().HasVehiclePermissions(ctx, signalData.VehicleDID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectVehicleState(mockRepo, trigger.ID, signalData.VehicleDID, time.Time{})
+			}
+
+			result, err := evaluator.EvaluateSignalTrigger(ctx, trigger, program, nil, signalData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShouldFire, result.ShouldFire)
+			assert.Equal(t, tt.wantOutsideSchedule, result.OutsideSchedule)
+		})
+	}
+}
+
 func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 	t.Parallel()
 
@@ -601,7 +1011,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		eventData := createTestEventData()
-		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs")
+		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs", nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -614,23 +1024,14 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromEvent(t, vss.Event{
-				Data: vss.EventData{
-					Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
-					DurationNs: 5,
-					Name:       "ignition",
-				},
-			}),
-			AssetDid:        eventData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-time.Hour), // Cooldown passed
+		eventData.Previous = vss.Event{
+			Data: vss.EventData{
+				Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
+				DurationNs: 5,
+				Name:       "HarshBraking",
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, eventData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
+		saved := expectVehicleState(mockRepo, trigger.ID, eventData.VehicleDID, time.Now().Add(-time.Hour)) // Cooldown passed
 
 		result, err := evaluator.EvaluateEventTrigger(ctx, trigger, program, eventData)
 
@@ -640,6 +1041,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		assert.False(t, result.PermissionDenied)
 		assert.False(t, result.CoolDownNotMet)
 		assert.False(t, result.ConditionNotMet)
+		assert.WithinDuration(t, time.Now(), saved.LastTriggeredAt.Time, time.Minute)
 	})
 
 	t.Run("permission denied", func(t *testing.T) {
@@ -653,7 +1055,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		eventData := createTestEventData()
-		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs")
+		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs", nil)
 		require.NoError(t, err)
 
 		// Mock permission check - denied
@@ -688,7 +1090,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		trigger := createTestTrigger()
 		trigger.CooldownPeriod = 3600 // 1 hour cooldown
 		eventData := createTestEventData()
-		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs")
+		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs", nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -701,23 +1103,14 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - recent trigger
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromEvent(t, vss.Event{
-				Data: vss.EventData{
-					Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
-					DurationNs: 5,
-					Name:       "HarshBraking",
-				},
-			}),
-			AssetDid:        eventData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-30 * time.Minute), // Cooldown not passed
+		eventData.Previous = vss.Event{
+			Data: vss.EventData{
+				Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
+				DurationNs: 5,
+				Name:       "HarshBraking",
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, eventData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
+		expectVehicleState(mockRepo, trigger.ID, eventData.VehicleDID, time.Now().Add(-30*time.Minute)) // Cooldown not passed
 
 		result, err := evaluator.EvaluateEventTrigger(ctx, trigger, program, eventData)
 
@@ -740,7 +1133,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		eventData := createTestEventData()
-		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs")
+		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs", nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -753,23 +1146,15 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - same event type
-		lastLog := &models.TriggerLog{
-			SnapshotData: snapShotFromEvent(t, vss.Event{
-				Data: vss.EventData{
-					Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
-					DurationNs: 15,
-					Name:       "HarshBraking",
-				},
-			}), // Same as current
-			AssetDid:        eventData.VehicleDID.String(),
-			TriggerID:       trigger.ID,
-			LastTriggeredAt: time.Now().Add(-time.Hour), // Cooldown passed
+		// Previous event of the same name
+		eventData.Previous = vss.Event{
+			Data: vss.EventData{
+				Timestamp:  eventData.Event.Data.Timestamp.Add(-time.Hour),
+				DurationNs: 15,
+				Name:       "HarshBraking",
+			},
 		}
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, eventData.VehicleDID).
-			Return(lastLog, nil).
-			Times(1)
+		expectVehicleState(mockRepo, trigger.ID, eventData.VehicleDID, time.Now().Add(-time.Hour)) // Cooldown passed
 
 		result, err := evaluator.EvaluateEventTrigger(ctx, trigger, program, eventData)
 
@@ -781,7 +1166,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		assert.True(t, result.ConditionNotMet)
 	})
 
-	t.Run("no previous log - first trigger", func(t *testing.T) {
+	t.Run("no previous state - first trigger", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -792,7 +1177,7 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 		ctx := context.Background()
 		trigger := createTestTrigger()
 		eventData := createTestEventData()
-		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs")
+		program, err := celcondition.PrepareEventCondition("durationNs > previousDurationNs", nil)
 		require.NoError(t, err)
 
 		// Mock permission check - success
@@ -805,11 +1190,8 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 			Return(true, nil).
 			Times(1)
 
-		// Mock last log retrieval - no rows (first trigger)
-		mockRepo.EXPECT().
-			GetLastLogValue(ctx, trigger.ID, eventData.VehicleDID).
-			Return(nil, sql.ErrNoRows).
-			Times(1)
+		// No state recorded yet (first trigger)
+		expectVehicleState(mockRepo, trigger.ID, eventData.VehicleDID, time.Time{})
 
 		result, err := evaluator.EvaluateEventTrigger(ctx, trigger, program, eventData)
 
@@ -824,6 +1206,88 @@ func TestTriggerEvaluator_EvaluateEventTrigger(t *testing.T) {
 
 // Helper functions for creating test data
 
+func TestTriggerEvaluator_EvaluateAbsenceTrigger(t *testing.T) {
+	t.Parallel()
+
+	program, err := celcondition.PrepareAbsenceCondition("offline && silentSeconds >= 3600", nil)
+	require.NoError(t, err)
+	newTrigger := func(metricName string) *models.Trigger {
+		trigger := createTestTrigger()
+		trigger.Service = triggersrepo.ServiceAbsence
+		trigger.MetricName = metricName
+		trigger.Condition = "offline && silentSeconds >= 3600"
+		trigger.AbsentFor = 3600
+		return trigger
+	}
+	newData := func(offline bool, silentFor time.Duration) *AbsenceEvaluationData {
+		return &AbsenceEvaluationData{
+			VehicleDID: createTestAssetDID(),
+			Offline:    offline,
+			LastSeenAt: time.Now().Add(-silentFor),
+			SilentFor:  silentFor,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		metricName  string
+		permissions []string
+		hasPerm     bool
+		data        *AbsenceEvaluationData
+		expected    TriggerEvaluationResult
+	}{
+		{
+			name:        "any signal fires with the default permissions",
+			metricName:  triggersrepo.AnySignal,
+			permissions: signals.DefaultPermissions,
+			hasPerm:     true,
+			data:        newData(true, 2*time.Hour),
+			expected:    TriggerEvaluationResult{ShouldFire: true},
+		},
+		{
+			name:        "single signal uses its permissions",
+			metricName:  "vss.speed",
+			permissions: signals.GetSignalDefinitionOrDefault("speed", signals.NumberType).Permissions,
+			hasPerm:     true,
+			data:        newData(true, 2*time.Hour),
+			expected:    TriggerEvaluationResult{ShouldFire: true},
+		},
+		{
+			name:        "condition not met",
+			metricName:  triggersrepo.AnySignal,
+			permissions: signals.DefaultPermissions,
+			hasPerm:     true,
+			data:        newData(false, 2*time.Hour),
+			expected:    TriggerEvaluationResult{ConditionNotMet: true},
+		},
+		{
+			name:        "permission denied",
+			metricName:  triggersrepo.AnySignal,
+			permissions: signals.DefaultPermissions,
+			data:        newData(true, 2*time.Hour),
+			expected:    TriggerEvaluationResult{PermissionDenied: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			// The cooldown is not applied, so the vehicle state is never read.
+			evaluator := NewTriggerEvaluator(NewMockTriggerRepo(ctrl), mockTokenClient)
+			trigger := newTrigger(tt.metricName)
+
+			mockTokenClient.EXPECT().
+				HasVehiclePermissions(gomock.Any(), tt.data.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), tt.permissions).
+				Return(tt.hasPerm, nil)
+
+			result, err := evaluator.EvaluateAbsenceTrigger(context.Background(), trigger, program, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *result)
+		})
+	}
+}
+
 func createTestTrigger() *models.Trigger {
 	return &models.Trigger{
 		ID:                      "test-trigger-id",
@@ -845,16 +1309,27 @@ func createTestAssetDID() cloudevent.ERC721DID {
 	}
 }
 
-func snapShotFromSignal(t *testing.T, signal vss.Signal) []byte {
-	json, err := json.Marshal(signal)
-	require.NoError(t, err)
-	return json
-}
-
-func snapShotFromEvent(t *testing.T, event vss.Event) []byte {
-	json, err := json.Marshal(event)
-	require.NoError(t, err)
-	return json
+// expectVehicleState expects an evaluation to load the state of the trigger for the vehicle and to save it.
+// lastFiredAt is when the trigger last fired, or zero if no state was recorded. The saved state is copied into
+// the returned one.
+func expectVehicleState(mockRepo *MockTriggerRepo, triggerID string, vehicleDID cloudevent.ERC721DID, lastFiredAt time.Time) *models.TriggerVehicleState {
+	var state *models.TriggerVehicleState
+	if !lastFiredAt.IsZero() {
+		state = &models.TriggerVehicleState{
+			TriggerID:       triggerID,
+			AssetDid:        vehicleDID.String(),
+			LastTriggeredAt: null.TimeFrom(lastFiredAt),
+		}
+	}
+	mockRepo.EXPECT().GetTriggerVehicleState(gomock.Any(), triggerID, vehicleDID).Return(state, nil)
+	saved := &models.TriggerVehicleState{}
+	mockRepo.EXPECT().
+		UpsertTriggerVehicleState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, state *models.TriggerVehicleState) error {
+			*saved = *state
+			return nil
+		})
+	return saved
 }
 
 func createTestSignalData() *SignalEvaluationData {
@@ -888,3 +1363,80 @@ func createTestEventData() *EventEvaluationData {
 		RawData:    json.RawMessage(`{"eventType": "HarshBraking", "timestamp": "2024-01-01T12:00:00Z", "durationNs": 10}`),
 	}
 }
+
+func TestTriggerEvaluator_EvaluateCompositeTrigger(t *testing.T) {
+	t.Parallel()
+
+	condition := "signals.speed > 0 && signals.currentLocationCoordinates.latitude > 40.0"
+	program, err := celcondition.PrepareCompositeCondition(condition, nil)
+	require.NoError(t, err)
+	newData := func(speed float64) *CompositeEvaluationData {
+		return &CompositeEvaluationData{
+			VehicleDID: createTestAssetDID(),
+			Signals: map[string]vss.Signal{
+				"speed":                      {Data: vss.SignalData{Name: "speed", ValueNumber: speed}},
+				"currentLocationCoordinates": {Data: vss.SignalData{Name: "currentLocationCoordinates", ValueLocation: vss.Location{Latitude: 41}}},
+			},
+		}
+	}
+	// The permissions are the union of the permissions of both signals.
+	permissions := signals.GetPermissions([]string{"currentLocationCoordinates", "speed"})
+
+	tests := []struct {
+		name        string
+		hasPerm     bool
+		lastFiredAt time.Time
+		data        *CompositeEvaluationData
+		expected    TriggerEvaluationResult
+	}{
+		{
+			name:     "condition met",
+			hasPerm:  true,
+			data:     newData(10),
+			expected: TriggerEvaluationResult{ShouldFire: true},
+		},
+		{
+			name:     "condition not met",
+			hasPerm:  true,
+			data:     newData(0),
+			expected: TriggerEvaluationResult{ConditionNotMet: true},
+		},
+		{
+			name:        "cooldown not met",
+			hasPerm:     true,
+			lastFiredAt: time.Now().Add(-time.Second),
+			data:        newData(10),
+			expected:    TriggerEvaluationResult{CoolDownNotMet: true},
+		},
+		{
+			name:     "permission denied",
+			data:     newData(10),
+			expected: TriggerEvaluationResult{PermissionDenied: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockTriggerRepo(ctrl)
+			mockTokenClient := NewMockTokenExchangeClient(ctrl)
+			evaluator := NewTriggerEvaluator(mockRepo, mockTokenClient)
+			trigger := createTestTrigger()
+			trigger.Service = triggersrepo.ServiceComposite
+			trigger.MetricName = triggersrepo.AnySignal
+			trigger.Condition = condition
+			trigger.CooldownPeriod = 60
+
+			mockTokenClient.EXPECT().
+				HasVehiclePermissions(gomock.Any(), tt.data.VehicleDID, common.BytesToAddress(trigger.DeveloperLicenseAddress), permissions).
+				Return(tt.hasPerm, nil)
+			if tt.hasPerm {
+				expectVehicleState(mockRepo, trigger.ID, tt.data.VehicleDID, tt.lastFiredAt)
+			}
+
+			result, err := evaluator.EvaluateCompositeTrigger(context.Background(), trigger, program, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *result)
+		})
+	}
+}
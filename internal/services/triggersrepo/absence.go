@@ -0,0 +1,144 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/lib/pq"
+)
+
+// AbsenceState is the state of an absence trigger for a vehicle that went silent or came back online.
+type AbsenceState struct {
+	TriggerID  string `boil:"trigger_id"`
+	AssetDid   string `boil:"asset_did"`
+	MetricName string `boil:"metric_name"`
+	// LastSeenAt is the timestamp of the last signal before the vehicle went silent.
+	LastSeenAt time.Time `boil:"last_seen_at"`
+	// AbsentSince is when the vehicle was found to be silent.
+	AbsentSince time.Time `boil:"absent_since"`
+	// SeenAt is the timestamp of the signal that brought the vehicle back online. Not set for silent vehicles.
+	SeenAt null.Time `boil:"seen_at"`
+}
+
+// LastSeen is the timestamp of the latest signal an absence trigger saw for a vehicle.
+type LastSeen struct {
+	TriggerID string
+	AssetDid  string
+	SeenAt    time.Time
+}
+
+// TrackAbsenceSubscriptions starts the absence clock of vehicles subscribed to enabled absence triggers that
+// have no last seen timestamp yet, so a vehicle that never sends a signal after subscribing is reported too.
+// It returns the number of vehicles that started to be tracked.
+func (r *Repository) TrackAbsenceSubscriptions(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO trigger_vehicle_state (trigger_id, asset_did, last_seen_at, updated_at)
+		SELECT s.trigger_id, s.asset_did, now(), now()
+		FROM vehicle_subscriptions s
+		JOIN triggers t ON t.id = s.trigger_id
+		WHERE t.service = $1 AND t.status = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM trigger_vehicle_state st
+				WHERE st.trigger_id = s.trigger_id AND st.asset_did = s.asset_did AND st.last_seen_at IS NOT NULL
+			)
+		ON CONFLICT (trigger_id, asset_did) DO UPDATE
+		SET last_seen_at = EXCLUDED.last_seen_at, updated_at = EXCLUDED.updated_at
+		WHERE trigger_vehicle_state.last_seen_at IS NULL`,
+		ServiceAbsence, StatusEnabled)
+	if err != nil {
+		return 0, fmt.Errorf("failed to track absence subscriptions: %w", err)
+	}
+	tracked, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to track absence subscriptions: %w", err)
+	}
+	return tracked, nil
+}
+
+// RecordLastSeen stores the last seen timestamps of absence triggers. A timestamp only moves forward, and a
+// vehicle that was silent is back online once a newer signal is recorded. It returns the vehicles that came
+// back online. seen must not hold the same trigger and vehicle twice.
+func (r *Repository) RecordLastSeen(ctx context.Context, seen []LastSeen) ([]AbsenceState, error) {
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	triggerIDs := make([]string, len(seen))
+	assetDids := make([]string, len(seen))
+	seenAts := make([]string, len(seen))
+	for i, s := range seen {
+		triggerIDs[i] = s.TriggerID
+		assetDids[i] = s.AssetDid
+		seenAts[i] = s.SeenAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	var states []AbsenceState
+	err := queries.Raw(`
+		WITH seen AS (
+			SELECT * FROM unnest($1::uuid[], $2::text[], $3::timestamptz[]) AS s(trigger_id, asset_did, seen_at)
+		), previous AS (
+			SELECT st.trigger_id, st.asset_did, st.last_seen_at, st.absent_since
+			FROM trigger_vehicle_state st
+			JOIN seen ON seen.trigger_id = st.trigger_id AND seen.asset_did = st.asset_did
+			FOR UPDATE OF st
+		), upserted AS (
+			INSERT INTO trigger_vehicle_state (trigger_id, asset_did, last_seen_at, updated_at)
+			SELECT seen.trigger_id, seen.asset_did, seen.seen_at, now()
+			FROM seen
+			WHERE EXISTS (SELECT 1 FROM triggers t WHERE t.id = seen.trigger_id AND t.status <> $4)
+			ON CONFLICT (trigger_id, asset_did) DO UPDATE
+			SET last_seen_at = GREATEST(trigger_vehicle_state.last_seen_at, EXCLUDED.last_seen_at),
+				absent_since = CASE
+					WHEN trigger_vehicle_state.last_seen_at IS NULL OR EXCLUDED.last_seen_at > trigger_vehicle_state.last_seen_at THEN NULL
+					ELSE trigger_vehicle_state.absent_since
+				END,
+				updated_at = EXCLUDED.updated_at
+			RETURNING trigger_id, asset_did, absent_since
+		)
+		SELECT p.trigger_id, p.asset_did, t.metric_name, p.last_seen_at, p.absent_since, seen.seen_at
+		FROM previous p
+		JOIN upserted u ON u.trigger_id = p.trigger_id AND u.asset_did = p.asset_did
+		JOIN seen ON seen.trigger_id = p.trigger_id AND seen.asset_did = p.asset_did
+		JOIN triggers t ON t.id = p.trigger_id
+		WHERE p.absent_since IS NOT NULL AND u.absent_since IS NULL`,
+		pq.Array(triggerIDs), pq.Array(assetDids), pq.Array(seenAts), StatusDeleted).Bind(ctx, r.db, &states)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record last seen: %w", err)
+	}
+	return states, nil
+}
+
+// ClaimAbsentVehicles marks up to limit vehicles as absent whose last signal is older than the absentFor of
+// their enabled absence trigger plus grace, and returns them. A vehicle is only claimed once per silence, so
+// concurrent schedulers never report it twice. grace covers signals that other instances have seen but not
+// stored yet, so it should be at least the interval at which they store them.
+func (r *Repository) ClaimAbsentVehicles(ctx context.Context, limit int, grace time.Duration) ([]AbsenceState, error) {
+	var states []AbsenceState
+	err := queries.Raw(`
+		UPDATE trigger_vehicle_state st
+		SET absent_since = now(), updated_at = now()
+		FROM triggers t
+		WHERE t.id = st.trigger_id AND (st.trigger_id, st.asset_did) IN (
+			SELECT due.trigger_id, due.asset_did
+			FROM trigger_vehicle_state due
+			JOIN triggers dt ON dt.id = due.trigger_id
+			WHERE dt.service = $1 AND dt.status = $2
+				AND due.absent_since IS NULL AND due.last_seen_at IS NOT NULL
+				AND due.last_seen_at < now() - make_interval(secs => dt.absent_for + $4::float8)
+				AND EXISTS (
+					SELECT 1 FROM vehicle_subscriptions s
+					WHERE s.trigger_id = due.trigger_id AND s.asset_did = due.asset_did
+				)
+			ORDER BY due.last_seen_at
+			LIMIT $3
+			FOR UPDATE OF due SKIP LOCKED
+		)
+		RETURNING st.trigger_id, st.asset_did, t.metric_name, st.last_seen_at, st.absent_since`,
+		ServiceAbsence, StatusEnabled, limit, grace.Seconds()).Bind(ctx, r.db, &states)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim absent vehicles: %w", err)
+	}
+	return states, nil
+}
@@ -0,0 +1,95 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/lib/pq"
+)
+
+// CreateDeadLetter stores a delivery that was given up on. Only the first maxPerTrigger dead letters of a
+// trigger are kept so an endpoint that stays down does not grow the table without bound.
+// It returns false when the dead letter was not stored.
+func (r *Repository) CreateDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter, maxPerTrigger int) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_dead_letters (id, trigger_id, asset_did, payload, snapshot_data, attempts, last_error)
+		SELECT $1::uuid, $2::uuid, $3::text, $4::jsonb, $5::jsonb, $6::integer, $7::text
+		WHERE (SELECT count(*) FROM webhook_dead_letters WHERE trigger_id = $2::uuid) < $8
+		ON CONFLICT (id) DO NOTHING`,
+		deadLetter.ID, deadLetter.TriggerID, deadLetter.AssetDid, deadLetter.Payload, deadLetter.SnapshotData,
+		deadLetter.Attempts, deadLetter.LastError, maxPerTrigger)
+	if err != nil {
+		return false, fmt.Errorf("failed to create dead letter: %w", err)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create dead letter: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+// GetDeadLettersByTriggerID returns the dead letters of a trigger, newest first.
+func (r *Repository) GetDeadLettersByTriggerID(ctx context.Context, triggerID string) (models.WebhookDeadLetterSlice, error) {
+	deadLetters, err := models.WebhookDeadLetters(
+		models.WebhookDeadLetterWhere.TriggerID.EQ(triggerID),
+		qm.OrderBy(models.WebhookDeadLetterColumns.CreatedAt+" DESC"),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error getting dead letters",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	if deadLetters == nil {
+		deadLetters = models.WebhookDeadLetterSlice{}
+	}
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters moves dead letters of a trigger back into the outbox so the retry worker delivers them
+// again with a fresh set of attempts. If ids is empty all dead letters of the trigger are replayed.
+// A dead letter whose delivery is still waiting in the outbox is kept, so it can be replayed later.
+// Replays are not capped by the maximum of pending retries: the developer asked for them, and the dead
+// letters of a trigger are capped already. It returns the number of deliveries that were queued.
+func (r *Repository) ReplayDeadLetters(ctx context.Context, triggerID string, ids []string) (int64, error) {
+	var filter any
+	if len(ids) > 0 {
+		filter = pq.Array(ids)
+	}
+	res, err := r.db.ExecContext(ctx, `
+		WITH replayed AS (
+			SELECT id, trigger_id, asset_did, payload, snapshot_data
+			FROM webhook_dead_letters
+			WHERE trigger_id = $1::uuid AND ($2::uuid[] IS NULL OR id = ANY($2::uuid[]))
+			FOR UPDATE
+		), queued AS (
+			INSERT INTO webhook_outbox (id, trigger_id, asset_did, payload, snapshot_data, attempts, next_attempt_at)
+			SELECT id, trigger_id, asset_did, payload, snapshot_data, 0, now() FROM replayed
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id
+		)
+		DELETE FROM webhook_dead_letters
+		WHERE id IN (SELECT id FROM queued)`,
+		triggerID, filter)
+	if err != nil {
+		return 0, richerrors.Error{
+			ExternalMsg: "Error replaying dead letters",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	replayed, err := res.RowsAffected()
+	if err != nil {
+		return 0, richerrors.Error{
+			ExternalMsg: "Error replaying dead letters",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	return replayed, nil
+}
@@ -0,0 +1,87 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+// DeliveryFilter narrows down the delivery attempts returned by GetWebhookDeliveries.
+type DeliveryFilter struct {
+	// AssetDid only returns attempts for this asset if set.
+	AssetDid string
+	// From only returns attempts made at or after this time if set.
+	From time.Time
+	// To only returns attempts made before this time if set.
+	To time.Time
+	// Cursor continues a previous listing after the attempt it points to.
+	Cursor *DeliveryCursor
+	// Limit caps the number of attempts returned.
+	Limit int
+}
+
+// DeliveryCursor points to the last delivery attempt of a page.
+type DeliveryCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CreateWebhookDelivery records a single delivery attempt.
+func (r *Repository) CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if err := delivery.Insert(ctx, r.db, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries returns the delivery attempts of a trigger, newest first.
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, triggerID string, filter DeliveryFilter) (models.WebhookDeliverySlice, error) {
+	mods := []qm.QueryMod{
+		models.WebhookDeliveryWhere.TriggerID.EQ(triggerID),
+		qm.OrderBy(models.WebhookDeliveryColumns.CreatedAt + " DESC, " + models.WebhookDeliveryColumns.ID + " DESC"),
+		qm.Limit(filter.Limit),
+	}
+	if filter.AssetDid != "" {
+		mods = append(mods, models.WebhookDeliveryWhere.AssetDid.EQ(filter.AssetDid))
+	}
+	if !filter.From.IsZero() {
+		mods = append(mods, models.WebhookDeliveryWhere.CreatedAt.GTE(filter.From))
+	}
+	if !filter.To.IsZero() {
+		mods = append(mods, models.WebhookDeliveryWhere.CreatedAt.LT(filter.To))
+	}
+	if filter.Cursor != nil {
+		mods = append(mods, qm.Where(
+			fmt.Sprintf("(%s, %s) < (?, ?)", models.WebhookDeliveryColumns.CreatedAt, models.WebhookDeliveryColumns.ID),
+			filter.Cursor.CreatedAt, filter.Cursor.ID,
+		))
+	}
+
+	deliveries, err := models.WebhookDeliveries(mods...).All(ctx, r.db)
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error getting webhook deliveries",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	if deliveries == nil {
+		deliveries = models.WebhookDeliverySlice{}
+	}
+	return deliveries, nil
+}
+
+// DeleteWebhookDeliveriesBefore removes the delivery attempts made before the given time.
+func (r *Repository) DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.CreatedAt.LT(before)).DeleteAll(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,55 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/google/uuid"
+)
+
+// ReserveTriggerFiring records a firing of trigger for a vehicle at firedAt, unless the trigger already fired
+// GlobalMaxFirings times across all vehicles within its firing window. It reports whether the firing was recorded.
+// Firings of the trigger that left the window are deleted. Concurrent reservations for the same trigger are
+// serialized with an advisory lock, so the cap holds across instances.
+func (r *Repository) ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer RollbackTx(ctx, tx)
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, trigger.ID); err != nil {
+		return false, fmt.Errorf("failed to lock trigger firings: %w", err)
+	}
+	windowStart := firedAt.Add(-time.Duration(trigger.FiringWindow) * time.Second)
+	if _, err := models.TriggerFirings(
+		models.TriggerFiringWhere.TriggerID.EQ(trigger.ID),
+		models.TriggerFiringWhere.FiredAt.LTE(windowStart),
+	).DeleteAll(ctx, tx); err != nil {
+		return false, fmt.Errorf("failed to delete expired trigger firings: %w", err)
+	}
+	count, err := models.TriggerFirings(models.TriggerFiringWhere.TriggerID.EQ(trigger.ID)).Count(ctx, tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to count trigger firings: %w", err)
+	}
+	reserved := count < int64(trigger.GlobalMaxFirings)
+	if reserved {
+		firing := &models.TriggerFiring{
+			ID:        uuid.New().String(),
+			TriggerID: trigger.ID,
+			AssetDid:  assetDid.String(),
+			FiredAt:   firedAt,
+		}
+		if err := firing.Insert(ctx, tx, boil.Infer()); err != nil {
+			return false, fmt.Errorf("failed to record trigger firing: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit trigger firing: %w", err)
+	}
+	return reserved, nil
+}
@@ -0,0 +1,147 @@
+package triggersrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CreateGeofence stores a geofence of a developer license. geometry is the GeoJSON polygon of the geofence.
+func (r *Repository) CreateGeofence(ctx context.Context, developerLicenseAddress common.Address, name string, geometry []byte) (*models.Geofence, error) {
+	currTime := time.Now().UTC()
+	geofence := &models.Geofence{
+		ID:                      uuid.New().String(),
+		DeveloperLicenseAddress: developerLicenseAddress.Bytes(),
+		Name:                    name,
+		Geometry:                types.JSON(geometry),
+		CreatedAt:               currTime,
+		UpdatedAt:               currTime,
+	}
+	if err := geofence.Insert(ctx, r.db, boil.Infer()); err != nil {
+		return nil, geofenceWriteError(err, name)
+	}
+	r.notifyChange(ctx, Change{Operation: ChangeGeofencesUpdated})
+	return geofence, nil
+}
+
+// GetGeofencesByDeveloperLicense returns the geofences of a developer license ordered by name.
+func (r *Repository) GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error) {
+	geofences, err := models.Geofences(
+		models.GeofenceWhere.DeveloperLicenseAddress.EQ(developerLicenseAddress.Bytes()),
+		qm.OrderBy(models.GeofenceColumns.Name),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error getting geofences",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	if geofences == nil {
+		geofences = models.GeofenceSlice{}
+	}
+	return geofences, nil
+}
+
+// GetGeofenceByIDAndDeveloperLicense returns a geofence owned by the developer license.
+func (r *Repository) GetGeofenceByIDAndDeveloperLicense(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) (*models.Geofence, error) {
+	if _, err := uuid.Parse(geofenceID); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Invalid geofence id",
+			Err:         err,
+			Code:        http.StatusBadRequest,
+		}
+	}
+	geofence, err := models.Geofences(
+		models.GeofenceWhere.ID.EQ(geofenceID),
+		models.GeofenceWhere.DeveloperLicenseAddress.EQ(developerLicenseAddress.Bytes()),
+	).One(ctx, r.db)
+	if err != nil {
+		if IsNoRowsError(err) {
+			return nil, richerrors.Error{
+				ExternalMsg: "Geofence not found",
+				Err:         err,
+				Code:        http.StatusNotFound,
+			}
+		}
+		return nil, richerrors.Error{
+			ExternalMsg: "Error getting geofence",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	return geofence, nil
+}
+
+// UpdateGeofence saves the name and geometry of a geofence.
+func (r *Repository) UpdateGeofence(ctx context.Context, geofence *models.Geofence) error {
+	if _, err := geofence.Update(ctx, r.db, boil.Whitelist(
+		models.GeofenceColumns.Name,
+		models.GeofenceColumns.Geometry,
+		models.GeofenceColumns.UpdatedAt,
+	)); err != nil {
+		return geofenceWriteError(err, geofence.Name)
+	}
+	r.notifyChange(ctx, Change{Operation: ChangeGeofencesUpdated})
+	return nil
+}
+
+// DeleteGeofence deletes a geofence owned by the developer license.
+func (r *Repository) DeleteGeofence(ctx context.Context, geofenceID string, developerLicenseAddress common.Address) error {
+	deleted, err := models.Geofences(
+		models.GeofenceWhere.ID.EQ(geofenceID),
+		models.GeofenceWhere.DeveloperLicenseAddress.EQ(developerLicenseAddress.Bytes()),
+	).DeleteAll(ctx, r.db)
+	if err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Error deleting geofence",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	if deleted == 0 {
+		return richerrors.Error{
+			ExternalMsg: "Geofence not found",
+			Code:        http.StatusNotFound,
+		}
+	}
+	r.notifyChange(ctx, Change{Operation: ChangeGeofencesUpdated})
+	return nil
+}
+
+// InternalGetAllGeofences returns the geofences of all developer licenses.
+func (r *Repository) InternalGetAllGeofences(ctx context.Context) (models.GeofenceSlice, error) {
+	geofences, err := models.Geofences().All(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geofences: %w", err)
+	}
+	return geofences, nil
+}
+
+// geofenceWriteError converts an error writing a geofence to the error returned to the developer.
+func geofenceWriteError(err error, name string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == DuplicateKeyError && pqErr.Constraint == "geofences_devaddr_name_uniq" {
+		return richerrors.Error{
+			ExternalMsg: fmt.Sprintf("A geofence named %q already exists", name),
+			Err:         err,
+			Code:        http.StatusConflict,
+		}
+	}
+	return richerrors.Error{
+		ExternalMsg: "Error saving geofence",
+		Err:         err,
+		Code:        http.StatusInternalServerError,
+	}
+}
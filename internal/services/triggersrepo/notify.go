@@ -0,0 +1,51 @@
+package triggersrepo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/rs/zerolog"
+)
+
+// ChangesChannel is the Postgres channel the changes of triggers, subscriptions and geofences are notified on,
+// so that every replica can apply them to its webhook cache.
+const ChangesChannel = "vehicle_triggers_changes"
+
+const (
+	// ChangeSubscriptionSaved is notified when a vehicle is subscribed or its subscription is muted or unmuted.
+	ChangeSubscriptionSaved = "subscriptionSaved"
+	// ChangeSubscriptionDeleted is notified when a vehicle is unsubscribed.
+	ChangeSubscriptionDeleted = "subscriptionDeleted"
+	// ChangeSubscriptionsDeleted is notified when all vehicles are unsubscribed from a trigger.
+	ChangeSubscriptionsDeleted = "subscriptionsDeleted"
+	// ChangeTriggerUpdated is notified when a trigger is updated.
+	ChangeTriggerUpdated = "triggerUpdated"
+	// ChangeTriggerDeleted is notified when a trigger is deleted along with its subscriptions.
+	ChangeTriggerDeleted = "triggerDeleted"
+	// ChangeGeofencesUpdated is notified when a geofence is created, updated or deleted.
+	ChangeGeofencesUpdated = "geofencesUpdated"
+)
+
+// Change is the payload of a notification on ChangesChannel.
+type Change struct {
+	// Operation is one of the Change constants.
+	Operation string `json:"op"`
+	// TriggerID is the ID of the changed trigger, empty for geofence changes.
+	TriggerID string `json:"triggerId,omitempty"`
+	// AssetDID is the DID of the vehicle whose subscription changed, empty for trigger and geofence changes.
+	AssetDID string `json:"assetDid,omitempty"`
+}
+
+// notifyChange notifies the replicas of a change that was written. It is sent after the write so that it is
+// never delivered for a write that was rolled back. A notification that fails to send is only logged, since the
+// write succeeded; the replicas pick up the change with their next cache rebuild.
+func (r *Repository) notifyChange(ctx context.Context, change Change) {
+	payload, err := json.Marshal(change)
+	if err == nil {
+		_, err = queries.Raw(`SELECT pg_notify($1, $2)`, ChangesChannel, string(payload)).ExecContext(ctx, r.db)
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("operation", change.Operation).Str("triggerId", change.TriggerID).Msg("failed to notify change")
+	}
+}
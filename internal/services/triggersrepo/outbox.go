@@ -0,0 +1,88 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+)
+
+// EnqueueWebhookRetry stores a failed delivery so it is attempted again at entry.NextAttemptAt.
+// The entry is only stored while the trigger has fewer than maxPending deliveries waiting, so an
+// endpoint that is down cannot grow the outbox without bound. It returns false when the entry was not stored.
+func (r *Repository) EnqueueWebhookRetry(ctx context.Context, entry *models.WebhookOutbox, maxPending int) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_outbox (id, trigger_id, asset_did, payload, snapshot_data, attempts, next_attempt_at, last_error)
+		SELECT $1::uuid, $2::uuid, $3::text, $4::jsonb, $5::jsonb, $6::integer, $7::timestamptz, $8::text
+		WHERE (SELECT count(*) FROM webhook_outbox WHERE trigger_id = $2::uuid) < $9
+		ON CONFLICT (id) DO NOTHING`,
+		entry.ID, entry.TriggerID, entry.AssetDid, entry.Payload, entry.SnapshotData,
+		entry.Attempts, entry.NextAttemptAt, entry.LastError, maxPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue webhook retry: %w", err)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue webhook retry: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+// ClaimWebhookRetries returns up to limit deliveries that are due for another attempt and leases them
+// for the given duration. Leased entries are skipped by other workers until the lease runs out, so a
+// worker that dies mid-attempt does not lose the delivery.
+func (r *Repository) ClaimWebhookRetries(ctx context.Context, limit int, lease time.Duration) (models.WebhookOutboxSlice, error) {
+	var entries models.WebhookOutboxSlice
+	err := queries.Raw(`
+		UPDATE webhook_outbox
+		SET locked_until = now() + make_interval(secs => $2::float8)
+		WHERE id IN (
+			SELECT id FROM webhook_outbox
+			WHERE next_attempt_at <= now() AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		limit, lease.Seconds()).Bind(ctx, r.db, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook retries: %w", err)
+	}
+	return entries, nil
+}
+
+// RescheduleWebhookRetry records a failed attempt for an outbox entry and releases its lease.
+func (r *Repository) RescheduleWebhookRetry(ctx context.Context, entry *models.WebhookOutbox) error {
+	entry.LockedUntil = null.Time{}
+	if _, err := entry.Update(ctx, r.db, boil.Whitelist(
+		models.WebhookOutboxColumns.Attempts,
+		models.WebhookOutboxColumns.NextAttemptAt,
+		models.WebhookOutboxColumns.LastError,
+		models.WebhookOutboxColumns.LockedUntil,
+	)); err != nil {
+		return fmt.Errorf("failed to reschedule webhook retry: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhookRetry removes an entry from the outbox once it was delivered or given up on.
+func (r *Repository) DeleteWebhookRetry(ctx context.Context, id string) error {
+	if _, err := models.WebhookOutboxes(models.WebhookOutboxWhere.ID.EQ(id)).DeleteAll(ctx, r.db); err != nil {
+		return fmt.Errorf("failed to delete webhook retry: %w", err)
+	}
+	return nil
+}
+
+// VehicleSubscriptionExists reports whether the asset is still subscribed to the trigger, so that a
+// delivery waiting in the outbox is not sent after the vehicle was unsubscribed.
+func (r *Repository) VehicleSubscriptionExists(ctx context.Context, triggerID string, assetDid string) (bool, error) {
+	exists, err := models.VehicleSubscriptionExists(ctx, r.db, assetDid, triggerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check vehicle subscription: %w", err)
+	}
+	return exists, nil
+}
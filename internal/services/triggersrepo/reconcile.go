@@ -0,0 +1,80 @@
+package triggersrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
+)
+
+// Reconciliation is the outcome of syncing the subscriptions of an allShared trigger with the vehicles shared
+// with its developer license.
+type Reconciliation struct {
+	// Added is the number of vehicles that were subscribed.
+	Added int
+	// Removed is the number of vehicles that were unsubscribed.
+	Removed int
+	// Error describes what could not be reconciled, empty if the subscriptions are in sync.
+	Error string
+}
+
+// ClaimTriggersToReconcile returns up to limit allShared triggers that have not been reconciled within interval,
+// or since a reconciliation was requested, and leases them for the given duration. Leased triggers are skipped
+// by other instances until the lease runs out or the outcome is recorded.
+func (r *Repository) ClaimTriggersToReconcile(ctx context.Context, limit int, interval, lease time.Duration) (models.TriggerSlice, error) {
+	var triggers models.TriggerSlice
+	err := queries.Raw(`
+		UPDATE triggers
+		SET reconcile_locked_until = now() + make_interval(secs => $3::float8)
+		WHERE id IN (
+			SELECT id FROM triggers
+			WHERE subscription_mode = $4 AND status != $5
+				AND (reconciled_at IS NULL OR reconciled_at < now() - make_interval(secs => $2::float8))
+				AND (reconcile_locked_until IS NULL OR reconcile_locked_until < now())
+			ORDER BY reconciled_at NULLS FIRST
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		limit, interval.Seconds(), lease.Seconds(), SubscriptionModeAllShared, StatusDeleted).Bind(ctx, r.db, &triggers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim triggers to reconcile: %w", err)
+	}
+	return triggers, nil
+}
+
+// RecordTriggerReconciliation stores the outcome of reconciling a trigger and releases its lease.
+func (r *Repository) RecordTriggerReconciliation(ctx context.Context, triggerID string, reconciliation Reconciliation) error {
+	_, err := models.Triggers(models.TriggerWhere.ID.EQ(triggerID)).UpdateAll(ctx, r.db, models.M{
+		models.TriggerColumns.ReconciledAt:         time.Now().UTC(),
+		models.TriggerColumns.ReconciledAdded:      reconciliation.Added,
+		models.TriggerColumns.ReconciledRemoved:    reconciliation.Removed,
+		models.TriggerColumns.ReconcileError:       null.NewString(reconciliation.Error, reconciliation.Error != ""),
+		models.TriggerColumns.ReconcileLockedUntil: null.Time{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record trigger reconciliation: %w", err)
+	}
+	return nil
+}
+
+// RequestTriggerReconciliation makes an allShared trigger due for reconciliation right away, e.g. after its
+// subscription mode or the permissions it needs changed.
+func (r *Repository) RequestTriggerReconciliation(ctx context.Context, triggerID string) error {
+	_, err := models.Triggers(models.TriggerWhere.ID.EQ(triggerID)).UpdateAll(ctx, r.db, models.M{
+		models.TriggerColumns.ReconciledAt: null.Time{},
+	})
+	if err != nil {
+		return richerrors.Error{
+			ExternalMsg: "Failed to request reconciliation of the webhook",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	return nil
+}
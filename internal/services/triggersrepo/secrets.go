@@ -0,0 +1,82 @@
+package triggersrepo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// signingSecretPrefix marks a value as a webhook signing secret so it is recognizable in configs and logs.
+const signingSecretPrefix = "whsec_"
+
+// newSigningSecret generates a random webhook signing secret.
+func newSigningSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate signing secret: %w", err)
+	}
+	return signingSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// RotateTriggerSecret replaces the signing secret of a trigger with a newly generated one.
+// The replaced secret stays valid for the given overlap so that deliveries are signed with both
+// secrets while the receiver switches over. An overlap of zero revokes the old secret immediately.
+func (r *Repository) RotateTriggerSecret(ctx context.Context, triggerID string, developerLicenseAddress common.Address, overlap time.Duration) (*models.Trigger, error) {
+	trigger, tx, err := r.GetTriggerByIDAndDeveloperLicenseForUpdate(ctx, triggerID, developerLicenseAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer RollbackTx(ctx, tx)
+
+	secret, err := newSigningSecret()
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error generating signing secret",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+
+	now := time.Now().UTC()
+	if overlap > 0 {
+		trigger.PreviousSigningSecret = null.StringFrom(trigger.SigningSecret)
+		trigger.PreviousSigningSecretExpiresAt = null.TimeFrom(now.Add(overlap))
+	} else {
+		trigger.PreviousSigningSecret = null.String{}
+		trigger.PreviousSigningSecretExpiresAt = null.Time{}
+	}
+	trigger.SigningSecret = secret
+	trigger.UpdatedAt = now
+
+	if _, err := trigger.Update(ctx, tx, boil.Whitelist(
+		models.TriggerColumns.SigningSecret,
+		models.TriggerColumns.PreviousSigningSecret,
+		models.TriggerColumns.PreviousSigningSecretExpiresAt,
+		models.TriggerColumns.UpdatedAt,
+	)); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error rotating signing secret",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Failed to commit Update.",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	r.notifyChange(ctx, Change{Operation: ChangeTriggerUpdated, TriggerID: trigger.ID})
+	return trigger, nil
+}
@@ -0,0 +1,85 @@
+package triggersrepo
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SnoozeTrigger stops a trigger from firing for any vehicle until the given time. An invalid until clears the
+// snooze. The snooze expires on its own; it does not need to be cleared once until has passed.
+func (r *Repository) SnoozeTrigger(ctx context.Context, triggerID string, developerLicenseAddress common.Address, until null.Time) (*models.Trigger, error) {
+	trigger, tx, err := r.GetTriggerByIDAndDeveloperLicenseForUpdate(ctx, triggerID, developerLicenseAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer RollbackTx(ctx, tx)
+
+	trigger.SnoozedUntil = until
+	trigger.UpdatedAt = time.Now().UTC()
+	if _, err := trigger.Update(ctx, tx, boil.Whitelist(
+		models.TriggerColumns.SnoozedUntil,
+		models.TriggerColumns.UpdatedAt,
+	)); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error snoozing webhook",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Failed to commit Update.",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	r.notifyChange(ctx, Change{Operation: ChangeTriggerUpdated, TriggerID: trigger.ID})
+	return trigger, nil
+}
+
+// MuteVehicleSubscription stops a trigger from firing for a single subscribed vehicle until the given time. An
+// invalid until clears the mute. It returns the number of subscriptions changed, 0 if the vehicle is not
+// subscribed to the trigger.
+func (r *Repository) MuteVehicleSubscription(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID, until null.Time) (int64, error) {
+	if triggerID == "" {
+		return 0, richerrors.Error{
+			ExternalMsg: "Trigger id is required",
+			Err:         ValidationError,
+			Code:        http.StatusBadRequest,
+		}
+	}
+	if assetDid == (cloudevent.ERC721DID{}) {
+		return 0, richerrors.Error{
+			ExternalMsg: "Asset DID is required",
+			Err:         ValidationError,
+			Code:        http.StatusBadRequest,
+		}
+	}
+	count, err := models.VehicleSubscriptions(
+		models.VehicleSubscriptionWhere.TriggerID.EQ(triggerID),
+		models.VehicleSubscriptionWhere.AssetDid.EQ(assetDid.String()),
+	).UpdateAll(ctx, r.db, models.M{
+		models.VehicleSubscriptionColumns.MutedUntil: until,
+		models.VehicleSubscriptionColumns.UpdatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, richerrors.Error{
+			ExternalMsg: "Failed to mute vehicle subscription",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	if count > 0 {
+		r.notifyChange(ctx, Change{Operation: ChangeSubscriptionSaved, TriggerID: triggerID, AssetDID: assetDid.String()})
+	}
+	return count, nil
+}
@@ -3,6 +3,7 @@ package triggersrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/migrations"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/schedule"
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
@@ -37,8 +39,57 @@ const (
 	ServiceSignal = "signals"
 	// ServiceEvent is the service name for event webhooks. MetricName is the full event name (e.g. "behavior.harshBraking", "security.isEngineBlocked").
 	ServiceEvent = "events"
+	// ServiceAbsence is the service name for absence webhooks, which fire when a vehicle stops sending a signal.
+	// MetricName is a signal name with the schema prefix (e.g. "vss.speed") or AnySignal.
+	ServiceAbsence = "absence"
+	// ServiceComposite is the service name for composite webhooks, whose condition reads the latest values of
+	// several signals of a vehicle. MetricName is AnySignal; the signals are the ones referenced by the condition.
+	ServiceComposite = "composite"
 )
 
+// AnySignal is the metric name of an absence webhook that watches all signals of a vehicle, and of composite
+// webhooks.
+const AnySignal = "*"
+
+const (
+	// FireModeLevel fires a trigger for every signal or event that matches its condition, subject to the cooldown.
+	FireModeLevel = "level"
+	// FireModeEdge fires a signal trigger only when its condition turns from false to true for a vehicle.
+	FireModeEdge = "edge"
+)
+
+const (
+	// PreviousScopeLastObserved makes conditions read the last value the vehicle sent for the metric as the
+	// previous value.
+	PreviousScopeLastObserved = "lastObserved"
+	// PreviousScopeLastFiredByThisTrigger makes conditions read the value the trigger last fired for.
+	PreviousScopeLastFiredByThisTrigger = "lastFiredByThisTrigger"
+	// PreviousScopeLastFiredForMetric makes conditions read the value any trigger of the metric last fired for.
+	PreviousScopeLastFiredForMetric = "lastFiredForMetric"
+)
+
+const (
+	// SubscriptionModeExplicit subscribes only the vehicles that are subscribed through the API.
+	SubscriptionModeExplicit = "explicit"
+	// SubscriptionModeAllShared keeps the subscriptions of a trigger in sync with the vehicles shared with its
+	// developer license.
+	SubscriptionModeAllShared = "allShared"
+)
+
+// IsSubscriptionMode returns true if mode is a known subscription mode.
+func IsSubscriptionMode(mode string) bool {
+	return mode == SubscriptionModeExplicit || mode == SubscriptionModeAllShared
+}
+
+// IsPreviousScope returns true if scope is a known previous scope.
+func IsPreviousScope(scope string) bool {
+	switch scope {
+	case PreviousScopeLastObserved, PreviousScopeLastFiredByThisTrigger, PreviousScopeLastFiredForMetric:
+		return true
+	}
+	return false
+}
+
 // IsSignalService returns true if service is a signal service.
 func IsSignalService(service string) bool {
 	return service == ServiceSignal
@@ -49,6 +100,16 @@ func IsEventService(service string) bool {
 	return service == ServiceEvent
 }
 
+// IsAbsenceService returns true if service is an absence service.
+func IsAbsenceService(service string) bool {
+	return service == ServiceAbsence
+}
+
+// IsCompositeService returns true if service is a composite service.
+func IsCompositeService(service string) bool {
+	return service == ServiceComposite
+}
+
 type Repository struct {
 	db *sql.DB
 }
@@ -67,6 +128,17 @@ type CreateTriggerRequest struct {
 	Status                  string
 	Description             string
 	CooldownPeriod          int
+	SustainFor              int
+	FireMode                string
+	ClearCondition          string
+	AbsentFor               int
+	PreviousScope           string
+	MaxFirings              int
+	GlobalMaxFirings        int
+	FiringWindow            int
+	Schedule                json.RawMessage
+	Params                  json.RawMessage
+	SubscriptionMode        string
 	DeveloperLicenseAddress common.Address
 }
 
@@ -93,6 +165,41 @@ func (req CreateTriggerRequest) Validate() error {
 	if req.CooldownPeriod < 0 {
 		return fmt.Errorf("%w cooldownPeriod cannot be negative", ValidationError)
 	}
+	if req.SustainFor < 0 {
+		return fmt.Errorf("%w sustainFor cannot be negative", ValidationError)
+	}
+	if req.FireMode != "" && req.FireMode != FireModeLevel && req.FireMode != FireModeEdge {
+		return fmt.Errorf("%w fireMode must be %q or %q", ValidationError, FireModeLevel, FireModeEdge)
+	}
+	if req.ClearCondition != "" && req.FireMode != FireModeEdge {
+		return fmt.Errorf("%w clearCondition requires fireMode %q", ValidationError, FireModeEdge)
+	}
+	if req.AbsentFor < 0 {
+		return fmt.Errorf("%w absentFor cannot be negative", ValidationError)
+	}
+	if IsAbsenceService(req.Service) && req.AbsentFor == 0 {
+		return fmt.Errorf("%w absentFor is required for service %q", ValidationError, ServiceAbsence)
+	}
+	if req.PreviousScope != "" && !IsPreviousScope(req.PreviousScope) {
+		return fmt.Errorf("%w previousScope %q is not supported", ValidationError, req.PreviousScope)
+	}
+	if req.MaxFirings < 0 || req.GlobalMaxFirings < 0 || req.FiringWindow < 0 {
+		return fmt.Errorf("%w maxFirings, globalMaxFirings and firingWindow cannot be negative", ValidationError)
+	}
+	if (req.MaxFirings > 0 || req.GlobalMaxFirings > 0) && req.FiringWindow == 0 {
+		return fmt.Errorf("%w firingWindow is required with maxFirings or globalMaxFirings", ValidationError)
+	}
+	if len(req.Schedule) > 0 {
+		if _, err := schedule.Parse(req.Schedule); err != nil {
+			return fmt.Errorf("%w %w", ValidationError, err)
+		}
+	}
+	if len(req.Params) > 0 && !json.Valid(req.Params) {
+		return fmt.Errorf("%w params must be valid JSON", ValidationError)
+	}
+	if req.SubscriptionMode != "" && !IsSubscriptionMode(req.SubscriptionMode) {
+		return fmt.Errorf("%w subscriptionMode %q is not supported", ValidationError, req.SubscriptionMode)
+	}
 	return nil
 }
 
@@ -110,6 +217,26 @@ func (r *Repository) CreateTrigger(ctx context.Context, req CreateTriggerRequest
 	if displayName == "" {
 		displayName = id
 	}
+	secret, err := newSigningSecret()
+	if err != nil {
+		return nil, richerrors.Error{
+			ExternalMsg: "Error during creation",
+			Err:         err,
+			Code:        http.StatusInternalServerError,
+		}
+	}
+	fireMode := req.FireMode
+	if fireMode == "" {
+		fireMode = FireModeLevel
+	}
+	previousScope := req.PreviousScope
+	if previousScope == "" {
+		previousScope = PreviousScopeLastObserved
+	}
+	subscriptionMode := req.SubscriptionMode
+	if subscriptionMode == "" {
+		subscriptionMode = SubscriptionModeExplicit
+	}
 	currTime := time.Now().UTC()
 
 	trigger := &models.Trigger{
@@ -121,8 +248,20 @@ func (r *Repository) CreateTrigger(ctx context.Context, req CreateTriggerRequest
 		Description:             null.StringFrom(req.Description),
 		TargetURI:               req.TargetURI,
 		CooldownPeriod:          req.CooldownPeriod,
+		SustainFor:              req.SustainFor,
+		FireMode:                fireMode,
+		ClearCondition:          null.NewString(req.ClearCondition, req.ClearCondition != ""),
+		AbsentFor:               req.AbsentFor,
+		PreviousScope:           previousScope,
+		MaxFirings:              req.MaxFirings,
+		GlobalMaxFirings:        req.GlobalMaxFirings,
+		FiringWindow:            req.FiringWindow,
+		Schedule:                null.NewJSON(req.Schedule, len(req.Schedule) > 0),
+		Params:                  null.NewJSON(req.Params, len(req.Params) > 0),
+		SubscriptionMode:        subscriptionMode,
 		DeveloperLicenseAddress: req.DeveloperLicenseAddress.Bytes(),
 		Status:                  req.Status,
+		SigningSecret:           secret,
 		CreatedAt:               currTime,
 		UpdatedAt:               currTime,
 	}
@@ -285,6 +424,7 @@ func (r *Repository) UpdateTrigger(ctx context.Context, trigger *models.Trigger)
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	r.notifyChange(ctx, Change{Operation: ChangeTriggerUpdated, TriggerID: trigger.ID})
 	return nil
 }
 
@@ -299,6 +439,18 @@ func (r *Repository) updateTrigger(tx *sql.Tx, ctx context.Context, trigger *mod
 		models.TriggerColumns.DeveloperLicenseAddress,
 		models.TriggerColumns.Service,
 		models.TriggerColumns.CreatedAt,
+		// signing secrets are only changed through RotateTriggerSecret
+		models.TriggerColumns.SigningSecret,
+		models.TriggerColumns.PreviousSigningSecret,
+		models.TriggerColumns.PreviousSigningSecretExpiresAt,
+		// snoozes are only changed through SnoozeTrigger
+		models.TriggerColumns.SnoozedUntil,
+		// reconciliations are only changed by the reconciler and RequestTriggerReconciliation
+		models.TriggerColumns.ReconciledAt,
+		models.TriggerColumns.ReconciledAdded,
+		models.TriggerColumns.ReconciledRemoved,
+		models.TriggerColumns.ReconcileError,
+		models.TriggerColumns.ReconcileLockedUntil,
 	))
 	if err != nil {
 		if isDuplicateDisplayNameError(err) {
@@ -380,13 +532,15 @@ func (r *Repository) DeleteTrigger(ctx context.Context, triggerID string, develo
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	r.notifyChange(ctx, Change{Operation: ChangeTriggerDeleted, TriggerID: trigger.ID})
 	return nil
 }
 
 // Vehicle Subscription operations
 
-// CreateVehicleSubscription creates a new vehicle subscription
-func (r *Repository) CreateVehicleSubscription(ctx context.Context, assetDid cloudevent.ERC721DID, triggerID string) (*models.VehicleSubscription, error) {
+// CreateVehicleSubscription creates a new vehicle subscription. params are the parameter values overriding the
+// defaults of the trigger for the vehicle; an invalid params uses the defaults.
+func (r *Repository) CreateVehicleSubscription(ctx context.Context, assetDid cloudevent.ERC721DID, triggerID string, params null.JSON) (*models.VehicleSubscription, error) {
 	if assetDid == (cloudevent.ERC721DID{}) {
 		return nil, richerrors.Error{
 			ExternalMsg: "Asset DID is required",
@@ -405,6 +559,7 @@ func (r *Repository) CreateVehicleSubscription(ctx context.Context, assetDid clo
 	subscription := &models.VehicleSubscription{
 		AssetDid:  assetDid.String(),
 		TriggerID: triggerID,
+		Params:    params,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
@@ -434,6 +589,7 @@ func (r *Repository) CreateVehicleSubscription(ctx context.Context, assetDid clo
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	r.notifyChange(ctx, Change{Operation: ChangeSubscriptionSaved, TriggerID: triggerID, AssetDID: subscription.AssetDid})
 
 	return subscription, nil
 }
@@ -483,6 +639,7 @@ func (r *Repository) GetVehicleSubscriptionsByVehicleAndDeveloperLicense(ctx con
 			models.TriggerTableColumns.DeveloperLicenseAddress,
 		), developerLicenseAddress.Bytes()),
 		qm.Where(fmt.Sprintf("%s != ?", models.TriggerTableColumns.Status), StatusDeleted),
+		qm.Load(models.VehicleSubscriptionRels.Trigger),
 	).All(ctx, r.db)
 
 	if err != nil {
@@ -523,6 +680,9 @@ func (r *Repository) DeleteVehicleSubscription(ctx context.Context, triggerID st
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	if deleteCount > 0 {
+		r.notifyChange(ctx, Change{Operation: ChangeSubscriptionDeleted, TriggerID: triggerID, AssetDID: assetDid.String()})
+	}
 	return deleteCount, nil
 }
 
@@ -545,6 +705,9 @@ func (r *Repository) DeleteAllVehicleSubscriptionsForTrigger(ctx context.Context
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	if deleteCount > 0 {
+		r.notifyChange(ctx, Change{Operation: ChangeSubscriptionsDeleted, TriggerID: triggerID})
+	}
 	return deleteCount, nil
 }
 
@@ -570,86 +733,69 @@ func (r *Repository) InternalGetAllVehicleSubscriptions(ctx context.Context) ([]
 	return subs, nil
 }
 
-// InternalGetTriggerByID retrieves a specific trigger by ID.
-// This should not be used with handler calls. Instead use GetTriggerByIDAndDeveloperLicense.
-func (r *Repository) InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error) {
-	if triggerID == "" {
-		return nil, richerrors.Error{
-			ExternalMsg: "Webhook id is required",
-			Err:         ValidationError,
-			Code:        http.StatusBadRequest,
-		}
-	}
-
-	trigger, err := models.Triggers(
-		models.TriggerWhere.ID.EQ(triggerID),
-		models.TriggerWhere.Status.NEQ(StatusDeleted),
+// InternalGetVehicleSubscription retrieves the subscription of a vehicle to a trigger.
+// This should not be used with handler calls. Instead use GetVehicleSubscriptionsByVehicleAndDeveloperLicense.
+func (r *Repository) InternalGetVehicleSubscription(ctx context.Context, triggerID string, assetDid string) (*models.VehicleSubscription, error) {
+	sub, err := models.VehicleSubscriptions(
+		models.VehicleSubscriptionWhere.TriggerID.EQ(triggerID),
+		models.VehicleSubscriptionWhere.AssetDid.EQ(assetDid),
 	).One(ctx, r.db)
-
 	if err != nil {
 		return nil, richerrors.Error{
-			ExternalMsg: "Error getting trigger",
+			ExternalMsg: "Error getting vehicle subscription",
 			Err:         err,
 			Code:        http.StatusInternalServerError,
 		}
 	}
-
-	return trigger, nil
+	return sub, nil
 }
 
-// GetLastLogValue returns the last triggered at timestamp for a trigger and vehicle token ID.
-func (r *Repository) GetLastLogValue(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerLog, error) {
-	logs, err := models.TriggerLogs(
-		models.TriggerLogWhere.TriggerID.EQ(triggerID),
-		models.TriggerLogWhere.AssetDid.EQ(assetDid.String()),
-		qm.OrderBy("last_triggered_at DESC"),
-	).One(ctx, r.db)
-
+// InternalGetTriggersByIDs retrieves the triggers with the given IDs that are not deleted, in a single query.
+// IDs without a trigger are left out of the result.
+// This should not be used with handler calls. Instead use GetTriggerByIDAndDeveloperLicense.
+func (r *Repository) InternalGetTriggersByIDs(ctx context.Context, triggerIDs []string) (models.TriggerSlice, error) {
+	if len(triggerIDs) == 0 {
+		return nil, nil
+	}
+	triggers, err := models.Triggers(
+		models.TriggerWhere.ID.IN(triggerIDs),
+		models.TriggerWhere.Status.NEQ(StatusDeleted),
+	).All(ctx, r.db)
 	if err != nil {
 		return nil, richerrors.Error{
-			ExternalMsg: "Failed to get last log value",
-			Err:         err,
+			ExternalMsg: "Error getting triggers",
+			Err:         fmt.Errorf("failed to get triggers by ids: %w", err),
 			Code:        http.StatusInternalServerError,
 		}
 	}
-	return logs, nil
+	return triggers, nil
 }
 
-// GetLastLogForMetric returns the most recent trigger log for the given asset and metric (across any trigger with that metric).
-// Used for condition evaluation so "previous" is the last seen value for the metric, not only when this trigger fired.
-// Returns (nil, nil) when no log exists for this vehicle+metric.
-func (r *Repository) GetLastLogForMetric(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.TriggerLog, error) {
-	triggers, err := models.Triggers(models.TriggerWhere.MetricName.EQ(metricName)).All(ctx, r.db)
-	if err != nil {
+// InternalGetTriggerByID retrieves a specific trigger by ID.
+// This should not be used with handler calls. Instead use GetTriggerByIDAndDeveloperLicense.
+func (r *Repository) InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error) {
+	if triggerID == "" {
 		return nil, richerrors.Error{
-			ExternalMsg: "Failed to get triggers for metric",
-			Err:         err,
-			Code:        http.StatusInternalServerError,
+			ExternalMsg: "Webhook id is required",
+			Err:         ValidationError,
+			Code:        http.StatusBadRequest,
 		}
 	}
-	if len(triggers) == 0 {
-		return nil, nil
-	}
-	triggerIDs := make([]string, 0, len(triggers))
-	for _, t := range triggers {
-		triggerIDs = append(triggerIDs, t.ID)
-	}
-	log, err := models.TriggerLogs(
-		models.TriggerLogWhere.AssetDid.EQ(assetDid.String()),
-		models.TriggerLogWhere.TriggerID.IN(triggerIDs),
-		qm.OrderBy(models.TriggerLogTableColumns.LastTriggeredAt+" DESC"),
+
+	trigger, err := models.Triggers(
+		models.TriggerWhere.ID.EQ(triggerID),
+		models.TriggerWhere.Status.NEQ(StatusDeleted),
 	).One(ctx, r.db)
+
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
 		return nil, richerrors.Error{
-			ExternalMsg: "Failed to get last log for metric",
+			ExternalMsg: "Error getting trigger",
 			Err:         err,
 			Code:        http.StatusInternalServerError,
 		}
 	}
-	return log, nil
+
+	return trigger, nil
 }
 
 // CreateTriggerLog creates a new trigger log.
@@ -712,6 +858,7 @@ func (r *Repository) IncrementTriggerFailureCount(ctx context.Context, trigger *
 	defer RollbackTx(ctx, tx)
 
 	// Increment failure count
+	previousStatus := updatedTrigger.Status
 	updatedTrigger.FailureCount++
 
 	// Disable webhook if failure threshold reached
@@ -734,6 +881,10 @@ func (r *Repository) IncrementTriggerFailureCount(ctx context.Context, trigger *
 			Code:        http.StatusInternalServerError,
 		}
 	}
+	// Only the failure disables the webhook, so the caches are not updated for every failure counted.
+	if previousStatus != updatedTrigger.Status {
+		r.notifyChange(ctx, Change{Operation: ChangeTriggerUpdated, TriggerID: updatedTrigger.ID})
+	}
 
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"net/http"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,6 +57,7 @@ func TestCreateTrigger(t *testing.T) {
 		assert.Equal(t, baseReq.Description, trigger.Description.String)
 		assert.Equal(t, baseReq.CooldownPeriod, trigger.CooldownPeriod)
 		assert.Equal(t, baseReq.DeveloperLicenseAddress.Bytes(), trigger.DeveloperLicenseAddress)
+		assert.True(t, strings.HasPrefix(trigger.SigningSecret, signingSecretPrefix))
 	})
 
 	t.Run("allow duplicates triggers", func(t *testing.T) {
@@ -411,6 +413,44 @@ func TestGetTriggerByIDAndDeveloperLicense(t *testing.T) {
 	})
 }
 
+func TestInternalGetTriggersByIDs(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	req := CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 20",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		Description:             "Speed alert",
+		CooldownPeriod:          10,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	}
+	first, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	second, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	deleted, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteTrigger(ctx, deleted.ID, req.DeveloperLicenseAddress))
+
+	triggers, err := repo.InternalGetTriggersByIDs(ctx, []string{first.ID, second.ID, deleted.ID, uuid.New().String()})
+	require.NoError(t, err)
+	ids := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		ids = append(ids, trigger.ID)
+	}
+	assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+
+	triggers, err = repo.InternalGetTriggersByIDs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, triggers)
+}
+
 func TestUpdateTrigger(t *testing.T) {
 	t.Parallel()
 	tc := tests.SetupTestContainer(t)
@@ -750,7 +790,7 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		}
 
 		// Create vehicle subscription
-		subscription, err := repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		subscription, err := repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 		require.NotNil(t, subscription)
 
@@ -760,6 +800,30 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		assert.NotZero(t, subscription.UpdatedAt)
 	})
 
+	t.Run("creation with params", func(t *testing.T) {
+		req := baseReq
+		req.DeveloperLicenseAddress = tests.RandomAddr(t)
+		req.Condition = "valueNumber > params.limit"
+		req.Params = []byte(`{"limit": 55}`)
+
+		trigger, err := repo.CreateTrigger(ctx, req)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit": 55}`, string(trigger.Params.JSON))
+
+		assetDid := cloudevent.ERC721DID{
+			ChainID:         137,
+			ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+			TokenID:         big.NewInt(12346),
+		}
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSONFrom([]byte(`{"limit": 70}`)))
+		require.NoError(t, err)
+
+		subs, err := repo.GetVehicleSubscriptionsByTriggerID(ctx, trigger.ID)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.JSONEq(t, `{"limit": 70}`, string(subs[0].Params.JSON))
+	})
+
 	t.Run("create multiple subscriptions for same trigger", func(t *testing.T) {
 		devAddress := tests.RandomAddr(t)
 		req := baseReq
@@ -789,15 +853,15 @@ func TestCreateVehicleSubscription(t *testing.T) {
 			TokenID:         big.NewInt(67890),
 		}
 		// Create multiple subscriptions for the same trigger
-		subscription1, err := repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID)
+		subscription1, err := repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 		require.NotNil(t, subscription1)
 
-		subscription2, err := repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID)
+		subscription2, err := repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 		require.NotNil(t, subscription2)
 
-		subscription3, err := repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID)
+		subscription3, err := repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 		require.NotNil(t, subscription3)
 
@@ -833,7 +897,7 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		nonExistentTriggerID := uuid.New().String()
 
 		// Try to create subscription for non-existent trigger
-		_, err := repo.CreateVehicleSubscription(ctx, assetDid, nonExistentTriggerID)
+		_, err := repo.CreateVehicleSubscription(ctx, assetDid, nonExistentTriggerID, null.JSON{})
 		require.Error(t, err)
 		var richErr richerrors.Error
 		require.ErrorAs(t, err, &richErr)
@@ -852,7 +916,7 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		var zeroAssetDID cloudevent.ERC721DID
 
 		// Try to create subscription with zero vehicle token ID
-		_, err = repo.CreateVehicleSubscription(ctx, zeroAssetDID, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, zeroAssetDID, trigger.ID, null.JSON{})
 		require.Error(t, err)
 		assert.ErrorIs(t, err, ValidationError)
 	})
@@ -866,7 +930,7 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		emptyTriggerID := ""
 
 		// Try to create subscription with empty trigger ID
-		_, err := repo.CreateVehicleSubscription(ctx, assetDid, emptyTriggerID)
+		_, err := repo.CreateVehicleSubscription(ctx, assetDid, emptyTriggerID, null.JSON{})
 		require.Error(t, err)
 		assert.ErrorIs(t, err, ValidationError)
 	})
@@ -891,12 +955,12 @@ func TestCreateVehicleSubscription(t *testing.T) {
 		}
 
 		// Create first subscription
-		subscription1, err := repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		subscription1, err := repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 		require.NotNil(t, subscription1)
 
 		// Try to create duplicate subscription
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.Error(t, err)
 		assert.True(t, IsDuplicateKeyError(err))
 
@@ -958,13 +1022,13 @@ func TestGetVehicleSubscriptionsByTriggerID(t *testing.T) {
 		assetDid1 := randAssetDID(t)
 		assetDid2 := randAssetDID(t)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting subscriptions by trigger IDs
@@ -1046,10 +1110,10 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		assetDid := randAssetDID(t)
 
 		// Create vehicle subscriptions
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting subscriptions for devAddress1
@@ -1057,6 +1121,9 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, subscriptions, 1)
 		assert.Equal(t, trigger1.ID, subscriptions[0].TriggerID)
+		require.NotNil(t, subscriptions[0].R)
+		require.NotNil(t, subscriptions[0].R.Trigger)
+		assert.Equal(t, "Speed alert", subscriptions[0].R.Trigger.Description.String)
 		assert.Equal(t, assetDid.String(), subscriptions[0].AssetDid)
 
 		// Test getting subscriptions for devAddress2
@@ -1077,7 +1144,7 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		require.NotNil(t, trigger)
 
 		assetDid := randAssetDID(t)
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting subscriptions for non-existent vehicle
@@ -1098,7 +1165,7 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, trigger)
 		assetDid := randAssetDID(t)
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting subscriptions for non-existent developer license
@@ -1147,13 +1214,13 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		assetDid := randAssetDID(t)
 
 		// Create subscriptions for all triggers
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger3.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger3.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting all subscriptions for the vehicle and developer
@@ -1206,10 +1273,10 @@ func TestGetVehicleSubscriptionsByVehicleAndDeveloperLicense(t *testing.T) {
 		assetDid := randAssetDID(t)
 
 		// Create subscriptions for both triggers
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Test getting subscriptions for devAddress1
@@ -1261,7 +1328,7 @@ func TestDeleteVehicleSubscription(t *testing.T) {
 		assetDid := randAssetDID(t)
 
 		// Create vehicle subscription
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Verify subscription exists
@@ -1325,13 +1392,13 @@ func TestDeleteVehicleSubscription(t *testing.T) {
 		assetDid2 := randAssetDID(t)
 		assetDid3 := randAssetDID(t)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Verify all subscriptions exist
@@ -1389,10 +1456,10 @@ func TestDeleteVehicleSubscription(t *testing.T) {
 		assetDid := randAssetDID(t)
 
 		// Create subscriptions for both triggers
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Verify both subscriptions exist
@@ -1454,13 +1521,13 @@ func TestDeleteAllVehicleSubscriptionsForTrigger(t *testing.T) {
 		assetDid2 := randAssetDID(t)
 		assetDid3 := randAssetDID(t)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Verify subscriptions exist
@@ -1543,13 +1610,13 @@ func TestDeleteAllVehicleSubscriptionsForTrigger(t *testing.T) {
 		assetDid2 := randAssetDID(t)
 		assetDid3 := randAssetDID(t)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid1, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger1.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid2, trigger1.ID, null.JSON{})
 		require.NoError(t, err)
 
-		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger2.ID)
+		_, err = repo.CreateVehicleSubscription(ctx, assetDid3, trigger2.ID, null.JSON{})
 		require.NoError(t, err)
 
 		// Verify subscriptions exist for both triggers
@@ -1596,7 +1663,7 @@ func TestDeleteAllVehicleSubscriptionsForTrigger(t *testing.T) {
 		assetDids := make([]cloudevent.ERC721DID, subscriptionCount)
 		for i := 0; i < subscriptionCount; i++ {
 			assetDids[i] = randAssetDID(t)
-			_, err = repo.CreateVehicleSubscription(ctx, assetDids[i], trigger.ID)
+			_, err = repo.CreateVehicleSubscription(ctx, assetDids[i], trigger.ID, null.JSON{})
 			require.NoError(t, err)
 		}
 
@@ -1929,6 +1996,265 @@ func randAssetDID(t *testing.T) cloudevent.ERC721DID {
 	}
 }
 
+func TestRotateTriggerSecret(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+	devLicense := tests.RandomAddr(t)
+
+	newTrigger := func(t *testing.T) *models.Trigger {
+		t.Helper()
+		trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+			Service:                 ServiceSignal,
+			MetricName:              "vss.speed",
+			Condition:               "valueNumber > 20",
+			TargetURI:               "https://example.com/webhook",
+			Status:                  StatusEnabled,
+			DeveloperLicenseAddress: devLicense,
+		})
+		require.NoError(t, err)
+		return trigger
+	}
+
+	t.Run("keeps previous secret during overlap", func(t *testing.T) {
+		trigger := newTrigger(t)
+
+		rotated, err := repo.RotateTriggerSecret(ctx, trigger.ID, devLicense, time.Hour)
+		require.NoError(t, err)
+		assert.NotEqual(t, trigger.SigningSecret, rotated.SigningSecret)
+
+		stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rotated.SigningSecret, stored.SigningSecret)
+		assert.Equal(t, trigger.SigningSecret, stored.PreviousSigningSecret.String)
+		require.True(t, stored.PreviousSigningSecretExpiresAt.Valid)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.PreviousSigningSecretExpiresAt.Time, time.Minute)
+	})
+
+	t.Run("zero overlap revokes previous secret", func(t *testing.T) {
+		trigger := newTrigger(t)
+		_, err := repo.RotateTriggerSecret(ctx, trigger.ID, devLicense, time.Hour)
+		require.NoError(t, err)
+
+		rotated, err := repo.RotateTriggerSecret(ctx, trigger.ID, devLicense, 0)
+		require.NoError(t, err)
+
+		stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rotated.SigningSecret, stored.SigningSecret)
+		assert.False(t, stored.PreviousSigningSecret.Valid)
+		assert.False(t, stored.PreviousSigningSecretExpiresAt.Valid)
+	})
+
+	t.Run("update does not overwrite secret", func(t *testing.T) {
+		trigger := newTrigger(t)
+		rotated, err := repo.RotateTriggerSecret(ctx, trigger.ID, devLicense, 0)
+		require.NoError(t, err)
+
+		// trigger still carries the original secret in memory
+		trigger.Condition = "valueNumber > 30"
+		require.NoError(t, repo.UpdateTrigger(ctx, trigger))
+
+		stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rotated.SigningSecret, stored.SigningSecret)
+	})
+
+	t.Run("other developer license", func(t *testing.T) {
+		trigger := newTrigger(t)
+		_, err := repo.RotateTriggerSecret(ctx, trigger.ID, tests.RandomAddr(t), time.Hour)
+		require.Error(t, err)
+		var richErr richerrors.Error
+		require.ErrorAs(t, err, &richErr)
+		assert.Equal(t, http.StatusNotFound, richErr.Code)
+	})
+}
+
+func TestWebhookOutbox(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 20",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	})
+	require.NoError(t, err)
+
+	newEntry := func(nextAttemptAt time.Time) *models.WebhookOutbox {
+		return &models.WebhookOutbox{
+			ID:            uuid.New().String(),
+			TriggerID:     trigger.ID,
+			AssetDid:      "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1",
+			Payload:       []byte(`{"id":"payload"}`),
+			SnapshotData:  []byte(`{}`),
+			Attempts:      1,
+			NextAttemptAt: nextAttemptAt,
+			LastError:     null.StringFrom("webhook returned status code 503"),
+		}
+	}
+
+	due := newEntry(time.Now().Add(-time.Minute))
+	inserted, err := repo.EnqueueWebhookRetry(ctx, due, 2)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	later := newEntry(time.Now().Add(time.Hour))
+	inserted, err = repo.EnqueueWebhookRetry(ctx, later, 2)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	t.Run("respects max pending", func(t *testing.T) {
+		inserted, err := repo.EnqueueWebhookRetry(ctx, newEntry(time.Now()), 2)
+		require.NoError(t, err)
+		assert.False(t, inserted)
+	})
+
+	t.Run("claims only due and unleased entries", func(t *testing.T) {
+		claimed, err := repo.ClaimWebhookRetries(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, due.ID, claimed[0].ID)
+		assert.JSONEq(t, `{"id":"payload"}`, string(claimed[0].Payload))
+
+		// leased entries are not handed out twice
+		claimed, err = repo.ClaimWebhookRetries(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+
+	t.Run("reschedule releases lease", func(t *testing.T) {
+		due.Attempts = 2
+		due.NextAttemptAt = time.Now().Add(-time.Second)
+		require.NoError(t, repo.RescheduleWebhookRetry(ctx, due))
+
+		claimed, err := repo.ClaimWebhookRetries(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, 2, claimed[0].Attempts)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteWebhookRetry(ctx, due.ID))
+		exists, err := models.WebhookOutboxExists(ctx, tc.DB, due.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestWebhookDeadLetters(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 20",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	})
+	require.NoError(t, err)
+
+	newDeadLetter := func() *models.WebhookDeadLetter {
+		return &models.WebhookDeadLetter{
+			ID:           uuid.New().String(),
+			TriggerID:    trigger.ID,
+			AssetDid:     "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1",
+			Payload:      []byte(`{"id":"payload"}`),
+			SnapshotData: []byte(`{}`),
+			Attempts:     6,
+			LastError:    null.StringFrom("webhook returned status code 503"),
+		}
+	}
+
+	first := newDeadLetter()
+	inserted, err := repo.CreateDeadLetter(ctx, first, 2)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	second := newDeadLetter()
+	inserted, err = repo.CreateDeadLetter(ctx, second, 2)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	t.Run("respects max per trigger", func(t *testing.T) {
+		inserted, err := repo.CreateDeadLetter(ctx, newDeadLetter(), 2)
+		require.NoError(t, err)
+		assert.False(t, inserted)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		deadLetters, err := repo.GetDeadLettersByTriggerID(ctx, trigger.ID)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 2)
+		assert.Equal(t, 6, deadLetters[0].Attempts)
+		assert.Equal(t, "webhook returned status code 503", deadLetters[0].LastError.String)
+	})
+
+	t.Run("replay selected", func(t *testing.T) {
+		replayed, err := repo.ReplayDeadLetters(ctx, trigger.ID, []string{first.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), replayed)
+
+		entry, err := models.FindWebhookOutbox(ctx, tc.DB, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, entry.Attempts)
+		assert.JSONEq(t, `{"id":"payload"}`, string(entry.Payload))
+
+		exists, err := models.WebhookDeadLetterExists(ctx, tc.DB, first.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("replay all", func(t *testing.T) {
+		replayed, err := repo.ReplayDeadLetters(ctx, trigger.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), replayed)
+
+		deadLetters, err := repo.GetDeadLettersByTriggerID(ctx, trigger.ID)
+		require.NoError(t, err)
+		assert.Empty(t, deadLetters)
+	})
+
+	t.Run("replay keeps dead letters that are still queued", func(t *testing.T) {
+		queued := newDeadLetter()
+		inserted, err := repo.CreateDeadLetter(ctx, queued, 2)
+		require.NoError(t, err)
+		require.True(t, inserted)
+		inserted, err = repo.EnqueueWebhookRetry(ctx, &models.WebhookOutbox{
+			ID:            queued.ID,
+			TriggerID:     trigger.ID,
+			AssetDid:      queued.AssetDid,
+			Payload:       queued.Payload,
+			SnapshotData:  queued.SnapshotData,
+			Attempts:      1,
+			NextAttemptAt: time.Now(),
+		}, 10)
+		require.NoError(t, err)
+		require.True(t, inserted)
+
+		replayed, err := repo.ReplayDeadLetters(ctx, trigger.ID, nil)
+		require.NoError(t, err)
+		assert.Zero(t, replayed)
+
+		exists, err := models.WebhookDeadLetterExists(ctx, tc.DB, queued.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
 func TestIsSignalService(t *testing.T) {
 	assert.True(t, IsSignalService(ServiceSignal))
 	assert.False(t, IsSignalService(ServiceEvent))
@@ -1942,3 +2268,702 @@ func TestIsEventService(t *testing.T) {
 	assert.False(t, IsEventService("events.behavior"))
 	assert.False(t, IsEventService("unknown"))
 }
+
+func TestIsAbsenceService(t *testing.T) {
+	assert.True(t, IsAbsenceService(ServiceAbsence))
+	assert.False(t, IsAbsenceService(ServiceSignal))
+	assert.False(t, IsAbsenceService(ServiceEvent))
+}
+
+func TestIsCompositeService(t *testing.T) {
+	assert.True(t, IsCompositeService(ServiceComposite))
+	assert.False(t, IsCompositeService(ServiceSignal))
+	assert.False(t, IsCompositeService(ServiceAbsence))
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 20",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	})
+	require.NoError(t, err)
+
+	vehicleA := "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:1"
+	vehicleB := "did:erc721:137:0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF:2"
+	start := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	var ids []string
+	for i, assetDid := range []string{vehicleA, vehicleB, vehicleA, vehicleA} {
+		delivery := &models.WebhookDelivery{
+			ID:            uuid.New().String(),
+			DeliveryID:    uuid.New().String(),
+			TriggerID:     trigger.ID,
+			AssetDid:      assetDid,
+			Attempt:       1,
+			StatusCode:    null.IntFrom(200),
+			LatencyMillis: 10 * i,
+			CreatedAt:     start.Add(time.Duration(i) * time.Minute),
+		}
+		require.NoError(t, repo.CreateWebhookDelivery(ctx, delivery))
+		ids = append(ids, delivery.ID)
+	}
+
+	t.Run("newest first", func(t *testing.T) {
+		deliveries, err := repo.GetWebhookDeliveries(ctx, trigger.ID, DeliveryFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 4)
+		assert.Equal(t, ids[3], deliveries[0].ID)
+		assert.Equal(t, ids[0], deliveries[3].ID)
+		assert.Equal(t, 200, deliveries[0].StatusCode.Int)
+	})
+
+	t.Run("filter by asset and time", func(t *testing.T) {
+		deliveries, err := repo.GetWebhookDeliveries(ctx, trigger.ID, DeliveryFilter{
+			AssetDid: vehicleA,
+			From:     start.Add(time.Minute),
+			To:       start.Add(3 * time.Minute),
+			Limit:    10,
+		})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, ids[2], deliveries[0].ID)
+	})
+
+	t.Run("cursor continues after last page", func(t *testing.T) {
+		page, err := repo.GetWebhookDeliveries(ctx, trigger.ID, DeliveryFilter{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+
+		last := page[1]
+		next, err := repo.GetWebhookDeliveries(ctx, trigger.ID, DeliveryFilter{
+			Cursor: &DeliveryCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+			Limit:  2,
+		})
+		require.NoError(t, err)
+		require.Len(t, next, 2)
+		assert.Equal(t, ids[1], next[0].ID)
+		assert.Equal(t, ids[0], next[1].ID)
+	})
+
+	t.Run("delete before", func(t *testing.T) {
+		deleted, err := repo.DeleteWebhookDeliveriesBefore(ctx, start.Add(2*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		deliveries, err := repo.GetWebhookDeliveries(ctx, trigger.ID, DeliveryFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+	})
+}
+
+func TestTriggerVehicleState(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 120",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		SustainFor:              60,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 60, trigger.SustainFor)
+
+	assetDid := randAssetDID(t)
+	state, err := repo.GetTriggerVehicleState(ctx, trigger.ID, assetDid)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	since := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.UpsertTriggerVehicleState(ctx, &models.TriggerVehicleState{
+		TriggerID:          trigger.ID,
+		AssetDid:           assetDid.String(),
+		ConditionTrueSince: null.TimeFrom(since),
+	}))
+	state, err = repo.GetTriggerVehicleState(ctx, trigger.ID, assetDid)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, since.Equal(state.ConditionTrueSince.Time))
+
+	assert.False(t, state.LastConditionResult)
+
+	state.ConditionTrueSince = null.Time{}
+	state.LastConditionResult = true
+	require.NoError(t, repo.UpsertTriggerVehicleState(ctx, state))
+	state, err = repo.GetTriggerVehicleState(ctx, trigger.ID, assetDid)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.False(t, state.ConditionTrueSince.Valid)
+	assert.True(t, state.LastConditionResult)
+
+	other, err := repo.GetTriggerVehicleState(ctx, trigger.ID, randAssetDID(t))
+	require.NoError(t, err)
+	assert.Nil(t, other)
+}
+
+func TestSaveTriggerVehicleStates(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	devLicense := tests.RandomAddr(t)
+	newTrigger := func() *models.Trigger {
+		trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+			Service:                 ServiceSignal,
+			MetricName:              "vss.speed",
+			Condition:               "valueNumber > 120",
+			TargetURI:               "https://example.com/webhook",
+			Status:                  StatusEnabled,
+			DeveloperLicenseAddress: devLicense,
+		})
+		require.NoError(t, err)
+		return trigger
+	}
+	kept, deleted := newTrigger(), newTrigger()
+	assetDid := randAssetDID(t)
+
+	// The absence columns are owned by the absence scheduler and must survive the save.
+	seenAt := time.Now().UTC().Truncate(time.Second)
+	_, err := repo.RecordLastSeen(ctx, []LastSeen{{TriggerID: kept.ID, AssetDid: assetDid.String(), SeenAt: seenAt}})
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteTrigger(ctx, deleted.ID, devLicense))
+
+	triggeredAt := seenAt.Add(time.Second)
+	require.NoError(t, repo.SaveTriggerVehicleStates(ctx, []*models.TriggerVehicleState{
+		{
+			TriggerID:           kept.ID,
+			AssetDid:            assetDid.String(),
+			LastConditionResult: true,
+			LastTriggeredAt:     null.TimeFrom(triggeredAt),
+			LastTriggeredData:   null.JSONFrom([]byte(`{"value":2}`)),
+			LastEvaluatedAt:     null.TimeFrom(triggeredAt),
+			RecentFirings:       null.JSONFrom([]byte(`["2025-01-01T00:00:00Z"]`)),
+		},
+		// The trigger was deleted after the state was cached; its state is skipped.
+		{
+			TriggerID:           deleted.ID,
+			AssetDid:            assetDid.String(),
+			LastConditionResult: true,
+		},
+	}))
+
+	state, err := repo.GetTriggerVehicleState(ctx, kept.ID, assetDid)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.LastConditionResult)
+	assert.True(t, triggeredAt.Equal(state.LastTriggeredAt.Time))
+	assert.True(t, triggeredAt.Equal(state.LastEvaluatedAt.Time))
+	assert.JSONEq(t, `{"value":2}`, string(state.LastTriggeredData.JSON))
+	assert.JSONEq(t, `["2025-01-01T00:00:00Z"]`, string(state.RecentFirings.JSON))
+	assert.False(t, state.ConditionTrueSince.Valid)
+	assert.True(t, seenAt.Equal(state.LastSeenAt.Time))
+
+	state, err = repo.GetTriggerVehicleState(ctx, deleted.ID, assetDid)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestVehicleMetricState(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	assetDid := randAssetDID(t)
+	state, err := repo.GetVehicleMetricState(ctx, assetDid, "vss.speed")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	observedAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.SaveVehicleMetricStates(ctx, []*models.VehicleMetricState{
+		{AssetDid: assetDid.String(), MetricName: "vss.speed", SnapshotData: []byte(`{"value":2}`), ObservedAt: observedAt},
+		{AssetDid: assetDid.String(), MetricName: "vss.powertrainRange", SnapshotData: []byte(`{"value":300}`), ObservedAt: observedAt},
+	}))
+
+	// An older observation, e.g. saved late by another instance, does not overwrite a newer one.
+	require.NoError(t, repo.SaveVehicleMetricStates(ctx, []*models.VehicleMetricState{
+		{AssetDid: assetDid.String(), MetricName: "vss.speed", SnapshotData: []byte(`{"value":1}`), ObservedAt: observedAt.Add(-time.Minute)},
+	}))
+	state, err = repo.GetVehicleMetricState(ctx, assetDid, "vss.speed")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.JSONEq(t, `{"value":2}`, string(state.SnapshotData))
+	assert.True(t, observedAt.Equal(state.ObservedAt))
+
+	require.NoError(t, repo.SaveVehicleMetricStates(ctx, []*models.VehicleMetricState{
+		{AssetDid: assetDid.String(), MetricName: "vss.speed", SnapshotData: []byte(`{"value":3}`), ObservedAt: observedAt.Add(time.Minute)},
+	}))
+	state, err = repo.GetVehicleMetricState(ctx, assetDid, "vss.speed")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.JSONEq(t, `{"value":3}`, string(state.SnapshotData))
+	assert.False(t, state.FiredSnapshotData.Valid)
+
+	// The value last fired for moves forward independently of the observed value.
+	firedAt := observedAt.Add(time.Minute)
+	require.NoError(t, repo.SaveVehicleMetricStates(ctx, []*models.VehicleMetricState{
+		{
+			AssetDid:          assetDid.String(),
+			MetricName:        "vss.speed",
+			SnapshotData:      []byte(`{"value":1}`),
+			ObservedAt:        observedAt,
+			FiredSnapshotData: null.JSONFrom([]byte(`{"value":1}`)),
+			FiredAt:           null.TimeFrom(firedAt),
+		},
+	}))
+	require.NoError(t, repo.SaveVehicleMetricStates(ctx, []*models.VehicleMetricState{
+		{AssetDid: assetDid.String(), MetricName: "vss.speed", SnapshotData: []byte(`{"value":4}`), ObservedAt: observedAt.Add(2 * time.Minute)},
+	}))
+	state, err = repo.GetVehicleMetricState(ctx, assetDid, "vss.speed")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.JSONEq(t, `{"value":4}`, string(state.SnapshotData))
+	assert.JSONEq(t, `{"value":1}`, string(state.FiredSnapshotData.JSON))
+	assert.True(t, firedAt.Equal(state.FiredAt.Time))
+
+	state, err = repo.GetVehicleMetricState(ctx, assetDid, "vss.powertrainRange")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.JSONEq(t, `{"value":300}`, string(state.SnapshotData))
+}
+
+func TestCreateTriggerFireMode(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	req := CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	}
+	trigger, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, FireModeLevel, trigger.FireMode)
+	assert.False(t, trigger.ClearCondition.Valid)
+	assert.Equal(t, PreviousScopeLastObserved, trigger.PreviousScope)
+
+	req.DeveloperLicenseAddress = tests.RandomAddr(t)
+	req.FireMode = FireModeEdge
+	req.ClearCondition = "valueNumber < 50"
+	trigger, err = repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+	require.NoError(t, err)
+	assert.Equal(t, FireModeEdge, stored.FireMode)
+	assert.Equal(t, "valueNumber < 50", stored.ClearCondition.String)
+
+	req.FireMode = FireModeLevel
+	_, err = repo.CreateTrigger(ctx, req)
+	require.Error(t, err)
+}
+
+func TestCreateTriggerSchedule(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	req := CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+		Schedule:                json.RawMessage(`{"timezone":"America/New_York","windows":[{"start":"20:00","end":"06:00"}]}`),
+	}
+	trigger, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(req.Schedule), string(stored.Schedule.JSON))
+
+	req.DeveloperLicenseAddress = tests.RandomAddr(t)
+	req.Schedule = json.RawMessage(`{"timezone":"Mars/Olympus"}`)
+	_, err = repo.CreateTrigger(ctx, req)
+	require.Error(t, err)
+}
+
+func TestSnoozeTrigger(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	devAddress := tests.RandomAddr(t)
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: devAddress,
+	})
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	snoozed, err := repo.SnoozeTrigger(ctx, trigger.ID, devAddress, null.TimeFrom(until))
+	require.NoError(t, err)
+	assert.True(t, until.Equal(snoozed.SnoozedUntil.Time))
+
+	// Updating the trigger keeps the snooze.
+	stored, err := repo.GetTriggerByIDAndDeveloperLicense(ctx, trigger.ID, devAddress)
+	require.NoError(t, err)
+	stored.SnoozedUntil = null.Time{}
+	require.NoError(t, repo.UpdateTrigger(ctx, stored))
+	stored, err = repo.InternalGetTriggerByID(ctx, trigger.ID)
+	require.NoError(t, err)
+	require.True(t, stored.SnoozedUntil.Valid)
+	assert.True(t, until.Equal(stored.SnoozedUntil.Time))
+
+	_, err = repo.SnoozeTrigger(ctx, trigger.ID, devAddress, null.Time{})
+	require.NoError(t, err)
+	stored, err = repo.InternalGetTriggerByID(ctx, trigger.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.SnoozedUntil.Valid)
+
+	_, err = repo.SnoozeTrigger(ctx, trigger.ID, tests.RandomAddr(t), null.TimeFrom(until))
+	require.Error(t, err)
+}
+
+func TestTriggerReconciliation(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	devAddress := tests.RandomAddr(t)
+	newTrigger := func(mode string) *models.Trigger {
+		t.Helper()
+		trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+			Service:                 ServiceSignal,
+			MetricName:              "vss.speed",
+			Condition:               "valueNumber > 55",
+			TargetURI:               "https://example.com/webhook",
+			Status:                  StatusEnabled,
+			SubscriptionMode:        mode,
+			DeveloperLicenseAddress: devAddress,
+		})
+		require.NoError(t, err)
+		return trigger
+	}
+	allShared := newTrigger(SubscriptionModeAllShared)
+	explicit := newTrigger("")
+	assert.Equal(t, SubscriptionModeExplicit, explicit.SubscriptionMode)
+
+	// New allShared triggers are due right away and leased once claimed.
+	claimed, err := repo.ClaimTriggersToReconcile(ctx, 100, time.Hour, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, allShared.ID, claimed[0].ID)
+	claimed, err = repo.ClaimTriggersToReconcile(ctx, 100, time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	require.NoError(t, repo.RecordTriggerReconciliation(ctx, allShared.ID, Reconciliation{Added: 2, Removed: 1, Error: "Failed to sync 1 vehicles"}))
+	stored, err := repo.InternalGetTriggerByID(ctx, allShared.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.ReconciledAt.Valid)
+	assert.Equal(t, 2, stored.ReconciledAdded)
+	assert.Equal(t, 1, stored.ReconciledRemoved)
+	assert.Equal(t, "Failed to sync 1 vehicles", stored.ReconcileError.String)
+	assert.False(t, stored.ReconcileLockedUntil.Valid)
+
+	// Updating the trigger keeps the outcome, and the trigger is not due again within the interval.
+	stored.ReconciledAdded = 0
+	require.NoError(t, repo.UpdateTrigger(ctx, stored))
+	stored, err = repo.InternalGetTriggerByID(ctx, allShared.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.ReconciledAdded)
+	claimed, err = repo.ClaimTriggersToReconcile(ctx, 100, time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// A requested reconciliation makes it due right away.
+	require.NoError(t, repo.RequestTriggerReconciliation(ctx, allShared.ID))
+	claimed, err = repo.ClaimTriggersToReconcile(ctx, 100, time.Hour, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, allShared.ID, claimed[0].ID)
+
+	_, err = repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		SubscriptionMode:        "everything",
+		DeveloperLicenseAddress: devAddress,
+	})
+	require.Error(t, err)
+}
+
+func TestMuteVehicleSubscription(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	trigger, err := repo.CreateTrigger(ctx, CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	})
+	require.NoError(t, err)
+	mutedDid := randAssetDID(t)
+	otherDid := randAssetDID(t)
+	_, err = repo.CreateVehicleSubscription(ctx, mutedDid, trigger.ID, null.JSON{})
+	require.NoError(t, err)
+	_, err = repo.CreateVehicleSubscription(ctx, otherDid, trigger.ID, null.JSON{})
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	count, err := repo.MuteVehicleSubscription(ctx, trigger.ID, mutedDid, null.TimeFrom(until))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	subs, err := repo.InternalGetAllVehicleSubscriptions(ctx)
+	require.NoError(t, err)
+	mutedUntil := make(map[string]null.Time)
+	for _, sub := range subs {
+		mutedUntil[sub.AssetDid] = sub.MutedUntil
+	}
+	require.True(t, mutedUntil[mutedDid.String()].Valid)
+	assert.True(t, until.Equal(mutedUntil[mutedDid.String()].Time))
+	assert.False(t, mutedUntil[otherDid.String()].Valid)
+
+	count, err = repo.MuteVehicleSubscription(ctx, trigger.ID, mutedDid, null.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	subs, err = repo.GetVehicleSubscriptionsByTriggerID(ctx, trigger.ID)
+	require.NoError(t, err)
+	for _, sub := range subs {
+		assert.False(t, sub.MutedUntil.Valid)
+	}
+
+	count, err = repo.MuteVehicleSubscription(ctx, trigger.ID, randAssetDID(t), null.TimeFrom(until))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestReserveTriggerFiring(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	req := CreateTriggerRequest{
+		Service:                 ServiceSignal,
+		MetricName:              "vss.speed",
+		Condition:               "valueNumber > 55",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+		MaxFirings:              1,
+		GlobalMaxFirings:        2,
+		FiringWindow:            3600,
+	}
+	trigger, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	stored, err := repo.InternalGetTriggerByID(ctx, trigger.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.MaxFirings)
+	assert.Equal(t, 2, stored.GlobalMaxFirings)
+	assert.Equal(t, 3600, stored.FiringWindow)
+
+	now := time.Now()
+	for i, want := range []bool{true, true, false} {
+		reserved, err := repo.ReserveTriggerFiring(ctx, trigger, randAssetDID(t), now.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, want, reserved, "firing %d", i)
+	}
+
+	// The first firing leaves the window.
+	reserved, err := repo.ReserveTriggerFiring(ctx, trigger, randAssetDID(t), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	count, err := models.TriggerFirings(models.TriggerFiringWhere.TriggerID.EQ(trigger.ID)).Count(ctx, tc.DB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	req.DeveloperLicenseAddress = tests.RandomAddr(t)
+	req.FiringWindow = 0
+	_, err = repo.CreateTrigger(ctx, req)
+	require.Error(t, err)
+}
+
+func TestGeofences(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+	devLicense := tests.RandomAddr(t)
+	polygon := []byte(`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`)
+
+	depot, err := repo.CreateGeofence(ctx, devLicense, "depot", polygon)
+	require.NoError(t, err)
+	_, err = repo.CreateGeofence(ctx, devLicense, "yard", polygon)
+	require.NoError(t, err)
+	_, err = repo.CreateGeofence(ctx, tests.RandomAddr(t), "depot", polygon)
+	require.NoError(t, err, "names are unique per developer license")
+
+	_, err = repo.CreateGeofence(ctx, devLicense, "depot", polygon)
+	richErr, ok := richerrors.AsRichError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, richErr.Code)
+
+	geofences, err := repo.GetGeofencesByDeveloperLicense(ctx, devLicense)
+	require.NoError(t, err)
+	require.Len(t, geofences, 2)
+	assert.Equal(t, "depot", geofences[0].Name)
+	assert.Equal(t, "yard", geofences[1].Name)
+
+	_, err = repo.GetGeofenceByIDAndDeveloperLicense(ctx, depot.ID, tests.RandomAddr(t))
+	richErr, ok = richerrors.AsRichError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, richErr.Code)
+
+	got, err := repo.GetGeofenceByIDAndDeveloperLicense(ctx, depot.ID, devLicense)
+	require.NoError(t, err)
+	got.Name = "main-depot"
+	require.NoError(t, repo.UpdateGeofence(ctx, got))
+	got, err = repo.GetGeofenceByIDAndDeveloperLicense(ctx, depot.ID, devLicense)
+	require.NoError(t, err)
+	assert.Equal(t, "main-depot", got.Name)
+
+	got.Name = "yard"
+	err = repo.UpdateGeofence(ctx, got)
+	richErr, ok = richerrors.AsRichError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, richErr.Code)
+
+	all, err := repo.InternalGetAllGeofences(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, len(all), 3)
+
+	require.Error(t, repo.DeleteGeofence(ctx, depot.ID, tests.RandomAddr(t)))
+	require.NoError(t, repo.DeleteGeofence(ctx, depot.ID, devLicense))
+	geofences, err = repo.GetGeofencesByDeveloperLicense(ctx, devLicense)
+	require.NoError(t, err)
+	require.Len(t, geofences, 1)
+}
+
+func TestAbsence(t *testing.T) {
+	t.Parallel()
+	tc := tests.SetupTestContainer(t)
+
+	repo := NewRepository(tc.DB)
+	ctx := context.Background()
+
+	req := CreateTriggerRequest{
+		Service:                 ServiceAbsence,
+		MetricName:              AnySignal,
+		Condition:               "offline",
+		TargetURI:               "https://example.com/webhook",
+		Status:                  StatusEnabled,
+		DeveloperLicenseAddress: tests.RandomAddr(t),
+	}
+	_, err := repo.CreateTrigger(ctx, req)
+	require.Error(t, err)
+
+	req.AbsentFor = 60
+	trigger, err := repo.CreateTrigger(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 60, trigger.AbsentFor)
+
+	silent, online := randAssetDID(t), randAssetDID(t)
+	for _, assetDid := range []cloudevent.ERC721DID{silent, online} {
+		_, err := repo.CreateVehicleSubscription(ctx, assetDid, trigger.ID, null.JSON{})
+		require.NoError(t, err)
+	}
+
+	tracked, err := repo.TrackAbsenceSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), tracked)
+	tracked, err = repo.TrackAbsenceSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, tracked)
+
+	// Nobody has been silent for long enough yet.
+	states, err := repo.ClaimAbsentVehicles(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	lastSeen := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Second)
+	_, err = tc.DB.ExecContext(ctx, `UPDATE trigger_vehicle_state SET last_seen_at = $1 WHERE trigger_id = $2 AND asset_did = $3`,
+		lastSeen, trigger.ID, silent.String())
+	require.NoError(t, err)
+
+	// Other instances may not have stored a newer signal yet.
+	states, err = repo.ClaimAbsentVehicles(ctx, 10, 2*time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	states, err = repo.ClaimAbsentVehicles(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, trigger.ID, states[0].TriggerID)
+	assert.Equal(t, silent.String(), states[0].AssetDid)
+	assert.Equal(t, AnySignal, states[0].MetricName)
+	assert.True(t, lastSeen.Equal(states[0].LastSeenAt))
+	assert.False(t, states[0].AbsentSince.IsZero())
+
+	// A silence is only claimed once.
+	states, err = repo.ClaimAbsentVehicles(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	seenAt := time.Now().UTC().Truncate(time.Millisecond)
+	states, err = repo.RecordLastSeen(ctx, []LastSeen{
+		{TriggerID: trigger.ID, AssetDid: silent.String(), SeenAt: seenAt},
+		{TriggerID: trigger.ID, AssetDid: online.String(), SeenAt: seenAt},
+	})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, silent.String(), states[0].AssetDid)
+	assert.True(t, lastSeen.Equal(states[0].LastSeenAt))
+	assert.True(t, seenAt.Equal(states[0].SeenAt.Time))
+
+	// Older timestamps do not move the last seen timestamp back.
+	states, err = repo.RecordLastSeen(ctx, []LastSeen{{TriggerID: trigger.ID, AssetDid: silent.String(), SeenAt: lastSeen}})
+	require.NoError(t, err)
+	assert.Empty(t, states)
+	state, err := repo.GetTriggerVehicleState(ctx, trigger.ID, silent)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, seenAt.Equal(state.LastSeenAt.Time))
+	assert.False(t, state.AbsentSince.Valid)
+}
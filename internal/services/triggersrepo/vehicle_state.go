@@ -0,0 +1,159 @@
+package triggersrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/lib/pq"
+)
+
+// GetTriggerVehicleState returns the evaluation state of a trigger for a vehicle.
+// Returns (nil, nil) when no state has been recorded yet.
+func (r *Repository) GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error) {
+	state, err := models.FindTriggerVehicleState(ctx, r.db, triggerID, assetDid.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get trigger vehicle state: %w", err)
+	}
+	return state, nil
+}
+
+// UpsertTriggerVehicleState creates or replaces the evaluation state of a trigger for a vehicle.
+func (r *Repository) UpsertTriggerVehicleState(ctx context.Context, state *models.TriggerVehicleState) error {
+	if err := state.Upsert(ctx, r.db, true,
+		[]string{models.TriggerVehicleStateColumns.TriggerID, models.TriggerVehicleStateColumns.AssetDid},
+		boil.Infer(), boil.Infer(),
+	); err != nil {
+		return fmt.Errorf("failed to upsert trigger vehicle state: %w", err)
+	}
+	return nil
+}
+
+// SaveTriggerVehicleStates stores the evaluation state of triggers for vehicles in one statement. Only the
+// columns written by evaluations are updated, so the absence timestamps of the rows are kept. States of
+// triggers that have been deleted in the meantime are skipped. states must not hold the same trigger and
+// vehicle twice.
+func (r *Repository) SaveTriggerVehicleStates(ctx context.Context, states []*models.TriggerVehicleState) error {
+	if len(states) == 0 {
+		return nil
+	}
+	triggerIDs := make([]string, len(states))
+	assetDids := make([]string, len(states))
+	trueSince := make([]sql.NullString, len(states))
+	results := make([]bool, len(states))
+	triggeredAts := make([]sql.NullString, len(states))
+	evaluatedAts := make([]sql.NullString, len(states))
+	triggeredData := make([]sql.NullString, len(states))
+	recentFirings := make([]sql.NullString, len(states))
+	for i, s := range states {
+		triggerIDs[i] = s.TriggerID
+		assetDids[i] = s.AssetDid
+		trueSince[i] = nullTimeText(s.ConditionTrueSince)
+		results[i] = s.LastConditionResult
+		triggeredAts[i] = nullTimeText(s.LastTriggeredAt)
+		evaluatedAts[i] = nullTimeText(s.LastEvaluatedAt)
+		triggeredData[i] = nullJSONText(s.LastTriggeredData)
+		recentFirings[i] = nullJSONText(s.RecentFirings)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO trigger_vehicle_state (trigger_id, asset_did, condition_true_since, last_condition_result,
+			last_triggered_at, last_evaluated_at, last_triggered_data, recent_firings, updated_at)
+		SELECT s.trigger_id, s.asset_did, s.condition_true_since, s.last_condition_result,
+			s.last_triggered_at, s.last_evaluated_at, s.last_triggered_data::jsonb, s.recent_firings::jsonb, now()
+		FROM unnest($1::uuid[], $2::text[], $3::timestamptz[], $4::boolean[], $5::timestamptz[], $6::timestamptz[], $7::text[], $8::text[])
+			AS s(trigger_id, asset_did, condition_true_since, last_condition_result, last_triggered_at, last_evaluated_at,
+				last_triggered_data, recent_firings)
+		WHERE EXISTS (SELECT 1 FROM triggers t WHERE t.id = s.trigger_id AND t.status <> $9)
+		ON CONFLICT (trigger_id, asset_did) DO UPDATE
+		SET condition_true_since = EXCLUDED.condition_true_since,
+			last_condition_result = EXCLUDED.last_condition_result,
+			last_triggered_at = EXCLUDED.last_triggered_at,
+			last_evaluated_at = EXCLUDED.last_evaluated_at,
+			last_triggered_data = EXCLUDED.last_triggered_data,
+			recent_firings = EXCLUDED.recent_firings,
+			updated_at = EXCLUDED.updated_at`,
+		pq.Array(triggerIDs), pq.Array(assetDids), pq.Array(trueSince), pq.Array(results), pq.Array(triggeredAts), pq.Array(evaluatedAts),
+		pq.Array(triggeredData), pq.Array(recentFirings), StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to save trigger vehicle states: %w", err)
+	}
+	return nil
+}
+
+// GetVehicleMetricState returns the last observed value of a metric of a vehicle.
+// Returns (nil, nil) when the metric has not been observed yet.
+func (r *Repository) GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error) {
+	state, err := models.FindVehicleMetricState(ctx, r.db, assetDid.String(), metricName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get vehicle metric state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveVehicleMetricStates stores the last observed values of metrics of vehicles, and the values triggers last
+// fired for, in one statement. A stored value is only replaced by one observed or fired for at the same time or
+// later. states must not hold the same vehicle and metric twice.
+func (r *Repository) SaveVehicleMetricStates(ctx context.Context, states []*models.VehicleMetricState) error {
+	if len(states) == 0 {
+		return nil
+	}
+	assetDids := make([]string, len(states))
+	metricNames := make([]string, len(states))
+	snapshots := make([]string, len(states))
+	observedAts := make([]string, len(states))
+	firedSnapshots := make([]sql.NullString, len(states))
+	firedAts := make([]sql.NullString, len(states))
+	for i, s := range states {
+		assetDids[i] = s.AssetDid
+		metricNames[i] = s.MetricName
+		snapshots[i] = string(s.SnapshotData)
+		observedAts[i] = s.ObservedAt.UTC().Format(time.RFC3339Nano)
+		firedSnapshots[i] = nullJSONText(s.FiredSnapshotData)
+		firedAts[i] = nullTimeText(s.FiredAt)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO vehicle_metric_state (asset_did, metric_name, snapshot_data, observed_at, fired_snapshot_data, fired_at, updated_at)
+		SELECT s.asset_did, s.metric_name, s.snapshot_data::jsonb, s.observed_at, s.fired_snapshot_data::jsonb, s.fired_at, now()
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::timestamptz[], $5::text[], $6::timestamptz[])
+			AS s(asset_did, metric_name, snapshot_data, observed_at, fired_snapshot_data, fired_at)
+		ON CONFLICT (asset_did, metric_name) DO UPDATE
+		SET snapshot_data = CASE WHEN vehicle_metric_state.observed_at <= EXCLUDED.observed_at
+				THEN EXCLUDED.snapshot_data ELSE vehicle_metric_state.snapshot_data END,
+			observed_at = GREATEST(vehicle_metric_state.observed_at, EXCLUDED.observed_at),
+			fired_snapshot_data = CASE WHEN vehicle_metric_state.fired_at IS NULL OR vehicle_metric_state.fired_at <= EXCLUDED.fired_at
+				THEN EXCLUDED.fired_snapshot_data ELSE vehicle_metric_state.fired_snapshot_data END,
+			fired_at = GREATEST(vehicle_metric_state.fired_at, EXCLUDED.fired_at),
+			updated_at = EXCLUDED.updated_at`,
+		pq.Array(assetDids), pq.Array(metricNames), pq.Array(snapshots), pq.Array(observedAts), pq.Array(firedSnapshots), pq.Array(firedAts))
+	if err != nil {
+		return fmt.Errorf("failed to save vehicle metric states: %w", err)
+	}
+	return nil
+}
+
+// nullJSONText formats j for a text array parameter that is cast to jsonb.
+func nullJSONText(j null.JSON) sql.NullString {
+	return sql.NullString{String: string(j.JSON), Valid: j.Valid}
+}
+
+// nullTimeText formats t for a timestamptz array parameter.
+func nullTimeText(t null.Time) sql.NullString {
+	if !t.Valid {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.Time.UTC().Format(time.RFC3339Nano), Valid: true}
+}
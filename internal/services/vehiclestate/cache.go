@@ -0,0 +1,271 @@
+// Package vehiclestate caches the evaluation state of triggers and the last observed value of metrics per vehicle
+// in memory and writes them to the database in the background.
+package vehiclestate
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultIdleTimeout   = 10 * time.Minute
+)
+
+type Repository interface {
+	GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error)
+	SaveTriggerVehicleStates(ctx context.Context, states []*models.TriggerVehicleState) error
+	GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error)
+	SaveVehicleMetricStates(ctx context.Context, states []*models.VehicleMetricState) error
+	ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error)
+}
+
+type triggerKey struct {
+	triggerID string
+	assetDid  string
+}
+
+type metricKey struct {
+	assetDid   string
+	metricName string
+}
+
+// entry is a cached row. value is nil if the database has no row, so that a miss is not looked up again.
+type entry[T any] struct {
+	value  *T
+	dirty  bool
+	usedAt time.Time
+}
+
+// Cache is a write-behind cache of trigger_vehicle_state and vehicle_metric_state. Reads are served from memory
+// once a row was loaded, and writes are stored in memory and written to the database every flush interval.
+// It is safe for concurrent use, but it assumes it is the only writer of the evaluation columns of the rows it
+// holds, which is the case while each vehicle is consumed by a single instance.
+type Cache struct {
+	repo          Repository
+	flushInterval time.Duration
+	idleTimeout   time.Duration
+
+	mu       sync.Mutex
+	triggers map[triggerKey]*entry[models.TriggerVehicleState]
+	metrics  map[metricKey]*entry[models.VehicleMetricState]
+}
+
+// NewCache creates a new Cache.
+func NewCache(repo Repository, settings *config.Settings) *Cache {
+	flushInterval := settings.VehicleStateFlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	idleTimeout := settings.VehicleStateIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	return &Cache{
+		repo:          repo,
+		flushInterval: flushInterval,
+		idleTimeout:   idleTimeout,
+		triggers:      make(map[triggerKey]*entry[models.TriggerVehicleState]),
+		metrics:       make(map[metricKey]*entry[models.VehicleMetricState]),
+	}
+}
+
+// GetTriggerVehicleState returns a copy of the evaluation state of a trigger for a vehicle.
+// Returns (nil, nil) when no state has been recorded yet.
+func (c *Cache) GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error) {
+	key := triggerKey{triggerID: triggerID, assetDid: assetDid.String()}
+	return get(c, c.triggers, key, func() (*models.TriggerVehicleState, error) {
+		return c.repo.GetTriggerVehicleState(ctx, triggerID, assetDid)
+	})
+}
+
+// UpsertTriggerVehicleState records the evaluation state of a trigger for a vehicle. It is written to the
+// database with the next flush.
+func (c *Cache) UpsertTriggerVehicleState(_ context.Context, state *models.TriggerVehicleState) error {
+	key := triggerKey{triggerID: state.TriggerID, assetDid: state.AssetDid}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	set(c.triggers, key, state)
+	return nil
+}
+
+// GetVehicleMetricState returns a copy of the last observed value of a metric of a vehicle.
+// Returns (nil, nil) when the metric has not been observed yet.
+func (c *Cache) GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error) {
+	key := metricKey{assetDid: assetDid.String(), metricName: metricName}
+	return get(c, c.metrics, key, func() (*models.VehicleMetricState, error) {
+		return c.repo.GetVehicleMetricState(ctx, assetDid, metricName)
+	})
+}
+
+// SetVehicleMetricState records the last observed value of a metric of a vehicle, unless a value observed later
+// is already cached. The value a trigger last fired for is kept unless state holds a newer one. It is written to
+// the database with the next flush.
+func (c *Cache) SetVehicleMetricState(state *models.VehicleMetricState) {
+	key := metricKey{assetDid: state.AssetDid, metricName: state.MetricName}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, ok := c.metrics[key]
+	if !ok || current.value == nil {
+		set(c.metrics, key, state)
+		return
+	}
+	if current.value.ObservedAt.After(state.ObservedAt) {
+		current.usedAt = time.Now()
+		return
+	}
+	state = clone(state)
+	if !state.FiredAt.Valid || current.value.FiredAt.Time.After(state.FiredAt.Time) {
+		state.FiredSnapshotData = current.value.FiredSnapshotData
+		state.FiredAt = current.value.FiredAt
+	}
+	set(c.metrics, key, state)
+}
+
+// SetVehicleMetricFired records data as the value a trigger of a metric last fired for a vehicle. It requires
+// the observation of the metric to be cached, which is the case while the metric is evaluated, and is ignored
+// otherwise. It is written to the database with the next flush.
+func (c *Cache) SetVehicleMetricFired(assetDid cloudevent.ERC721DID, metricName string, data types.JSON, firedAt time.Time) {
+	key := metricKey{assetDid: assetDid.String(), metricName: metricName}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, ok := c.metrics[key]
+	if !ok || current.value == nil || current.value.FiredAt.Time.After(firedAt) {
+		return
+	}
+	state := clone(current.value)
+	state.FiredSnapshotData = null.JSONFrom(data)
+	state.FiredAt = null.TimeFrom(firedAt)
+	set(c.metrics, key, state)
+}
+
+// ReserveTriggerFiring records a firing of a trigger with a global cap in the database. It is not cached, since
+// the firings of a trigger across all vehicles are shared by all instances.
+func (c *Cache) ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error) {
+	return c.repo.ReserveTriggerFiring(ctx, trigger, assetDid, firedAt)
+}
+
+// Run flushes the cache every flush interval until the context is canceled, and one final time on the way out.
+func (c *Cache) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.Flush(context.WithoutCancel(ctx)); err != nil {
+				logger.Error().Err(err).Msg("failed to store vehicle state")
+			}
+			return nil
+		case <-ticker.C:
+		}
+		if err := c.Flush(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to store vehicle state")
+		}
+	}
+}
+
+// Flush writes the state recorded since the last flush to the database and evicts the state that has not been
+// used for longer than the idle timeout. State that fails to be written is kept and written with the next flush.
+func (c *Cache) Flush(ctx context.Context) error {
+	idleSince := time.Now().Add(-c.idleTimeout)
+	c.mu.Lock()
+	triggers := collectDirty(c.triggers, idleSince)
+	metrics := collectDirty(c.metrics, idleSince)
+	c.mu.Unlock()
+
+	var errs error
+	if len(triggers) != 0 {
+		if err := c.repo.SaveTriggerVehicleStates(ctx, slices.Collect(maps.Values(triggers))); err != nil {
+			markDirty(c, c.triggers, triggers)
+			errs = errors.Join(errs, err)
+		}
+	}
+	if len(metrics) != 0 {
+		if err := c.repo.SaveVehicleMetricStates(ctx, slices.Collect(maps.Values(metrics))); err != nil {
+			markDirty(c, c.metrics, metrics)
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+// get returns a copy of the cached value of key, loading it with load on a miss.
+func get[K comparable, T any](c *Cache, entries map[K]*entry[T], key K, load func() (*T, error)) (*T, error) {
+	c.mu.Lock()
+	if e, ok := entries[key]; ok {
+		e.usedAt = time.Now()
+		value := clone(e.value)
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.mu.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another caller may have loaded or recorded the value in the meantime; that one is at least as recent.
+	if e, ok := entries[key]; ok {
+		e.usedAt = time.Now()
+		return clone(e.value), nil
+	}
+	entries[key] = &entry[T]{value: value, usedAt: time.Now()}
+	return clone(value), nil
+}
+
+// set caches a copy of value for key and marks it to be written. The caller must hold the lock.
+func set[K comparable, T any](entries map[K]*entry[T], key K, value *T) {
+	entries[key] = &entry[T]{value: clone(value), dirty: true, usedAt: time.Now()}
+}
+
+// collectDirty returns copies of the values to write, clearing their dirty flag, and evicts clean entries that
+// have not been used since idleSince. The caller must hold the lock.
+func collectDirty[K comparable, T any](entries map[K]*entry[T], idleSince time.Time) map[K]*T {
+	dirty := make(map[K]*T)
+	for key, e := range entries {
+		if e.dirty {
+			dirty[key] = clone(e.value)
+			e.dirty = false
+			continue
+		}
+		if e.usedAt.Before(idleSince) {
+			delete(entries, key)
+		}
+	}
+	return dirty
+}
+
+// markDirty marks the entries of values to be written again after a failed write. A value recorded in the
+// meantime is written instead of the one that failed.
+func markDirty[K comparable, T any](c *Cache, entries map[K]*entry[T], values map[K]*T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range values {
+		if e, ok := entries[key]; ok {
+			e.dirty = true
+		}
+	}
+}
+
+func clone[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	c := *value
+	return &c
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cache.go -destination=cache_mock_test.go -package=vehiclestate
+//
+
+// Package vehiclestate is a generated GoMock package.
+package vehiclestate
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTriggerVehicleState mocks base method.
+func (m *MockRepository) GetTriggerVehicleState(ctx context.Context, triggerID string, assetDid cloudevent.ERC721DID) (*models.TriggerVehicleState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggerVehicleState", ctx, triggerID, assetDid)
+	ret0, _ := ret[0].(*models.TriggerVehicleState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggerVehicleState indicates an expected call of GetTriggerVehicleState.
+func (mr *MockRepositoryMockRecorder) GetTriggerVehicleState(ctx, triggerID, assetDid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerVehicleState", reflect.TypeOf((*MockRepository)(nil).GetTriggerVehicleState), ctx, triggerID, assetDid)
+}
+
+// GetVehicleMetricState mocks base method.
+func (m *MockRepository) GetVehicleMetricState(ctx context.Context, assetDid cloudevent.ERC721DID, metricName string) (*models.VehicleMetricState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleMetricState", ctx, assetDid, metricName)
+	ret0, _ := ret[0].(*models.VehicleMetricState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleMetricState indicates an expected call of GetVehicleMetricState.
+func (mr *MockRepositoryMockRecorder) GetVehicleMetricState(ctx, assetDid, metricName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleMetricState", reflect.TypeOf((*MockRepository)(nil).GetVehicleMetricState), ctx, assetDid, metricName)
+}
+
+// ReserveTriggerFiring mocks base method.
+func (m *MockRepository) ReserveTriggerFiring(ctx context.Context, trigger *models.Trigger, assetDid cloudevent.ERC721DID, firedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveTriggerFiring", ctx, trigger, assetDid, firedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveTriggerFiring indicates an expected call of ReserveTriggerFiring.
+func (mr *MockRepositoryMockRecorder) ReserveTriggerFiring(ctx, trigger, assetDid, firedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveTriggerFiring", reflect.TypeOf((*MockRepository)(nil).ReserveTriggerFiring), ctx, trigger, assetDid, firedAt)
+}
+
+// SaveTriggerVehicleStates mocks base method.
+func (m *MockRepository) SaveTriggerVehicleStates(ctx context.Context, states []*models.TriggerVehicleState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTriggerVehicleStates", ctx, states)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTriggerVehicleStates indicates an expected call of SaveTriggerVehicleStates.
+func (mr *MockRepositoryMockRecorder) SaveTriggerVehicleStates(ctx, states any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTriggerVehicleStates", reflect.TypeOf((*MockRepository)(nil).SaveTriggerVehicleStates), ctx, states)
+}
+
+// SaveVehicleMetricStates mocks base method.
+func (m *MockRepository) SaveVehicleMetricStates(ctx context.Context, states []*models.VehicleMetricState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVehicleMetricStates", ctx, states)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveVehicleMetricStates indicates an expected call of SaveVehicleMetricStates.
+func (mr *MockRepositoryMockRecorder) SaveVehicleMetricStates(ctx, states any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVehicleMetricStates", reflect.TypeOf((*MockRepository)(nil).SaveVehicleMetricStates), ctx, states)
+}
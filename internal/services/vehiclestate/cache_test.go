@@ -0,0 +1,215 @@
+//go:generate go tool mockgen -source=cache.go -destination=cache_mock_test.go -package=vehiclestate
+package vehiclestate
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testTriggerID = "test-trigger-id"
+
+var testAssetDID = cloudevent.ERC721DID{
+	ChainID:         137,
+	ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+	TokenID:         big.NewInt(12345),
+}
+
+func newTestCache(t *testing.T, settings *config.Settings) (*Cache, *MockRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepository(ctrl)
+	if settings == nil {
+		settings = &config.Settings{}
+	}
+	return NewCache(repo, settings), repo
+}
+
+func TestCacheTriggerVehicleState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("loads a state once", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		stored := &models.TriggerVehicleState{TriggerID: testTriggerID, AssetDid: testAssetDID.String(), LastConditionResult: true}
+		repo.EXPECT().GetTriggerVehicleState(gomock.Any(), testTriggerID, testAssetDID).Return(stored, nil).Times(1)
+
+		for range 2 {
+			state, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+			require.NoError(t, err)
+			assert.Equal(t, stored, state)
+		}
+	})
+
+	t.Run("remembers a state that does not exist", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		repo.EXPECT().GetTriggerVehicleState(gomock.Any(), testTriggerID, testAssetDID).Return(nil, nil).Times(1)
+
+		for range 2 {
+			state, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+			require.NoError(t, err)
+			assert.Nil(t, state)
+		}
+	})
+
+	t.Run("does not cache a failed load", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		repo.EXPECT().GetTriggerVehicleState(gomock.Any(), testTriggerID, testAssetDID).Return(nil, errors.New("db down"))
+		repo.EXPECT().GetTriggerVehicleState(gomock.Any(), testTriggerID, testAssetDID).Return(nil, nil)
+
+		_, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+		require.Error(t, err)
+		state, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+		require.NoError(t, err)
+		assert.Nil(t, state)
+	})
+
+	t.Run("serves and flushes recorded states", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		recorded := &models.TriggerVehicleState{
+			TriggerID:       testTriggerID,
+			AssetDid:        testAssetDID.String(),
+			LastTriggeredAt: null.TimeFrom(time.Now()),
+		}
+		require.NoError(t, cache.UpsertTriggerVehicleState(ctx, recorded))
+
+		// The caller keeps its own copy.
+		recorded.LastConditionResult = true
+		state, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+		require.NoError(t, err)
+		assert.False(t, state.LastConditionResult)
+		state.LastConditionResult = true
+		state, err = cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+		require.NoError(t, err)
+		assert.False(t, state.LastConditionResult)
+
+		repo.EXPECT().SaveTriggerVehicleStates(gomock.Any(), []*models.TriggerVehicleState{state}).Return(nil)
+		require.NoError(t, cache.Flush(ctx))
+		// Nothing changed since the last flush.
+		require.NoError(t, cache.Flush(ctx))
+	})
+
+	t.Run("writes states again after a failed flush", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		recorded := &models.TriggerVehicleState{TriggerID: testTriggerID, AssetDid: testAssetDID.String()}
+		require.NoError(t, cache.UpsertTriggerVehicleState(ctx, recorded))
+
+		gomock.InOrder(
+			repo.EXPECT().SaveTriggerVehicleStates(gomock.Any(), []*models.TriggerVehicleState{recorded}).Return(errors.New("db down")),
+			repo.EXPECT().SaveTriggerVehicleStates(gomock.Any(), []*models.TriggerVehicleState{recorded}).Return(nil),
+		)
+		require.Error(t, cache.Flush(ctx))
+		require.NoError(t, cache.Flush(ctx))
+		require.NoError(t, cache.Flush(ctx))
+	})
+
+	t.Run("evicts idle states", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, &config.Settings{VehicleStateIdleTimeout: time.Millisecond})
+		recorded := &models.TriggerVehicleState{TriggerID: testTriggerID, AssetDid: testAssetDID.String()}
+		require.NoError(t, cache.UpsertTriggerVehicleState(ctx, recorded))
+
+		repo.EXPECT().SaveTriggerVehicleStates(gomock.Any(), gomock.Len(1)).Return(nil)
+		require.NoError(t, cache.Flush(ctx))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, cache.Flush(ctx))
+
+		repo.EXPECT().GetTriggerVehicleState(gomock.Any(), testTriggerID, testAssetDID).Return(recorded, nil)
+		state, err := cache.GetTriggerVehicleState(ctx, testTriggerID, testAssetDID)
+		require.NoError(t, err)
+		assert.Equal(t, recorded, state)
+	})
+}
+
+func TestCacheVehicleMetricState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	newState := func(value string, observedAt time.Time) *models.VehicleMetricState {
+		return &models.VehicleMetricState{
+			AssetDid:     testAssetDID.String(),
+			MetricName:   "vss.speed",
+			SnapshotData: types.JSON(value),
+			ObservedAt:   observedAt,
+		}
+	}
+
+	t.Run("keeps the latest observation", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		latest := newState(`{"value":2}`, now)
+		cache.SetVehicleMetricState(newState(`{"value":1}`, now.Add(-time.Second)))
+		cache.SetVehicleMetricState(latest)
+		cache.SetVehicleMetricState(newState(`{"value":0}`, now.Add(-time.Minute)))
+
+		state, err := cache.GetVehicleMetricState(ctx, testAssetDID, "vss.speed")
+		require.NoError(t, err)
+		assert.Equal(t, latest, state)
+
+		repo.EXPECT().SaveVehicleMetricStates(gomock.Any(), []*models.VehicleMetricState{latest}).Return(nil)
+		require.NoError(t, cache.Flush(ctx))
+	})
+
+	t.Run("loads the stored observation", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		stored := newState(`{"value":1}`, now)
+		repo.EXPECT().GetVehicleMetricState(gomock.Any(), testAssetDID, "vss.speed").Return(stored, nil).Times(1)
+
+		state, err := cache.GetVehicleMetricState(ctx, testAssetDID, "vss.speed")
+		require.NoError(t, err)
+		assert.Equal(t, stored, state)
+
+		// An older observation does not replace the stored one.
+		cache.SetVehicleMetricState(newState(`{"value":0}`, now.Add(-time.Second)))
+		state, err = cache.GetVehicleMetricState(ctx, testAssetDID, "vss.speed")
+		require.NoError(t, err)
+		assert.Equal(t, stored, state)
+		require.NoError(t, cache.Flush(ctx))
+	})
+
+	t.Run("keeps the value last fired for across observations", func(t *testing.T) {
+		t.Parallel()
+		cache, repo := newTestCache(t, nil)
+		cache.SetVehicleMetricState(newState(`{"value":1}`, now.Add(-time.Minute)))
+		cache.SetVehicleMetricFired(testAssetDID, "vss.speed", types.JSON(`{"value":1}`), now)
+		// An older firing does not replace the recorded one.
+		cache.SetVehicleMetricFired(testAssetDID, "vss.speed", types.JSON(`{"value":0}`), now.Add(-time.Second))
+		latest := newState(`{"value":2}`, now)
+		cache.SetVehicleMetricState(latest)
+
+		state, err := cache.GetVehicleMetricState(ctx, testAssetDID, "vss.speed")
+		require.NoError(t, err)
+		assert.Equal(t, latest.SnapshotData, state.SnapshotData)
+		assert.Equal(t, null.JSONFrom([]byte(`{"value":1}`)), state.FiredSnapshotData)
+		assert.Equal(t, null.TimeFrom(now), state.FiredAt)
+
+		repo.EXPECT().SaveVehicleMetricStates(gomock.Any(), []*models.VehicleMetricState{state}).Return(nil)
+		require.NoError(t, cache.Flush(ctx))
+	})
+
+	t.Run("ignores a firing of a metric that is not cached", func(t *testing.T) {
+		t.Parallel()
+		cache, _ := newTestCache(t, nil)
+		cache.SetVehicleMetricFired(testAssetDID, "vss.speed", types.JSON(`{"value":1}`), now)
+		require.NoError(t, cache.Flush(ctx))
+	})
+}
@@ -0,0 +1,187 @@
+package webhookcache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+// AddSubscription adds the subscription of a vehicle to the cache, replacing its previous subscription to the
+// trigger. The trigger is compiled if none of its subscriptions are cached yet. If that fails a rebuild is
+// scheduled instead.
+func (wc *WebhookCache) AddSubscription(ctx context.Context, sub *models.VehicleSubscription) {
+	wc.mu.RLock()
+	webhook := wc.triggers[sub.TriggerID]
+	wc.mu.RUnlock()
+	if webhook == nil {
+		trigger, err := wc.repo.InternalGetTriggerByID(ctx, sub.TriggerID)
+		if err != nil {
+			wc.scheduleRefreshAfter(ctx, fmt.Errorf("failed to get trigger by id: %w", err), sub.TriggerID)
+			return
+		}
+		webhook, err = wc.compileTrigger(ctx, trigger)
+		if err != nil {
+			wc.scheduleRefreshAfter(ctx, err, sub.TriggerID)
+			return
+		}
+	}
+	wc.apply(func() {
+		cached, ok := wc.triggers[sub.TriggerID]
+		if !ok {
+			wc.triggers[sub.TriggerID] = webhook
+			cached = webhook
+		}
+		wc.setSubscription(ctx, cached, sub)
+	})
+}
+
+// RemoveSubscription removes the subscription of a vehicle to a trigger from the cache.
+func (wc *WebhookCache) RemoveSubscription(triggerID, assetDID string) {
+	wc.apply(func() {
+		delete(wc.subscriptions[triggerID], assetDID)
+		if len(wc.subscriptions[triggerID]) == 0 {
+			delete(wc.subscriptions, triggerID)
+			delete(wc.triggers, triggerID)
+		}
+		wc.removeVehicleWebhook(assetDID, triggerID)
+	})
+}
+
+// MuteSubscription sets when the subscription of a vehicle to a trigger is muted until, a null time unmutes it.
+func (wc *WebhookCache) MuteSubscription(ctx context.Context, triggerID, assetDID string, until null.Time) {
+	wc.apply(func() {
+		webhook, sub := wc.triggers[triggerID], wc.subscriptions[triggerID][assetDID]
+		if webhook == nil || sub == nil {
+			return
+		}
+		muted := *sub
+		muted.MutedUntil = until
+		wc.setSubscription(ctx, webhook, &muted)
+	})
+}
+
+// ReplaceTrigger recompiles a trigger that was changed and replaces it for all its subscribed vehicles. A
+// trigger that is not enabled stays cached with its subscriptions but is not returned by GetWebhooks. If the
+// trigger fails to compile a rebuild is scheduled instead.
+func (wc *WebhookCache) ReplaceTrigger(ctx context.Context, trigger *models.Trigger) {
+	wc.mu.RLock()
+	_, subscribed := wc.subscriptions[trigger.ID]
+	wc.mu.RUnlock()
+	if !subscribed {
+		// Nothing is cached for a trigger without subscriptions; it is compiled when a vehicle subscribes.
+		return
+	}
+	webhook, err := wc.compileTrigger(ctx, trigger)
+	if err != nil {
+		wc.scheduleRefreshAfter(ctx, err, trigger.ID)
+		return
+	}
+	wc.apply(func() {
+		subs, ok := wc.subscriptions[trigger.ID]
+		if !ok {
+			return
+		}
+		wc.triggers[trigger.ID] = webhook
+		for _, sub := range subs {
+			wc.setSubscription(ctx, webhook, sub)
+		}
+	})
+}
+
+// RemoveTrigger removes a trigger and all its subscriptions from the cache.
+func (wc *WebhookCache) RemoveTrigger(triggerID string) {
+	wc.apply(func() {
+		for assetDID := range wc.subscriptions[triggerID] {
+			wc.removeVehicleWebhook(assetDID, triggerID)
+		}
+		delete(wc.subscriptions, triggerID)
+		delete(wc.triggers, triggerID)
+	})
+}
+
+// apply runs a mutation of the cache under the write lock. A mutation applied while a rebuild is running is run
+// again on the result of the rebuild, so it must not depend on the state it was first applied to.
+func (wc *WebhookCache) apply(mutation func()) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	mutation()
+	if wc.rebuilding > 0 {
+		wc.replay = append(wc.replay, mutation)
+	}
+}
+
+// setSubscription caches the subscription of a vehicle and replaces the vehicle's webhook for the trigger.
+// Must be called with the write lock held.
+func (wc *WebhookCache) setSubscription(ctx context.Context, webhook *Webhook, sub *models.VehicleSubscription) {
+	if wc.subscriptions[sub.TriggerID] == nil {
+		wc.subscriptions[sub.TriggerID] = make(map[string]*models.VehicleSubscription)
+	}
+	wc.subscriptions[sub.TriggerID][sub.AssetDid] = sub
+	wc.removeVehicleWebhook(sub.AssetDid, sub.TriggerID)
+	if webhook.Trigger.Status != triggersrepo.StatusEnabled {
+		return
+	}
+	if wc.webhooks[sub.AssetDid] == nil {
+		wc.webhooks[sub.AssetDid] = make(map[string][]*Webhook)
+	}
+	byKey := wc.webhooks[sub.AssetDid]
+	own := vehicleWebhook(ctx, webhook, sub)
+	for _, key := range webhookKeys(own) {
+		// Clip so that appending copies the slice instead of writing to an array shared with callers.
+		byKey[key] = append(slices.Clip(byKey[key]), own)
+	}
+}
+
+// removeVehicleWebhook removes the webhook of a trigger from the webhooks of a vehicle. The slices returned by
+// GetWebhooks are replaced, never modified. Must be called with the write lock held.
+func (wc *WebhookCache) removeVehicleWebhook(assetDID, triggerID string) {
+	byKey, ok := wc.webhooks[assetDID]
+	if !ok {
+		return
+	}
+	isTrigger := func(webhook *Webhook) bool {
+		return webhook.Trigger.ID == triggerID
+	}
+	for key, webhooks := range byKey {
+		if !slices.ContainsFunc(webhooks, isTrigger) {
+			continue
+		}
+		remaining := slices.DeleteFunc(slices.Clone(webhooks), isTrigger)
+		if len(remaining) == 0 {
+			delete(byKey, key)
+			continue
+		}
+		byKey[key] = remaining
+	}
+	if len(byKey) == 0 {
+		delete(wc.webhooks, assetDID)
+	}
+}
+
+// compileTrigger compiles a trigger with the current geofences of its developer license.
+func (wc *WebhookCache) compileTrigger(ctx context.Context, trigger *models.Trigger) (*Webhook, error) {
+	developerLicense := common.BytesToAddress(trigger.DeveloperLicenseAddress)
+	stored, err := wc.repo.GetGeofencesByDeveloperLicense(ctx, developerLicense)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geofences: %w", err)
+	}
+	geofences, err := celcondition.ParseGeofences(stored)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("developer_license", developerLicense.Hex()).Msg("failed to parse geofences")
+		geofences = nil
+	}
+	return compileWebhook(trigger, geofences, nil)
+}
+
+// scheduleRefreshAfter logs a mutation that could not be applied and schedules a rebuild to pick it up.
+func (wc *WebhookCache) scheduleRefreshAfter(ctx context.Context, err error, triggerID string) {
+	zerolog.Ctx(ctx).Error().Err(err).Str("trigger_id", triggerID).Msg("failed to update webhook cache, scheduling a rebuild")
+	wc.ScheduleRefresh(ctx)
+}
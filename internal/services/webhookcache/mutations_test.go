@@ -0,0 +1,166 @@
+package webhookcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func speedTrigger(id string) *models.Trigger {
+	return &models.Trigger{
+		ID:         id,
+		Service:    triggersrepo.ServiceSignal,
+		MetricName: "vss.speed",
+		Status:     triggersrepo.StatusEnabled,
+		Condition:  "valueNumber > 10",
+	}
+}
+
+func TestWebhookCache_Mutations(t *testing.T) {
+	t.Parallel()
+
+	// populatedCache returns a cache built with a subscription of the vehicle to trigger-1.
+	populatedCache := func(t *testing.T, assetDID string) (*WebhookCache, *MockRepository) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return([]*models.VehicleSubscription{
+			{AssetDid: assetDID, TriggerID: "trigger-1"},
+		}, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{speedTrigger("trigger-1")}, nil)
+		require.NoError(t, cache.PopulateCache(ctx))
+		return cache, mockRepo
+	}
+
+	t.Run("adds subscriptions and compiles a trigger once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+		car, truck := randAssetDID(t).String(), randAssetDID(t).String()
+
+		mockRepo.EXPECT().InternalGetTriggerByID(ctx, "trigger-1").Return(speedTrigger("trigger-1"), nil).Times(1)
+		mockRepo.EXPECT().GetGeofencesByDeveloperLicense(ctx, gomock.Any()).Return(nil, nil).Times(1)
+
+		cache.AddSubscription(ctx, &models.VehicleSubscription{AssetDid: car, TriggerID: "trigger-1"})
+		cache.AddSubscription(ctx, &models.VehicleSubscription{AssetDid: truck, TriggerID: "trigger-1", Params: null.JSONFrom([]byte(`{}`))})
+
+		carWebhooks := cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, carWebhooks, 1)
+		assert.Equal(t, "trigger-1", carWebhooks[0].Trigger.ID)
+		assert.NotNil(t, carWebhooks[0].Program)
+		require.Len(t, cache.GetWebhooks(truck, triggersrepo.ServiceSignal, "vss.speed"), 1)
+
+		// Subscribing again replaces the subscription instead of adding a second webhook.
+		cache.AddSubscription(ctx, &models.VehicleSubscription{AssetDid: car, TriggerID: "trigger-1"})
+		require.Len(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"), 1)
+	})
+
+	t.Run("schedules a rebuild when the trigger can not be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{CacheDebounceTime: time.Hour})
+		ctx := context.Background()
+		car := randAssetDID(t).String()
+
+		mockRepo.EXPECT().InternalGetTriggerByID(ctx, "trigger-1").Return(nil, errors.New("db down"))
+
+		cache.AddSubscription(ctx, &models.VehicleSubscription{AssetDid: car, TriggerID: "trigger-1"})
+		assert.Nil(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+		assert.True(t, cache.schedule.Load())
+	})
+
+	t.Run("removes a subscription without changing returned slices", func(t *testing.T) {
+		car := randAssetDID(t).String()
+		cache, _ := populatedCache(t, car)
+		cache.AddSubscription(context.Background(), &models.VehicleSubscription{AssetDid: car, TriggerID: "trigger-1"})
+		before := cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, before, 1)
+
+		cache.RemoveSubscription("trigger-1", car)
+
+		assert.Nil(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+		require.Len(t, before, 1)
+		assert.Equal(t, "trigger-1", before[0].Trigger.ID)
+	})
+
+	t.Run("mutes and unmutes a subscription", func(t *testing.T) {
+		car := randAssetDID(t).String()
+		cache, _ := populatedCache(t, car)
+		ctx := context.Background()
+
+		cache.MuteSubscription(ctx, "trigger-1", car, null.TimeFrom(time.Now().Add(time.Hour)))
+		assert.Empty(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+		require.Len(t, cache.GetWebhooksIncludingPaused(car, triggersrepo.ServiceSignal, "vss.speed"), 1)
+
+		cache.MuteSubscription(ctx, "trigger-1", car, null.Time{})
+		require.Len(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"), 1)
+	})
+
+	t.Run("replaces a trigger for its vehicles", func(t *testing.T) {
+		car := randAssetDID(t).String()
+		cache, mockRepo := populatedCache(t, car)
+		ctx := context.Background()
+		mockRepo.EXPECT().GetGeofencesByDeveloperLicense(ctx, gomock.Any()).Return(nil, nil).Times(3)
+
+		changed := speedTrigger("trigger-1")
+		changed.Condition = "valueNumber > 50"
+		cache.ReplaceTrigger(ctx, changed)
+		webhooks := cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "valueNumber > 50", webhooks[0].Trigger.Condition)
+
+		disabled := speedTrigger("trigger-1")
+		disabled.Status = triggersrepo.StatusDisabled
+		cache.ReplaceTrigger(ctx, disabled)
+		assert.Nil(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+
+		// Re-enabling restores the vehicles that stayed subscribed while the trigger was disabled.
+		cache.ReplaceTrigger(ctx, changed)
+		require.Len(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"), 1)
+
+		// Triggers without subscriptions are not compiled.
+		cache.ReplaceTrigger(ctx, speedTrigger("trigger-2"))
+	})
+
+	t.Run("removes a trigger with its subscriptions", func(t *testing.T) {
+		car := randAssetDID(t).String()
+		cache, _ := populatedCache(t, car)
+
+		cache.RemoveTrigger("trigger-1")
+
+		assert.Nil(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+		assert.Empty(t, cache.triggers)
+		assert.Empty(t, cache.subscriptions)
+	})
+
+	t.Run("replays mutations applied while a rebuild is running", func(t *testing.T) {
+		car := randAssetDID(t).String()
+		cache, mockRepo := populatedCache(t, car)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).DoAndReturn(func(context.Context) ([]*models.VehicleSubscription, error) {
+			// The vehicle is unsubscribed after the rebuild loaded its subscription.
+			cache.RemoveSubscription("trigger-1", car)
+			return []*models.VehicleSubscription{{AssetDid: car, TriggerID: "trigger-1"}}, nil
+		})
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{speedTrigger("trigger-1")}, nil)
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		assert.Nil(t, cache.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed"))
+		assert.Nil(t, cache.replay)
+	})
+}
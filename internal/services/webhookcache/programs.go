@@ -0,0 +1,76 @@
+package webhookcache
+
+import (
+	"sync"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/cel-go/cel"
+)
+
+// programCache shares the compiled programs of identical conditions between the triggers of a cache build. Many
+// triggers are created from the same templates, and each compiled program holds its own checked AST and plan.
+// A cel.Program is safe for concurrent use, so sharing it is no different from sharing it between vehicles.
+type programCache struct {
+	mu       sync.Mutex
+	programs map[programKey]*cachedProgram
+}
+
+// programKey identifies the conditions that compile to the same program. Conditions are checked against the
+// default params, so triggers only share programs with the same defaults. The geofences of the developer license
+// are bound into signal programs, so conditions referring to geofences are only shared within a license.
+type programKey struct {
+	service          string
+	valueType        string
+	condition        string
+	params           string
+	developerLicense common.Address
+}
+
+type cachedProgram struct {
+	once    sync.Once
+	program cel.Program
+	err     error
+}
+
+func newProgramCache() *programCache {
+	return &programCache{programs: make(map[programKey]*cachedProgram)}
+}
+
+// prepare returns the compiled condition of the trigger, compiling it only for the first trigger with the same
+// key. A nil cache compiles every condition.
+func (pc *programCache) prepare(trigger *models.Trigger, condition, valueType string, geofences celcondition.Geofences, params celcondition.Params) (cel.Program, error) {
+	if pc == nil {
+		return celcondition.PrepareCondition(trigger.Service, condition, valueType, geofences, params)
+	}
+	key := programKey{
+		service:   trigger.Service,
+		valueType: valueType,
+		condition: condition,
+		params:    string(trigger.Params.JSON),
+	}
+	if names, err := celcondition.ConditionGeofences(trigger.Service, condition); err != nil || len(names) > 0 {
+		key.developerLicense = common.BytesToAddress(trigger.DeveloperLicenseAddress)
+	}
+
+	pc.mu.Lock()
+	cached, ok := pc.programs[key]
+	if !ok {
+		cached = &cachedProgram{}
+		pc.programs[key] = cached
+	}
+	pc.mu.Unlock()
+
+	cached.once.Do(func() {
+		cached.program, cached.err = celcondition.PrepareCondition(trigger.Service, condition, valueType, geofences, params)
+	})
+	return cached.program, cached.err
+}
+
+// len returns the number of distinct programs compiled.
+func (pc *programCache) len() int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return len(pc.programs)
+}
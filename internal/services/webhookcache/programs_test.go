@@ -0,0 +1,84 @@
+package webhookcache
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/aarondl/null/v8"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shares programs of identical conditions", func(t *testing.T) {
+		programs := newProgramCache()
+		first, err := compileWebhook(speedTrigger("trigger-1"), nil, programs)
+		require.NoError(t, err)
+		second, err := compileWebhook(speedTrigger("trigger-2"), nil, programs)
+		require.NoError(t, err)
+		other := speedTrigger("trigger-3")
+		other.Condition = "valueNumber > 50"
+		third, err := compileWebhook(other, nil, programs)
+		require.NoError(t, err)
+
+		assert.True(t, first.Program == second.Program, "expected the same program")
+		assert.False(t, first.Program == third.Program, "expected a program per condition")
+		assert.Equal(t, 2, programs.len())
+	})
+
+	t.Run("shares programs only between triggers with the same params", func(t *testing.T) {
+		programs := newProgramCache()
+		withParams := func(id, params string) *models.Trigger {
+			trigger := speedTrigger(id)
+			trigger.Condition = "valueNumber > params.limit"
+			trigger.Params = null.JSONFrom([]byte(params))
+			return trigger
+		}
+		_, err := compileWebhook(withParams("trigger-1", `{"limit":10}`), nil, programs)
+		require.NoError(t, err)
+		_, err = compileWebhook(withParams("trigger-2", `{"limit":10}`), nil, programs)
+		require.NoError(t, err)
+		_, err = compileWebhook(withParams("trigger-3", `{"limit":20}`), nil, programs)
+		require.NoError(t, err)
+
+		// The condition is checked against the defaults, so missing defaults still fail to compile.
+		_, err = compileWebhook(withParams("trigger-4", `{"other":10}`), nil, programs)
+		require.Error(t, err)
+		assert.Equal(t, 3, programs.len())
+	})
+
+	t.Run("shares programs referring to geofences only within a license", func(t *testing.T) {
+		programs := newProgramCache()
+		geofences, err := celcondition.ParseGeofences(models.GeofenceSlice{
+			{Name: "depot", Geometry: []byte(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`)},
+		})
+		require.NoError(t, err)
+		prepare := func(license common.Address) error {
+			trigger := &models.Trigger{
+				Service:                 triggersrepo.ServiceSignal,
+				Condition:               `enteredGeofence("depot")`,
+				DeveloperLicenseAddress: license.Bytes(),
+			}
+			_, err := programs.prepare(trigger, trigger.Condition, signals.LocationType, geofences, nil)
+			return err
+		}
+		require.NoError(t, prepare(common.HexToAddress("0x1")))
+		require.NoError(t, prepare(common.HexToAddress("0x1")))
+		require.NoError(t, prepare(common.HexToAddress("0x2")))
+		assert.Equal(t, 2, programs.len())
+	})
+
+	t.Run("compiles every condition without a cache", func(t *testing.T) {
+		first, err := compileWebhook(speedTrigger("trigger-1"), nil, nil)
+		require.NoError(t, err)
+		second, err := compileWebhook(speedTrigger("trigger-2"), nil, nil)
+		require.NoError(t, err)
+		assert.False(t, first.Program == second.Program, "expected separate programs")
+	})
+}
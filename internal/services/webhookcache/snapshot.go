@@ -0,0 +1,154 @@
+package webhookcache
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/rs/zerolog"
+)
+
+// snapshotVersion is the version of the snapshot format. Snapshots of other versions are not loaded, so it must be
+// increased whenever a change to the models or to how the cache is built makes older snapshots unusable.
+const snapshotVersion = 1
+
+// ErrNoSnapshot is returned by LoadSnapshot when snapshots are disabled or none has been written yet.
+var ErrNoSnapshot = errors.New("no webhook cache snapshot")
+
+// snapshot holds the rows a cache is built from. The snapshot of every build from the database is written to
+// disk, so that the next start can build the cache from it without waiting for the database.
+type snapshot struct {
+	Version int `json:"version"`
+	// CreatedAt is when the rows were loaded from the database.
+	CreatedAt     time.Time                     `json:"createdAt"`
+	Subscriptions []*models.VehicleSubscription `json:"subscriptions"`
+	Triggers      models.TriggerSlice           `json:"triggers"`
+	Geofences     models.GeofenceSlice          `json:"geofences"`
+}
+
+// LoadSnapshot builds the cache from the snapshot written by the last build from the database, without querying
+// the database. The snapshot may be out of date, so PopulateCache should be called afterwards to reconcile the
+// cache with the database.
+func (wc *WebhookCache) LoadSnapshot(ctx context.Context) error {
+	if wc.snapshotPath == "" {
+		return ErrNoSnapshot
+	}
+	start := time.Now()
+	snap, err := readSnapshot(wc.snapshotPath)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*models.Trigger, len(snap.Triggers))
+	for _, trigger := range snap.Triggers {
+		byID[trigger.ID] = trigger
+	}
+	loadTriggers := func(_ context.Context, triggerIDs []string) (models.TriggerSlice, error) {
+		triggers := make(models.TriggerSlice, 0, len(triggerIDs))
+		for _, id := range triggerIDs {
+			if trigger, ok := byID[id]; ok {
+				triggers = append(triggers, trigger)
+			}
+		}
+		return triggers, nil
+	}
+	data, err := wc.rebuild(ctx, func(ctx context.Context) (*cacheData, error) {
+		data, err := wc.buildCacheData(ctx, snap.Subscriptions, snap.Geofences, loadTriggers)
+		if err != nil {
+			return nil, err
+		}
+		data.snapshot.CreatedAt = snap.CreatedAt
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Int("asset_count", len(data.webhooks)).
+		Dur("snapshot_age", time.Since(snap.CreatedAt)).
+		Dur("elapsed", time.Since(start)).
+		Msg("webhook cache loaded from snapshot")
+	return nil
+}
+
+// readSnapshot reads the snapshot at path, returning ErrNoSnapshot if there is none.
+func readSnapshot(path string) (*snapshot, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	var snap snapshot
+	if err := json.NewDecoder(bufio.NewReader(file)).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("snapshot version %d is not supported, expected %d", snap.Version, snapshotVersion)
+	}
+	return &snap, nil
+}
+
+// writeSnapshot writes the snapshot if snapshots are enabled. The snapshot holds the signing secrets of the
+// triggers, so the file is only readable by the user of the service. A snapshot that fails to write is only
+// logged, since the cache itself was built.
+func (wc *WebhookCache) writeSnapshot(ctx context.Context, snap *snapshot) {
+	if wc.snapshotPath == "" {
+		return
+	}
+	start := time.Now()
+	if err := writeSnapshotFile(wc.snapshotPath, snap); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("path", wc.snapshotPath).Msg("failed to write webhook cache snapshot")
+		return
+	}
+	zerolog.Ctx(ctx).Info().
+		Str("path", wc.snapshotPath).
+		Dur("elapsed", time.Since(start)).
+		Msg("webhook cache snapshot written")
+}
+
+// writeSnapshotFile writes the snapshot to a temporary file that replaces the file at path once it is complete,
+// so that a crash while writing never leaves a partial snapshot behind.
+func writeSnapshotFile(path string, snap *snapshot) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	// CreateTemp creates the file with mode 0600.
+	file, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	buf := bufio.NewWriter(file)
+	if err := json.NewEncoder(buf).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package webhookcache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookCache_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("starts from the snapshot of the last build", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshots", "webhooks.json")
+		car, truck := randAssetDID(t).String(), randAssetDID(t).String()
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{CacheSnapshotPath: path})
+		trigger := speedTrigger("trigger-1")
+		trigger.SigningSecret = "whsec_test"
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return([]*models.VehicleSubscription{
+			{AssetDid: car, TriggerID: "trigger-1"},
+			{AssetDid: truck, TriggerID: "trigger-1", Params: null.JSONFrom([]byte(`{}`))},
+		}, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		// The new cache must not touch the database.
+		restarted := NewWebhookCache(NewMockRepository(gomock.NewController(t)), &config.Settings{CacheSnapshotPath: path})
+		require.NoError(t, restarted.LoadSnapshot(ctx))
+		// The restarted cache is as old as the data in the snapshot.
+		require.False(t, cache.LastRefresh().IsZero())
+		assert.True(t, restarted.LastRefresh().Equal(cache.LastRefresh()))
+
+		webhooks := restarted.GetWebhooks(car, triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "trigger-1", webhooks[0].Trigger.ID)
+		assert.Equal(t, "whsec_test", webhooks[0].Trigger.SigningSecret)
+		assert.NotNil(t, webhooks[0].Program)
+		require.Len(t, restarted.GetWebhooks(truck, triggersrepo.ServiceSignal, "vss.speed"), 1)
+		assert.Len(t, restarted.subscriptions["trigger-1"], 2)
+	})
+
+	t.Run("returns ErrNoSnapshot when disabled or not written yet", func(t *testing.T) {
+		cache := NewWebhookCache(nil, &config.Settings{})
+		require.ErrorIs(t, cache.LoadSnapshot(context.Background()), ErrNoSnapshot)
+
+		cache = NewWebhookCache(nil, &config.Settings{CacheSnapshotPath: filepath.Join(t.TempDir(), "webhooks.json")})
+		require.ErrorIs(t, cache.LoadSnapshot(context.Background()), ErrNoSnapshot)
+	})
+
+	t.Run("rejects snapshots of other versions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "webhooks.json")
+		require.NoError(t, writeSnapshotFile(path, &snapshot{Version: snapshotVersion + 1}))
+
+		cache := NewWebhookCache(nil, &config.Settings{CacheSnapshotPath: path})
+		err := cache.LoadSnapshot(context.Background())
+		require.ErrorContains(t, err, "not supported")
+		assert.NotErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("rejects corrupt snapshots", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "webhooks.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version":1,"subscriptions":[`), 0o600))
+
+		cache := NewWebhookCache(nil, &config.Settings{CacheSnapshotPath: path})
+		require.ErrorContains(t, cache.LoadSnapshot(context.Background()), "failed to decode snapshot")
+	})
+
+	t.Run("replaces the previous snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "webhooks.json")
+		require.NoError(t, writeSnapshotFile(path, &snapshot{Version: snapshotVersion, Triggers: models.TriggerSlice{speedTrigger("trigger-1")}}))
+		require.NoError(t, writeSnapshotFile(path, &snapshot{Version: snapshotVersion, Triggers: models.TriggerSlice{speedTrigger("trigger-2")}}))
+
+		snap, err := readSnapshot(path)
+		require.NoError(t, err)
+		require.Len(t, snap.Triggers, 1)
+		assert.Equal(t, "trigger-2", snap.Triggers[0].ID)
+
+		// No temporary files are left behind.
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
@@ -2,9 +2,10 @@ package webhookcache
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"maps"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,38 +13,73 @@ import (
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/schedule"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/signals"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/cel-go/cel"
 	"github.com/rs/zerolog"
 )
 
-var errNoWebhookConfig = errors.New("no webhook configurations found in the database")
-
 const (
 	defaultCacheDebounceTime = 5 * time.Second
 	defaultCacheBuildWorkers = 2
+	// triggerBatchSize is the number of triggers loaded by a single query when the cache is built.
+	triggerBatchSize = 500
 )
 
 type Webhook struct {
 	Trigger *models.Trigger
 	Program cel.Program
+	// ClearProgram is the compiled clear condition of an edge trigger, nil if it has none.
+	ClearProgram cel.Program
+	// Window is the longest window aggregated over by the conditions, 0 if they do not use aggregate functions.
+	Window time.Duration
+	// Signals are the names of the signals read by a composite or event condition, e.g. "speed", nil if it reads none.
+	Signals []string
+	// Schedule is the schedule the trigger is active in, nil if it is always active.
+	Schedule *schedule.Schedule
+	// MutedUntil is when the vehicle's subscription to the trigger is muted until, zero if it is not muted.
+	MutedUntil time.Time
+	// Params are the parameter values the conditions are evaluated with for the vehicle: the defaults of the
+	// trigger with the overrides of the vehicle's subscription.
+	Params celcondition.Params
+}
+
+// Paused reports whether the webhook is snoozed or muted for the vehicle at now.
+func (w *Webhook) Paused(now time.Time) bool {
+	return now.Before(w.MutedUntil) || (w.Trigger.SnoozedUntil.Valid && now.Before(w.Trigger.SnoozedUntil.Time))
 }
 
 type Repository interface {
 	InternalGetAllVehicleSubscriptions(ctx context.Context) ([]*models.VehicleSubscription, error)
 	InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error)
+	InternalGetTriggersByIDs(ctx context.Context, triggerIDs []string) (models.TriggerSlice, error)
+	InternalGetAllGeofences(ctx context.Context) (models.GeofenceSlice, error)
+	GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error)
 }
 
 // WebhookCache is an in-memory map: assetDID -> signal name -> []*models.Trigger.
+// It is built from the database on startup and by periodic rebuilds, and kept up to date in between by the
+// mutations in mutations.go.
 type WebhookCache struct {
 	mu            sync.RWMutex
 	webhooks      map[string]map[string][]*Webhook
+	// triggers are the compiled triggers that have subscriptions, including the ones that are not enabled.
+	triggers      map[string]*Webhook
+	// subscriptions are the subscriptions by trigger ID and asset DID.
+	subscriptions map[string]map[string]*models.VehicleSubscription
+	// rebuilding is the number of rebuilds running. The mutations applied while a rebuild is running are kept
+	// in replay and applied again to its result, which may have been loaded before them.
+	rebuilding    int
+	replay        []func()
 	repo          Repository
-	lastRefresh   time.Time // last time the cache was refreshed
+	lastRefresh   time.Time // when the data of the last build was loaded from the database
 	schedule      atomic.Bool
 	debounce      time.Duration
 	buildWorkers  int
+	// snapshotPath is where the snapshot of the last build from the database is written, empty if disabled.
+	snapshotPath  string
 }
 
 func NewWebhookCache(repo Repository, settings *config.Settings) *WebhookCache {
@@ -56,37 +92,63 @@ func NewWebhookCache(repo Repository, settings *config.Settings) *WebhookCache {
 		workers = defaultCacheBuildWorkers
 	}
 	return &WebhookCache{
-		webhooks:     make(map[string]map[string][]*Webhook),
-		repo:         repo,
-		debounce:     debounce,
-		buildWorkers: workers,
+		webhooks:      make(map[string]map[string][]*Webhook),
+		triggers:      make(map[string]*Webhook),
+		subscriptions: make(map[string]map[string]*models.VehicleSubscription),
+		repo:          repo,
+		debounce:      debounce,
+		buildWorkers:  workers,
+		snapshotPath:  settings.CacheSnapshotPath,
 	}
 }
 
-// PopulateCache builds the cache from the database
+// PopulateCache builds the cache from the database and writes its snapshot if enabled.
 func (wc *WebhookCache) PopulateCache(ctx context.Context) error {
 	logger := zerolog.Ctx(ctx)
 	start := time.Now()
 	logMemStats(logger, "populate_cache_enter")
 
-	webhooks, err := wc.fetchVehicleWebhooks(ctx)
+	data, err := wc.rebuild(ctx, wc.fetchVehicleWebhooks)
 	if err != nil {
-		if errors.Is(err, errNoWebhookConfig) {
-			webhooks = make(map[string]map[string][]*Webhook)
-		} else {
-			return err
-		}
+		return err
 	}
 
-	wc.Update(webhooks)
 	logger.Info().
-		Int("asset_count", len(webhooks)).
+		Int("asset_count", len(data.webhooks)).
 		Dur("elapsed", time.Since(start)).
 		Msg("webhook cache populated")
+	wc.writeSnapshot(ctx, data.snapshot)
 	logMemStats(logger, "populate_cache_exit")
 	return nil
 }
 
+// rebuild replaces the cache with the data returned by fetch. The mutations applied while fetch runs are applied
+// again to its data.
+func (wc *WebhookCache) rebuild(ctx context.Context, fetch func(ctx context.Context) (*cacheData, error)) (*cacheData, error) {
+	wc.mu.Lock()
+	wc.rebuilding++
+	wc.mu.Unlock()
+
+	data, err := fetch(ctx)
+
+	wc.mu.Lock()
+	wc.rebuilding--
+	if err == nil {
+		wc.webhooks = data.webhooks
+		wc.triggers = data.triggers
+		wc.subscriptions = data.subscriptions
+		wc.lastRefresh = data.snapshot.CreatedAt
+		for _, mutation := range wc.replay {
+			mutation()
+		}
+	}
+	if wc.rebuilding == 0 {
+		wc.replay = nil
+	}
+	wc.mu.Unlock()
+	return data, err
+}
+
 // logMemStats records the current Go heap stats so we can attribute OOMs to a
 // specific startup phase. Cheap: ReadMemStats stops the world briefly but is
 // negligible at startup.
@@ -121,8 +183,16 @@ func (wc *WebhookCache) ScheduleRefresh(ctx context.Context) {
 }
 
 // GetWebhooks returns the webhooks for a given vehicle token id, service, and metric name
+// Webhooks that are snoozed or muted for the vehicle are left out until their snooze or mute expires.
 // Do not modify the returned slice or the webhooks themselves since they are shared with other callers.
 func (wc *WebhookCache) GetWebhooks(assetDID string, service, metricName string) []*Webhook {
+	return withoutPaused(wc.GetWebhooksIncludingPaused(assetDID, service, metricName), time.Now())
+}
+
+// GetWebhooksIncludingPaused returns the webhooks for a given vehicle, service, and metric name like GetWebhooks,
+// including the webhooks that are snoozed or muted for the vehicle.
+// Do not modify the returned slice or the webhooks themselves since they are shared with other callers.
+func (wc *WebhookCache) GetWebhooksIncludingPaused(assetDID string, service, metricName string) []*Webhook {
 	wc.mu.RLock()
 	defer wc.mu.RUnlock()
 
@@ -135,6 +205,32 @@ func (wc *WebhookCache) GetWebhooks(assetDID string, service, metricName string)
 	return byVehicle[key]
 }
 
+// withoutPaused returns the webhooks that are not paused at now. The shared slice is returned as is unless a
+// webhook is paused, so that the common case does not allocate.
+func withoutPaused(webhooks []*Webhook, now time.Time) []*Webhook {
+	for i, webhook := range webhooks {
+		if !webhook.Paused(now) {
+			continue
+		}
+		active := slices.Clone(webhooks[:i])
+		for _, webhook := range webhooks[i+1:] {
+			if !webhook.Paused(now) {
+				active = append(active, webhook)
+			}
+		}
+		return active
+	}
+	return webhooks
+}
+
+// LastRefresh returns when the data of the last build was loaded from the database, zero if the cache has not
+// been built yet. For a cache built from a snapshot that is when the snapshot was loaded from the database.
+func (wc *WebhookCache) LastRefresh() time.Time {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.lastRefresh
+}
+
 func (wc *WebhookCache) Update(newData map[string]map[string][]*Webhook) {
 	wc.mu.Lock()
 	defer wc.mu.Unlock()
@@ -142,7 +238,16 @@ func (wc *WebhookCache) Update(newData map[string]map[string][]*Webhook) {
 	wc.lastRefresh = time.Now()
 }
 
-func (wc *WebhookCache) fetchVehicleWebhooks(ctx context.Context) (map[string]map[string][]*Webhook, error) {
+// cacheData is the content of the cache loaded by a rebuild.
+type cacheData struct {
+	webhooks      map[string]map[string][]*Webhook
+	triggers      map[string]*Webhook
+	subscriptions map[string]map[string]*models.VehicleSubscription
+	// snapshot holds the rows the data was built from.
+	snapshot *snapshot
+}
+
+func (wc *WebhookCache) fetchVehicleWebhooks(ctx context.Context) (*cacheData, error) {
 	logger := zerolog.Ctx(ctx)
 	fetchStart := time.Now()
 	subs, err := wc.repo.InternalGetAllVehicleSubscriptions(ctx)
@@ -155,61 +260,176 @@ func (wc *WebhookCache) fetchVehicleWebhooks(ctx context.Context) (map[string]ma
 		Msg("loaded vehicle subscriptions")
 	logMemStats(logger, "after_load_subs")
 
+	stored, err := wc.repo.InternalGetAllGeofences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all geofences: %w", err)
+	}
+
+	data, err := wc.buildCacheData(ctx, subs, stored, wc.repo.InternalGetTriggersByIDs)
+	if err != nil {
+		return nil, err
+	}
+	data.snapshot.CreatedAt = fetchStart
+	return data, nil
+}
+
+// buildCacheData builds the cache from the subscriptions and geofences, loading their triggers with
+// loadTriggers.
+func (wc *WebhookCache) buildCacheData(ctx context.Context, subs []*models.VehicleSubscription, stored models.GeofenceSlice, loadTriggers func(ctx context.Context, triggerIDs []string) (models.TriggerSlice, error)) (*cacheData, error) {
+	logger := zerolog.Ctx(ctx)
+
 	// Collect unique trigger IDs first; many subscriptions share triggers.
 	uniqueTriggerIDs := make(map[string]struct{}, len(subs))
 	for _, sub := range subs {
 		uniqueTriggerIDs[sub.TriggerID] = struct{}{}
 	}
 
-	// Fetch + CEL-compile each unique trigger in parallel. Each compile is
+	// Fetch + CEL-compile the unique triggers in parallel. Each compile is
 	// CPU-bound (~5ms) and the loop is hot on startup (~10k triggers => 50s
-	// serial). Parallelising across GOMAXPROCS workers brings build_elapsed
-	// well under the kubelet liveness deadline.
+	// serial). Triggers are loaded in batches and triggers with identical
+	// conditions share one compiled program, so most triggers cost a row
+	// and a map lookup.
+	geofences := geofencesByLicense(ctx, stored)
+
 	triggerFetchStart := time.Now()
-	uniqueTriggers := wc.compileTriggersParallel(ctx, uniqueTriggerIDs)
+	programs := newProgramCache()
+	uniqueTriggers, err := wc.compileTriggersParallel(ctx, uniqueTriggerIDs, loadTriggers, geofences, programs)
+	if err != nil {
+		return nil, err
+	}
 
 	newData := make(map[string]map[string][]*Webhook)
+	subscriptions := make(map[string]map[string]*models.VehicleSubscription, len(uniqueTriggers))
 	for _, sub := range subs {
 		webhook, ok := uniqueTriggers[sub.TriggerID]
 		if !ok {
 			continue
 		}
+		if subscriptions[sub.TriggerID] == nil {
+			subscriptions[sub.TriggerID] = make(map[string]*models.VehicleSubscription)
+		}
+		subscriptions[sub.TriggerID][sub.AssetDid] = sub
 		if webhook.Trigger.Status != triggersrepo.StatusEnabled {
 			continue
 		}
 
+		webhook = vehicleWebhook(ctx, webhook, sub)
+
 		if newData[sub.AssetDid] == nil {
 			newData[sub.AssetDid] = make(map[string][]*Webhook)
 		}
 
-		key := webhookKey(webhook.Trigger.Service, webhook.Trigger.MetricName)
-		newData[sub.AssetDid][key] = append(newData[sub.AssetDid][key], webhook)
+		for _, key := range webhookKeys(webhook) {
+			newData[sub.AssetDid][key] = append(newData[sub.AssetDid][key], webhook)
+		}
 	}
 	logger.Info().
 		Int("sub_count", len(subs)).
 		Int("unique_trigger_ids", len(uniqueTriggerIDs)).
 		Int("unique_trigger_cache", len(uniqueTriggers)).
+		Int("unique_programs", programs.len()).
 		Int("asset_count", len(newData)).
 		Dur("build_elapsed", time.Since(triggerFetchStart)).
 		Msg("webhook cache build complete")
 	logMemStats(logger, "after_build_cache")
 
-	if len(newData) == 0 {
-		return nil, errNoWebhookConfig
+	triggers := make(models.TriggerSlice, 0, len(uniqueTriggers))
+	for _, webhook := range uniqueTriggers {
+		triggers = append(triggers, webhook.Trigger)
+	}
+	return &cacheData{
+		webhooks:      newData,
+		triggers:      uniqueTriggers,
+		subscriptions: subscriptions,
+		snapshot: &snapshot{
+			Version:       snapshotVersion,
+			Subscriptions: subs,
+			Triggers:      triggers,
+			Geofences:     stored,
+		},
+	}, nil
+}
+
+// vehicleWebhook returns the webhook of the subscribed vehicle. The webhook is shared by all subscribed vehicles,
+// so a muted vehicle or one with its own parameter values gets its own copy.
+func vehicleWebhook(ctx context.Context, webhook *Webhook, sub *models.VehicleSubscription) *Webhook {
+	muted := sub.MutedUntil.Valid && time.Now().Before(sub.MutedUntil.Time)
+	if !muted && !sub.Params.Valid {
+		return webhook
+	}
+	own := *webhook
+	if muted {
+		own.MutedUntil = sub.MutedUntil.Time
+	}
+	if sub.Params.Valid {
+		own.Params = subscriptionParams(ctx, webhook, sub)
+	}
+	return &own
+}
+
+// subscriptionParams returns the parameter values of the subscribed vehicle. Overrides that no longer match the
+// parameters of the trigger, because a parameter was removed or changed its type, are logged and the default
+// is used instead.
+func subscriptionParams(ctx context.Context, webhook *Webhook, sub *models.VehicleSubscription) celcondition.Params {
+	overrides, err := celcondition.ParseParams(sub.Params.JSON)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("trigger_id", sub.TriggerID).Str("asset_did", sub.AssetDid).Msg("failed to parse subscription params, using defaults")
+		return webhook.Params
+	}
+	params, err := webhook.Params.Override(overrides)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("trigger_id", sub.TriggerID).Str("asset_did", sub.AssetDid).Msg("ignoring invalid subscription params")
 	}
-	return newData, nil
+	return params
 }
 
 func webhookKey(service, metricName string) string {
 	return service + ":" + metricName
 }
 
-// compileTriggersParallel fetches and CEL-compiles each unique trigger in
-// parallel. Worker count comes from CACHE_BUILD_WORKERS so prod can tune it
-// against its CPU limit and DB connection pool. Triggers that fail to fetch
-// or compile are logged and skipped, mirroring the previous behaviour of
-// the serial loop.
-func (wc *WebhookCache) compileTriggersParallel(ctx context.Context, triggerIDs map[string]struct{}) map[string]*Webhook {
+// webhookKeys returns the keys the webhook is cached under. Webhooks reading the latest values of signals are
+// cached under the composite service and each signal they read, so they are found whenever one of them updates.
+// Event webhooks are also cached under their event; composite webhooks only have signal keys.
+func webhookKeys(webhook *Webhook) []string {
+	var keys []string
+	if !triggersrepo.IsCompositeService(webhook.Trigger.Service) {
+		keys = append(keys, webhookKey(webhook.Trigger.Service, webhook.Trigger.MetricName))
+	}
+	for _, name := range webhook.Signals {
+		keys = append(keys, webhookKey(triggersrepo.ServiceComposite, signals.VSSPrefix+name))
+	}
+	return keys
+}
+
+// geofencesByLicense returns the parsed geofences keyed by developer license address. Geofences of a license
+// that fail to parse are logged and left out, so conditions referring to them fail to compile.
+func geofencesByLicense(ctx context.Context, stored models.GeofenceSlice) map[common.Address]celcondition.Geofences {
+	logger := zerolog.Ctx(ctx)
+	byLicense := make(map[common.Address]models.GeofenceSlice)
+	for _, gf := range stored {
+		addr := common.BytesToAddress(gf.DeveloperLicenseAddress)
+		byLicense[addr] = append(byLicense[addr], gf)
+	}
+	geofences := make(map[common.Address]celcondition.Geofences, len(byLicense))
+	for addr, slice := range byLicense {
+		parsed, err := celcondition.ParseGeofences(slice)
+		if err != nil {
+			logger.Error().Err(err).Str("developer_license", addr.Hex()).Msg("failed to parse geofences")
+			continue
+		}
+		geofences[addr] = parsed
+	}
+	return geofences
+}
+
+// compileTriggersParallel loads the unique triggers in batches of
+// triggerBatchSize, one loadTriggers call per batch, and CEL-compiles them in
+// parallel.
+// Worker count comes from CACHE_BUILD_WORKERS so prod can tune it against
+// its CPU limit and DB connection pool. Triggers that fail to compile are
+// logged and skipped; a batch that fails to load fails the build, so a
+// rebuild keeps the previous cache instead of dropping its triggers.
+func (wc *WebhookCache) compileTriggersParallel(ctx context.Context, triggerIDs map[string]struct{}, loadTriggers func(ctx context.Context, triggerIDs []string) (models.TriggerSlice, error), geofences map[common.Address]celcondition.Geofences, programs *programCache) (map[string]*Webhook, error) {
 	logger := zerolog.Ctx(ctx)
 
 	workers := wc.buildWorkers
@@ -222,34 +442,35 @@ func (wc *WebhookCache) compileTriggersParallel(ctx context.Context, triggerIDs
 		webhook *Webhook
 	}
 
-	ids := make(chan string, len(triggerIDs))
-	for id := range triggerIDs {
-		ids <- id
+	// Sorted so that the batches are the same on every build.
+	ids := slices.Sorted(maps.Keys(triggerIDs))
+	batches := make(chan []string, (len(ids)+triggerBatchSize-1)/triggerBatchSize)
+	for batch := range slices.Chunk(ids, triggerBatchSize) {
+		batches <- batch
 	}
-	close(ids)
+	close(batches)
 
 	results := make(chan result, len(triggerIDs))
+	errs := make(chan error, cap(batches))
 	var wg sync.WaitGroup
 	wg.Add(workers)
 	for w := 0; w < workers; w++ {
 		go func() {
 			defer wg.Done()
-			for id := range ids {
-				trigger, err := wc.repo.InternalGetTriggerByID(ctx, id)
+			for batch := range batches {
+				triggers, err := loadTriggers(ctx, batch)
 				if err != nil {
-					logger.Error().Err(err).Str("trigger_id", id).Msg("failed to get trigger by id for webhook cache")
+					errs <- fmt.Errorf("failed to get triggers for webhook cache: %w", err)
 					continue
 				}
-				valueType := ""
-				if triggersrepo.IsSignalService(trigger.Service) {
-					valueType = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).ValueType
-				}
-				program, err := celcondition.PrepareCondition(trigger.Service, trigger.Condition, valueType)
-				if err != nil {
-					logger.Error().Err(err).Str("trigger_id", trigger.ID).Msg("failed to prepare condition")
-					continue
+				for _, trigger := range triggers {
+					webhook, err := compileWebhook(trigger, geofences[common.BytesToAddress(trigger.DeveloperLicenseAddress)], programs)
+					if err != nil {
+						logger.Error().Err(err).Str("trigger_id", trigger.ID).Msg("failed to compile trigger for webhook cache")
+						continue
+					}
+					results <- result{id: trigger.ID, webhook: webhook}
 				}
-				results <- result{id: id, webhook: &Webhook{Trigger: trigger, Program: program}}
 			}
 		}()
 	}
@@ -257,11 +478,61 @@ func (wc *WebhookCache) compileTriggersParallel(ctx context.Context, triggerIDs
 	go func() {
 		wg.Wait()
 		close(results)
+		close(errs)
 	}()
 
 	out := make(map[string]*Webhook, len(triggerIDs))
 	for r := range results {
 		out[r.id] = r.webhook
 	}
-	return out
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// compileWebhook compiles the conditions and schedule of a trigger with the geofences of its developer license.
+// The conditions are compiled through programs, so triggers with identical conditions share their programs; a nil
+// programs compiles them for this trigger only.
+func compileWebhook(trigger *models.Trigger, geofences celcondition.Geofences, programs *programCache) (*Webhook, error) {
+	valueType := ""
+	if triggersrepo.IsSignalService(trigger.Service) {
+		valueType = signals.GetSignalDefinitionOrDefault(signals.BareSignalName(trigger.MetricName), signals.NumberType).ValueType
+	}
+	params, err := celcondition.ParseParams(trigger.Params.JSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse params: %w", err)
+	}
+	program, err := programs.prepare(trigger, trigger.Condition, valueType, geofences, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare condition: %w", err)
+	}
+	window, err := celcondition.ConditionWindow(trigger.Service, trigger.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare condition: %w", err)
+	}
+	var clearProgram cel.Program
+	if trigger.ClearCondition.Valid {
+		clearProgram, err = programs.prepare(trigger, trigger.ClearCondition.String, valueType, geofences, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare clear condition: %w", err)
+		}
+		clearWindow, err := celcondition.ConditionWindow(trigger.Service, trigger.ClearCondition.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare clear condition: %w", err)
+		}
+		window = max(window, clearWindow)
+	}
+	conditionSignals, err := celcondition.ConditionSignals(trigger.Service, trigger.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare condition: %w", err)
+	}
+	var activeSchedule *schedule.Schedule
+	if trigger.Schedule.Valid {
+		activeSchedule, err = schedule.Parse(trigger.Schedule.JSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schedule: %w", err)
+		}
+	}
+	return &Webhook{Trigger: trigger, Program: program, ClearProgram: clearProgram, Window: window, Signals: conditionSignals, Schedule: activeSchedule, Params: params}, nil
 }
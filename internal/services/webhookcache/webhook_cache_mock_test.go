@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	common "github.com/ethereum/go-ethereum/common"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,36 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetGeofencesByDeveloperLicense mocks base method.
+func (m *MockRepository) GetGeofencesByDeveloperLicense(ctx context.Context, developerLicenseAddress common.Address) (models.GeofenceSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByDeveloperLicense", ctx, developerLicenseAddress)
+	ret0, _ := ret[0].(models.GeofenceSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByDeveloperLicense indicates an expected call of GetGeofencesByDeveloperLicense.
+func (mr *MockRepositoryMockRecorder) GetGeofencesByDeveloperLicense(ctx, developerLicenseAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByDeveloperLicense", reflect.TypeOf((*MockRepository)(nil).GetGeofencesByDeveloperLicense), ctx, developerLicenseAddress)
+}
+
+// InternalGetAllGeofences mocks base method.
+func (m *MockRepository) InternalGetAllGeofences(ctx context.Context) (models.GeofenceSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalGetAllGeofences", ctx)
+	ret0, _ := ret[0].(models.GeofenceSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InternalGetAllGeofences indicates an expected call of InternalGetAllGeofences.
+func (mr *MockRepositoryMockRecorder) InternalGetAllGeofences(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetAllGeofences", reflect.TypeOf((*MockRepository)(nil).InternalGetAllGeofences), ctx)
+}
+
 // InternalGetAllVehicleSubscriptions mocks base method.
 func (m *MockRepository) InternalGetAllVehicleSubscriptions(ctx context.Context) ([]*models.VehicleSubscription, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +101,18 @@ func (mr *MockRepositoryMockRecorder) InternalGetTriggerByID(ctx, triggerID any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetTriggerByID", reflect.TypeOf((*MockRepository)(nil).InternalGetTriggerByID), ctx, triggerID)
 }
+
+// InternalGetTriggersByIDs mocks base method.
+func (m *MockRepository) InternalGetTriggersByIDs(ctx context.Context, triggerIDs []string) (models.TriggerSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalGetTriggersByIDs", ctx, triggerIDs)
+	ret0, _ := ret[0].(models.TriggerSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InternalGetTriggersByIDs indicates an expected call of InternalGetTriggersByIDs.
+func (mr *MockRepositoryMockRecorder) InternalGetTriggersByIDs(ctx, triggerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetTriggersByIDs", reflect.TypeOf((*MockRepository)(nil).InternalGetTriggersByIDs), ctx, triggerIDs)
+}
@@ -5,14 +5,18 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/DIMO-Network/cloudevent"
 	"github.com/DIMO-Network/model-garage/pkg/vss"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/celcondition"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,15 +74,11 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return(subs, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-1").
-			Return(trigger1, nil).
-			Times(1)
-
-		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-2").
-			Return(trigger2, nil).
+			InternalGetTriggersByIDs(ctx, []string{"trigger-1", "trigger-2"}).
+			Return(models.TriggerSlice{trigger1, trigger2}, nil).
 			Times(1)
 
 		// Execute
@@ -117,6 +117,7 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return([]*models.VehicleSubscription{}, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		// Execute
 		err := cache.PopulateCache(ctx)
@@ -151,7 +152,7 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 		assert.ErrorIs(t, err, expectedErr)
 	})
 
-	t.Run("handles error when getting trigger", func(t *testing.T) {
+	t.Run("skips subscriptions of triggers that are not found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -167,15 +168,15 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			},
 		}
 
-		expectedErr := errors.New("trigger not found")
 		mockRepo.EXPECT().
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return(subs, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-1").
-			Return(nil, expectedErr).
+			InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).
+			Return(nil, nil).
 			Times(1)
 
 		// Execute
@@ -189,6 +190,244 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 		assert.Nil(t, webhooks)
 	})
 
+	t.Run("keeps the previous cache when triggers fail to load", func(t *testing.T) {
+		assetDid := randAssetDID(t).String()
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		subs := []*models.VehicleSubscription{{AssetDid: assetDid, TriggerID: "trigger-1"}}
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil).Times(2)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil).Times(2)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{speedTrigger("trigger-1")}, nil)
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		expectedErr := errors.New("db down")
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(nil, expectedErr)
+		err := cache.PopulateCache(ctx)
+		require.ErrorIs(t, err, expectedErr)
+
+		require.Len(t, cache.GetWebhooks(assetDid, triggersrepo.ServiceSignal, "vss.speed"), 1)
+	})
+
+	t.Run("loads triggers in batches", func(t *testing.T) {
+		assetDid := randAssetDID(t).String()
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		subs := make([]*models.VehicleSubscription, 0, triggerBatchSize+1)
+		for i := range triggerBatchSize + 1 {
+			subs = append(subs, &models.VehicleSubscription{AssetDid: assetDid, TriggerID: fmt.Sprintf("trigger-%04d", i)})
+		}
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ids []string) (models.TriggerSlice, error) {
+			triggers := make(models.TriggerSlice, 0, len(ids))
+			for _, id := range ids {
+				triggers = append(triggers, speedTrigger(id))
+			}
+			return triggers, nil
+		}).Times(2)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		webhooks := cache.GetWebhooks(assetDid, triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, webhooks, triggerBatchSize+1)
+		// Every trigger has the same condition, so they share one program.
+		for _, webhook := range webhooks {
+			require.True(t, webhook.Program == webhooks[0].Program, "expected the same program")
+		}
+	})
+
+	t.Run("compiles clear conditions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		assetDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{
+			{AssetDid: assetDid.String(), TriggerID: "trigger-1"},
+			{AssetDid: assetDid.String(), TriggerID: "trigger-2"},
+		}
+		edgeTrigger := &models.Trigger{
+			ID:             "trigger-1",
+			Service:        triggersrepo.ServiceSignal,
+			MetricName:     "vss.speed",
+			Status:         triggersrepo.StatusEnabled,
+			Condition:      "valueNumber > 55",
+			FireMode:       triggersrepo.FireModeEdge,
+			ClearCondition: null.StringFrom("valueNumber < 50"),
+		}
+		levelTrigger := &models.Trigger{
+			ID:         "trigger-2",
+			Service:    triggersrepo.ServiceSignal,
+			MetricName: "vss.speed",
+			Status:     triggersrepo.StatusEnabled,
+			Condition:  "valueNumber > 55",
+			FireMode:   triggersrepo.FireModeLevel,
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1", "trigger-2"}).Return(models.TriggerSlice{edgeTrigger, levelTrigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, webhooks, 2)
+		for _, wh := range webhooks {
+			if wh.Trigger.ID == edgeTrigger.ID {
+				assert.NotNil(t, wh.ClearProgram)
+			} else {
+				assert.Nil(t, wh.ClearProgram)
+			}
+		}
+	})
+
+	t.Run("computes aggregate windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		assetDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{
+			{AssetDid: assetDid.String(), TriggerID: "trigger-1"},
+		}
+		trigger := &models.Trigger{
+			ID:             "trigger-1",
+			Service:        triggersrepo.ServiceSignal,
+			MetricName:     "vss.speed",
+			Status:         triggersrepo.StatusEnabled,
+			Condition:      `avg(valueNumber, "5m") > 55`,
+			FireMode:       triggersrepo.FireModeEdge,
+			ClearCondition: null.StringFrom(`max(valueNumber, "10m") < 50`),
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, 10*time.Minute, webhooks[0].Window)
+	})
+
+	t.Run("compiles conditions with the geofences of the developer license", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		devLicense, otherLicense := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+		assetDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{
+			{AssetDid: assetDid.String(), TriggerID: "trigger-1"},
+			{AssetDid: assetDid.String(), TriggerID: "trigger-2"},
+		}
+		geometry := []byte(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`)
+		newTrigger := func(id string, license common.Address) *models.Trigger {
+			return &models.Trigger{
+				ID:                      id,
+				Service:                 triggersrepo.ServiceSignal,
+				MetricName:              "vss.currentLocationCoordinates",
+				Status:                  triggersrepo.StatusEnabled,
+				Condition:               `enteredGeofence("depot")`,
+				DeveloperLicenseAddress: license.Bytes(),
+			}
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(models.GeofenceSlice{
+			{Name: "depot", Geometry: geometry, DeveloperLicenseAddress: devLicense.Bytes()},
+		}, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1", "trigger-2"}).Return(models.TriggerSlice{
+			newTrigger("trigger-1", devLicense),
+			newTrigger("trigger-2", otherLicense),
+		}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		// The other license has no geofence named depot, so its trigger fails to compile.
+		webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceSignal, "vss.currentLocationCoordinates")
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "trigger-1", webhooks[0].Trigger.ID)
+	})
+
+	t.Run("caches composite triggers under each signal they read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		assetDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{{AssetDid: assetDid.String(), TriggerID: "trigger-1"}}
+		trigger := &models.Trigger{
+			ID:         "trigger-1",
+			Service:    triggersrepo.ServiceComposite,
+			MetricName: triggersrepo.AnySignal,
+			Status:     triggersrepo.StatusEnabled,
+			Condition:  "signals.speed > 0 && signals.isIgnitionOn == 0",
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		for _, metricName := range []string{"vss.speed", "vss.isIgnitionOn"} {
+			webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceComposite, metricName)
+			require.Len(t, webhooks, 1, metricName)
+			assert.Equal(t, []string{"isIgnitionOn", "speed"}, webhooks[0].Signals)
+		}
+		assert.Empty(t, cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceComposite, triggersrepo.AnySignal))
+	})
+
+	t.Run("caches event triggers under the signals they read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		assetDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{{AssetDid: assetDid.String(), TriggerID: "trigger-1"}}
+		trigger := &models.Trigger{
+			ID:         "trigger-1",
+			Service:    triggersrepo.ServiceEvent,
+			MetricName: "behavior.harshBraking",
+			Status:     triggersrepo.StatusEnabled,
+			Condition:  "signals.speed > 80",
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceEvent, "behavior.harshBraking")
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, []string{"speed"}, webhooks[0].Signals)
+		assert.Len(t, cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceComposite, "vss.speed"), 1)
+	})
+
 	t.Run("skips disabled triggers", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -217,10 +456,11 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return(subs, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-1").
-			Return(disabledTrigger, nil).
+			InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).
+			Return(models.TriggerSlice{disabledTrigger}, nil).
 			Times(1)
 
 		// Execute
@@ -234,6 +474,99 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 		assert.Nil(t, webhooks)
 	})
 
+	t.Run("gives muted subscriptions their own webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		mutedDid := randAssetDID(t)
+		expiredDid := randAssetDID(t)
+		mutedUntil := time.Now().Add(time.Hour)
+		subs := []*models.VehicleSubscription{
+			{
+				AssetDid:   mutedDid.String(),
+				TriggerID:  "trigger-1",
+				MutedUntil: null.TimeFrom(mutedUntil),
+			},
+			{
+				AssetDid:   expiredDid.String(),
+				TriggerID:  "trigger-1",
+				MutedUntil: null.TimeFrom(time.Now().Add(-time.Hour)),
+			},
+		}
+		trigger := &models.Trigger{
+			ID:         "trigger-1",
+			Service:    triggersrepo.ServiceSignal,
+			MetricName: "vss.speed",
+			Status:     triggersrepo.StatusEnabled,
+			Condition:  "valueNumber > 10",
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		assert.Empty(t, cache.GetWebhooks(mutedDid.String(), triggersrepo.ServiceSignal, "vss.speed"))
+		muted := cache.GetWebhooksIncludingPaused(mutedDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, muted, 1)
+		assert.True(t, muted[0].MutedUntil.Equal(mutedUntil))
+
+		active := cache.GetWebhooks(expiredDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, active, 1)
+		assert.True(t, active[0].MutedUntil.IsZero())
+		assert.Same(t, muted[0].Trigger, active[0].Trigger)
+	})
+
+	t.Run("merges the params of subscriptions into the trigger defaults", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := NewMockRepository(ctrl)
+		cache := NewWebhookCache(mockRepo, &config.Settings{})
+		ctx := context.Background()
+
+		defaultDid := randAssetDID(t)
+		overrideDid := randAssetDID(t)
+		invalidDid := randAssetDID(t)
+		subs := []*models.VehicleSubscription{
+			{AssetDid: defaultDid.String(), TriggerID: "trigger-1"},
+			{AssetDid: overrideDid.String(), TriggerID: "trigger-1", Params: null.JSONFrom([]byte(`{"limit": 70}`))},
+			{AssetDid: invalidDid.String(), TriggerID: "trigger-1", Params: null.JSONFrom([]byte(`{"limit": "fast", "zone": "south"}`))},
+		}
+		trigger := &models.Trigger{
+			ID:         "trigger-1",
+			Service:    triggersrepo.ServiceSignal,
+			MetricName: "vss.speed",
+			Status:     triggersrepo.StatusEnabled,
+			Condition:  "valueNumber > params.limit",
+			Params:     null.JSONFrom([]byte(`{"limit": 55, "zone": "north"}`)),
+		}
+
+		mockRepo.EXPECT().InternalGetAllVehicleSubscriptions(ctx).Return(subs, nil)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
+		mockRepo.EXPECT().InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).Return(models.TriggerSlice{trigger}, nil)
+
+		require.NoError(t, cache.PopulateCache(ctx))
+
+		defaults := cache.GetWebhooks(defaultDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, defaults, 1)
+		assert.Equal(t, celcondition.Params{"limit": 55.0, "zone": "north"}, defaults[0].Params)
+
+		overridden := cache.GetWebhooks(overrideDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, overridden, 1)
+		assert.Equal(t, celcondition.Params{"limit": 70.0, "zone": "north"}, overridden[0].Params)
+		assert.Same(t, defaults[0].Trigger, overridden[0].Trigger)
+
+		invalid := cache.GetWebhooks(invalidDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		require.Len(t, invalid, 1)
+		assert.Equal(t, celcondition.Params{"limit": 55.0, "zone": "south"}, invalid[0].Params)
+	})
+
 	t.Run("handles multiple triggers with same metric name", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -274,15 +607,11 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return(subs, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-1").
-			Return(trigger1, nil).
-			Times(1)
-
-		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-2").
-			Return(trigger2, nil).
+			InternalGetTriggersByIDs(ctx, []string{"trigger-1", "trigger-2"}).
+			Return(models.TriggerSlice{trigger1, trigger2}, nil).
 			Times(1)
 
 		// Execute
@@ -335,11 +664,12 @@ func TestWebhookCache_PopulateCache(t *testing.T) {
 			InternalGetAllVehicleSubscriptions(ctx).
 			Return(subs, nil).
 			Times(1)
+		mockRepo.EXPECT().InternalGetAllGeofences(ctx).Return(nil, nil)
 
 		// Should only be called once due to caching
 		mockRepo.EXPECT().
-			InternalGetTriggerByID(ctx, "trigger-1").
-			Return(trigger1, nil).
+			InternalGetTriggersByIDs(ctx, []string{"trigger-1"}).
+			Return(models.TriggerSlice{trigger1}, nil).
 			Times(1)
 
 		// Execute
@@ -502,6 +832,46 @@ func TestWebhookCache_GetWebhooks(t *testing.T) {
 		assert.Contains(t, triggerIDs, "trigger-1")
 		assert.Contains(t, triggerIDs, "trigger-2")
 	})
+
+	t.Run("leaves out snoozed and muted webhooks until they expire", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cache := NewWebhookCache(NewMockRepository(ctrl), &config.Settings{})
+		newTrigger := func(id string, snoozedUntil null.Time) *models.Trigger {
+			return &models.Trigger{
+				ID:           id,
+				Service:      triggersrepo.ServiceSignal,
+				MetricName:   "vss.speed",
+				Status:       triggersrepo.StatusEnabled,
+				Condition:    "valueNumber > 10",
+				SnoozedUntil: snoozedUntil,
+			}
+		}
+		later := time.Now().Add(time.Hour)
+		earlier := time.Now().Add(-time.Hour)
+
+		assetDid := randAssetDID(t)
+		cache.Update(map[string]map[string][]*Webhook{
+			assetDid.String(): {
+				webhookKey(triggersrepo.ServiceSignal, "vss.speed"): []*Webhook{
+					{Trigger: newTrigger("snoozed", null.TimeFrom(later))},
+					{Trigger: newTrigger("snooze-expired", null.TimeFrom(earlier))},
+					{Trigger: newTrigger("muted", null.Time{}), MutedUntil: later},
+					{Trigger: newTrigger("mute-expired", null.Time{}), MutedUntil: earlier},
+					{Trigger: newTrigger("active", null.Time{})},
+				},
+			},
+		})
+
+		webhooks := cache.GetWebhooks(assetDid.String(), triggersrepo.ServiceSignal, "vss.speed")
+		var triggerIDs []string
+		for _, wh := range webhooks {
+			triggerIDs = append(triggerIDs, wh.Trigger.ID)
+		}
+		assert.Equal(t, []string{"snooze-expired", "mute-expired", "active"}, triggerIDs)
+		assert.Len(t, cache.GetWebhooksIncludingPaused(assetDid.String(), triggersrepo.ServiceSignal, "vss.speed"), 5)
+	})
 }
 
 func TestWebhookCache_Update(t *testing.T) {
@@ -0,0 +1,74 @@
+package webhookretry
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
+)
+
+const (
+	defaultBaseDelay = 30 * time.Second
+	defaultMaxDelay  = time.Hour
+)
+
+// Policy decides whether and when a failed webhook delivery is attempted again.
+type Policy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+	// MaxPending caps the deliveries waiting for a retry per trigger.
+	MaxPending int
+	// MaxDeadLetters caps the dead letters kept per trigger.
+	MaxDeadLetters int
+}
+
+// NewPolicy creates a Policy from the application settings.
+func NewPolicy(settings *config.Settings) Policy {
+	p := Policy{
+		MaxAttempts:    settings.WebhookMaxAttempts,
+		BaseDelay:      settings.WebhookRetryBaseDelay,
+		MaxDelay:       settings.WebhookRetryMaxDelay,
+		MaxPending:     settings.WebhookMaxPendingRetries,
+		MaxDeadLetters: settings.WebhookMaxDeadLetters,
+	}
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 1
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultMaxDelay
+	}
+	return p
+}
+
+// ShouldRetry reports whether a delivery that has failed attempts times, most recently with err,
+// should be attempted again.
+func (p Policy) ShouldRetry(attempts int, err error) bool {
+	return attempts < p.MaxAttempts && webhooksender.IsRetryable(err)
+}
+
+// NextDelay returns how long to wait before the next attempt of a delivery that has failed attempts times.
+// The delay grows exponentially with equal jitter so that deliveries which failed together do not retry
+// together. A longer delay requested by the endpoint through Retry-After takes precedence.
+func (p Policy) NextDelay(attempts int, err error) time.Duration {
+	delay := p.MaxDelay
+	if attempts < 1 {
+		attempts = 1
+	}
+	if shift := attempts - 1; shift < 32 && p.BaseDelay<<shift > 0 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+
+	if retryAfter := webhooksender.RetryAfter(err); retryAfter > delay {
+		delay = min(retryAfter, p.MaxDelay)
+	}
+	return delay
+}
@@ -0,0 +1,65 @@
+package webhookretry
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := NewPolicy(&config.Settings{
+		WebhookMaxAttempts:    4,
+		WebhookRetryBaseDelay: 10 * time.Second,
+		WebhookRetryMaxDelay:  time.Minute,
+	})
+	statusErr := func(code int, retryAfter time.Duration) error {
+		return richerrors.Error{
+			Code: webhooksender.WebhookFailureCode,
+			Err:  &webhooksender.StatusError{StatusCode: code, RetryAfter: retryAfter},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		p := NewPolicy(&config.Settings{})
+		assert.Equal(t, 1, p.MaxAttempts)
+		assert.Equal(t, defaultBaseDelay, p.BaseDelay)
+		assert.Equal(t, defaultMaxDelay, p.MaxDelay)
+		assert.False(t, p.ShouldRetry(1, statusErr(http.StatusServiceUnavailable, 0)))
+	})
+
+	t.Run("should retry", func(t *testing.T) {
+		assert.True(t, policy.ShouldRetry(1, statusErr(http.StatusServiceUnavailable, 0)))
+		assert.True(t, policy.ShouldRetry(3, errors.New("connection refused")))
+		assert.False(t, policy.ShouldRetry(4, statusErr(http.StatusServiceUnavailable, 0)))
+		assert.False(t, policy.ShouldRetry(1, statusErr(http.StatusNotFound, 0)))
+	})
+
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		for attempts, expected := range map[int]time.Duration{
+			1:  10 * time.Second,
+			2:  20 * time.Second,
+			3:  40 * time.Second,
+			4:  time.Minute,
+			50: time.Minute,
+		} {
+			for range 20 {
+				delay := policy.NextDelay(attempts, statusErr(http.StatusBadGateway, 0))
+				assert.GreaterOrEqual(t, delay, expected/2)
+				assert.LessOrEqual(t, delay, expected)
+			}
+		}
+	})
+
+	t.Run("retry after takes precedence", func(t *testing.T) {
+		assert.Equal(t, 45*time.Second, policy.NextDelay(1, statusErr(http.StatusTooManyRequests, 45*time.Second)))
+		assert.Equal(t, time.Minute, policy.NextDelay(1, statusErr(http.StatusTooManyRequests, time.Hour)))
+	})
+}
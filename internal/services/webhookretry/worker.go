@@ -0,0 +1,253 @@
+package webhookretry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
+	"github.com/aarondl/null/v8"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	// batchSize is the number of due deliveries claimed per poll.
+	batchSize = 50
+	// concurrency is the number of deliveries attempted in parallel.
+	concurrency = 10
+	// leaseDuration must outlast a single delivery attempt so that an entry is not claimed twice.
+	leaseDuration = 2 * time.Minute
+	// pruneInterval is how often delivery log entries past their retention are deleted.
+	pruneInterval = time.Hour
+)
+
+type Repository interface {
+	ClaimWebhookRetries(ctx context.Context, limit int, lease time.Duration) (models.WebhookOutboxSlice, error)
+	RescheduleWebhookRetry(ctx context.Context, entry *models.WebhookOutbox) error
+	DeleteWebhookRetry(ctx context.Context, id string) error
+	VehicleSubscriptionExists(ctx context.Context, triggerID string, assetDid string) (bool, error)
+	CreateDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter, maxPerTrigger int) (bool, error)
+	CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+	InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error)
+	CreateTriggerLog(ctx context.Context, triggerLog *models.TriggerLog) error
+	ResetTriggerFailureCount(ctx context.Context, trigger *models.Trigger) error
+	IncrementTriggerFailureCount(ctx context.Context, trigger *models.Trigger, failureReason error, maxFailureCount int) error
+}
+
+type WebhookSender interface {
+	SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error)
+}
+
+// Worker re-attempts webhook deliveries that were put in the outbox after a transient failure.
+type Worker struct {
+	repo            Repository
+	sender          WebhookSender
+	policy          Policy
+	maxFailureCount int
+	pollInterval    time.Duration
+	retention       time.Duration
+	lastPrune       time.Time
+}
+
+// NewWorker creates a new retry Worker.
+func NewWorker(repo Repository, sender WebhookSender, settings *config.Settings) *Worker {
+	failureCount := int(settings.MaxWebhookFailureCount)
+	if failureCount < 1 {
+		failureCount = 1
+	}
+	pollInterval := settings.WebhookRetryPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	return &Worker{
+		repo:            repo,
+		sender:          sender,
+		policy:          NewPolicy(settings),
+		maxFailureCount: failureCount,
+		pollInterval:    pollInterval,
+		retention:       settings.WebhookDeliveryRetention,
+	}
+}
+
+// Run polls the outbox for due deliveries until the context is canceled.
+func (w *Worker) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		w.pruneDeliveries(ctx)
+		// Drain the backlog before waiting for the next tick.
+		for {
+			n, err := w.ProcessDue(ctx)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to process webhook retries")
+				break
+			}
+			if n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+// pruneDeliveries deletes delivery log entries older than the retention, at most once per pruneInterval.
+// A retention of zero keeps the delivery log forever.
+func (w *Worker) pruneDeliveries(ctx context.Context) {
+	if w.retention <= 0 || time.Since(w.lastPrune) < pruneInterval {
+		return
+	}
+	w.lastPrune = time.Now()
+	deleted, err := w.repo.DeleteWebhookDeliveriesBefore(ctx, w.lastPrune.Add(-w.retention))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to prune webhook delivery log")
+		return
+	}
+	if deleted > 0 {
+		zerolog.Ctx(ctx).Info().Int64("deleted", deleted).Msg("pruned webhook delivery log")
+	}
+}
+
+// ProcessDue attempts one batch of due deliveries and returns how many were claimed.
+func (w *Worker) ProcessDue(ctx context.Context) (int, error) {
+	entries, err := w.repo.ClaimWebhookRetries(ctx, batchSize, leaseDuration)
+	if err != nil {
+		return 0, err
+	}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for _, entry := range entries {
+		group.Go(func() error {
+			if err := w.retry(groupCtx, entry); err != nil {
+				zerolog.Ctx(groupCtx).Error().Err(err).Str("triggerId", entry.TriggerID).Str("deliveryId", entry.ID).Msg("failed to retry webhook")
+			}
+			return nil
+		})
+	}
+	return len(entries), group.Wait()
+}
+
+func (w *Worker) retry(ctx context.Context, entry *models.WebhookOutbox) error {
+	trigger, err := w.repo.InternalGetTriggerByID(ctx, entry.TriggerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return w.repo.DeleteWebhookRetry(ctx, entry.ID)
+		}
+		return fmt.Errorf("failed to get trigger: %w", err)
+	}
+	// The trigger failed while the delivery was waiting; keep the delivery for a replay.
+	if trigger.Status == triggersrepo.StatusFailed {
+		return w.giveUp(ctx, entry)
+	}
+	// The trigger was disabled while the delivery was waiting; drop it like the listener would.
+	if trigger.Status != triggersrepo.StatusEnabled {
+		return w.repo.DeleteWebhookRetry(ctx, entry.ID)
+	}
+	// The vehicle was unsubscribed while the delivery was waiting.
+	subscribed, err := w.repo.VehicleSubscriptionExists(ctx, entry.TriggerID, entry.AssetDid)
+	if err != nil {
+		return err
+	}
+	if !subscribed {
+		return w.repo.DeleteWebhookRetry(ctx, entry.ID)
+	}
+
+	var payload cloudevent.CloudEvent[webhook.WebhookPayload]
+	if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+		if delErr := w.repo.DeleteWebhookRetry(ctx, entry.ID); delErr != nil {
+			return delErr
+		}
+		return fmt.Errorf("failed to unmarshal webhook payload: %w", err)
+	}
+
+	resp, sendErr := w.sender.SendWebhook(ctx, trigger, &payload)
+	if err := w.repo.CreateWebhookDelivery(ctx, webhooksender.NewDeliveryLog(&payload, entry.Attempts+1, resp, sendErr)); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", entry.TriggerID).Str("deliveryId", entry.ID).Msg("failed to log webhook delivery")
+	}
+	if sendErr == nil {
+		return w.handleSuccess(ctx, trigger, entry)
+	}
+
+	entry.Attempts++
+	entry.LastError = null.StringFrom(sendErr.Error())
+	if richErr, ok := richerrors.AsRichError(sendErr); !ok || richErr.Code != webhooksender.WebhookFailureCode {
+		// Not the endpoint's fault (e.g. a request that cannot be built), so the trigger is not penalized,
+		// but the attempt still counts so that the entry cannot loop forever.
+		if entry.Attempts < w.policy.MaxAttempts {
+			entry.NextAttemptAt = time.Now().UTC().Add(w.policy.NextDelay(entry.Attempts, sendErr))
+			if err := w.repo.RescheduleWebhookRetry(ctx, entry); err != nil {
+				return err
+			}
+		} else if err := w.giveUp(ctx, entry); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed to send webhook: %w", sendErr)
+	}
+	if w.policy.ShouldRetry(entry.Attempts, sendErr) {
+		entry.NextAttemptAt = time.Now().UTC().Add(w.policy.NextDelay(entry.Attempts, sendErr))
+		return w.repo.RescheduleWebhookRetry(ctx, entry)
+	}
+
+	if err := w.giveUp(ctx, entry); err != nil {
+		return err
+	}
+	if err := w.repo.IncrementTriggerFailureCount(ctx, trigger, sendErr, w.maxFailureCount); err != nil {
+		return fmt.Errorf("failed to handle webhook failure: %w", err)
+	}
+	return fmt.Errorf("webhook delivery failed after %d attempts: %w", entry.Attempts, sendErr)
+}
+
+// giveUp moves an entry from the outbox to the dead letters.
+func (w *Worker) giveUp(ctx context.Context, entry *models.WebhookOutbox) error {
+	deadLetter := &models.WebhookDeadLetter{
+		ID:           entry.ID,
+		TriggerID:    entry.TriggerID,
+		AssetDid:     entry.AssetDid,
+		Payload:      entry.Payload,
+		SnapshotData: entry.SnapshotData,
+		Attempts:     entry.Attempts,
+		LastError:    entry.LastError,
+	}
+	if _, err := w.repo.CreateDeadLetter(ctx, deadLetter, w.policy.MaxDeadLetters); err != nil {
+		return err
+	}
+	return w.repo.DeleteWebhookRetry(ctx, entry.ID)
+}
+
+func (w *Worker) handleSuccess(ctx context.Context, trigger *models.Trigger, entry *models.WebhookOutbox) error {
+	if err := w.repo.DeleteWebhookRetry(ctx, entry.ID); err != nil {
+		return err
+	}
+	if err := w.repo.ResetTriggerFailureCount(ctx, trigger); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("triggerId", trigger.ID).Msg("failed to handle webhook success")
+	}
+	now := time.Now().UTC()
+	triggerLog := &models.TriggerLog{
+		ID:              entry.ID,
+		TriggerID:       entry.TriggerID,
+		AssetDid:        entry.AssetDid,
+		SnapshotData:    entry.SnapshotData,
+		LastTriggeredAt: now,
+		CreatedAt:       now,
+	}
+	if err := w.repo.CreateTriggerLog(ctx, triggerLog); err != nil {
+		return fmt.Errorf("failed to create trigger log: %w", err)
+	}
+	return nil
+}
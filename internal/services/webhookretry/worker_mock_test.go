@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: worker.go
+//
+// Generated by this command:
+//
+//	mockgen -source=worker.go -destination=worker_mock_test.go -package=webhookretry
+//
+
+// Package webhookretry is a generated GoMock package.
+package webhookretry
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	cloudevent "github.com/DIMO-Network/cloudevent"
+	webhook "github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	models "github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookRetries mocks base method.
+func (m *MockRepository) ClaimWebhookRetries(ctx context.Context, limit int, lease time.Duration) (models.WebhookOutboxSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookRetries", ctx, limit, lease)
+	ret0, _ := ret[0].(models.WebhookOutboxSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookRetries indicates an expected call of ClaimWebhookRetries.
+func (mr *MockRepositoryMockRecorder) ClaimWebhookRetries(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookRetries", reflect.TypeOf((*MockRepository)(nil).ClaimWebhookRetries), ctx, limit, lease)
+}
+
+// CreateDeadLetter mocks base method.
+func (m *MockRepository) CreateDeadLetter(ctx context.Context, deadLetter *models.WebhookDeadLetter, maxPerTrigger int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", ctx, deadLetter, maxPerTrigger)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockRepositoryMockRecorder) CreateDeadLetter(ctx, deadLetter, maxPerTrigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockRepository)(nil).CreateDeadLetter), ctx, deadLetter, maxPerTrigger)
+}
+
+// CreateTriggerLog mocks base method.
+func (m *MockRepository) CreateTriggerLog(ctx context.Context, triggerLog *models.TriggerLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTriggerLog", ctx, triggerLog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTriggerLog indicates an expected call of CreateTriggerLog.
+func (mr *MockRepositoryMockRecorder) CreateTriggerLog(ctx, triggerLog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTriggerLog", reflect.TypeOf((*MockRepository)(nil).CreateTriggerLog), ctx, triggerLog)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockRepository) CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockRepositoryMockRecorder) CreateWebhookDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockRepository)(nil).CreateWebhookDelivery), ctx, delivery)
+}
+
+// DeleteWebhookDeliveriesBefore mocks base method.
+func (m *MockRepository) DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDeliveriesBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookDeliveriesBefore indicates an expected call of DeleteWebhookDeliveriesBefore.
+func (mr *MockRepositoryMockRecorder) DeleteWebhookDeliveriesBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDeliveriesBefore", reflect.TypeOf((*MockRepository)(nil).DeleteWebhookDeliveriesBefore), ctx, before)
+}
+
+// DeleteWebhookRetry mocks base method.
+func (m *MockRepository) DeleteWebhookRetry(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookRetry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookRetry indicates an expected call of DeleteWebhookRetry.
+func (mr *MockRepositoryMockRecorder) DeleteWebhookRetry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookRetry", reflect.TypeOf((*MockRepository)(nil).DeleteWebhookRetry), ctx, id)
+}
+
+// IncrementTriggerFailureCount mocks base method.
+func (m *MockRepository) IncrementTriggerFailureCount(ctx context.Context, trigger *models.Trigger, failureReason error, maxFailureCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementTriggerFailureCount", ctx, trigger, failureReason, maxFailureCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementTriggerFailureCount indicates an expected call of IncrementTriggerFailureCount.
+func (mr *MockRepositoryMockRecorder) IncrementTriggerFailureCount(ctx, trigger, failureReason, maxFailureCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementTriggerFailureCount", reflect.TypeOf((*MockRepository)(nil).IncrementTriggerFailureCount), ctx, trigger, failureReason, maxFailureCount)
+}
+
+// InternalGetTriggerByID mocks base method.
+func (m *MockRepository) InternalGetTriggerByID(ctx context.Context, triggerID string) (*models.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalGetTriggerByID", ctx, triggerID)
+	ret0, _ := ret[0].(*models.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InternalGetTriggerByID indicates an expected call of InternalGetTriggerByID.
+func (mr *MockRepositoryMockRecorder) InternalGetTriggerByID(ctx, triggerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalGetTriggerByID", reflect.TypeOf((*MockRepository)(nil).InternalGetTriggerByID), ctx, triggerID)
+}
+
+// RescheduleWebhookRetry mocks base method.
+func (m *MockRepository) RescheduleWebhookRetry(ctx context.Context, entry *models.WebhookOutbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleWebhookRetry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleWebhookRetry indicates an expected call of RescheduleWebhookRetry.
+func (mr *MockRepositoryMockRecorder) RescheduleWebhookRetry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleWebhookRetry", reflect.TypeOf((*MockRepository)(nil).RescheduleWebhookRetry), ctx, entry)
+}
+
+// ResetTriggerFailureCount mocks base method.
+func (m *MockRepository) ResetTriggerFailureCount(ctx context.Context, trigger *models.Trigger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTriggerFailureCount", ctx, trigger)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTriggerFailureCount indicates an expected call of ResetTriggerFailureCount.
+func (mr *MockRepositoryMockRecorder) ResetTriggerFailureCount(ctx, trigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTriggerFailureCount", reflect.TypeOf((*MockRepository)(nil).ResetTriggerFailureCount), ctx, trigger)
+}
+
+// VehicleSubscriptionExists mocks base method.
+func (m *MockRepository) VehicleSubscriptionExists(ctx context.Context, triggerID, assetDid string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VehicleSubscriptionExists", ctx, triggerID, assetDid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VehicleSubscriptionExists indicates an expected call of VehicleSubscriptionExists.
+func (mr *MockRepositoryMockRecorder) VehicleSubscriptionExists(ctx, triggerID, assetDid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VehicleSubscriptionExists", reflect.TypeOf((*MockRepository)(nil).VehicleSubscriptionExists), ctx, triggerID, assetDid)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// SendWebhook mocks base method.
+func (m *MockWebhookSender) SendWebhook(ctx context.Context, trigger *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWebhook", ctx, trigger, payload)
+	ret0, _ := ret[0].(webhook.DeliveryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendWebhook indicates an expected call of SendWebhook.
+func (mr *MockWebhookSenderMockRecorder) SendWebhook(ctx, trigger, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWebhook", reflect.TypeOf((*MockWebhookSender)(nil).SendWebhook), ctx, trigger, payload)
+}
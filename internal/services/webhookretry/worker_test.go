@@ -0,0 +1,270 @@
+//go:generate go tool mockgen -source=worker.go -destination=worker_mock_test.go -package=webhookretry
+package webhookretry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/server-garage/pkg/richerrors"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/config"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/triggersrepo"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/services/webhooksender"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorker_ProcessDue(t *testing.T) {
+	t.Parallel()
+
+	trigger := &models.Trigger{ID: "test-trigger-id", Status: triggersrepo.StatusEnabled}
+	assetDID := cloudevent.ERC721DID{
+		ChainID:         137,
+		ContractAddress: common.HexToAddress("0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF"),
+		TokenID:         big.NewInt(1),
+	}
+	newEntry := func(t *testing.T, attempts int) *models.WebhookOutbox {
+		t.Helper()
+		payload := cloudevent.CloudEvent[webhook.WebhookPayload]{
+			CloudEventHeader: cloudevent.CloudEventHeader{ID: "test-delivery-id"},
+			Data:             webhook.WebhookPayload{WebhookId: trigger.ID, AssetDID: assetDID},
+		}
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return &models.WebhookOutbox{
+			ID:           "test-delivery-id",
+			TriggerID:    trigger.ID,
+			AssetDid:     assetDID.String(),
+			Payload:      body,
+			SnapshotData: []byte(`{"value":1}`),
+			Attempts:     attempts,
+		}
+	}
+	unavailable := richerrors.Error{
+		Code: webhooksender.WebhookFailureCode,
+		Err:  &webhooksender.StatusError{StatusCode: http.StatusServiceUnavailable},
+	}
+
+	newWorker := func(t *testing.T) (*Worker, *MockRepository, *MockWebhookSender) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		repo := NewMockRepository(ctrl)
+		sender := NewMockWebhookSender(ctrl)
+		worker := NewWorker(repo, sender, &config.Settings{
+			MaxWebhookFailureCount: 5,
+			WebhookMaxAttempts:     3,
+			WebhookRetryBaseDelay:  time.Second,
+			WebhookRetryMaxDelay:   time.Minute,
+			WebhookMaxDeadLetters:  50,
+		})
+		return worker, repo, sender
+	}
+
+	t.Run("successful retry", func(t *testing.T) {
+		worker, repo, sender := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(true, nil)
+		sender.EXPECT().SendWebhook(gomock.Any(), trigger, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+				assert.Equal(t, "test-delivery-id", payload.ID)
+				return webhook.DeliveryResult{StatusCode: http.StatusOK}, nil
+			})
+		repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+		repo.EXPECT().ResetTriggerFailureCount(gomock.Any(), trigger).Return(nil)
+		repo.EXPECT().CreateTriggerLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, log *models.TriggerLog) error {
+				assert.Equal(t, entry.ID, log.ID)
+				assert.Equal(t, entry.AssetDid, log.AssetDid)
+				assert.JSONEq(t, `{"value":1}`, string(log.SnapshotData))
+				return nil
+			})
+
+		n, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("transient failure is rescheduled", func(t *testing.T) {
+		worker, repo, sender := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(true, nil)
+		sender.EXPECT().SendWebhook(gomock.Any(), trigger, gomock.Any()).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusServiceUnavailable}, unavailable)
+		repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+				assert.Equal(t, entry.ID, delivery.DeliveryID)
+				assert.Equal(t, 2, delivery.Attempt)
+				assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode.Int)
+				assert.Contains(t, delivery.ErrorMessage.String, "503")
+				return nil
+			})
+		repo.EXPECT().RescheduleWebhookRetry(gomock.Any(), entry).
+			DoAndReturn(func(_ context.Context, e *models.WebhookOutbox) error {
+				assert.Equal(t, 2, e.Attempts)
+				assert.True(t, e.NextAttemptAt.After(time.Now()))
+				assert.Contains(t, e.LastError.String, "503")
+				return nil
+			})
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("exhausted retries count as failure", func(t *testing.T) {
+		worker, repo, sender := newWorker(t)
+		entry := newEntry(t, 2)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(true, nil)
+		sender.EXPECT().SendWebhook(gomock.Any(), trigger, gomock.Any()).
+			Return(webhook.DeliveryResult{StatusCode: http.StatusServiceUnavailable}, unavailable)
+		repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).
+			DoAndReturn(func(_ context.Context, deadLetter *models.WebhookDeadLetter, _ int) (bool, error) {
+				assert.Equal(t, entry.ID, deadLetter.ID)
+				assert.Equal(t, 3, deadLetter.Attempts)
+				assert.Equal(t, entry.Payload, deadLetter.Payload)
+				assert.Contains(t, deadLetter.LastError.String, "503")
+				return true, nil
+			})
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+		repo.EXPECT().IncrementTriggerFailureCount(gomock.Any(), trigger, gomock.Any(), 5).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("send error is rescheduled", func(t *testing.T) {
+		worker, repo, sender := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(true, nil)
+		sender.EXPECT().SendWebhook(gomock.Any(), trigger, gomock.Any()).Return(webhook.DeliveryResult{}, errors.New("failed to create request"))
+		repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().RescheduleWebhookRetry(gomock.Any(), entry).
+			DoAndReturn(func(_ context.Context, e *models.WebhookOutbox) error {
+				assert.Equal(t, 2, e.Attempts)
+				assert.True(t, e.NextAttemptAt.After(time.Now()))
+				return nil
+			})
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("send error is given up after max attempts", func(t *testing.T) {
+		worker, repo, sender := newWorker(t)
+		entry := newEntry(t, 2)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(true, nil)
+		sender.EXPECT().SendWebhook(gomock.Any(), trigger, gomock.Any()).Return(webhook.DeliveryResult{}, errors.New("failed to create request"))
+		repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).
+			DoAndReturn(func(_ context.Context, d *models.WebhookDeadLetter, _ int) (bool, error) {
+				assert.Equal(t, 3, d.Attempts)
+				assert.Equal(t, "failed to create request", d.LastError.String)
+				return true, nil
+			})
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("unsubscribed vehicle drops delivery", func(t *testing.T) {
+		worker, repo, _ := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(trigger, nil)
+		repo.EXPECT().VehicleSubscriptionExists(gomock.Any(), trigger.ID, entry.AssetDid).Return(false, nil)
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled trigger drops delivery", func(t *testing.T) {
+		worker, repo, _ := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(&models.Trigger{ID: trigger.ID, Status: triggersrepo.StatusDisabled}, nil)
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("failed trigger dead letters delivery", func(t *testing.T) {
+		worker, repo, _ := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(&models.Trigger{ID: trigger.ID, Status: triggersrepo.StatusFailed}, nil)
+		repo.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any(), 50).Return(true, nil)
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("deleted trigger drops delivery", func(t *testing.T) {
+		worker, repo, _ := newWorker(t)
+		entry := newEntry(t, 1)
+
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(models.WebhookOutboxSlice{entry}, nil)
+		repo.EXPECT().InternalGetTriggerByID(gomock.Any(), trigger.ID).Return(nil, richerrors.Error{Err: sql.ErrNoRows})
+		repo.EXPECT().DeleteWebhookRetry(gomock.Any(), entry.ID).Return(nil)
+
+		_, err := worker.ProcessDue(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		worker, repo, _ := newWorker(t)
+		repo.EXPECT().ClaimWebhookRetries(gomock.Any(), batchSize, leaseDuration).Return(nil, errors.New("db down"))
+
+		_, err := worker.ProcessDue(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestWorkerPruneDeliveries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	repo := NewMockRepository(ctrl)
+	worker := NewWorker(repo, NewMockWebhookSender(ctrl), &config.Settings{WebhookDeliveryRetention: 24 * time.Hour})
+
+	repo.EXPECT().DeleteWebhookDeliveriesBefore(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+			return 3, nil
+		}).Times(1)
+
+	worker.pruneDeliveries(context.Background())
+	// A second call within the prune interval does not touch the database.
+	worker.pruneDeliveries(context.Background())
+}
@@ -0,0 +1,29 @@
+package webhooksender
+
+import (
+	"github.com/DIMO-Network/cloudevent"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
+	"github.com/google/uuid"
+)
+
+// NewDeliveryLog describes the attempt-th attempt to deliver payload, answered with resp or failed with sendErr.
+// The error text includes the excerpt of the response body read by SendWebhook.
+func NewDeliveryLog(payload *cloudevent.CloudEvent[webhook.WebhookPayload], attempt int, resp webhook.DeliveryResult, sendErr error) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		ID:            uuid.New().String(),
+		DeliveryID:    payload.ID,
+		TriggerID:     payload.Data.WebhookId,
+		AssetDid:      payload.Data.AssetDID.String(),
+		Attempt:       attempt,
+		LatencyMillis: int(resp.Latency.Milliseconds()),
+	}
+	if resp.StatusCode != 0 {
+		delivery.StatusCode = null.IntFrom(resp.StatusCode)
+	}
+	if sendErr != nil {
+		delivery.ErrorMessage = null.StringFrom(sendErr.Error())
+	}
+	return delivery
+}
@@ -0,0 +1,72 @@
+package webhooksender
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errPermanent marks delivery failures that will not succeed on a retry, e.g. an unparsable target URL.
+var errPermanent = errors.New("permanent delivery failure")
+
+// StatusError is returned when the webhook endpoint responds with an error status code.
+type StatusError struct {
+	// StatusCode is the HTTP status code returned by the endpoint.
+	StatusCode int
+	// Body is an excerpt of the response body.
+	Body string
+	// RetryAfter is the delay requested by the endpoint through the Retry-After header, zero if absent.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook returned status code %d: %s", e.StatusCode, e.Body)
+}
+
+// IsRetryable reports whether a failed delivery may succeed if it is attempted again later.
+// Timeouts, connection errors, 5xx responses and 408/425/429 are considered transient;
+// other 4xx responses mean the endpoint rejected the request and retrying will not help.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, errPermanent) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+			return true
+		}
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// RetryAfter returns the delay the endpoint asked for before the next attempt, zero if it did not ask for one.
+func RetryAfter(err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package webhooksender
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, status int, retryAfter string) error {
+		t.Helper()
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+		}))
+		defer testServer.Close()
+		_, err := NewWebhookSender(nil).SendWebhook(context.Background(), &models.Trigger{ID: "test-webhook-id", TargetURI: testServer.URL}, createTestPayload("test-webhook-id"))
+		require.Error(t, err)
+		return err
+	}
+
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusRequestTimeout, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("status %d", tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.retryable, IsRetryable(send(t, tt.status, "")))
+		})
+	}
+
+	t.Run("retry after header", func(t *testing.T) {
+		err := send(t, http.StatusTooManyRequests, "120")
+		assert.Equal(t, 2*time.Minute, RetryAfter(err))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		_, err := NewWebhookSender(nil).SendWebhook(context.Background(), &models.Trigger{TargetURI: "http://127.0.0.1:1"}, createTestPayload("test-webhook-id"))
+		require.Error(t, err)
+		assert.True(t, IsRetryable(err))
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := NewWebhookSender(nil).SendWebhook(context.Background(), &models.Trigger{TargetURI: "://invalid"}, createTestPayload("test-webhook-id"))
+		require.Error(t, err)
+		assert.False(t, IsRetryable(err))
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		assert.False(t, IsRetryable(nil))
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
@@ -0,0 +1,42 @@
+package webhooksender
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+)
+
+const (
+	// SignatureHeader carries the HMAC signatures of a delivery, e.g. "v1=<hex>,v1=<hex>".
+	SignatureHeader = "X-DIMO-Signature"
+	// TimestampHeader carries the unix time in seconds at which the delivery was signed.
+	TimestampHeader = "X-DIMO-Timestamp"
+
+	signatureVersion = "v1"
+)
+
+// ComputeSignature returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed by secret.
+func ComputeSignature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signatureHeaders returns the timestamp and signature header values for a delivery of body.
+// While a rotated secret is still inside its overlap window the body is signed with both secrets,
+// newest first, so receivers that have not switched yet keep verifying deliveries.
+func signatureHeaders(t *models.Trigger, body []byte, now time.Time) (string, string) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	sigs := []string{signatureVersion + "=" + ComputeSignature(t.SigningSecret, timestamp, body)}
+	if t.PreviousSigningSecret.Valid && t.PreviousSigningSecretExpiresAt.Valid && now.Before(t.PreviousSigningSecretExpiresAt.Time) {
+		sigs = append(sigs, signatureVersion+"="+ComputeSignature(t.PreviousSigningSecret.String, timestamp, body))
+	}
+	return timestamp, strings.Join(sigs, ",")
+}
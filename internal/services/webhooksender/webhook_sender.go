@@ -25,6 +25,10 @@ const (
 	defaultWebhookTimeout = 30 * time.Second
 	// Maximum response body size to read for error logging
 	maxResponseBodySize = 1024
+
+	// TestHeader is set to "true" on deliveries sent through the test endpoint so that receivers can
+	// tell them apart from real firings.
+	TestHeader = "X-DIMO-Webhook-Test"
 )
 
 // WebhookSender handles all webhook delivery operations
@@ -47,11 +51,20 @@ func NewWebhookSender(client *http.Client) *WebhookSender {
 
 // SendWebhook sends a webhook notification to the specified trigger
 // Returns error for failures, nil for success
-func (w *WebhookSender) SendWebhook(ctx context.Context, t *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) error {
+func (w *WebhookSender) SendWebhook(ctx context.Context, t *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+	return w.send(ctx, t, payload, false)
+}
+
+// SendTestWebhook sends a synthetic webhook notification to the specified trigger, marked with TestHeader.
+func (w *WebhookSender) SendTestWebhook(ctx context.Context, t *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload]) (webhook.DeliveryResult, error) {
+	return w.send(ctx, t, payload, true)
+}
+
+func (w *WebhookSender) send(ctx context.Context, t *models.Trigger, payload *cloudevent.CloudEvent[webhook.WebhookPayload], test bool) (webhook.DeliveryResult, error) {
 	// Marshal payload
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		return webhook.DeliveryResult{}, fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
 
 	// Create request
@@ -59,45 +72,57 @@ func (w *WebhookSender) SendWebhook(ctx context.Context, t *models.Trigger, payl
 	if err != nil {
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) {
-			return richerrors.Error{
+			return webhook.DeliveryResult{}, richerrors.Error{
 				Code: WebhookFailureCode,
-				Err:  fmt.Errorf("invalid URL: %w", err),
+				Err:  fmt.Errorf("invalid URL: %w: %w", errPermanent, err),
 			}
 		}
-		return fmt.Errorf("failed to create webhook request: %w", err)
+		return webhook.DeliveryResult{}, fmt.Errorf("failed to create webhook request: %w", err)
 
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "DIMO-Webhook/1.0")
-	// TODO: Add webhook signature for security
+	timestamp, signature := signatureHeaders(t, body, time.Now())
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, signature)
+	if test {
+		req.Header.Set(TestHeader, "true")
+	}
 
 	// Send request
+	start := time.Now()
 	resp, err := w.client.Do(req)
 	if err != nil {
+		result := webhook.DeliveryResult{Latency: time.Since(start)}
 		if errors.Is(ctx.Err(), context.Canceled) {
 			// Our context was canceled (e.g. consumer shutdown mid-send), so the
 			// endpoint is not at fault; don't count this toward the trigger's
 			// failure threshold. Client timeouts leave ctx.Err() nil and still count.
-			return fmt.Errorf("webhook request canceled: %w", err)
+			return result, fmt.Errorf("webhook request canceled: %w", err)
 		}
-		return richerrors.Error{
+		return result, richerrors.Error{
 			Code: WebhookFailureCode,
 			Err:  fmt.Errorf("failed to POST to webhook: %w", err),
 		}
 	}
 	defer resp.Body.Close() // nolint:errcheck
+	result := webhook.DeliveryResult{StatusCode: resp.StatusCode, Latency: time.Since(start)}
 
 	// Check status code
 	if resp.StatusCode >= 400 {
 		// Read response body for error details (limited size for security)
 		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
-		return richerrors.Error{
+		return result, richerrors.Error{
 			Code: WebhookFailureCode,
-			Err:  fmt.Errorf("webhook returned status code %d: %s", resp.StatusCode, string(respBody)),
+			Err: &StatusError{
+				StatusCode: resp.StatusCode,
+				Body:       string(respBody),
+				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			},
 		}
 	}
 
-	return nil
+	return result, nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/DIMO-Network/server-garage/pkg/richerrors"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/controllers/webhook"
 	"github.com/DIMO-Network/vehicle-triggers-api/internal/db/models"
+	"github.com/aarondl/null/v8"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
 			assert.Equal(t, "DIMO-Webhook/1.0", r.Header.Get("User-Agent"))
 			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Empty(t, r.Header.Get(TestHeader))
 
 			// Verify payload can be parsed
 			body, err := io.ReadAll(r.Body)
@@ -56,8 +58,30 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		resp, err := sender.SendWebhook(ctx, trigger, payload)
 		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Positive(t, resp.Latency)
+	})
+
+	t.Run("test delivery is marked", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.Header.Get(TestHeader))
+			assert.NotEmpty(t, r.Header.Get(SignatureHeader))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer testServer.Close()
+
+		sender := NewWebhookSender(nil)
+		trigger := &models.Trigger{
+			ID:                      "test-webhook-id",
+			TargetURI:               testServer.URL,
+			DeveloperLicenseAddress: common.HexToAddress("0x1234").Bytes(),
+		}
+
+		resp, err := sender.SendTestWebhook(context.Background(), trigger, createTestPayload("test-webhook-id"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
 	})
 
 	t.Run("webhook returns 400 error", func(t *testing.T) {
@@ -76,8 +100,9 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		resp, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
 		// Should be a webhook failure error
 		richErr, ok := richerrors.AsRichError(err)
@@ -101,7 +126,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		richErr, ok := richerrors.AsRichError(err)
@@ -119,7 +144,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		richErr, ok := richerrors.AsRichError(err)
@@ -137,7 +162,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 		richErr, ok := richerrors.AsRichError(err)
 		require.True(t, ok)
@@ -166,7 +191,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		richErr, ok := richerrors.AsRichError(err)
@@ -196,7 +221,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 			cancel()
 		}()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		// Cancellation of our own context is not the endpoint's fault and must
@@ -225,7 +250,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		// Error message should contain truncated response (limited by maxResponseBodySize)
@@ -257,7 +282,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		assert.NoError(t, err)
 	})
 
@@ -277,7 +302,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		assert.NoError(t, err)
 	})
 
@@ -297,7 +322,7 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 		payload := createTestPayload("test-webhook-id")
 		ctx := context.Background()
 
-		err := sender.SendWebhook(ctx, trigger, payload)
+		_, err := sender.SendWebhook(ctx, trigger, payload)
 		require.Error(t, err)
 
 		richErr, ok := richerrors.AsRichError(err)
@@ -306,6 +331,64 @@ func TestWebhookSender_SendWebhook(t *testing.T) {
 	})
 }
 
+func TestWebhookSender_Signature(t *testing.T) {
+	t.Parallel()
+
+	receive := func(t *testing.T, trigger *models.Trigger) (http.Header, []byte) {
+		t.Helper()
+		var headers http.Header
+		var body []byte
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header.Clone()
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer testServer.Close()
+
+		trigger.TargetURI = testServer.URL
+		_, err := NewWebhookSender(nil).SendWebhook(context.Background(), trigger, createTestPayload(trigger.ID))
+		require.NoError(t, err)
+		return headers, body
+	}
+
+	t.Run("signs body with current secret", func(t *testing.T) {
+		trigger := &models.Trigger{ID: "test-webhook-id", SigningSecret: "whsec_current"}
+		headers, body := receive(t, trigger)
+
+		timestamp := headers.Get(TimestampHeader)
+		require.NotEmpty(t, timestamp)
+		assert.Equal(t, "v1="+ComputeSignature("whsec_current", timestamp, body), headers.Get(SignatureHeader))
+	})
+
+	t.Run("signs body with previous secret during overlap", func(t *testing.T) {
+		trigger := &models.Trigger{
+			ID:                             "test-webhook-id",
+			SigningSecret:                  "whsec_current",
+			PreviousSigningSecret:          null.StringFrom("whsec_previous"),
+			PreviousSigningSecretExpiresAt: null.TimeFrom(time.Now().Add(time.Hour)),
+		}
+		headers, body := receive(t, trigger)
+
+		timestamp := headers.Get(TimestampHeader)
+		expected := "v1=" + ComputeSignature("whsec_current", timestamp, body) +
+			",v1=" + ComputeSignature("whsec_previous", timestamp, body)
+		assert.Equal(t, expected, headers.Get(SignatureHeader))
+	})
+
+	t.Run("ignores expired previous secret", func(t *testing.T) {
+		trigger := &models.Trigger{
+			ID:                             "test-webhook-id",
+			SigningSecret:                  "whsec_current",
+			PreviousSigningSecret:          null.StringFrom("whsec_previous"),
+			PreviousSigningSecretExpiresAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+		}
+		headers, body := receive(t, trigger)
+
+		timestamp := headers.Get(TimestampHeader)
+		assert.Equal(t, "v1="+ComputeSignature("whsec_current", timestamp, body), headers.Get(SignatureHeader))
+	})
+}
+
 func TestNewWebhookSender(t *testing.T) {
 	t.Parallel()
 
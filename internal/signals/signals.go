@@ -5,6 +5,7 @@ package signals
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 
@@ -99,6 +100,20 @@ func GetSignalDefinitionOrDefault(name, valueType string) SignalDefinition {
 	return DefaultDefinition(name, valueType)
 }
 
+// GetPermissions returns the permissions required to read all of the named signals, which is the union of the
+// permissions of their definitions. Signals that are not in the schema require the default permissions.
+func GetPermissions(names []string) []string {
+	var permissions []string
+	for _, name := range names {
+		for _, permission := range GetSignalDefinitionOrDefault(name, NumberType).Permissions {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
 // GetAllSignalDefinitions returns a copy of the signal definitions.
 func GetAllSignalDefinitions() []SignalDefinition {
 	loadLock.RLock()
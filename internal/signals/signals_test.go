@@ -48,3 +48,10 @@ func TestGetSignalDefinitionOrDefault_UnknownSignal(t *testing.T) {
 	assert.Equal(t, StringType, def.ValueType)
 	assert.Equal(t, DefaultPermissions, def.Permissions)
 }
+
+func TestGetPermissions(t *testing.T) {
+	assert.Equal(t, []string{"privilege:GetNonLocationHistory"}, GetPermissions([]string{"speed", "isIgnitionOn"}))
+	assert.Equal(t, []string{"privilege:GetNonLocationHistory", "privilege:GetLocationHistory"}, GetPermissions([]string{"speed", "currentLocationCoordinates"}))
+	assert.Equal(t, DefaultPermissions, GetPermissions([]string{"nonExistentSignal"}))
+	assert.Empty(t, GetPermissions(nil))
+}
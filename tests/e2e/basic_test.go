@@ -28,4 +28,11 @@ func TestBasic(t *testing.T) {
 	}
 
 	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The consumers are not started, so the server is not ready.
+	req, err = http.NewRequestWithContext(t.Context(), "GET", "/health/ready", nil)
+	require.NoError(t, err)
+	resp, err = servers.Application.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 }